@@ -0,0 +1,196 @@
+package ztype
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// JSONAppender is implemented by the scalar types of the package. AppendJSON
+// appends the same bytes MarshalJSON returns to dst, without allocating
+// when dst has enough capacity.
+//
+// Example:
+//
+//	buf := make([]byte, 0, 64)
+//	buf, err := ztype.NewNumber(42).AppendJSON(buf)
+type JSONAppender interface {
+	AppendJSON(dst []byte) ([]byte, error)
+}
+
+var (
+	_ JSONAppender = Bool{}
+	_ JSONAppender = Byte{}
+	_ JSONAppender = Char{}
+	_ JSONAppender = Bytes{}
+	_ JSONAppender = String{}
+	_ JSONAppender = Numeric[int]{}
+	_ JSONAppender = Time{}
+	_ JSONAppender = Duration{}
+	_ JSONAppender = Rune{}
+	_ JSONAppender = Enum[string]{}
+	_ JSONAppender = IP{}
+	_ JSONAppender = CIDR{}
+	_ JSONAppender = RawJSON{}
+	_ JSONAppender = Money{}
+)
+
+// StringAppender is implemented by the types whose String output is
+// written on hot logging paths. AppendString appends the same text String
+// returns to dst, without allocating when dst has enough capacity.
+//
+// Example:
+//
+//	buf := make([]byte, 0, 256)
+//	buf = append(buf, "user="...)
+//	buf = user.ID.AppendString(buf)
+type StringAppender interface {
+	AppendString(dst []byte) []byte
+}
+
+var (
+	_ StringAppender = Bool{}
+	_ StringAppender = Byte{}
+	_ StringAppender = String{}
+	_ StringAppender = Numeric[int]{}
+	_ StringAppender = Time{}
+	_ StringAppender = Duration{}
+)
+
+// MarshalAppend appends the JSON encoding of v to dst. Values implementing
+// JSONAppender are encoded in place; anything else, including collections
+// and structs, goes through json.Marshal. Reusing dst across calls avoids
+// the per-value allocations of MarshalJSON.
+//
+// Example:
+//
+//	buf := make([]byte, 0, 4096)
+//	for _, price := range prices {
+//		buf, err = ztype.MarshalAppend(buf[:0], price)
+//		// ...
+//	}
+func MarshalAppend(dst []byte, v any) ([]byte, error) {
+	if appender, ok := v.(JSONAppender); ok {
+		return appender.AppendJSON(dst)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, data...), nil
+}
+
+// jsonHex holds the digits used for \u00XX escapes.
+const jsonHex = "0123456789abcdef"
+
+// appendJSONString appends src as a JSON string, escaped exactly like
+// encoding/json does, including HTML characters, U+2028 and U+2029.
+func appendJSONString[S []byte | string](dst []byte, src S) []byte {
+	begin := len(dst)
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(src); {
+		if c := src[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, src[start:i]...)
+			switch c {
+			case '\\', '"':
+				dst = append(dst, '\\', c)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', jsonHex[c>>4], jsonHex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(string(src[i:min(len(src), i+utf8.UTFMax)]))
+		if r == utf8.RuneError && size == 1 {
+			// The replacement of invalid UTF-8 differs between Go releases,
+			// so leave it to encoding/json.
+			data, _ := json.Marshal(string(src))
+			return append(dst[:begin], data...)
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, src[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', jsonHex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, src[start:]...)
+	return append(dst, '"')
+}
+
+// jsonStringCapacity returns the buffer size MarshalJSON uses for a string
+// of n bytes, leaving room for the quotes and a few escapes.
+func jsonStringCapacity(n int) int {
+	return n + n/2 + 8
+}
+
+// appendJSONNumber appends value the way encoding/json encodes T. Types with
+// their own JSON or text encoding go through json.Marshal.
+func appendJSONNumber[T NumberType](dst []byte, value T) ([]byte, error) {
+	t := reflect.TypeFor[T]()
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, data...), nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(dst, int64(value), 10), nil
+	case reflect.Float32:
+		return appendJSONFloat(dst, float64(value), 32)
+	case reflect.Float64:
+		return appendJSONFloat(dst, float64(value), 64)
+	default:
+		return strconv.AppendUint(dst, uint64(value), 10), nil
+	}
+}
+
+// appendJSONFloat appends f with encoding/json's float format: decimal
+// notation, switching to exponent notation for very small or large values.
+func appendJSONFloat(dst []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, &json.UnsupportedValueError{
+			Value: reflect.ValueOf(f),
+			Str:   strconv.FormatFloat(f, 'g', -1, bits),
+		}
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// Shorten e-09 to e-9, as encoding/json does.
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
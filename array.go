@@ -0,0 +1,501 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StringArray is an Array of String, for text[] and varchar[] columns.
+type StringArray = Array[String]
+
+// NumberArray is an Array of Numeric, for integer and float array columns.
+type NumberArray[T NumberType] = Array[Numeric[T]]
+
+// postgresTimeLayouts are the timestamp formats PostgreSQL writes inside
+// array literals, tried when an element does not parse as RFC 3339.
+var postgresTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// Array is a nullable one-dimensional PostgreSQL array of ztype values,
+// such as Array[String] or Array[Numeric[int64]]. Value writes an array
+// literal like {"a","b",NULL}, so it can be bound to text[] columns or used
+// with = ANY($1); Scan parses the same syntax back, mapping NULL elements to
+// null values. Strings are always quoted, with quotes and backslashes
+// escaped, and Bytes elements use the bytea hex format.
+//
+// Example:
+//
+//	ids := ztype.NewArray(ztype.NewNumber[int64](1), ztype.NewNumber[int64](2))
+//	rows, err := db.Query("SELECT name FROM users WHERE id = ANY($1)", ids)
+type Array[T driver.Valuer] struct {
+	value       []T
+	valid       bool
+	unmarshaled bool
+}
+
+// NewArray creates a new valid Array holding values.
+//
+// Example:
+//
+//	tags := ztype.NewArray(ztype.NewString("a"), ztype.NewNullString())
+func NewArray[T driver.Valuer](values ...T) Array[T] {
+	if values == nil {
+		values = []T{}
+	}
+	return Array[T]{value: values, valid: true}
+}
+
+// NewNullArray creates a new null Array.
+//
+// Example:
+//
+//	tags := ztype.NewNullArray[ztype.String]()
+//	fmt.Println(tags.IsNull())  // Output: true
+func NewNullArray[T driver.Valuer]() Array[T] {
+	return Array[T]{}
+}
+
+// Get returns the elements. When null, returns nil.
+//
+// Example:
+//
+//	for _, tag := range tags.Get() { /* ... */ }
+func (a Array[T]) Get() []T {
+	return a.value
+}
+
+// Val returns the elements and whether the Array is valid.
+//
+// Example:
+//
+//	if items, ok := a.Val(); ok { fmt.Println(len(items)) }
+func (a Array[T]) Val() ([]T, bool) {
+	return a.value, a.valid
+}
+
+// Set replaces the elements and marks the Array as valid.
+//
+// Example:
+//
+//	tags.Set([]ztype.String{ztype.NewString("a")})
+func (a *Array[T]) Set(values []T) {
+	if values == nil {
+		values = []T{}
+	}
+	a.value = values
+	a.valid = true
+}
+
+// SetNull marks the Array as null and drops its elements.
+//
+// Example:
+//
+//	tags.SetNull()
+func (a *Array[T]) SetNull() {
+	a.value = nil
+	a.valid = false
+}
+
+// IsNull returns true if the Array is null.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullArray[ztype.String]().IsNull())  // Output: true
+func (a Array[T]) IsNull() bool {
+	return !a.valid
+}
+
+// IsEmpty returns true if the Array is null or has no elements.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewArray[ztype.String]().IsEmpty())  // Output: true
+func (a Array[T]) IsEmpty() bool {
+	return len(a.value) == 0
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullArray[ztype.String]().IsZero())  // Output: true
+func (a Array[T]) IsZero() bool {
+	return a.IsEmpty()
+}
+
+// Unmarshaled returns true if the Array was present in the decoded input.
+//
+// Example:
+//
+//	fmt.Println(tags.Unmarshaled())
+func (a Array[T]) Unmarshaled() bool {
+	return a.unmarshaled
+}
+
+// SetUnmarshaled sets the unmarshaled state.
+//
+// Example:
+//
+//	tags.SetUnmarshaled(true)
+func (a *Array[T]) SetUnmarshaled(value bool) {
+	a.unmarshaled = value
+}
+
+// Len returns the number of elements.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewArray(ztype.NewString("a")).Len())  // Output: 1
+func (a Array[T]) Len() int {
+	return len(a.value)
+}
+
+// Value implements driver.Valuer, returning a PostgreSQL array literal or
+// nil when null.
+//
+// Example:
+//
+//	v, _ := ztype.NewArray(ztype.NewString(`a"b`), ztype.NewNullString()).Value()
+//	fmt.Println(v)  // Output: {"a\"b",NULL}
+func (a Array[T]) Value() (driver.Value, error) {
+	if !a.valid {
+		return nil, nil
+	}
+	var builder strings.Builder
+	builder.WriteByte('{')
+	for i, element := range a.value {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		if err := appendArrayElement(&builder, element); err != nil {
+			return nil, fmt.Errorf("array element %d: %w", i, err)
+		}
+	}
+	builder.WriteByte('}')
+	return builder.String(), nil
+}
+
+// ValueOrZero is like Value, but returns an empty array literal instead of
+// NULL when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullArray[ztype.String]().ValueOrZero() // "{}"
+func (a Array[T]) ValueOrZero() (driver.Value, error) {
+	if !a.valid {
+		return "{}", nil
+	}
+	return a.Value()
+}
+
+// appendArrayElement writes element as an array literal element.
+func appendArrayElement(builder *strings.Builder, element driver.Valuer) error {
+	value, err := element.Value()
+	if err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		builder.WriteString("NULL")
+	case bool:
+		builder.WriteString(strconv.FormatBool(v))
+	case int64:
+		builder.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			builder.WriteString("NaN")
+		case math.IsInf(v, 1):
+			builder.WriteString("Infinity")
+		case math.IsInf(v, -1):
+			builder.WriteString("-Infinity")
+		default:
+			builder.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	case time.Time:
+		quoteArrayElement(builder, v.Format(time.RFC3339Nano))
+	case []byte:
+		if _, ok := element.(Bytes); ok {
+			quoteArrayElement(builder, `\x`+hex.EncodeToString(v))
+		} else {
+			quoteArrayElement(builder, string(v))
+		}
+	case string:
+		quoteArrayElement(builder, v)
+	default:
+		quoteArrayElement(builder, fmt.Sprint(v))
+	}
+	return nil
+}
+
+// quoteArrayElement writes text double-quoted, escaping quotes and backslashes.
+func quoteArrayElement(builder *strings.Builder, text string) {
+	builder.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		if text[i] == '"' || text[i] == '\\' {
+			builder.WriteByte('\\')
+		}
+		builder.WriteByte(text[i])
+	}
+	builder.WriteByte('"')
+}
+
+// Scan implements sql.Scanner, parsing a PostgreSQL array literal. NULL
+// elements become null values. Multi-dimensional arrays are rejected.
+//
+// Example:
+//
+//	var tags ztype.StringArray
+//	err := tags.Scan(`{a,"b,c",NULL}`) // ["a" "b,c" <NULL>]
+func (a *Array[T]) Scan(value any) error {
+	var literal string
+	switch v := value.(type) {
+	case nil:
+		a.SetNull()
+		return nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return newUnsupportedScanType(value)
+	}
+
+	elements, err := parseArrayLiteral(literal)
+	if err != nil {
+		return err
+	}
+	values := make([]T, len(elements))
+	for i, element := range elements {
+		if err := scanArrayElement(&values[i], element); err != nil {
+			return fmt.Errorf("array element %d: %w", i, err)
+		}
+	}
+	a.Set(values)
+	return nil
+}
+
+// parseArrayLiteral splits a one-dimensional array literal into its
+// elements, unescaped. NULL elements are returned as nil.
+func parseArrayLiteral(literal string) ([]*string, error) {
+	text := strings.TrimSpace(literal)
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, &ErrInvalidFormat{Type: "Array", Input: literal}
+	}
+	text = text[1 : len(text)-1]
+	elements := []*string{}
+	if strings.TrimSpace(text) == "" {
+		return elements, nil
+	}
+
+	for i := 0; ; {
+		for i < len(text) && text[i] == ' ' {
+			i++
+		}
+		var element strings.Builder
+		quoted := i < len(text) && text[i] == '"'
+		if quoted {
+			i++
+			closed := false
+			for i < len(text) {
+				c := text[i]
+				i++
+				if c == '\\' && i < len(text) {
+					element.WriteByte(text[i])
+					i++
+					continue
+				}
+				if c == '"' {
+					closed = true
+					break
+				}
+				element.WriteByte(c)
+			}
+			if !closed {
+				return nil, newInvalidFormat("Array", literal, "unterminated quote")
+			}
+			for i < len(text) && text[i] == ' ' {
+				i++
+			}
+		} else {
+			for i < len(text) && text[i] != ',' {
+				c := text[i]
+				if c == '{' || c == '}' || c == '"' {
+					return nil, newInvalidFormat("Array", literal, "unexpected %q", c)
+				}
+				if c == '\\' && i+1 < len(text) {
+					i++
+					c = text[i]
+				}
+				element.WriteByte(c)
+				i++
+			}
+		}
+
+		content := element.String()
+		if !quoted {
+			content = strings.TrimSpace(content)
+			if content == "" {
+				return nil, newInvalidFormat("Array", literal, "empty element")
+			}
+		}
+		if !quoted && strings.EqualFold(content, "NULL") {
+			elements = append(elements, nil)
+		} else {
+			elements = append(elements, &content)
+		}
+
+		if i >= len(text) {
+			return elements, nil
+		}
+		if text[i] != ',' {
+			return nil, newInvalidFormat("Array", literal, "unexpected %q", text[i])
+		}
+		i++
+	}
+}
+
+// scanArrayElement stores one parsed element into target, trying Scan with
+// the text first and UnmarshalText second. Bytes elements accept the bytea
+// hex format and Time elements the PostgreSQL timestamp format.
+func scanArrayElement[T any](target *T, element *string) error {
+	scanner, ok := any(target).(sql.Scanner)
+	if !ok {
+		return fmt.Errorf("%T does not implement sql.Scanner", target)
+	}
+	if element == nil {
+		return scanner.Scan(nil)
+	}
+	text := *element
+
+	switch any(target).(type) {
+	case *Bytes:
+		if encoded, ok := strings.CutPrefix(text, `\x`); ok {
+			decoded, err := hex.DecodeString(encoded)
+			if err != nil {
+				return err
+			}
+			return scanner.Scan(decoded)
+		}
+	case *Time:
+		for _, layout := range postgresTimeLayouts {
+			if parsed, err := time.Parse(layout, text); err == nil {
+				return scanner.Scan(parsed)
+			}
+		}
+	}
+
+	scanErr := scanner.Scan(text)
+	if scanErr == nil {
+		return nil
+	}
+	if unmarshaler, ok := any(target).(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(text)); err == nil {
+			return nil
+		}
+	}
+	return scanErr
+}
+
+// MarshalJSON implements json.Marshaler, writing a JSON array of the
+// elements or null.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ztype.NewArray(ztype.NewString("a"), ztype.NewNullString()))
+//	fmt.Println(string(data))  // Output: ["a",null]
+func (a Array[T]) MarshalJSON() ([]byte, error) {
+	if !a.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(a.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a JSON array or null.
+//
+// Example:
+//
+//	var tags ztype.StringArray
+//	err := json.Unmarshal([]byte(`["a",null]`), &tags)
+func (a *Array[T]) UnmarshalJSON(data []byte) error {
+	a.unmarshaled = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		a.SetNull()
+		return nil
+	}
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return wrapJSONError("Array", data, err)
+	}
+	a.Set(values)
+	return nil
+}
+
+// String returns the array literal, or the null token when null.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewArray(ztype.NewNumber(1), ztype.NewNumber(2)))  // Output: {1,2}
+func (a Array[T]) String() string {
+	if !a.valid {
+		return nullToken()
+	}
+	value, err := a.Value()
+	if err != nil {
+		return fmt.Sprintf("%v", a.value)
+	}
+	return value.(string)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullArray[ztype.String]().StringOr("{}"))  // Output: {}
+func (a Array[T]) StringOr(fallback string) string {
+	if !a.valid {
+		return fallback
+	}
+	return a.String()
+}
+
+// SchemaType reports Array as a nullable JSON array.
+//
+// Example:
+//
+//	ztype.StringArray{}.SchemaType() // "array", "", true
+func (a Array[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "array", "", true
+}
+
+// schemaItem returns the element type, described as items.
+func (a Array[T]) schemaItem() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// Clone returns a copy of the Array with its own backing array, keeping its
+// null and unmarshaled state.
+//
+// Example:
+//
+//	c := tags.Clone()
+func (a Array[T]) Clone() Array[T] {
+	a.value = slices.Clone(a.value)
+	return a
+}
@@ -0,0 +1,410 @@
+package ztype
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// The binary format of MarshalBinary is a version byte, a validity byte
+// (0 for null, 1 for a value) and, for valid values, the type's payload.
+// Payloads of version 1:
+//
+//	Bool      1 byte, 0 or 1
+//	Byte      1 byte
+//	String    the UTF-8 bytes
+//	Duration  signed varint of the nanoseconds
+//	Time      the time.Time MarshalBinary encoding, with its offset
+//	Numeric   signed varint for signed integers, unsigned varint for
+//	          unsigned ones and the 8 big-endian bytes of a float64 for floats
+//	Map       the JSON encoding of the map
+//
+// Version 2 puts a kind byte before the Numeric payload: 0 for signed
+// integers, 1 for unsigned ones and 2 for floats. The other payloads are
+// unchanged, and Time was added. Decoders keep accepting every earlier
+// version.
+const binaryVersion = 2
+
+// appendBinaryHeader starts a MarshalBinary payload.
+func appendBinaryHeader(valid bool) []byte {
+	if !valid {
+		return []byte{binaryVersion, 0}
+	}
+	return []byte{binaryVersion, 1}
+}
+
+// readBinaryHeader checks the header of data and returns its payload and
+// whether it holds a value.
+func readBinaryHeader(typeName string, data []byte) ([]byte, bool, error) {
+	if len(data) < 2 {
+		return nil, false, newInvalidFormat(typeName, string(data), "truncated binary payload")
+	}
+	if data[0] == 0 || data[0] > binaryVersion {
+		return nil, false, newInvalidFormat(typeName, string(data), "unsupported binary version %d", data[0])
+	}
+	switch data[1] {
+	case 0:
+		if len(data) > 2 {
+			return nil, false, newInvalidFormat(typeName, string(data), "trailing bytes after null")
+		}
+		return nil, false, nil
+	case 1:
+		return data[2:], true, nil
+	}
+	return nil, false, newInvalidFormat(typeName, string(data), "invalid null flag %d", data[1])
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with a compact, versioned
+// format that keeps null, so values can be stored in caches such as Redis.
+//
+// Example:
+//
+//	data, _ := ztype.NewBool(true).MarshalBinary()
+//	err := rdb.Set(ctx, "flag", ztype.NewBool(true), time.Hour).Err()
+func (b Bool) MarshalBinary() ([]byte, error) {
+	data := appendBinaryHeader(b.value.Valid)
+	if !b.value.Valid {
+		return data, nil
+	}
+	if b.value.Bool {
+		return append(data, 1), nil
+	}
+	return append(data, 0), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the output
+// of MarshalBinary.
+//
+// Example:
+//
+//	var b ztype.Bool
+//	err := rdb.Get(ctx, "flag").Scan(&b)
+func (b *Bool) UnmarshalBinary(data []byte) error {
+	b.unmarshaled = true
+	payload, valid, err := readBinaryHeader("Bool", data)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		b.SetNull()
+		return nil
+	}
+	if len(payload) != 1 || payload[0] > 1 {
+		return newInvalidFormat("Bool", string(data), "invalid binary payload")
+	}
+	b.Set(payload[0] == 1)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. See Bool.MarshalBinary.
+//
+// Example:
+//
+//	data, _ := ztype.NewByte(7).MarshalBinary()
+func (b Byte) MarshalBinary() ([]byte, error) {
+	data := appendBinaryHeader(b.value.Valid)
+	if !b.value.Valid {
+		return data, nil
+	}
+	return append(data, b.value.Byte), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// Example:
+//
+//	var b ztype.Byte
+//	err := b.UnmarshalBinary(data)
+func (b *Byte) UnmarshalBinary(data []byte) error {
+	b.unmarshaled = true
+	payload, valid, err := readBinaryHeader("Byte", data)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		b.SetNull()
+		return nil
+	}
+	if len(payload) != 1 {
+		return newInvalidFormat("Byte", string(data), "invalid binary payload")
+	}
+	b.Set(payload[0])
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The empty string and
+// null are told apart by the header.
+//
+// Example:
+//
+//	data, _ := ztype.NewString("hello").MarshalBinary()
+func (s String) MarshalBinary() ([]byte, error) {
+	data := appendBinaryHeader(s.value.Valid)
+	if !s.value.Valid {
+		return data, nil
+	}
+	return append(data, s.value.String...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// Example:
+//
+//	var s ztype.String
+//	err := rdb.Get(ctx, "name").Scan(&s)
+func (s *String) UnmarshalBinary(data []byte) error {
+	s.unmarshaled = true
+	payload, valid, err := readBinaryHeader("String", data)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		s.SetNull()
+		return nil
+	}
+	s.Set(string(payload))
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, storing the
+// nanoseconds as a varint.
+//
+// Example:
+//
+//	data, _ := ztype.NewDuration(time.Minute).MarshalBinary()
+func (d Duration) MarshalBinary() ([]byte, error) {
+	data := appendBinaryHeader(d.valid)
+	if !d.valid {
+		return data, nil
+	}
+	return binary.AppendVarint(data, int64(d.value)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// Example:
+//
+//	var d ztype.Duration
+//	err := d.UnmarshalBinary(data)
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	d.unmarshaled = true
+	payload, valid, err := readBinaryHeader("Duration", data)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		d.SetNull()
+		return nil
+	}
+	nanos, n := binary.Varint(payload)
+	if n <= 0 || n != len(payload) {
+		return newInvalidFormat("Duration", string(data), "invalid binary payload")
+	}
+	d.Set(time.Duration(nanos))
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, storing the time with
+// its offset after the header.
+//
+// Example:
+//
+//	data, _ := ztype.NewTime(time.Now()).MarshalBinary()
+func (t Time) MarshalBinary() ([]byte, error) {
+	data := appendBinaryHeader(t.value.Valid)
+	if !t.value.Valid {
+		return data, nil
+	}
+	return t.value.Time.AppendBinary(data)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It also reads the
+// bare time.Time encoding that Time wrote before it had a header, where the
+// zero time stands for null.
+//
+// Example:
+//
+//	var t ztype.Time
+//	err := rdb.Get(ctx, "expires").Scan(&t)
+func (t *Time) UnmarshalBinary(data []byte) error {
+	t.unmarshaled = true
+	payload, valid := data, true
+	if !isRawTimeBinary(data) {
+		var err error
+		if payload, valid, err = readBinaryHeader("Time", data); err != nil {
+			return err
+		}
+	}
+	if !valid {
+		t.SetNull()
+		return nil
+	}
+	var value time.Time
+	if err := value.UnmarshalBinary(payload); err != nil {
+		return newInvalidFormat("Time", string(data), "%w", err)
+	}
+	if value.IsZero() && isRawTimeBinary(data) {
+		t.SetNull()
+		return nil
+	}
+	t.Set(value)
+	return nil
+}
+
+// isRawTimeBinary reports whether data is a bare time.Time encoding, which
+// is 15 or 16 bytes long and starts with its own version 1 or 2. A Time
+// payload with a header is either 2 bytes or longer than 16.
+func isRawTimeBinary(data []byte) bool {
+	return (len(data) == 15 || len(data) == 16) && (data[0] == 1 || data[0] == 2)
+}
+
+// Kinds of number in a Numeric payload of version 2.
+const (
+	binarySigned byte = iota
+	binaryUnsigned
+	binaryFloat
+)
+
+// binaryNumberKind returns the kind of number T is stored as.
+func binaryNumberKind[T NumberType]() byte {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binaryUnsigned
+	case reflect.Float32, reflect.Float64:
+		return binaryFloat
+	}
+	return binarySigned
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The payload records
+// whether the number is signed, unsigned or a float; integers are stored as
+// varints and floats as their 8-byte IEEE 754 bits, so values decode exactly
+// into any Numeric wide enough to hold them.
+//
+// Example:
+//
+//	data, _ := ztype.NewNumber[int64](42).MarshalBinary()
+func (n Numeric[T]) MarshalBinary() ([]byte, error) {
+	data := appendBinaryHeader(n.value.Valid)
+	if !n.value.Valid {
+		return data, nil
+	}
+	kind := binaryNumberKind[T]()
+	data = append(data, kind)
+	switch kind {
+	case binaryUnsigned:
+		return binary.AppendUvarint(data, uint64(n.value.V)), nil
+	case binaryFloat:
+		return binary.BigEndian.AppendUint64(data, math.Float64bits(float64(n.value.V))), nil
+	}
+	return binary.AppendVarint(data, int64(n.value.V)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The stored number
+// is converted to T, so a value written by another Numeric decodes when it
+// fits; one that does not fails with ErrOverflow, and a fraction decoded
+// into an integer type fails with ErrInvalidFormat. Payloads of version 1
+// carry no kind and are read as the kind of T.
+//
+// Example:
+//
+//	var n ztype.Numeric[int64]
+//	err := rdb.Get(ctx, "count").Scan(&n)
+func (n *Numeric[T]) UnmarshalBinary(data []byte) error {
+	n.unmarshaled = true
+	typeName := numericTypeName[T]()
+	payload, valid, err := readBinaryHeader(typeName, data)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		n.SetNull()
+		return nil
+	}
+
+	kind := binaryNumberKind[T]()
+	if data[0] > 1 { // version 1 has no kind byte
+		if len(payload) == 0 {
+			return newInvalidFormat(typeName, string(data), "invalid binary payload")
+		}
+		kind, payload = payload[0], payload[1:]
+	}
+	var decoded any
+	var size int
+	switch kind {
+	case binarySigned:
+		var signed int64
+		signed, size = binary.Varint(payload)
+		decoded = signed
+	case binaryUnsigned:
+		var unsigned uint64
+		unsigned, size = binary.Uvarint(payload)
+		decoded = unsigned
+	case binaryFloat:
+		if len(payload) == 8 {
+			decoded, size = math.Float64frombits(binary.BigEndian.Uint64(payload)), 8
+		}
+	default:
+		return newInvalidFormat(typeName, string(data), "unknown number kind %d", kind)
+	}
+	if size <= 0 || size != len(payload) {
+		return newInvalidFormat(typeName, string(data), "invalid binary payload")
+	}
+
+	var value T
+	if err := convertNumber(decoded, reflect.ValueOf(&value).Elem()); err != nil {
+		if errors.Is(err, &ErrOverflow{}) {
+			return &ErrOverflow{Type: typeName, Value: fmt.Sprint(decoded)}
+		}
+		return newInvalidFormat(typeName, fmt.Sprint(decoded), "not an integer")
+	}
+	n.Set(value)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, storing the JSON
+// encoding of the map after the header.
+//
+// Example:
+//
+//	data, _ := ztype.NewMap(map[string]int{"a": 1}).MarshalBinary()
+func (m Map[K, V]) MarshalBinary() ([]byte, error) {
+	data := appendBinaryHeader(m.valid)
+	if !m.valid {
+		return data, nil
+	}
+	encoded, err := json.Marshal(m.value)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, encoded...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// Example:
+//
+//	var m ztype.Map[string, int]
+//	err := m.UnmarshalBinary(data)
+func (m *Map[K, V]) UnmarshalBinary(data []byte) error {
+	m.unmarshaled = true
+	payload, valid, err := readBinaryHeader("Map", data)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		m.SetNull()
+		return nil
+	}
+	result, err := unmarshalMap[K, V](&defaultCodec, payload)
+	if err != nil {
+		return err
+	}
+	m.value = result
+	m.valid = true
+	return nil
+}
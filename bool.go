@@ -5,9 +5,81 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
 )
 
+// SetBoolJSONLenient enables or disables lenient JSON decoding for Bool.
+// When enabled, UnmarshalJSON also accepts the numbers 0 and 1 and quoted
+// tokens understood by UnmarshalText, such as "true", "1" or "yes".
+//
+// Example:
+//
+//	ztype.SetBoolJSONLenient(true)
+//	var b ztype.Bool
+//	json.Unmarshal([]byte(`"yes"`), &b)
+//	fmt.Println(b.Get())  // Output: true
+func SetBoolJSONLenient(lenient bool) {
+	defaultCodec.boolJSONLenient.Store(lenient)
+}
+
+// parseBoolToken parses the tokens accepted by strconv.ParseBool plus
+// yes/no, y/n and on/off, all case-insensitively.
+func parseBoolToken(text string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, newInvalidFormat("Bool", text, "")
+}
+
+// SetBoolScanTokens replaces the text tokens Bool.Scan accepts from string
+// and []byte columns, such as CHAR(1) flags. Tokens are matched
+// case-insensitively after trimming spaces, so CHAR padding is ignored.
+// Calling it with no tokens restores the defaults: the strconv.ParseBool
+// set plus y/n, yes/no and on/off. UnmarshalText is not affected.
+//
+// Example:
+//
+//	ztype.SetBoolScanTokens([]string{"S", "Y", "1"}, []string{"N", "0"})
+//	var b ztype.Bool
+//	err := b.Scan("S") // true
+func SetBoolScanTokens(trueTokens, falseTokens []string) {
+	defaultCodec.boolScanTokens.Store(boolTokenMap(trueTokens, falseTokens))
+}
+
+// boolTokenMap maps the lower-cased, trimmed tokens to their value, or
+// returns nil, the default tokens, when there are none.
+func boolTokenMap(trueTokens, falseTokens []string) *map[string]bool {
+	if len(trueTokens) == 0 && len(falseTokens) == 0 {
+		return nil
+	}
+	tokens := make(map[string]bool, len(trueTokens)+len(falseTokens))
+	for _, token := range trueTokens {
+		tokens[strings.ToLower(strings.TrimSpace(token))] = true
+	}
+	for _, token := range falseTokens {
+		tokens[strings.ToLower(strings.TrimSpace(token))] = false
+	}
+	return &tokens
+}
+
+// scanBoolToken parses a text column with the scan tokens of the Codec.
+func (c *Codec) scanBoolToken(text string) (bool, error) {
+	tokens := c.boolScanTokens.Load()
+	if tokens == nil {
+		return parseBoolToken(text)
+	}
+	if value, ok := (*tokens)[strings.ToLower(strings.TrimSpace(text))]; ok {
+		return value, nil
+	}
+	return false, newInvalidFormat("Bool", text, "")
+}
+
 // Bool represents a nullable boolean type that can distinguish between:
 // - Explicit database/SQL NULL values
 // - Absent values in JSON unmarshaling
@@ -65,6 +137,21 @@ func NewNullBoolIfZero(value bool) Bool {
 	return NewBool(value)
 }
 
+// NewBoolFromPtr creates a Bool from a pointer: nil becomes null,
+// anything else a valid Bool holding the pointed-to value.
+//
+// Example:
+//
+//	var flag *bool
+//	b := ztype.NewBoolFromPtr(flag)
+//	fmt.Println(b.IsNull())  // Output: true
+func NewBoolFromPtr(value *bool) Bool {
+	if value == nil {
+		return NewNullBool()
+	}
+	return NewBool(*value)
+}
+
 // Get returns the boolean value. When null, returns false.
 // Use IsNull() to check validity before using this value.
 //
@@ -78,6 +165,72 @@ func (b *Bool) Get() bool {
 	return b.value.Bool
 }
 
+// Val returns the boolean and whether it is valid, the comma-ok form of Get
+// that keeps a false from null apart from a stored false.
+//
+// Example:
+//
+//	if active, ok := b.Val(); ok && active { /* ... */ }
+func (b Bool) Val() (bool, bool) {
+	return b.value.Bool, b.value.Valid
+}
+
+// GetOr returns the boolean value, or fallback when null.
+//
+// Example:
+//
+//	b := ztype.NewNullBool()
+//	fmt.Println(b.GetOr(true))  // Output: true
+func (b *Bool) GetOr(fallback bool) bool {
+	if !b.value.Valid {
+		return fallback
+	}
+	return b.value.Bool
+}
+
+// Truth reports whether the Bool is valid and true. It has a value receiver,
+// so templates can call it on fields of structs passed by value, where
+// {{ if .Active }} is always true and {{ if .Active.Get }} requires a pointer.
+//
+// Example:
+//
+//	{{ if .User.Active.Truth }}active{{ else }}inactive{{ end }}
+func (b Bool) Truth() bool {
+	return b.value.Valid && b.value.Bool
+}
+
+// Ptr returns a pointer to a copy of the value, or nil when null.
+//
+// Example:
+//
+//	b := ztype.NewBool(true)
+//	p := b.Ptr()
+//	fmt.Println(*p)  // Output: true
+func (b *Bool) Ptr() *bool {
+	if !b.value.Valid {
+		return nil
+	}
+	value := b.value.Bool
+	return &value
+}
+
+// ToInt converts the value to 1 (true) or 0 (false), e.g. for tinyint
+// columns. Null stays null.
+//
+// Example:
+//
+//	b := ztype.NewBool(true)
+//	fmt.Println(b.ToInt().Get())  // Output: 1
+func (b *Bool) ToInt() Numeric[int] {
+	if !b.value.Valid {
+		return NewNullNumber[int]()
+	}
+	if b.value.Bool {
+		return NewNumber(1)
+	}
+	return NewNumber(0)
+}
+
 // Set updates the value and marks it as valid.
 //
 // Example:
@@ -102,6 +255,19 @@ func (b *Bool) SetNull() {
 	b.value.Valid = false
 }
 
+// Toggle flips a valid value in place. Null values stay null.
+//
+// Example:
+//
+//	b := ztype.NewBool(true)
+//	b.Toggle()
+//	fmt.Println(b.Get())  // Output: false
+func (b *Bool) Toggle() {
+	if b.value.Valid {
+		b.value.Bool = !b.value.Bool
+	}
+}
+
 // IsNull returns true if the value is null.
 //
 // Example:
@@ -112,14 +278,37 @@ func (b *Bool) IsNull() bool {
 	return !b.value.Valid
 }
 
-// IsZero returns true if the value is zero/false.
+// IsEmpty returns true if the value is null or false.
 //
 // Example:
 //
 //	b := ztype.NewBool(false)
-//	fmt.Println(b.IsZero())  // Output: true
-func (b *Bool) IsZero() bool {
-	return !b.value.Bool
+//	fmt.Println(b.IsEmpty())  // Output: true
+func (b Bool) IsEmpty() bool {
+	return !b.value.Valid || !b.value.Bool
+}
+
+// IsZero returns true only if the value is null, so an explicit false
+// survives `json:",omitzero"`. Unlike the other types it is not an alias for
+// IsEmpty: a false flag is data, and dropping it would make it read as
+// absent. Use IsEmpty or IsNullOrFalse to treat false as empty too.
+//
+// Example:
+//
+//	b := ztype.NewBool(false)
+//	fmt.Println(b.IsZero())  // Output: false
+func (b Bool) IsZero() bool {
+	return !b.value.Valid
+}
+
+// IsNullOrFalse returns true if the value is null or false.
+//
+// Example:
+//
+//	b := ztype.NewBool(false)
+//	fmt.Println(b.IsNullOrFalse())  // Output: true
+func (b *Bool) IsNullOrFalse() bool {
+	return !b.value.Valid || !b.value.Bool
 }
 
 // Unmarshaled returns true if the value was present in the data source,
@@ -156,6 +345,28 @@ func (b *Bool) Equal(other Bool) bool {
 		b.value.Valid == other.value.Valid
 }
 
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	b := ztype.NewBool(true)
+//	fmt.Println(b.EqualValues(ztype.NewBool(true)))  // Output: true
+func (b Bool) EqualValues(other Bool) bool {
+	return b.Equal(other)
+}
+
+// Clone returns a copy of the Bool, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	b := ztype.NewBool(true)
+//	c := b.Clone()
+func (b Bool) Clone() Bool {
+	return b
+}
+
 // EqualRaw compares the boolean value while ignoring null state.
 // Returns false if either value is null.
 //
@@ -167,6 +378,144 @@ func (b *Bool) EqualRaw(other bool) bool {
 	return b.value.Bool == other
 }
 
+// IsTrue returns true only for a valid true value. Null is neither true nor false.
+//
+// Example:
+//
+//	b := ztype.NewNullBool()
+//	fmt.Println(b.IsTrue())  // Output: false
+func (b *Bool) IsTrue() bool {
+	return b.value.Valid && b.value.Bool
+}
+
+// IsFalse returns true only for a valid false value. Null is neither true nor false.
+//
+// Example:
+//
+//	b := ztype.NewNullBool()
+//	fmt.Println(b.IsFalse())  // Output: false
+func (b *Bool) IsFalse() bool {
+	return b.value.Valid && !b.value.Bool
+}
+
+// And returns the logical conjunction using SQL three-valued logic:
+// false wins over null, and null AND true is null.
+//
+// Example:
+//
+//	b := ztype.NewNullBool()
+//	fmt.Println(b.And(ztype.NewBool(false)))  // Output: false
+//	fmt.Println(b.And(ztype.NewBool(true)))   // Output: <NULL>
+func (b *Bool) And(other Bool) Bool {
+	if b.IsFalse() || other.IsFalse() {
+		return NewBool(false)
+	}
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullBool()
+	}
+	return NewBool(true)
+}
+
+// Or returns the logical disjunction using SQL three-valued logic:
+// true wins over null, and null OR false is null.
+//
+// Example:
+//
+//	b := ztype.NewNullBool()
+//	fmt.Println(b.Or(ztype.NewBool(true)))   // Output: true
+//	fmt.Println(b.Or(ztype.NewBool(false)))  // Output: <NULL>
+func (b *Bool) Or(other Bool) Bool {
+	if b.IsTrue() || other.IsTrue() {
+		return NewBool(true)
+	}
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullBool()
+	}
+	return NewBool(false)
+}
+
+// Not returns the logical negation. NOT null is null.
+//
+// Example:
+//
+//	b := ztype.NewBool(true)
+//	fmt.Println(b.Not())  // Output: false
+func (b *Bool) Not() Bool {
+	if !b.value.Valid {
+		return NewNullBool()
+	}
+	return NewBool(!b.value.Bool)
+}
+
+// Xor returns the exclusive disjunction. The result is null if either operand is null.
+//
+// Example:
+//
+//	b := ztype.NewBool(true)
+//	fmt.Println(b.Xor(ztype.NewBool(false)))  // Output: true
+func (b *Bool) Xor(other Bool) Bool {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullBool()
+	}
+	return NewBool(b.value.Bool != other.value.Bool)
+}
+
+// AnyTrue folds values with three-valued OR: true if any value is true,
+// null if none is true but some are null, false otherwise (including empty).
+//
+// Example:
+//
+//	r := ztype.AnyTrue([]ztype.Bool{ztype.NewBool(false), ztype.NewNullBool()})
+//	fmt.Println(r.IsNull())  // Output: true
+func AnyTrue(values []Bool) Bool {
+	result := NewBool(false)
+	for i := range values {
+		if values[i].IsTrue() {
+			return NewBool(true)
+		}
+		if values[i].IsNull() {
+			result = NewNullBool()
+		}
+	}
+	return result
+}
+
+// AllTrue folds values with three-valued AND: false if any value is false,
+// null if none is false but some are null, true otherwise (including empty).
+//
+// Example:
+//
+//	r := ztype.AllTrue([]ztype.Bool{ztype.NewBool(true), ztype.NewNullBool()})
+//	fmt.Println(r.IsNull())  // Output: true
+func AllTrue(values []Bool) Bool {
+	result := NewBool(true)
+	for i := range values {
+		if values[i].IsFalse() {
+			return NewBool(false)
+		}
+		if values[i].IsNull() {
+			result = NewNullBool()
+		}
+	}
+	return result
+}
+
+// CountTrue returns how many values are true, skipping nulls.
+//
+// Example:
+//
+//	n := ztype.CountTrue([]ztype.Bool{ztype.NewBool(true), ztype.NewNullBool()})
+//	fmt.Println(n)  // Output: 1
+func CountTrue(values []Bool) int {
+	count := 0
+	for i := range values {
+		if values[i].IsTrue() {
+			count++
+		}
+	}
+	return count
+}
+
 // MarshalText implements encoding.TextMarshaler.
 // Returns "true"/"false" for valid values, nil for null.
 //
@@ -175,7 +524,7 @@ func (b *Bool) EqualRaw(other bool) bool {
 //	b := ztype.NewBool(true)
 //	data, _ := b.MarshalText()
 //	fmt.Println(string(data))  // Output: true
-func (b *Bool) MarshalText() ([]byte, error) {
+func (b Bool) MarshalText() ([]byte, error) {
 	if b.value.Valid {
 		return []byte(strconv.FormatBool(b.value.Bool)), nil
 	}
@@ -183,7 +532,8 @@ func (b *Bool) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-// Sets unmarshaled flag and parses boolean from string.
+// Sets unmarshaled flag and parses boolean from string. Accepts the
+// strconv.ParseBool tokens plus yes/no, y/n and on/off, case-insensitively.
 //
 // Example:
 //
@@ -192,7 +542,7 @@ func (b *Bool) MarshalText() ([]byte, error) {
 //	fmt.Println(b.Get())  // Output: true
 func (b *Bool) UnmarshalText(data []byte) error {
 	b.unmarshaled = true
-	value, err := strconv.ParseBool(string(data))
+	value, err := parseBoolToken(string(data))
 	if err != nil {
 		return err
 	}
@@ -209,15 +559,26 @@ func (b *Bool) UnmarshalText(data []byte) error {
 //	b := ztype.NewBool(true)
 //	jsonData, _ := json.Marshal(b)
 //	fmt.Println(string(jsonData))  // Output: true
-func (b *Bool) MarshalJSON() ([]byte, error) {
-	if b.value.Valid {
-		return json.Marshal(b.value.Bool)
+func (b Bool) MarshalJSON() ([]byte, error) {
+	return b.AppendJSON(make([]byte, 0, 8))
+}
+
+// AppendJSON appends the JSON encoding of the Bool to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewBool(true).AppendJSON(buf) // true
+func (b Bool) AppendJSON(dst []byte) ([]byte, error) {
+	if !b.value.Valid {
+		return append(dst, "null"...), nil
 	}
-	return []byte("null"), nil
+	return strconv.AppendBool(dst, b.value.Bool), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
-// Handles both boolean values and explicit nulls.
+// Handles both boolean values and explicit nulls. See SetBoolJSONLenient
+// for accepting numbers and quoted tokens.
 //
 // Example:
 //
@@ -225,24 +586,113 @@ func (b *Bool) MarshalJSON() ([]byte, error) {
 //	json.Unmarshal([]byte(`null`), &b)
 //	fmt.Println(b.IsNull())  // Output: true
 func (b *Bool) UnmarshalJSON(data []byte) error {
+	return b.unmarshalJSONCodec(&defaultCodec, data)
+}
+
+// unmarshalJSONCodec implements UnmarshalJSON with the options of c.
+func (b *Bool) unmarshalJSONCodec(c *Codec, data []byte) error {
 	b.unmarshaled = true
 	if bytes.Equal(data, []byte("null")) {
 		b.value.Valid = false
 		b.value.Bool = false
 		return nil
 	}
+	if c.boolJSONLenient.Load() {
+		return b.unmarshalLenientJSON(data)
+	}
+	var value bool
+	if err := json.Unmarshal(data, &value); err != nil {
+		return wrapInvalidFormat("Bool", string(data), err)
+	}
+	b.value.Bool = value
 	b.value.Valid = true
-	return json.Unmarshal(data, &b.value.Bool)
+	return nil
+}
+
+// unmarshalLenientJSON decodes JSON booleans, the numbers 0 and 1 and
+// quoted tokens accepted by UnmarshalText.
+func (b *Bool) unmarshalLenientJSON(data []byte) error {
+	var token any
+	if err := json.Unmarshal(data, &token); err != nil {
+		return wrapInvalidFormat("Bool", string(data), err)
+	}
+	var value bool
+	switch v := token.(type) {
+	case bool:
+		value = v
+	case float64:
+		if v != 0 && v != 1 {
+			return newInvalidFormat("Bool", string(data), "expected 0 or 1")
+		}
+		value = v == 1
+	case string:
+		parsed, err := parseBoolToken(v)
+		if err != nil {
+			return err
+		}
+		value = parsed
+	default:
+		return newInvalidFormat("Bool", string(data), "")
+	}
+	b.value.Bool = value
+	b.value.Valid = true
+	return nil
 }
 
 // Scan implements sql.Scanner for database integration.
+// Accepts bool, int64 and float64 with any non-zero number read as true, as
+// TINYINT flag columns may hold values other than 1, a single 0x00 or 0x01
+// byte as read from BIT(1) columns, and string tokens such as Postgres'
+// "t"/"f" text output or CHAR(1) 'Y'/'N' flags; see SetBoolScanTokens.
+// NaN is rejected.
 //
 // Example:
 //
 //	var b ztype.Bool
 //	err := db.QueryRow("SELECT active FROM users WHERE id = 1").Scan(&b)
 func (b *Bool) Scan(value any) error {
-	return b.value.Scan(value)
+	return b.scanCodec(&defaultCodec, value)
+}
+
+// scanCodec implements Scan with the scan tokens of c.
+func (b *Bool) scanCodec(c *Codec, value any) error {
+	var parsed bool
+	switch v := value.(type) {
+	case nil:
+		b.value.Bool = false
+		b.value.Valid = false
+		return nil
+	case bool:
+		parsed = v
+	case int64:
+		parsed = v != 0
+	case float64:
+		if math.IsNaN(v) {
+			return newInvalidFormat("Bool", "NaN", "expected a number")
+		}
+		parsed = v != 0
+	case string:
+		token, err := c.scanBoolToken(v)
+		if err != nil {
+			return err
+		}
+		parsed = token
+	case []byte:
+		if len(v) == 1 && v[0] <= 1 {
+			parsed = v[0] == 1
+			break
+		}
+		token, err := c.scanBoolToken(string(v))
+		if err != nil {
+			return err
+		}
+		parsed = token
+	default:
+		return newUnsupportedScanType(value)
+	}
+	b.value.Bool = parsed
+	b.value.Valid = true
+	return nil
 }
 
 // Value implements driver.Valuer for database integration.
@@ -255,6 +705,19 @@ func (b Bool) Value() (driver.Value, error) {
 	return b.value.Value()
 }
 
+// ValueOrZero is like Value, but returns false instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullBool().ValueOrZero() // false
+func (b Bool) ValueOrZero() (driver.Value, error) {
+	if !b.value.Valid {
+		return NewBool(false).Value()
+	}
+	return b.Value()
+}
+
 // String returns human-readable representation.
 // Returns "<NULL>" for null values, otherwise "true"/"false".
 //
@@ -262,9 +725,55 @@ func (b Bool) Value() (driver.Value, error) {
 //
 //	b := ztype.NewNullBool()
 //	fmt.Println(b.String())  // Output: <NULL>
-func (b *Bool) String() string {
+func (b Bool) String() string {
 	if !b.value.Valid {
-		return "<NULL>"
+		return nullToken()
 	}
 	return strconv.FormatBool(b.value.Bool)
 }
+
+// AppendString appends the String output to dst without allocating when
+// dst has enough capacity.
+//
+// Example:
+//
+//	buf = ztype.NewBool(true).AppendString(buf[:0]) // "true"
+func (b Bool) AppendString(dst []byte) []byte {
+	if !b.value.Valid {
+		return append(dst, nullToken()...)
+	}
+	return strconv.AppendBool(dst, b.value.Bool)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullBool().StringOr("-"))  // Output: -
+func (b Bool) StringOr(fallback string) string {
+	if !b.value.Valid {
+		return fallback
+	}
+	return b.String()
+}
+
+// SchemaType reports Bool as a nullable JSON boolean.
+//
+// Example:
+//
+//	ztype.Bool{}.SchemaType() // "boolean", "", true
+func (b Bool) SchemaType() (jsonType string, format string, nullable bool) {
+	return "boolean", "", true
+}
+
+// Format implements fmt.Formatter. Verbs such as %t or %q and their flags
+// apply to the underlying bool; %v and %s print String and null prints
+// "<NULL>" for every verb.
+//
+// Example:
+//
+//	fmt.Printf("%-6t|", ztype.NewBool(true)) // Output: true  |
+func (b Bool) Format(f fmt.State, verb rune) {
+	formatNullable(f, verb, b.value.Valid, b.String(), b.value.Bool)
+}
@@ -0,0 +1,114 @@
+package ztype
+
+import (
+	"fmt"
+	"time"
+)
+
+// civilDay is a calendar date without a location, used to compare days.
+type civilDay struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// dayOf returns the calendar date of t in its own location.
+func dayOf(t time.Time) civilDay {
+	year, month, day := t.Date()
+	return civilDay{year, month, day}
+}
+
+// businessCalendar skips weekends and a set of holiday dates.
+type businessCalendar map[civilDay]struct{}
+
+// newBusinessCalendar collects the calendar dates of holidays, each read in
+// its own location.
+func newBusinessCalendar(holidays []time.Time) businessCalendar {
+	calendar := make(businessCalendar, len(holidays))
+	for _, holiday := range holidays {
+		calendar[dayOf(holiday)] = struct{}{}
+	}
+	return calendar
+}
+
+// isBusinessDay reports whether day, a noon UTC date, is a weekday that is
+// not a holiday.
+func (c businessCalendar) isBusinessDay(day time.Time) bool {
+	if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	_, holiday := c[dayOf(day)]
+	return !holiday
+}
+
+// noonUTC returns the calendar date of t as noon UTC, so stepping a day at
+// a time never crosses a daylight saving transition.
+func noonUTC(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+// AddBusinessDays moves the time n business days forward, or backward when
+// n is negative, skipping Saturdays, Sundays and the given holidays. Days are
+// compared by calendar date: the Time's in its own location and each
+// holiday's in the holiday's location, so time.Date(2024, 12, 25, 0, 0, 0,
+// 0, time.UTC) marks Christmas for a Time in any zone. The clock time is kept.
+// A null Time stays null.
+//
+// Example:
+//
+//	friday := ztype.NewTime(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+//	due := friday.AddBusinessDays(1)
+//	fmt.Println(due.Get().Format(time.DateOnly)) // Output: 2024-03-04
+func (t Time) AddBusinessDays(n int, holidays ...time.Time) Time {
+	if !t.value.Valid || n == 0 {
+		return t
+	}
+	calendar := newBusinessCalendar(holidays)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	day, offset := noonUTC(t.value.Time), 0
+	for n > 0 {
+		day = day.AddDate(0, 0, step)
+		offset += step
+		if calendar.isBusinessDay(day) {
+			n--
+		}
+	}
+	t.value.Time = t.value.Time.AddDate(0, 0, offset)
+	return t
+}
+
+// BusinessDaysUntil counts the business days after the Time up to and
+// including other, negated when other is earlier, so that for a Time on a
+// business day t.BusinessDaysUntil(t.AddBusinessDays(n)) is n. Days
+// are compared as in AddBusinessDays, with other read in the location of the
+// Time. Returns ErrNullValue when either Time is null.
+//
+// Example:
+//
+//	friday := ztype.NewTime(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+//	tuesday := ztype.NewTime(time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC))
+//	n, _ := friday.BusinessDaysUntil(tuesday) // 2
+func (t Time) BusinessDaysUntil(other Time, holidays ...time.Time) (int, error) {
+	if !t.value.Valid || !other.value.Valid {
+		return 0, fmt.Errorf("cannot count business days of %w", ErrNullValue)
+	}
+	calendar := newBusinessCalendar(holidays)
+	from := noonUTC(t.value.Time)
+	to := noonUTC(other.value.Time.In(t.value.Time.Location()))
+	sign := 1
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+	count := 0
+	for day := from.AddDate(0, 0, 1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if calendar.isBusinessDay(day) {
+			count++
+		}
+	}
+	return sign * count, nil
+}
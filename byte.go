@@ -5,9 +5,42 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 )
 
+// SetByteJSONHex enables or disables hex string output in Byte.MarshalJSON.
+// Decimal numbers are the default. UnmarshalJSON accepts both forms
+// regardless of this setting.
+//
+// Example:
+//
+//	ztype.SetByteJSONHex(true)
+//	data, _ := json.Marshal(ztype.NewByte(47))
+//	fmt.Println(string(data))  // Output: "0x2f"
+func SetByteJSONHex(hex bool) {
+	defaultCodec.byteJSONHex.Store(hex)
+}
+
+// parseByte parses a decimal byte, or a hex, binary or octal one when the
+// text carries a 0x, 0b or 0o prefix. Leading zeros stay decimal.
+func parseByte(text string) (byte, error) {
+	base := 10
+	if len(text) > 2 && text[0] == '0' && strings.ContainsRune("xXbBoO", rune(text[1])) {
+		base = 0
+	}
+	value, err := strconv.ParseUint(text, base, 8)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, &ErrOverflow{Type: "Byte", Value: text}
+	}
+	if err != nil {
+		return 0, &ErrInvalidFormat{Type: "Byte", Input: text}
+	}
+	return byte(value), nil
+}
+
 // Byte represents a nullable byte type that can distinguish between:
 // - Explicit database/SQL NULL values
 // - Absent values in JSON unmarshaling
@@ -82,6 +115,15 @@ func (b *Byte) Get() byte {
 	return b.value.Byte
 }
 
+// Val returns the byte and whether it is valid.
+//
+// Example:
+//
+//	if v, ok := b.Val(); ok { fmt.Println(v) }
+func (b Byte) Val() (byte, bool) {
+	return b.value.Byte, b.value.Valid
+}
+
 // Set updates the value and marks it as valid.
 //
 // Example:
@@ -116,14 +158,25 @@ func (b *Byte) IsNull() bool {
 	return !b.value.Valid
 }
 
-// IsZero returns true if the value is zero.
+// IsEmpty returns true if the value is null or 0.
+//
+// Example:
+//
+//	b := ztype.NewByte(0)
+//	fmt.Println(b.IsEmpty())  // Output: true
+func (b Byte) IsEmpty() bool {
+	return !b.value.Valid || b.value.Byte == 0
+}
+
+// IsZero implements common interface for zero checks (alias for IsEmpty).
+// Like Numeric and String, both null and 0 are zero.
 //
 // Example:
 //
 //	b := ztype.NewByte(0)
 //	fmt.Println(b.IsZero())  // Output: true
-func (b *Byte) IsZero() bool {
-	return !b.value.Valid
+func (b Byte) IsZero() bool {
+	return b.IsEmpty()
 }
 
 // Unmarshaled returns true if the value was present in the data source,
@@ -160,6 +213,28 @@ func (b *Byte) Equal(other Byte) bool {
 		b.value.Valid == other.value.Valid
 }
 
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	b := ztype.NewByte(7)
+//	fmt.Println(b.EqualValues(ztype.NewByte(7)))  // Output: true
+func (b Byte) EqualValues(other Byte) bool {
+	return b.Equal(other)
+}
+
+// Clone returns a copy of the Byte, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	b := ztype.NewByte(1)
+//	c := b.Clone()
+func (b Byte) Clone() Byte {
+	return b
+}
+
 // EqualRaw compares the byte value while ignoring null state.
 // Returns false if either value is null.
 //
@@ -171,6 +246,207 @@ func (b *Byte) EqualRaw(other byte) bool {
 	return b.value.Byte == other
 }
 
+// Add returns the sum of both values, wrapping around past 255 like byte
+// arithmetic does. Null if either is null. Use AddChecked to detect overflow.
+//
+// Example:
+//
+//	b := ztype.NewByte(250)
+//	fmt.Println(b.Add(ztype.NewByte(10)).Get())  // Output: 4
+func (b *Byte) Add(other Byte) Byte {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte + other.value.Byte)
+}
+
+// Sub returns the difference of both values, wrapping around below 0 like
+// byte arithmetic does. Null if either is null. Use SubChecked to detect underflow.
+//
+// Example:
+//
+//	b := ztype.NewByte(3)
+//	fmt.Println(b.Sub(ztype.NewByte(5)).Get())  // Output: 254
+func (b *Byte) Sub(other Byte) Byte {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte - other.value.Byte)
+}
+
+// AddChecked returns the sum of both values, or an error if it exceeds 255.
+// Null if either is null.
+//
+// Example:
+//
+//	b := ztype.NewByte(250)
+//	_, err := b.AddChecked(ztype.NewByte(10))  // error: byte overflow
+func (b *Byte) AddChecked(other Byte) (Byte, error) {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte(), nil
+	}
+	sum := b.value.Byte + other.value.Byte
+	if sum < b.value.Byte {
+		return NewNullByte(), &ErrOverflow{Type: "Byte", Value: fmt.Sprintf("%d + %d", b.value.Byte, other.value.Byte)}
+	}
+	return NewByte(sum), nil
+}
+
+// SubChecked returns the difference of both values, or an error if it is
+// below 0. Null if either is null.
+//
+// Example:
+//
+//	b := ztype.NewByte(3)
+//	_, err := b.SubChecked(ztype.NewByte(5))  // error: byte underflow
+func (b *Byte) SubChecked(other Byte) (Byte, error) {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte(), nil
+	}
+	if other.value.Byte > b.value.Byte {
+		return NewNullByte(), &ErrOverflow{Type: "Byte", Value: fmt.Sprintf("%d - %d", b.value.Byte, other.value.Byte)}
+	}
+	return NewByte(b.value.Byte - other.value.Byte), nil
+}
+
+// And returns the bitwise AND of both values. Null if either is null.
+//
+// Example:
+//
+//	b := ztype.NewByte(0b1100)
+//	fmt.Println(b.And(ztype.NewByte(0b1010)).Get())  // Output: 8
+func (b *Byte) And(other Byte) Byte {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte & other.value.Byte)
+}
+
+// Or returns the bitwise OR of both values. Null if either is null.
+//
+// Example:
+//
+//	b := ztype.NewByte(0b1100)
+//	fmt.Println(b.Or(ztype.NewByte(0b1010)).Get())  // Output: 14
+func (b *Byte) Or(other Byte) Byte {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte | other.value.Byte)
+}
+
+// Xor returns the bitwise XOR of both values. Null if either is null.
+//
+// Example:
+//
+//	b := ztype.NewByte(0b1100)
+//	fmt.Println(b.Xor(ztype.NewByte(0b1010)).Get())  // Output: 6
+func (b *Byte) Xor(other Byte) Byte {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte ^ other.value.Byte)
+}
+
+// AndNot clears the bits set in other (bit clear). Null if either is null.
+//
+// Example:
+//
+//	b := ztype.NewByte(0b1100)
+//	fmt.Println(b.AndNot(ztype.NewByte(0b1010)).Get())  // Output: 4
+func (b *Byte) AndNot(other Byte) Byte {
+	if !b.value.Valid || !other.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte &^ other.value.Byte)
+}
+
+// ShiftLeft shifts the value left by n bits. Shifting by 8 or more yields 0.
+// Null stays null.
+//
+// Example:
+//
+//	b := ztype.NewByte(1)
+//	fmt.Println(b.ShiftLeft(3).Get())  // Output: 8
+func (b *Byte) ShiftLeft(n uint) Byte {
+	if !b.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte << n)
+}
+
+// ShiftRight shifts the value right by n bits. Shifting by 8 or more yields 0.
+// Null stays null.
+//
+// Example:
+//
+//	b := ztype.NewByte(128)
+//	fmt.Println(b.ShiftRight(7).Get())  // Output: 1
+func (b *Byte) ShiftRight(n uint) Byte {
+	if !b.value.Valid {
+		return NewNullByte()
+	}
+	return NewByte(b.value.Byte >> n)
+}
+
+// HasBit returns true if the bit at pos (0 is the least significant) is set.
+// Returns false for null values and positions beyond 7.
+//
+// Example:
+//
+//	b := ztype.NewByte(0b0100)
+//	fmt.Println(b.HasBit(2))  // Output: true
+func (b *Byte) HasBit(pos uint) bool {
+	return b.value.Valid && pos < 8 && b.value.Byte&(1<<pos) != 0
+}
+
+// SetBit returns a copy with the bit at pos set or cleared. Positions beyond
+// 7 leave the value unchanged. Null stays null.
+//
+// Example:
+//
+//	b := ztype.NewByte(0)
+//	fmt.Println(b.SetBit(1, true).Get())  // Output: 2
+func (b *Byte) SetBit(pos uint, value bool) Byte {
+	if !b.value.Valid {
+		return NewNullByte()
+	}
+	if pos > 7 {
+		return NewByte(b.value.Byte)
+	}
+	if value {
+		return NewByte(b.value.Byte | 1<<pos)
+	}
+	return NewByte(b.value.Byte &^ (1 << pos))
+}
+
+// ToHex returns the value as a "0x"-prefixed, two-digit lowercase hex String.
+// Null stays null.
+//
+// Example:
+//
+//	b := ztype.NewByte(47)
+//	fmt.Println(b.ToHex().Get())  // Output: 0x2f
+func (b *Byte) ToHex() String {
+	if !b.value.Valid {
+		return NewNullString()
+	}
+	return NewString(fmt.Sprintf("0x%02x", b.value.Byte))
+}
+
+// ToBinary returns the value as an eight-digit binary String. Null stays null.
+//
+// Example:
+//
+//	b := ztype.NewByte(47)
+//	fmt.Println(b.ToBinary().Get())  // Output: 00101111
+func (b *Byte) ToBinary() String {
+	if !b.value.Valid {
+		return NewNullString()
+	}
+	return NewString(fmt.Sprintf("%08b", b.value.Byte))
+}
+
 // MarshalText implements encoding.TextMarshaler.
 // Returns string representation for valid values, nil for null.
 //
@@ -179,7 +455,7 @@ func (b *Byte) EqualRaw(other byte) bool {
 //	b := ztype.NewByte(10)
 //	data, _ := b.MarshalText()
 //	fmt.Println(string(data))  // Output: 10
-func (b *Byte) MarshalText() ([]byte, error) {
+func (b Byte) MarshalText() ([]byte, error) {
 	if b.value.Valid {
 		return []byte(strconv.FormatUint(uint64(b.value.Byte), 10)), nil
 	}
@@ -187,41 +463,61 @@ func (b *Byte) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-// Sets unmarshaled flag and parses byte from string.
+// Sets unmarshaled flag and parses byte from string. Accepts decimal,
+// and hex ("0xFF"), binary ("0b1010") or octal ("0o17") with a prefix.
 //
 // Example:
 //
 //	var b ztype.Byte
-//	err := b.UnmarshalText([]byte("255"))
+//	err := b.UnmarshalText([]byte("0xFF"))
 //	fmt.Println(b.Get())  // Output: 255
 func (b *Byte) UnmarshalText(data []byte) error {
 	b.unmarshaled = true
-	value, err := strconv.ParseUint(string(data), 10, 8)
+	value, err := parseByte(string(data))
 	if err != nil {
 		return err
 	}
-	b.value.Byte = byte(value)
+	b.value.Byte = value
 	b.value.Valid = true
 	return nil
 }
 
 // MarshalJSON implements json.Marshaler.
-// Returns JSON number for valid values, null for null.
+// Returns JSON number for valid values, null for null. With
+// SetByteJSONHex(true) valid values are written as "0x.." strings.
 //
 // Example:
 //
 //	b := ztype.NewByte(10)
 //	jsonData, _ := json.Marshal(b)
 //	fmt.Println(string(jsonData))  // Output: 10
-func (b *Byte) MarshalJSON() ([]byte, error) {
-	if b.value.Valid {
-		return json.Marshal(b.value.Byte)
+func (b Byte) MarshalJSON() ([]byte, error) {
+	return b.AppendJSON(make([]byte, 0, 8))
+}
+
+// AppendJSON appends the JSON encoding of the Byte to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewByte(10).AppendJSON(buf) // 10
+func (b Byte) AppendJSON(dst []byte) ([]byte, error) {
+	return b.appendJSONCodec(&defaultCodec, dst)
+}
+
+// appendJSONCodec implements AppendJSON with the options of c.
+func (b Byte) appendJSONCodec(c *Codec, dst []byte) ([]byte, error) {
+	if !b.value.Valid {
+		return append(dst, "null"...), nil
+	}
+	if c.byteJSONHex.Load() {
+		return append(dst, '"', '0', 'x', jsonHex[b.value.Byte>>4], jsonHex[b.value.Byte&0xF], '"'), nil
 	}
-	return []byte("null"), nil
+	return strconv.AppendUint(dst, uint64(b.value.Byte), 10), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
-// Handles both numeric values and explicit nulls.
+// Handles numeric values, strings in any UnmarshalText form and explicit nulls.
 //
 // Example:
 //
@@ -235,22 +531,54 @@ func (b *Byte) UnmarshalJSON(data []byte) error {
 		b.value.Byte = 0
 		return nil
 	}
-	if err := json.Unmarshal(data, &b.value.Byte); err != nil {
-		b.value.Valid = false
-		return err
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return wrapJSONError("Byte", data, err)
+		}
+		value, err := parseByte(text)
+		if err != nil {
+			return err
+		}
+		b.value.Byte = value
+		b.value.Valid = true
+		return nil
 	}
+	var value byte
+	if err := json.Unmarshal(data, &value); err != nil {
+		return wrapJSONError("Byte", data, err)
+	}
+	b.value.Byte = value
 	b.value.Valid = true
 	return nil
 }
 
 // Scan implements sql.Scanner for database integration.
+// Besides numbers and decimal text, a one-element []byte that is not a
+// decimal digit is taken as a single raw octet, as read from bytea columns,
+// and a bool scans as 1 or 0. See the package documentation for the full
+// list of coercions.
 //
 // Example:
 //
 //	var b ztype.Byte
 //	err := db.QueryRow("SELECT value FROM table WHERE id = 1").Scan(&b)
 func (b *Byte) Scan(value any) error {
-	return b.value.Scan(value)
+	if flag, ok := value.(bool); ok {
+		b.Set(boolNumber[byte](flag))
+		return nil
+	}
+	scanned := b.value
+	err := scanned.Scan(value)
+	if raw, ok := value.([]byte); ok && err != nil && len(raw) == 1 {
+		b.Set(raw[0])
+		return nil
+	}
+	if err != nil {
+		return wrapScanError("Byte", value, err)
+	}
+	b.value = scanned
+	return nil
 }
 
 // Value implements driver.Valuer for database integration.
@@ -263,6 +591,19 @@ func (b Byte) Value() (driver.Value, error) {
 	return b.value.Value()
 }
 
+// ValueOrZero is like Value, but returns 0 instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullByte().ValueOrZero() // int64(0)
+func (b Byte) ValueOrZero() (driver.Value, error) {
+	if !b.value.Valid {
+		return NewByte(0).Value()
+	}
+	return b.Value()
+}
+
 // String returns human-readable representation.
 // Returns "<NULL>" for null values, decimal string otherwise.
 //
@@ -270,9 +611,59 @@ func (b Byte) Value() (driver.Value, error) {
 //
 //	b := ztype.NewNullByte()
 //	fmt.Println(b.String())  // Output: <NULL>
-func (b *Byte) String() string {
+func (b Byte) String() string {
 	if !b.value.Valid {
-		return "<NULL>"
+		return nullToken()
 	}
 	return strconv.FormatUint(uint64(b.value.Byte), 10)
 }
+
+// AppendString appends the decimal String output to dst without
+// allocating when dst has enough capacity.
+//
+// Example:
+//
+//	buf = ztype.NewByte(7).AppendString(buf[:0]) // "7"
+func (b Byte) AppendString(dst []byte) []byte {
+	if !b.value.Valid {
+		return append(dst, nullToken()...)
+	}
+	return strconv.AppendUint(dst, uint64(b.value.Byte), 10)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullByte().StringOr("-"))  // Output: -
+func (b Byte) StringOr(fallback string) string {
+	if !b.value.Valid {
+		return fallback
+	}
+	return b.String()
+}
+
+// SchemaType reports Byte as a nullable integer, or as a string while
+// SetByteJSONHex is enabled.
+//
+// Example:
+//
+//	ztype.Byte{}.SchemaType() // "integer", "int32", true
+func (b Byte) SchemaType() (jsonType string, format string, nullable bool) {
+	if defaultCodec.byteJSONHex.Load() {
+		return "string", "", true
+	}
+	return "integer", "int32", true
+}
+
+// Format implements fmt.Formatter. Verbs such as %x, %08b or %c and their
+// flags apply to the underlying byte; %v and %s print String and null prints
+// "<NULL>" for every verb.
+//
+// Example:
+//
+//	fmt.Printf("%#02x", ztype.NewByte(10)) // Output: 0x0a
+func (b Byte) Format(f fmt.State, verb rune) {
+	formatNullable(f, verb, b.value.Valid, b.String(), b.value.Byte)
+}
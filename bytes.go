@@ -0,0 +1,414 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Bytes represents a nullable byte slice, suited for bytea/BLOB columns and
+// binary tokens. It distinguishes between:
+// - Explicit database/SQL NULL values
+// - Absent values in JSON unmarshaling
+// - Empty but valid byte slices
+//
+// JSON uses standard base64, like encoding/json does for []byte.
+//
+// Example Usage:
+//
+//	// Create valid bytes
+//	b := ztype.NewBytes([]byte{0xde, 0xad})
+//
+//	// JSON interaction
+//	data, _ := json.Marshal(b) // "3q0="
+type Bytes struct {
+	value       []byte
+	valid       bool
+	unmarshaled bool
+}
+
+// NewBytes creates a new valid Bytes instance.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("token"))
+//	fmt.Println(b.Len())  // Output: 5
+func NewBytes(value []byte) Bytes {
+	return Bytes{value: value, valid: true}
+}
+
+// NewNullBytes creates a new null Bytes instance.
+//
+// Example:
+//
+//	b := ztype.NewNullBytes()
+//	fmt.Println(b.IsNull())  // Output: true
+func NewNullBytes() Bytes {
+	return Bytes{valid: false}
+}
+
+// NewNullBytesIfZero creates a null Bytes if the given slice is empty;
+// otherwise, it returns a non-null Bytes with the specified value.
+//
+// Example:
+//
+//	b := ztype.NewNullBytesIfZero(nil)
+//	fmt.Println(b.IsNull())  // Output: true
+func NewNullBytesIfZero(value []byte) Bytes {
+	if len(value) == 0 {
+		return NewNullBytes()
+	}
+	return NewBytes(value)
+}
+
+// Get returns the underlying byte slice. When null, returns nil.
+// The slice is not copied; use bytes.Clone to keep an independent copy.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("a"))
+//	fmt.Println(string(b.Get()))  // Output: a
+func (b *Bytes) Get() []byte {
+	return b.value
+}
+
+// Val returns the bytes and whether they are valid; a valid empty slice
+// reports ok=true.
+//
+// Example:
+//
+//	if data, ok := b.Val(); ok { process(data) }
+func (b Bytes) Val() ([]byte, bool) {
+	return b.value, b.valid
+}
+
+// Set updates the value and marks it as valid.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	b.Set([]byte("a"))
+//	fmt.Println(b.IsNull())  // Output: false
+func (b *Bytes) Set(value []byte) {
+	b.value = value
+	b.valid = true
+}
+
+// SetNull marks the value as null and resets the slice.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("a"))
+//	b.SetNull()
+//	fmt.Println(b.IsNull())  // Output: true
+func (b *Bytes) SetNull() {
+	b.value = nil
+	b.valid = false
+}
+
+// IsNull returns true if the value is null.
+//
+// Example:
+//
+//	b := ztype.NewNullBytes()
+//	fmt.Println(b.IsNull())  // Output: true
+func (b *Bytes) IsNull() bool {
+	return !b.valid
+}
+
+// IsEmpty returns true if the value is null or holds no bytes.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte{})
+//	fmt.Println(b.IsEmpty())  // Output: true
+func (b Bytes) IsEmpty() bool {
+	return len(b.value) == 0
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullBytes().IsZero())  // Output: true
+func (b Bytes) IsZero() bool {
+	return b.IsEmpty()
+}
+
+// Len returns the number of bytes. Null values report 0.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("abc"))
+//	fmt.Println(b.Len())  // Output: 3
+func (b *Bytes) Len() int {
+	return len(b.value)
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values. Returns false if the field was absent.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	json.Unmarshal([]byte(`null`), &b)
+//	fmt.Println(b.Unmarshaled())  // Output: true
+func (b *Bytes) Unmarshaled() bool {
+	return b.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	b.SetUnmarshaled(true)
+func (b *Bytes) SetUnmarshaled(value bool) {
+	b.unmarshaled = value
+}
+
+// Equal performs equality check including null state.
+//
+// Example:
+//
+//	b1 := ztype.NewBytes([]byte("a"))
+//	b2 := ztype.NewBytes([]byte("a"))
+//	fmt.Println(b1.Equal(b2))  // Output: true
+func (b *Bytes) Equal(other Bytes) bool {
+	return b.valid == other.valid && bytes.Equal(b.value, other.value)
+}
+
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("a"))
+//	fmt.Println(b.EqualValues(ztype.NewBytes([]byte("a"))))  // Output: true
+func (b Bytes) EqualValues(other Bytes) bool {
+	return b.Equal(other)
+}
+
+// Clone returns a copy of the Bytes with its own backing array, keeping its
+// null and unmarshaled state.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("abc"))
+//	c := b.Clone()
+//	c.Get()[0] = 'x' // b is unchanged
+func (b Bytes) Clone() Bytes {
+	b.value = bytes.Clone(b.value)
+	return b
+}
+
+// EqualRaw compares the content with a raw byte slice while ignoring null state.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("a"))
+//	fmt.Println(b.EqualRaw([]byte("a")))  // Output: true
+func (b *Bytes) EqualRaw(other []byte) bool {
+	return bytes.Equal(b.value, other)
+}
+
+// EncodeHex returns the lowercase hexadecimal encoding of the value.
+// Returns an empty string for null values.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte{0xde, 0xad})
+//	fmt.Println(b.EncodeHex())  // Output: dead
+func (b *Bytes) EncodeHex() string {
+	return hex.EncodeToString(b.value)
+}
+
+// DecodeHex parses a hexadecimal string and stores the result as a valid value.
+// On error the current value is left untouched.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	err := b.DecodeHex("dead")
+//	fmt.Println(b.Get())  // Output: [222 173]
+func (b *Bytes) DecodeHex(value string) error {
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		return err
+	}
+	b.value = decoded
+	b.valid = true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Returns the standard base64 encoding for valid values, nil for null.
+//
+// Example:
+//
+//	data, _ := ztype.NewBytes([]byte("a")).MarshalText()
+//	fmt.Println(string(data))  // Output: YQ==
+func (b Bytes) MarshalText() ([]byte, error) {
+	if !b.valid {
+		return nil, nil
+	}
+	return base64.StdEncoding.AppendEncode(nil, b.value), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding standard base64.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	err := b.UnmarshalText([]byte("YQ=="))
+func (b *Bytes) UnmarshalText(data []byte) error {
+	b.unmarshaled = true
+	decoded, err := base64.StdEncoding.AppendDecode([]byte{}, data)
+	if err != nil {
+		return wrapInvalidFormat("Bytes", string(data), err)
+	}
+	b.value = decoded
+	b.valid = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Returns a base64 JSON string for valid values, null for null.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("a"))
+//	data, _ := json.Marshal(b)
+//	fmt.Println(string(data))  // Output: "YQ=="
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return b.AppendJSON(make([]byte, 0, base64.StdEncoding.EncodedLen(len(b.value))+4))
+}
+
+// AppendJSON appends the JSON encoding of the Bytes to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewBytes([]byte("hi")).AppendJSON(buf) // "aGk="
+func (b Bytes) AppendJSON(dst []byte) ([]byte, error) {
+	if !b.valid {
+		return append(dst, "null"...), nil
+	}
+	dst = append(dst, '"')
+	dst = base64.StdEncoding.AppendEncode(dst, b.value)
+	return append(dst, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Accepts base64 strings, arrays of numbers in [0, 255] and explicit nulls.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	json.Unmarshal([]byte(`[222,173]`), &b)
+//	fmt.Println(b.EncodeHex())  // Output: dead
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	b.unmarshaled = true
+	if bytes.Equal(data, []byte("null")) {
+		b.SetNull()
+		return nil
+	}
+	decoded := []byte{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return wrapJSONError("Bytes", data, err)
+	}
+	b.value = decoded
+	b.valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner for database integration.
+// The input is always copied, since drivers may reuse the buffer they pass
+// to Scan for the next row.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	err := db.QueryRow("SELECT payload FROM table WHERE id = 1").Scan(&b)
+func (b *Bytes) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		b.SetNull()
+	case []byte:
+		b.value = append([]byte{}, v...)
+		b.valid = true
+	case string:
+		b.value = []byte(v)
+		b.valid = true
+	default:
+		return newUnsupportedScanType(value)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer for database integration.
+// Returns []byte for valid values, nil for null.
+//
+// Example:
+//
+//	value, _ := b.Value()
+func (b Bytes) Value() (driver.Value, error) {
+	if !b.valid {
+		return nil, nil
+	}
+	if b.value == nil {
+		return []byte{}, nil
+	}
+	return b.value, nil
+}
+
+// ValueOrZero is like Value, but returns an empty byte slice instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullBytes().ValueOrZero() // []byte{}
+func (b Bytes) ValueOrZero() (driver.Value, error) {
+	if !b.valid {
+		return []byte{}, nil
+	}
+	return b.Value()
+}
+
+// String returns human-readable representation.
+// Returns "<NULL>" for null values, base64 otherwise.
+//
+// Example:
+//
+//	b := ztype.NewBytes([]byte("a"))
+//	fmt.Println(b.String())  // Output: YQ==
+func (b Bytes) String() string {
+	if !b.valid {
+		return nullToken()
+	}
+	return base64.StdEncoding.EncodeToString(b.value)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	cell := ztype.NewNullBytes().StringOr("") // ""
+func (b Bytes) StringOr(fallback string) string {
+	if !b.valid {
+		return fallback
+	}
+	return b.String()
+}
+
+// SchemaType reports Bytes as a nullable base64 string.
+//
+// Example:
+//
+//	ztype.Bytes{}.SchemaType() // "string", "byte", true
+func (b Bytes) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "byte", true
+}
@@ -0,0 +1,251 @@
+package ztype
+
+import (
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborEncMode writes times as tag 0 RFC 3339 strings with nanoseconds, so
+// sub-second precision survives the round trip.
+var cborEncMode, _ = cbor.EncOptions{
+	Time:    cbor.TimeRFC3339Nano,
+	TimeTag: cbor.EncTagRequired,
+}.EncMode()
+
+// cborNull is the CBOR encoding of null.
+var cborNull = []byte{0xf6}
+
+// marshalCBORNullable encodes value, or the CBOR null when not valid.
+func marshalCBORNullable(valid bool, value any) ([]byte, error) {
+	if !valid {
+		return cborNull, nil
+	}
+	return cborEncMode.Marshal(value)
+}
+
+// unmarshalCBORNullable decodes data into target and reports whether it was
+// the CBOR null or undefined value, in which case target is untouched.
+func unmarshalCBORNullable(data []byte, target any) (bool, error) {
+	if len(data) == 1 && (data[0] == 0xf6 || data[0] == 0xf7) {
+		return true, nil
+	}
+	return false, cbor.Unmarshal(data, target)
+}
+
+// MarshalCBOR implements cbor.Marshaler. Null is encoded as CBOR null.
+//
+// Example:
+//
+//	data, _ := cbor.Marshal(ztype.NewBool(true))
+func (b Bool) MarshalCBOR() ([]byte, error) {
+	return marshalCBORNullable(b.value.Valid, b.value.Bool)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+//
+// Example:
+//
+//	var b ztype.Bool
+//	err := cbor.Unmarshal(data, &b)
+func (b *Bool) UnmarshalCBOR(data []byte) error {
+	b.unmarshaled = true
+	var value bool
+	isNull, err := unmarshalCBORNullable(data, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		b.SetNull()
+		return nil
+	}
+	b.Set(value)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler. Null is encoded as CBOR null.
+//
+// Example:
+//
+//	data, _ := cbor.Marshal(ztype.NewByte(7))
+func (b Byte) MarshalCBOR() ([]byte, error) {
+	return marshalCBORNullable(b.value.Valid, b.value.Byte)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+//
+// Example:
+//
+//	var b ztype.Byte
+//	err := cbor.Unmarshal(data, &b)
+func (b *Byte) UnmarshalCBOR(data []byte) error {
+	b.unmarshaled = true
+	var value byte
+	isNull, err := unmarshalCBORNullable(data, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		b.SetNull()
+		return nil
+	}
+	b.Set(value)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler. Valid values are encoded as a CBOR
+// byte string, null as CBOR null.
+//
+// Example:
+//
+//	data, _ := cbor.Marshal(ztype.NewBytes([]byte{1, 2}))
+func (b Bytes) MarshalCBOR() ([]byte, error) {
+	if b.valid && b.value == nil {
+		return cborEncMode.Marshal([]byte{})
+	}
+	return marshalCBORNullable(b.valid, b.value)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	err := cbor.Unmarshal(data, &b)
+func (b *Bytes) UnmarshalCBOR(data []byte) error {
+	b.unmarshaled = true
+	value := []byte{}
+	isNull, err := unmarshalCBORNullable(data, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		b.SetNull()
+		return nil
+	}
+	b.Set(value)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler. Null is encoded as CBOR null.
+//
+// Example:
+//
+//	data, _ := cbor.Marshal(ztype.NewString("a"))
+func (s String) MarshalCBOR() ([]byte, error) {
+	return marshalCBORNullable(s.value.Valid, s.value.String)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+//
+// Example:
+//
+//	var s ztype.String
+//	err := cbor.Unmarshal(data, &s)
+func (s *String) UnmarshalCBOR(data []byte) error {
+	s.unmarshaled = true
+	var value string
+	isNull, err := unmarshalCBORNullable(data, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		s.SetNull()
+		return nil
+	}
+	s.Set(value)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler. Null is encoded as CBOR null.
+//
+// Example:
+//
+//	data, _ := cbor.Marshal(ztype.NewNumber[int64](math.MinInt64))
+func (n Numeric[T]) MarshalCBOR() ([]byte, error) {
+	return marshalCBORNullable(n.value.Valid, n.value.V)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+//
+// Example:
+//
+//	var n ztype.Numeric[int64]
+//	err := cbor.Unmarshal(data, &n)
+func (n *Numeric[T]) UnmarshalCBOR(data []byte) error {
+	n.unmarshaled = true
+	var value T
+	isNull, err := unmarshalCBORNullable(data, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		n.SetNull()
+		return nil
+	}
+	n.Set(value)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler. Valid values are encoded as a tag 0
+// RFC 3339 string with nanoseconds, null as CBOR null.
+//
+// Example:
+//
+//	data, _ := cbor.Marshal(ztype.NewTime(time.Now()))
+func (t Time) MarshalCBOR() ([]byte, error) {
+	return marshalCBORNullable(t.value.Valid, t.value.Time)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler. Accepts tag 0 strings and
+// tag 1 epoch numbers.
+//
+// Example:
+//
+//	var t ztype.Time
+//	err := cbor.Unmarshal(data, &t)
+func (t *Time) UnmarshalCBOR(data []byte) error {
+	t.unmarshaled = true
+	var value time.Time
+	isNull, err := unmarshalCBORNullable(data, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		t.SetNull()
+		return nil
+	}
+	t.Set(value)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler. Valid values are encoded as int64
+// nanoseconds, like Value does.
+//
+// Example:
+//
+//	data, _ := cbor.Marshal(ztype.NewDuration(time.Second))
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	return marshalCBORNullable(d.valid, int64(d.value))
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+//
+// Example:
+//
+//	var d ztype.Duration
+//	err := cbor.Unmarshal(data, &d)
+func (d *Duration) UnmarshalCBOR(data []byte) error {
+	d.unmarshaled = true
+	var value int64
+	isNull, err := unmarshalCBORNullable(data, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		d.SetNull()
+		return nil
+	}
+	d.Set(time.Duration(value))
+	return nil
+}
@@ -0,0 +1,183 @@
+package ztype
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Char is a Byte that marshals to JSON as a one-character string, for
+// payloads that carry single ASCII characters such as `"A"` instead of 65.
+// Printable ASCII (0x20 to 0x7E) is written as a quoted character; any other
+// value falls back to a JSON number, so the output always round-trips.
+// It embeds Byte, so all Byte methods and SQL integration are available.
+//
+// Example Usage:
+//
+//	c := ztype.NewChar('A')
+//	data, _ := json.Marshal(&c) // "A"
+type Char struct {
+	Byte
+}
+
+// NewChar creates a new valid Char instance.
+//
+// Example:
+//
+//	c := ztype.NewChar('A')
+//	fmt.Println(c.Get())  // Output: 65
+func NewChar(value byte) Char {
+	return Char{Byte: NewByte(value)}
+}
+
+// NewNullChar creates a new null Char instance.
+//
+// Example:
+//
+//	c := ztype.NewNullChar()
+//	fmt.Println(c.IsNull())  // Output: true
+func NewNullChar() Char {
+	return Char{Byte: NewNullByte()}
+}
+
+// isPrintableASCII reports whether value is written as a character by Char.
+func isPrintableASCII(value byte) bool {
+	return value >= 0x20 && value <= 0x7e
+}
+
+// MarshalJSON implements json.Marshaler.
+// Returns a quoted character for printable ASCII, a JSON number for other
+// values and null for null.
+//
+// Example:
+//
+//	c := ztype.NewChar('\n')
+//	data, _ := json.Marshal(&c)
+//	fmt.Println(string(data))  // Output: 10
+func (c Char) MarshalJSON() ([]byte, error) {
+	return c.AppendJSON(make([]byte, 0, 8))
+}
+
+// AppendJSON appends the JSON encoding of the Char to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewChar('A').AppendJSON(buf) // "A"
+func (c Char) AppendJSON(dst []byte) ([]byte, error) {
+	if !c.value.Valid {
+		return append(dst, "null"...), nil
+	}
+	if isPrintableASCII(c.value.Byte) {
+		return appendJSONString(dst, []byte{c.value.Byte}), nil
+	}
+	return strconv.AppendUint(dst, uint64(c.value.Byte), 10), nil
+}
+
+// appendJSONCodec shadows the method promoted from Byte, so a Codec writes
+// a Char in the same form as MarshalJSON.
+func (c Char) appendJSONCodec(_ *Codec, dst []byte) ([]byte, error) {
+	return c.AppendJSON(dst)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Accepts a one-character ASCII string, a number in the byte range or null.
+//
+// Example:
+//
+//	var c ztype.Char
+//	err := json.Unmarshal([]byte(`"AB"`), &c)  // error: expected a single character
+func (c *Char) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '"' {
+		return c.Byte.UnmarshalJSON(data)
+	}
+	c.unmarshaled = true
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return wrapJSONError("Char", data, err)
+	}
+	if len(text) != 1 || text[0] >= 0x80 {
+		return newInvalidFormat("Char", text, "expected a single ASCII character")
+	}
+	c.Set(text[0])
+	return nil
+}
+
+// String returns human-readable representation.
+// Returns "<NULL>" for null values, the character for printable ASCII and
+// the decimal value otherwise.
+//
+// Example:
+//
+//	c := ztype.NewChar('A')
+//	fmt.Println(c.String())  // Output: A
+func (c Char) String() string {
+	if c.value.Valid && isPrintableASCII(c.value.Byte) {
+		return string(rune(c.value.Byte))
+	}
+	return c.Byte.String()
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullChar().StringOr("-"))  // Output: -
+func (c Char) StringOr(fallback string) string {
+	if !c.value.Valid {
+		return fallback
+	}
+	return c.String()
+}
+
+// SchemaType reports Char as a nullable one-character string.
+//
+// Example:
+//
+//	ztype.Char{}.SchemaType() // "string", "", true
+func (c Char) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "", true
+}
+
+// Format implements fmt.Formatter like Byte.Format, with %v and %s printing
+// the character.
+//
+// Example:
+//
+//	fmt.Printf("%v %d", ztype.NewChar('A'), ztype.NewChar('A')) // Output: A 65
+func (c Char) Format(f fmt.State, verb rune) {
+	formatNullable(f, verb, c.value.Valid, c.String(), c.value.Byte)
+}
+
+// Equal performs equality check including null state.
+//
+// Example:
+//
+//	c := ztype.NewChar('A')
+//	fmt.Println(c.Equal(ztype.NewChar('A')))  // Output: true
+func (c *Char) Equal(other Char) bool {
+	return c.Byte.Equal(other.Byte)
+}
+
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	c := ztype.NewChar('A')
+//	fmt.Println(c.EqualValues(ztype.NewChar('A')))  // Output: true
+func (c Char) EqualValues(other Char) bool {
+	return c.Equal(other)
+}
+
+// Clone returns a copy of the Char, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	c := ztype.NewChar('A')
+//	c := c.Clone()
+func (c Char) Clone() Char {
+	return c
+}
@@ -0,0 +1,117 @@
+package ztype
+
+import (
+	"reflect"
+)
+
+// CloneStruct returns a deep copy of v, which is usually a struct of ztype
+// fields or a pointer to one. The result has the same type as v and shares
+// no maps, slices or pointers with it: ztype fields are copied with their
+// CloneDeep or Clone method and other exported fields are copied
+// recursively. Unexported fields of non-ztype structs are copied by
+// assignment. v must not contain cycles.
+//
+// Example:
+//
+//	type Payload struct {
+//		Name ztype.String
+//		Meta ztype.JSON
+//	}
+//	original := &Payload{Meta: ztype.NewMap(map[string]any{"tags": []any{"a"}})}
+//	clone := ztype.CloneStruct(original).(*Payload)
+//	clone.Meta.Get()["tags"].([]any)[0] = "b" // original is unchanged
+func CloneStruct(v any) any {
+	if v == nil {
+		return nil
+	}
+	return deepCopyValue(reflect.ValueOf(v)).Interface()
+}
+
+// deepCopy returns a copy of value that shares no maps, slices or pointers
+// with it.
+func deepCopy[T any](value T) T {
+	var copied T
+	reflect.ValueOf(&copied).Elem().Set(deepCopyValue(reflect.ValueOf(&value).Elem()))
+	return copied
+}
+
+// deepCopyValue returns a deep copy of value. ztype types are copied with
+// their own CloneDeep or Clone method.
+func deepCopyValue(value reflect.Value) reflect.Value {
+	t := value.Type()
+	if cloned, ok := callCloneMethod(value, "CloneDeep"); ok {
+		return cloned
+	}
+	if cloned, ok := callCloneMethod(value, "Clone"); ok {
+		return cloned
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return reflect.Zero(t)
+		}
+		copied := reflect.New(t.Elem())
+		copied.Elem().Set(deepCopyValue(value.Elem()))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return reflect.Zero(t)
+		}
+		copied := reflect.New(t).Elem()
+		copied.Set(deepCopyValue(value.Elem()))
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return reflect.Zero(t)
+		}
+		copied := reflect.MakeMapWithSize(t, value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			copied.SetMapIndex(deepCopyValue(iter.Key()), deepCopyValue(iter.Value()))
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return reflect.Zero(t)
+		}
+		copied := reflect.MakeSlice(t, value.Len(), value.Len())
+		for i := range value.Len() {
+			copied.Index(i).Set(deepCopyValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(t).Elem()
+		for i := range value.Len() {
+			copied.Index(i).Set(deepCopyValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(t).Elem()
+		copied.Set(value)
+		for i := range value.NumField() {
+			if t.Field(i).IsExported() {
+				copied.Field(i).Set(deepCopyValue(value.Field(i)))
+			}
+		}
+		return copied
+	}
+	return value
+}
+
+// callCloneMethod calls the named method when value is a ztype type with a
+// method of that name taking no arguments and returning the same type.
+func callCloneMethod(value reflect.Value, name string) (reflect.Value, bool) {
+	t := value.Type()
+	owner := t
+	if owner.Kind() == reflect.Pointer {
+		owner = owner.Elem()
+	}
+	if owner.PkgPath() != packagePath || (t.Kind() == reflect.Pointer && value.IsNil()) {
+		return reflect.Value{}, false
+	}
+	method, ok := t.MethodByName(name)
+	if !ok || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != t {
+		return reflect.Value{}, false
+	}
+	return value.Method(method.Index).Call(nil)[0], true
+}
@@ -0,0 +1,1082 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Codec holds the options that select the JSON and database forms of ztype
+// values, so that subsystems with different wire formats can each hold one
+// instead of sharing the package-level settings. The package-level setters,
+// such as SetTimeMarshalUTC and SetJSONNumberMode, configure DefaultCodec,
+// which the MarshalJSON, UnmarshalJSON and Value methods of the types use.
+//
+// A Codec reaches the ztype values inside structs, pointers, slices, arrays,
+// maps, interfaces, Map and Slice. Other types with their own MarshalJSON or
+// UnmarshalJSON, including OrderedMap, PairMap, Set, Array and Null, encode
+// their contents with the options of DefaultCodec. The display, text and
+// scan settings, such as SetNullToken, SetNumberTextLenient and
+// SetScanLocation, live in the Codec too; since String, UnmarshalText and
+// Scan have fixed signatures, a Codec applies them through its String,
+// UnmarshalText and Scan methods. A Codec is safe for concurrent use.
+//
+// Example Usage:
+//
+//	legacy := ztype.NewCodec(ztype.WithTimeLayout(time.RFC1123), ztype.WithTimeMarshalUTC(true))
+//	data, err := legacy.Marshal(event) // {"at":"Fri, 01 Mar 2024 12:00:00 UTC"}
+//	err = legacy.Unmarshal(data, &event)
+type Codec struct {
+	timeLayout         atomic.Pointer[string]
+	timeMarshalUTC     atomic.Bool
+	timePreserveOffset atomic.Bool
+	timeValueUTC       atomic.Bool
+	durationValueMode  atomic.Int32
+	boolJSONLenient    atomic.Bool
+	byteJSONHex        atomic.Bool
+	moneyJSONCompact   atomic.Bool
+	jsonNumberMode     atomic.Int32
+	mapValueAsBytes    atomic.Bool
+	rangeValueLiteral  atomic.Bool
+	nullToken          atomic.Pointer[string]
+	numberFormatter    atomic.Pointer[func(any) string]
+	numberTextLenient  atomic.Pointer[parseOptions]
+	boolScanTokens     atomic.Pointer[map[string]bool]
+	scanLocation       atomic.Pointer[time.Location]
+	scanConvertTo      atomic.Pointer[time.Location]
+}
+
+// defaultCodec holds the options of the package-level setters.
+var defaultCodec Codec
+
+// DefaultCodec returns the Codec the methods of the ztype values use. Its
+// options change only through the package-level setters, so its Marshal and
+// Unmarshal behave exactly as json.Marshal and json.Unmarshal.
+//
+// Example:
+//
+//	ztype.SetTimeMarshalUTC(true)
+//	data, _ := ztype.DefaultCodec().Marshal(event) // same as json.Marshal(event)
+func DefaultCodec() *Codec {
+	return &defaultCodec
+}
+
+// CodecOption configures NewCodec.
+type CodecOption func(*Codec)
+
+// NewCodec creates a Codec with the options of a freshly started program,
+// not the current settings of DefaultCodec, changed by opts.
+//
+// Example:
+//
+//	api := ztype.NewCodec(ztype.WithTimeLayout(time.DateTime), ztype.WithJSONNumberMode(ztype.JSONNumberExact))
+func NewCodec(opts ...CodecOption) *Codec {
+	c := &Codec{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithTimeLayout makes the Codec write valid Times with layout and read
+// them strictly with it, as the `ztime:"layout=..."` tag does for one field.
+// Date-only Times keep their date form. An empty layout restores the
+// default: RFC3339Nano, read with every format ParseTimeFlexible knows.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimeLayout(time.RFC1123))
+func WithTimeLayout(layout string) CodecOption {
+	return func(c *Codec) {
+		if layout == "" {
+			c.timeLayout.Store(nil)
+			return
+		}
+		c.timeLayout.Store(&layout)
+	}
+}
+
+// WithTimeMarshalUTC writes valid Times in UTC, as SetTimeMarshalUTC does
+// for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimeMarshalUTC(true))
+func WithTimeMarshalUTC(utc bool) CodecOption {
+	return func(c *Codec) {
+		c.timeMarshalUTC.Store(utc)
+	}
+}
+
+// WithTimePreserveOffset keeps the offset of the Times the Codec reads, as
+// SetTimePreserveOffset does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimePreserveOffset(true))
+func WithTimePreserveOffset(preserve bool) CodecOption {
+	return func(c *Codec) {
+		c.timePreserveOffset.Store(preserve)
+	}
+}
+
+// WithTimeValueUTC makes Codec.Value convert Times to UTC, as
+// SetTimeValueUTC does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimeValueUTC(true))
+func WithTimeValueUTC(utc bool) CodecOption {
+	return func(c *Codec) {
+		c.timeValueUTC.Store(utc)
+	}
+}
+
+// WithDurationValueMode selects what Codec.Value returns for Durations, as
+// SetDurationValueMode does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithDurationValueMode(ztype.DurationValueString))
+func WithDurationValueMode(mode DurationValueMode) CodecOption {
+	return func(c *Codec) {
+		c.durationValueMode.Store(int32(mode))
+	}
+}
+
+// WithBoolJSONLenient makes the Codec read the numbers 0 and 1 and quoted
+// tokens as Bools, as SetBoolJSONLenient does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithBoolJSONLenient(true))
+func WithBoolJSONLenient(lenient bool) CodecOption {
+	return func(c *Codec) {
+		c.boolJSONLenient.Store(lenient)
+	}
+}
+
+// WithByteJSONHex writes Bytes as "0x.." strings, as SetByteJSONHex does
+// for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithByteJSONHex(true))
+func WithByteJSONHex(hex bool) CodecOption {
+	return func(c *Codec) {
+		c.byteJSONHex.Store(hex)
+	}
+}
+
+// WithMoneyJSONCompact writes Money as "19.90 BRL" strings, as
+// SetMoneyJSONCompact does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithMoneyJSONCompact(true))
+func WithMoneyJSONCompact(compact bool) CodecOption {
+	return func(c *Codec) {
+		c.moneyJSONCompact.Store(compact)
+	}
+}
+
+// WithJSONNumberMode selects how the Codec decodes numbers held as any, as
+// SetJSONNumberMode does for DefaultCodec. It applies to any values in
+// structs, maps and slices too, not only inside the container types.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithJSONNumberMode(ztype.JSONNumberExact))
+func WithJSONNumberMode(mode JSONNumberMode) CodecOption {
+	return func(c *Codec) {
+		c.jsonNumberMode.Store(int32(mode))
+	}
+}
+
+// WithMapValueAsBytes makes Codec.Value return Maps as []byte, as
+// SetMapValueAsBytes does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithMapValueAsBytes(true))
+func WithMapValueAsBytes(asBytes bool) CodecOption {
+	return func(c *Codec) {
+		c.mapValueAsBytes.Store(asBytes)
+	}
+}
+
+// WithRangeValueLiteral makes Codec.Value return Ranges as PostgreSQL range
+// literals, as SetRangeValueLiteral does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithRangeValueLiteral(true))
+func WithRangeValueLiteral(literal bool) CodecOption {
+	return func(c *Codec) {
+		c.rangeValueLiteral.Store(literal)
+	}
+}
+
+// WithNullToken sets the text Codec.String writes for null values, as
+// SetNullToken does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithNullToken(""))
+func WithNullToken(token string) CodecOption {
+	return func(c *Codec) {
+		c.nullToken.Store(&token)
+	}
+}
+
+// WithNumberFormatter sets the function Codec.String formats valid Numerics
+// with, as SetNumberFormatter does for DefaultCodec. nil keeps the default.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithNumberFormatter(func(v any) string { return fmt.Sprintf("%.2f", v) }))
+func WithNumberFormatter(format func(any) string) CodecOption {
+	return func(c *Codec) {
+		if format == nil {
+			c.numberFormatter.Store(nil)
+			return
+		}
+		c.numberFormatter.Store(&format)
+	}
+}
+
+// WithNumberTextLenient makes Codec.UnmarshalText read display formatted
+// numbers into Numerics, as SetNumberTextLenient does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithNumberTextLenient(true, ztype.WithSeparators('.', ',')))
+func WithNumberTextLenient(lenient bool, opts ...ParseOption) CodecOption {
+	return func(c *Codec) {
+		c.numberTextLenient.Store(lenientOptions(lenient, opts))
+	}
+}
+
+// WithBoolScanTokens replaces the text tokens Codec.Scan accepts for Bools,
+// as SetBoolScanTokens does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithBoolScanTokens([]string{"S"}, []string{"N"}))
+func WithBoolScanTokens(trueTokens, falseTokens []string) CodecOption {
+	return func(c *Codec) {
+		c.boolScanTokens.Store(boolTokenMap(trueTokens, falseTokens))
+	}
+}
+
+// WithScanLocation makes Codec.Scan reinterpret zone-less times in loc, as
+// SetScanLocation does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithScanLocation(saoPaulo))
+func WithScanLocation(loc *time.Location) CodecOption {
+	return func(c *Codec) {
+		c.scanLocation.Store(loc)
+	}
+}
+
+// WithScanConvertTo makes Codec.Scan convert scanned times to loc, as
+// SetScanConvertTo does for DefaultCodec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithScanConvertTo(time.Local))
+func WithScanConvertTo(loc *time.Location) CodecOption {
+	return func(c *Codec) {
+		c.scanConvertTo.Store(loc)
+	}
+}
+
+// codecMarshaler is implemented by the types whose JSON form depends on the
+// options of a Codec.
+type codecMarshaler interface {
+	appendJSONCodec(c *Codec, dst []byte) ([]byte, error)
+}
+
+// codecUnmarshaler is implemented by the types whose JSON decoding depends
+// on the options of a Codec.
+type codecUnmarshaler interface {
+	unmarshalJSONCodec(c *Codec, data []byte) error
+}
+
+// codecValuer is implemented by the types whose driver value depends on the
+// options of a Codec.
+type codecValuer interface {
+	valueCodec(c *Codec) (driver.Value, error)
+}
+
+// codecScanner is implemented by the types whose Scan depends on the
+// options of a Codec.
+type codecScanner interface {
+	scanCodec(c *Codec, value any) error
+}
+
+// codecTextUnmarshaler is implemented by the types whose UnmarshalText
+// depends on the options of a Codec.
+type codecTextUnmarshaler interface {
+	unmarshalTextCodec(c *Codec, data []byte) error
+}
+
+// codecStringer is implemented by the types whose String depends on the
+// options of a Codec beyond the null token.
+type codecStringer interface {
+	stringCodec(c *Codec) string
+}
+
+var (
+	_ codecMarshaler = Time{}
+	_ codecMarshaler = Byte{}
+	_ codecMarshaler = Money{}
+	_ codecMarshaler = Map[string, any]{}
+	_ codecMarshaler = Slice[any]{}
+
+	_ codecUnmarshaler = (*Time)(nil)
+	_ codecUnmarshaler = (*Bool)(nil)
+	_ codecUnmarshaler = (*Map[string, any])(nil)
+	_ codecUnmarshaler = (*Slice[any])(nil)
+
+	_ codecValuer = Time{}
+	_ codecValuer = Duration{}
+	_ codecValuer = Range[int]{}
+	_ codecValuer = Map[string, any]{}
+	_ codecValuer = Slice[any]{}
+
+	_ codecScanner = (*Time)(nil)
+	_ codecScanner = (*Bool)(nil)
+
+	_ codecTextUnmarshaler = (*Numeric[int])(nil)
+
+	_ codecStringer = Numeric[int]{}
+	_ codecStringer = StringOrEmpty{}
+)
+
+// Marshal encodes v like json.Marshal, with the options of the Codec for
+// the ztype values it holds. It is not named MarshalJSON because it takes
+// the value to encode.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimeLayout(time.DateTime))
+//	data, err := c.Marshal(map[string]ztype.Time{"at": at}) // {"at":"2024-03-01 12:00:00"}
+func (c *Codec) Marshal(v any) ([]byte, error) {
+	return c.appendJSONValue(nil, reflect.ValueOf(v))
+}
+
+// Unmarshal decodes data into the value pointed to by dest like
+// json.Unmarshal, with the options of the Codec for the ztype values it
+// holds. Errors inside structs, maps and slices name the JSON path.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimeLayout(time.DateTime))
+//	var event struct{ At ztype.Time }
+//	err := c.Unmarshal([]byte(`{"At":"2024-03-01 12:00:00"}`), &event)
+func (c *Codec) Unmarshal(data []byte, dest any) error {
+	target := reflect.ValueOf(dest)
+	if c == &defaultCodec || target.Kind() != reflect.Pointer || target.IsNil() || !json.Valid(data) {
+		return json.Unmarshal(data, dest)
+	}
+	return c.decodeJSONValue("", bytes.TrimSpace(data), target.Elem())
+}
+
+// Value converts v to a driver.Value like ValueOf, with the options of the
+// Codec for the ztype values that depend on them, such as Time, Duration,
+// Map and Range.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithDurationValueMode(ztype.DurationValueString))
+//	v, _ := c.Value(ztype.NewDuration(90 * time.Minute)) // "1h30m0s"
+func (c *Codec) Value(v any) (driver.Value, error) {
+	return valueOf(c, v, 0)
+}
+
+// Scan scans the driver value src into dest like dest.Scan, with the scan
+// options of the Codec for Times and Bools.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithScanLocation(saoPaulo))
+//	var createdAt ztype.Time
+//	err := c.Scan(&createdAt, driverValue)
+func (c *Codec) Scan(dest sql.Scanner, src any) error {
+	if scanner, ok := dest.(codecScanner); ok {
+		return scanner.scanCodec(c, src)
+	}
+	return dest.Scan(src)
+}
+
+// UnmarshalText decodes data into dest like dest.UnmarshalText, with the
+// lenient number options of the Codec for Numerics.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithNumberTextLenient(true, ztype.WithSeparators('.', ',')))
+//	var price ztype.Numeric[float64]
+//	err := c.UnmarshalText([]byte("1.234,56"), &price) // 1234.56
+func (c *Codec) UnmarshalText(data []byte, dest encoding.TextUnmarshaler) error {
+	if unmarshaler, ok := dest.(codecTextUnmarshaler); ok {
+		return unmarshaler.unmarshalTextCodec(c, data)
+	}
+	return dest.UnmarshalText(data)
+}
+
+// String returns v.String(), with the null token and number formatter of
+// the Codec: null ztype values give the null token of the Codec.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithNullToken("-"))
+//	c.String(ztype.NewNullString()) // "-"
+func (c *Codec) String(v fmt.Stringer) string {
+	if stringer, ok := v.(codecStringer); ok {
+		return stringer.stringCodec(c)
+	}
+	if nullable := nullableOf(v); nullable != nil && nullable.IsNull() {
+		return c.nullTokenText()
+	}
+	return v.String()
+}
+
+// nullableOf returns the Nullable view of v, copying it when only its
+// pointer implements Nullable, or nil when v is not a ztype value.
+func nullableOf(v any) Nullable {
+	if nullable, ok := v.(Nullable); ok {
+		return nullable
+	}
+	value := reflect.ValueOf(v)
+	if !value.IsValid() || !reflect.PointerTo(value.Type()).Implements(nullableType) {
+		return nil
+	}
+	pointer := reflect.New(value.Type())
+	pointer.Elem().Set(value)
+	return pointer.Interface().(Nullable)
+}
+
+// FormatTime returns the text a Time has in the JSON form of the Codec, or
+// "" when it is null.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimeLayout(time.Kitchen))
+//	c.FormatTime(ztype.NewTime(at)) // "12:00PM"
+func (c *Codec) FormatTime(t Time) string {
+	if !t.value.Valid {
+		return ""
+	}
+	return string(t.appendText(c, nil))
+}
+
+// ParseTime parses s as the Codec reads the strings of Time JSON values.
+// Blank input gives a null Time.
+//
+// Example:
+//
+//	c := ztype.NewCodec(ztype.WithTimeLayout(time.RFC1123))
+//	t, err := c.ParseTime("Fri, 01 Mar 2024 12:00:00 UTC")
+func (c *Codec) ParseTime(s string) (Time, error) {
+	var t Time
+	if strings.TrimSpace(s) == "" {
+		return t, nil
+	}
+	parsed, err := c.parseTime(s)
+	if err != nil {
+		return Time{}, err
+	}
+	t.setParsed(c, parsed)
+	return t, nil
+}
+
+// timeLayoutOr returns the time layout of the Codec, or fallback when it
+// has none.
+func (c *Codec) timeLayoutOr(fallback string) string {
+	if layout := c.timeLayout.Load(); layout != nil {
+		return *layout
+	}
+	return fallback
+}
+
+// parseTime parses the text form of a Time with the layout of the Codec,
+// or with every format ParseTimeFlexible knows when it has none.
+func (c *Codec) parseTime(s string) (time.Time, error) {
+	layout := c.timeLayout.Load()
+	if layout == nil {
+		return ParseTimeFlexible(s)
+	}
+	parsed, err := time.Parse(*layout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, newInvalidFormat("Time", s, "layout %q: %w", *layout, err)
+	}
+	return parsed, nil
+}
+
+var (
+	codecMarshalerType   = reflect.TypeFor[codecMarshaler]()
+	codecUnmarshalerType = reflect.TypeFor[codecUnmarshaler]()
+)
+
+// codecAwareTypes caches codecAware by reflect.Type.
+var codecAwareTypes sync.Map
+
+// codecAware reports whether values of type t may hold ztype values that a
+// Codec encodes with its own options, so they cannot be handed to
+// encoding/json whole.
+func codecAware(t reflect.Type) bool {
+	if aware, ok := codecAwareTypes.Load(t); ok {
+		return aware.(bool)
+	}
+	aware := codecAwareType(t, map[reflect.Type]bool{})
+	codecAwareTypes.Store(t, aware)
+	return aware
+}
+
+// codecAwareType computes codecAware, skipping the types in seen to stop at
+// recursive types.
+func codecAwareType(t reflect.Type, seen map[reflect.Type]bool) bool {
+	pointer := reflect.PointerTo(t)
+	if pointer.Implements(codecMarshalerType) || pointer.Implements(codecUnmarshalerType) {
+		return true
+	}
+	if seen[t] || implementsMarshaler(t) || pointer.Implements(jsonUnmarshalerType) || pointer.Implements(textUnmarshalerType) {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return codecAwareType(t.Elem(), seen)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if (field.IsExported() || field.Anonymous) && codecAwareType(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// codecField is a JSON field of a struct, with the index path that reaches
+// it through embedded structs.
+type codecField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+}
+
+// codecFieldsByType caches codecFields by reflect.Type.
+var codecFieldsByType sync.Map
+
+// codecFields lists the JSON fields of struct type t in the order
+// encoding/json writes them. Embedded structs are flattened, and among the
+// fields sharing a name the rules of encoding/json pick one: the shallowest
+// wins, then a tagged field beats untagged ones, and the name is dropped
+// when that still leaves a tie.
+func codecFields(t reflect.Type) []codecField {
+	if fields, ok := codecFieldsByType.Load(t); ok {
+		return fields.([]codecField)
+	}
+	all := collectCodecFields(t, nil, map[reflect.Type]bool{t: true})
+	fields := all[:0:0]
+	for _, field := range all {
+		if dominantCodecField(all, field) {
+			fields = append(fields, field)
+		}
+	}
+	codecFieldsByType.Store(t, fields)
+	return fields
+}
+
+// collectCodecFields lists every JSON field of t, including the hidden ones,
+// with index prefixed by the path to t. Embedded struct types already in seen
+// are skipped to stop at cycles.
+func collectCodecFields(t reflect.Type, prefix []int, seen map[reflect.Type]bool) []codecField {
+	var all []codecField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		index := append(slices.Clone(prefix), i)
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				if !seen[fieldType] {
+					seen[fieldType] = true
+					all = append(all, collectCodecFields(fieldType, index, seen)...)
+					delete(seen, fieldType)
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+		all = append(all, codecField{
+			name:      name,
+			index:     index,
+			tagged:    tagged,
+			omitEmpty: hasJSONOption(options, "omitempty"),
+			omitZero:  hasJSONOption(options, "omitzero"),
+			quoted:    hasJSONOption(options, "string") && quotableKind(field.Type),
+		})
+	}
+	return all
+}
+
+// dominantCodecField reports whether field is the one of its name that
+// encoding/json keeps among all.
+func dominantCodecField(all []codecField, field codecField) bool {
+	for _, other := range all {
+		if other.name != field.name || slices.Equal(other.index, field.index) {
+			continue
+		}
+		switch {
+		case len(other.index) < len(field.index):
+			return false
+		case len(other.index) > len(field.index):
+			continue
+		case other.tagged == field.tagged:
+			return false
+		case other.tagged:
+			return false
+		}
+	}
+	return true
+}
+
+// quotableKind reports whether the ",string" tag option applies to fields
+// of type t, as encoding/json allows for booleans, numbers and strings.
+func quotableKind(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// findCodecField returns the field a JSON key decodes into, matching names
+// exactly first and then case-insensitively, like encoding/json.
+func findCodecField(fields []codecField, key string) *codecField {
+	for i := range fields {
+		if fields[i].name == key {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, key) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// appendJSONValue appends the JSON encoding of value with the options of c,
+// handing the values that need none of them to encoding/json.
+func (c *Codec) appendJSONValue(dst []byte, value reflect.Value) ([]byte, error) {
+	if !value.IsValid() {
+		return append(dst, "null"...), nil
+	}
+	if c == &defaultCodec || !codecAware(value.Type()) {
+		data, err := json.Marshal(value.Interface())
+		return append(dst, data...), err
+	}
+	if kind := value.Kind(); (kind == reflect.Pointer || kind == reflect.Interface) && value.IsNil() {
+		return append(dst, "null"...), nil
+	}
+	if marshaler, ok := value.Interface().(codecMarshaler); ok {
+		return marshaler.appendJSONCodec(c, dst)
+	}
+	if marshaler, ok := value.Interface().(json.Marshaler); ok {
+		data, err := json.Marshal(marshaler)
+		return append(dst, data...), err
+	}
+
+	var err error
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return c.appendJSONValue(dst, value.Elem())
+	case reflect.Struct:
+		return c.appendJSONStruct(dst, value)
+	case reflect.Map:
+		return c.appendJSONMap(dst, value)
+	case reflect.Slice:
+		if value.IsNil() {
+			return append(dst, "null"...), nil
+		}
+		fallthrough
+	case reflect.Array:
+		dst = append(dst, '[')
+		for i := range value.Len() {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = c.appendJSONValue(dst, value.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, ']'), nil
+	}
+	data, err := json.Marshal(value.Interface())
+	return append(dst, data...), err
+}
+
+// appendJSONStruct appends the fields of the struct value as a JSON object,
+// honoring the omitempty, omitzero and string tag options.
+func (c *Codec) appendJSONStruct(dst []byte, value reflect.Value) ([]byte, error) {
+	var err error
+	dst = append(dst, '{')
+	first := true
+	for _, field := range codecFields(value.Type()) {
+		fieldValue, ok := codecFieldValue(value, field.index, false)
+		if !ok || !fieldValue.CanInterface() {
+			continue
+		}
+		if field.omitEmpty && isEmptyJSONValue(fieldValue) {
+			continue
+		}
+		if field.omitZero && isZeroCodecValue(fieldValue) {
+			continue
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = appendJSONString(dst, field.name)
+		dst = append(dst, ':')
+
+		if field.quoted && !(fieldValue.Kind() == reflect.Pointer && fieldValue.IsNil()) {
+			data, err := json.Marshal(fieldValue.Interface())
+			if err != nil {
+				return nil, err
+			}
+			dst = appendJSONString(dst, data)
+			continue
+		}
+		if dst, err = c.appendJSONValue(dst, fieldValue); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// isZeroCodecValue mirrors the omitzero rules of encoding/json for a value
+// that may not be addressable.
+func isZeroCodecValue(value reflect.Value) bool {
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return true
+	}
+	if zeroer, ok := value.Interface().(interface{ IsZero() bool }); ok {
+		return zeroer.IsZero()
+	}
+	return value.IsZero()
+}
+
+// codecFieldValue follows index from the struct value through embedded
+// structs. A nil embedded pointer is allocated when alloc is set, and
+// reports ok=false otherwise or when it cannot be set.
+func codecFieldValue(value reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, step := range index {
+		if i > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				if !alloc || !value.CanSet() {
+					return reflect.Value{}, false
+				}
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(step)
+	}
+	return value, true
+}
+
+// appendJSONMap appends the map value as a JSON object with its keys sorted,
+// as encoding/json writes maps.
+func (c *Codec) appendJSONMap(dst []byte, value reflect.Value) ([]byte, error) {
+	if value.IsNil() {
+		return append(dst, "null"...), nil
+	}
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, value.Len())
+	iter := value.MapRange()
+	for iter.Next() {
+		key, err := jsonMapKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{key: key, value: iter.Value()})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	var err error
+	dst = append(dst, '{')
+	for i, entry := range entries {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, entry.key)
+		dst = append(dst, ':')
+		if dst, err = c.appendJSONValue(dst, entry.value); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// jsonMapKey returns the object key encoding/json writes for a map key:
+// string kinds as they are, then encoding.TextMarshaler, then integers.
+func jsonMapKey(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Pointer && key.IsNil() {
+			return "", nil
+		}
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch {
+	case key.CanInt():
+		return strconv.FormatInt(key.Int(), 10), nil
+	case key.CanUint():
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", &json.UnsupportedTypeError{Type: key.Type()}
+}
+
+// decodeJSONValue decodes the well-formed JSON data into the addressable
+// target with the options of c, handing the values that need none of them
+// to encoding/json. path names target in errors.
+func (c *Codec) decodeJSONValue(path string, data []byte, target reflect.Value) error {
+	if c == &defaultCodec || !codecAware(target.Type()) {
+		return decodeError(path, json.Unmarshal(data, target.Addr().Interface()))
+	}
+	null := bytes.Equal(data, []byte("null"))
+	if target.Kind() == reflect.Pointer {
+		if null {
+			target.SetZero()
+			return nil
+		}
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return c.decodeJSONValue(path, data, target.Elem())
+	}
+	if unmarshaler, ok := target.Addr().Interface().(codecUnmarshaler); ok {
+		return decodeError(path, unmarshaler.unmarshalJSONCodec(c, data))
+	}
+	if unmarshaler, ok := target.Addr().Interface().(json.Unmarshaler); ok {
+		return decodeError(path, json.Unmarshal(data, unmarshaler))
+	}
+
+	switch target.Kind() {
+	case reflect.Interface:
+		if null || target.NumMethod() == 0 && (target.IsNil() || target.Elem().Kind() != reflect.Pointer) {
+			return decodeError(path, c.decodeJSONAny(data, target))
+		}
+		if target.Elem().Kind() == reflect.Pointer && !target.Elem().IsNil() {
+			return c.decodeJSONValue(path, data, target.Elem().Elem())
+		}
+	case reflect.Struct:
+		if null {
+			return nil
+		}
+		return c.decodeJSONStruct(path, data, target)
+	case reflect.Map:
+		if null {
+			target.SetZero()
+			return nil
+		}
+		return c.decodeJSONMap(path, data, target)
+	case reflect.Slice, reflect.Array:
+		if null {
+			if target.Kind() == reflect.Slice {
+				target.SetZero()
+			}
+			return nil
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return decodeError(path, json.Unmarshal(data, target.Addr().Interface()))
+		}
+		result := target
+		if target.Kind() == reflect.Slice {
+			result = reflect.MakeSlice(target.Type(), len(items), len(items))
+		}
+		for i := range result.Len() {
+			if i >= len(items) {
+				result.Index(i).SetZero()
+				continue
+			}
+			if err := c.decodeJSONValue(fmt.Sprintf("%s[%d]", path, i), items[i], result.Index(i)); err != nil {
+				return err
+			}
+		}
+		target.Set(result)
+		return nil
+	}
+	return decodeError(path, json.Unmarshal(data, target.Addr().Interface()))
+}
+
+// decodeJSONAny decodes data into the interface target as a fresh value,
+// honoring the JSONNumberMode of c.
+func (c *Codec) decodeJSONAny(data []byte, target reflect.Value) error {
+	var decoded any
+	var err error
+	if c.useJSONNumber() {
+		err = unmarshalJSONNumbers(data, &decoded)
+	} else {
+		err = json.Unmarshal(data, &decoded)
+	}
+	if err != nil {
+		return err
+	}
+	if decoded == nil {
+		target.SetZero()
+		return nil
+	}
+	value := reflect.ValueOf(decoded)
+	if !value.Type().AssignableTo(target.Type()) {
+		return &json.UnmarshalTypeError{Value: value.Kind().String(), Type: target.Type()}
+	}
+	target.Set(value)
+	return nil
+}
+
+// decodeJSONStruct decodes the members of a JSON object into the fields of
+// the struct target with matching names, ignoring unknown members.
+func (c *Codec) decodeJSONStruct(path string, data []byte, target reflect.Value) error {
+	members, ok := readJSONObject(data)
+	if !ok {
+		return decodeError(path, json.Unmarshal(data, target.Addr().Interface()))
+	}
+	fields := codecFields(target.Type())
+	for _, member := range members {
+		field := findCodecField(fields, member.key)
+		if field == nil {
+			continue
+		}
+		fieldValue, ok := codecFieldValue(target, field.index, true)
+		if !ok || !fieldValue.CanSet() {
+			continue
+		}
+		fieldPath := field.name
+		if path != "" {
+			fieldPath = path + "." + field.name
+		}
+		raw := []byte(member.value)
+		if field.quoted && !bytes.Equal(raw, []byte("null")) {
+			var text string
+			if err := json.Unmarshal(raw, &text); err != nil {
+				return decodeError(fieldPath, err)
+			}
+			raw = []byte(text)
+		}
+		if err := c.decodeJSONValue(fieldPath, raw, fieldValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeJSONMap decodes the members of a JSON object into the map target,
+// converting each key as encoding/json does.
+func (c *Codec) decodeJSONMap(path string, data []byte, target reflect.Value) error {
+	members, ok := readJSONObject(data)
+	if !ok {
+		return decodeError(path, json.Unmarshal(data, target.Addr().Interface()))
+	}
+	if target.IsNil() {
+		target.Set(reflect.MakeMapWithSize(target.Type(), len(members)))
+	}
+	for _, member := range members {
+		itemPath := member.key
+		if path != "" {
+			itemPath = path + "." + member.key
+		}
+		key, err := jsonMapKeyValue(member.key, target.Type().Key())
+		if err != nil {
+			return decodeError(itemPath, err)
+		}
+		item := reflect.New(target.Type().Elem()).Elem()
+		if err := c.decodeJSONValue(itemPath, member.value, item); err != nil {
+			return err
+		}
+		target.SetMapIndex(key, item)
+	}
+	return nil
+}
+
+// jsonMapKeyValue converts an object key into a map key of type t:
+// encoding.TextUnmarshaler first, then string kinds, then integers in base
+// 10, in the order encoding/json tries them.
+func jsonMapKeyValue(key string, t reflect.Type) (reflect.Value, error) {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		value := reflect.New(t)
+		if err := value.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(key)); err != nil {
+			return reflect.Value{}, err
+		}
+		return value.Elem(), nil
+	}
+	value := reflect.New(t).Elem()
+	switch {
+	case t.Kind() == reflect.String:
+		value.SetString(key)
+		return value, nil
+	case value.CanInt():
+		n, err := strconv.ParseInt(key, 10, t.Bits())
+		if err == nil {
+			value.SetInt(n)
+			return value, nil
+		}
+	case value.CanUint():
+		n, err := strconv.ParseUint(key, 10, t.Bits())
+		if err == nil {
+			value.SetUint(n)
+			return value, nil
+		}
+	}
+	return reflect.Value{}, &json.UnmarshalTypeError{Value: "number " + key, Type: t}
+}
@@ -0,0 +1,140 @@
+package ztype
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AsMapOf converts every value of m to V, as needed for a JSON read from a
+// jsonb column that has a known value type. Numbers are converted exactly:
+// float64 and json.Number values must be whole and in range for integer V,
+// so 1e15 converts to int64 while 1.5 and 1e20 are errors. Other types are
+// converted with a JSON round trip, which covers strings, structs and the
+// ztype types; JSON null becomes the zero value of V, or null for the ztype
+// types.
+//
+// Every key that fails is reported, in key order, in one error joined with
+// errors.Join; the returned Map then holds the entries that did convert. A
+// null JSON gives a null Map.
+//
+// Example:
+//
+//	var payload ztype.JSON
+//	json.Unmarshal([]byte(`{"a":1,"b":2.5}`), &payload)
+//	counts, err := ztype.AsMapOf[int64](payload)
+//	// counts: map[a:1], err: b: invalid int64 "2.5": not an integer
+func AsMapOf[V any](m JSON) (Map[string, V], error) {
+	if !m.valid {
+		return NewNullMap[string, V](), nil
+	}
+	keys := slices.Sorted(m.Keys())
+	result := make(map[string]V, len(keys))
+	var errs []error
+	for _, key := range keys {
+		var converted V
+		if err := convertValue(m.value[key], reflect.ValueOf(&converted).Elem()); err != nil {
+			errs = append(errs, decodeError(key, err))
+			continue
+		}
+		result[key] = converted
+	}
+	return NewMap(result), errors.Join(errs...)
+}
+
+// convertValue stores the JSON value item into the settable target.
+func convertValue(item any, target reflect.Value) error {
+	if value := reflect.ValueOf(item); value.IsValid() && value.Type() == target.Type() {
+		target.Set(value)
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if item != nil {
+			return convertNumber(item, target)
+		}
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return wrapJSONError(target.Type().String(), data, json.Unmarshal(data, target.Addr().Interface()))
+}
+
+// convertNumber stores the number item into the numeric target, rejecting
+// fractions for integers and values out of range.
+func convertNumber(item any, target reflect.Value) error {
+	if number, ok := item.(json.Number); ok {
+		err := convertNumberText(string(number), target)
+		if f, floatErr := strconv.ParseFloat(string(number), 64); errors.Is(err, &ErrInvalidFormat{}) && floatErr == nil {
+			return convertFloat(f, target)
+		}
+		return err
+	}
+	value := reflect.ValueOf(item)
+	switch {
+	case value.CanInt():
+		return convertNumberText(strconv.FormatInt(value.Int(), 10), target)
+	case value.CanUint():
+		return convertNumberText(strconv.FormatUint(value.Uint(), 10), target)
+	case value.CanFloat():
+		return convertFloat(value.Float(), target)
+	}
+	return newUnsupportedScanType(item)
+}
+
+// convertFloat stores f into the numeric target.
+func convertFloat(f float64, target reflect.Value) error {
+	text := strconv.FormatFloat(f, 'g', -1, 64)
+	if target.CanFloat() {
+		if target.OverflowFloat(f) {
+			return &ErrOverflow{Type: target.Type().String(), Value: text}
+		}
+		target.SetFloat(f)
+		return nil
+	}
+	if f != math.Trunc(f) {
+		return newInvalidFormat(target.Type().String(), text, "not an integer")
+	}
+	return convertNumberText(strconv.FormatFloat(f, 'f', -1, 64), target)
+}
+
+// convertNumberText parses the decimal text into the numeric target.
+func convertNumberText(text string, target reflect.Value) error {
+	typeName := target.Type().String()
+	bits := target.Type().Bits()
+	var err error
+	switch {
+	case target.CanInt():
+		var n int64
+		if n, err = strconv.ParseInt(text, 10, bits); err == nil {
+			target.SetInt(n)
+		}
+	case target.CanUint():
+		if strings.HasPrefix(text, "-") {
+			return &ErrOverflow{Type: typeName, Value: text}
+		}
+		var n uint64
+		if n, err = strconv.ParseUint(text, 10, bits); err == nil {
+			target.SetUint(n)
+		}
+	default:
+		var f float64
+		if f, err = strconv.ParseFloat(text, bits); err == nil {
+			target.SetFloat(f)
+		}
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return &ErrOverflow{Type: typeName, Value: text}
+	}
+	if err != nil {
+		return newInvalidFormat(typeName, text, "%w", errors.Unwrap(err))
+	}
+	return nil
+}
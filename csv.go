@@ -0,0 +1,280 @@
+package ztype
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// csvField is a struct field mapped to a CSV column.
+type csvField struct {
+	header string
+	index  []int
+}
+
+// csvFields returns the columns of struct type t in field order. Columns
+// are named by the `csv` tag or the field name; `csv:"-"` skips a field, and
+// embedded structs without a tag that are not ztype types are flattened.
+func csvFields(t reflect.Type) []csvField {
+	var fields []csvField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("csv"), ",")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct &&
+			!reflect.PointerTo(field.Type).Implements(nullableType) {
+			for _, inner := range csvFields(field.Type) {
+				inner.index = append([]int{i}, inner.index...)
+				fields = append(fields, inner)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		fields = append(fields, csvField{header: tag, index: []int{i}})
+	}
+	return fields
+}
+
+// csvRecordType returns the struct type of the elements of a slice type,
+// which may be structs or pointers to structs.
+func csvRecordType(sliceType reflect.Type) (reflect.Type, bool) {
+	elem := sliceType.Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	return elem, elem.Kind() == reflect.Struct
+}
+
+// MarshalCSV encodes records, a slice of structs or struct pointers, as CSV
+// with a header row. Each field is written with its type's MarshalText, or
+// with its JSON encoding unquoted for types without one, so Time uses
+// RFC 3339 with nanoseconds and Duration strings such as "1h30m0s". Null
+// fields are written as empty cells. Quoting and escaping are those of
+// encoding/csv.
+//
+// Because an empty cell means null, an empty but valid String reads back
+// as null with UnmarshalCSV.
+//
+// Example:
+//
+//	type Row struct {
+//		Name  ztype.String       `csv:"name"`
+//		Price ztype.Numeric[int] `csv:"price"`
+//	}
+//	data, err := ztype.MarshalCSV([]Row{{Name: ztype.NewString("pen")}})
+//	// name,price
+//	// pen,
+func MarshalCSV(records any) ([]byte, error) {
+	value := reflect.ValueOf(records)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", records)
+	}
+	recordType, ok := csvRecordType(value.Type())
+	if !ok {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", records)
+	}
+	fields := csvFields(recordType)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	row := make([]string, len(fields))
+	for i, field := range fields {
+		row[i] = field.header
+	}
+	if err := writer.Write(row); err != nil {
+		return nil, err
+	}
+	for i := range value.Len() {
+		record := value.Index(i)
+		if record.Kind() == reflect.Pointer {
+			if record.IsNil() {
+				return nil, fmt.Errorf("record %d: nil pointer", i)
+			}
+			record = record.Elem()
+		}
+		for j, field := range fields {
+			cell, err := marshalCSVCell(record.FieldByIndex(field.index))
+			if err != nil {
+				return nil, fmt.Errorf("record %d, column %q: %w", i, field.header, err)
+			}
+			row[j] = cell
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// marshalCSVCell returns the text of a field, empty for null. RawJSON is
+// written as the document it holds.
+func marshalCSVCell(field reflect.Value) (string, error) {
+	target := field.Interface()
+	if field.CanAddr() {
+		target = field.Addr().Interface()
+	}
+	if nullable, ok := target.(Nullable); ok && nullable.IsNull() {
+		return "", nil
+	}
+	if marshaler, ok := target.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	if raw, ok := field.Interface().(RawJSON); ok {
+		return string(raw.value), nil
+	}
+	data, err := json.Marshal(target)
+	if err != nil {
+		return "", err
+	}
+	var text string
+	if len(data) > 0 && data[0] == '"' && json.Unmarshal(data, &text) == nil {
+		return text, nil
+	}
+	if string(data) == "null" {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+// UnmarshalCSV decodes CSV data with a header row into dest, a pointer to a
+// slice of structs or struct pointers, replacing its contents. Columns are
+// matched to fields by their `csv` tag or name, then case-insensitively;
+// unknown columns are ignored and fields without a column keep their zero
+// value. A leading UTF-8 byte order mark is skipped.
+//
+// Each cell is parsed with the field's UnmarshalText, or as JSON for types
+// without one, so Time and Duration accept the same layouts as everywhere
+// else. An empty cell makes a ztype field null and marks it as unmarshaled;
+// other fields are left at their zero value. Errors name the line and
+// column of the offending cell.
+//
+// Example:
+//
+//	var rows []Row
+//	err := ztype.UnmarshalCSV([]byte("name,price\npen,\n"), &rows)
+//	fmt.Println(rows[0].Price.IsNull()) // Output: true
+func UnmarshalCSV(data []byte, dest any) error {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("expected a non-nil pointer to a slice of structs, got %T", dest)
+	}
+	slice := target.Elem()
+	recordType, ok := csvRecordType(slice.Type())
+	if !ok {
+		return fmt.Errorf("expected a non-nil pointer to a slice of structs, got %T", dest)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		slice.Set(slice.Slice(0, 0))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	columns := csvColumns(header, csvFields(recordType))
+
+	result := reflect.MakeSlice(slice.Type(), 0, 0)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		record := reflect.New(recordType).Elem()
+		for i, cell := range row {
+			if i >= len(columns) || columns[i] == nil {
+				continue
+			}
+			if err := unmarshalCSVCell(record.FieldByIndex(columns[i]), cell); err != nil {
+				line, _ := reader.FieldPos(i)
+				return fmt.Errorf("line %d, column %q: %w", line, header[i], err)
+			}
+		}
+		if slice.Type().Elem().Kind() == reflect.Pointer {
+			record = record.Addr()
+		}
+		result = reflect.Append(result, record)
+	}
+	slice.Set(result)
+	return nil
+}
+
+// csvColumns maps each header to the index of its field, nil for unknown
+// headers. Exact names win over case-insensitive matches.
+func csvColumns(header []string, fields []csvField) [][]int {
+	columns := make([][]int, len(header))
+	for i, name := range header {
+		for _, field := range fields {
+			if field.header == name {
+				columns[i] = field.index
+				break
+			}
+		}
+		if columns[i] != nil {
+			continue
+		}
+		for _, field := range fields {
+			if strings.EqualFold(field.header, name) {
+				columns[i] = field.index
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// unmarshalCSVCell parses cell into the addressable field.
+func unmarshalCSVCell(field reflect.Value, cell string) error {
+	target := field.Addr().Interface()
+	nullable, isNullable := target.(Nullable)
+	if cell == "" {
+		if isNullable {
+			nullable.SetNull()
+			nullable.SetUnmarshaled(true)
+		} else {
+			field.SetZero()
+		}
+		return nil
+	}
+	if unmarshaler, ok := target.(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText([]byte(cell))
+		if isNullable {
+			nullable.SetUnmarshaled(true)
+		}
+		return err
+	}
+	if raw, ok := target.(*RawJSON); ok {
+		raw.unmarshaled = true
+		return raw.store([]byte(cell))
+	}
+	// Try the cell as a JSON string first, so text such as "A" or "19.90 BRL"
+	// works, then as a JSON document for numbers, arrays and objects.
+	quoted, _ := json.Marshal(cell)
+	if json.Unmarshal(quoted, target) == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(cell), target)
+}
@@ -0,0 +1,388 @@
+package ztype
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jsonUnmarshalerType is the reflect type of json.Unmarshaler.
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// DecodeHook converts data before DecodeInto stores it into a destination of
+// type to, like mapstructure's DecodeHookFunc. from is the type of data, nil
+// for null. A hook returns data unchanged when it does not apply, and hooks
+// run in order, each one seeing the result of the previous.
+//
+// Example:
+//
+//	cents := func(from, to reflect.Type, data any) (any, error) {
+//		if f, ok := data.(float64); ok && to == reflect.TypeFor[ztype.Money]() {
+//			return ztype.NewMoney(int64(f), "USD"), nil
+//		}
+//		return data, nil
+//	}
+//	err := doc.DecodeInto(&order, append(ztype.DefaultDecodeHooks(), cents)...)
+type DecodeHook func(from, to reflect.Type, data any) (any, error)
+
+// DefaultDecodeHooks returns the hooks DecodeInto uses when it is given none:
+// StringToTimeHook, StringToDurationHook and StringToNumberHook.
+//
+// Example:
+//
+//	hooks := append(ztype.DefaultDecodeHooks(), myHook)
+func DefaultDecodeHooks() []DecodeHook {
+	return []DecodeHook{StringToTimeHook, StringToDurationHook, StringToNumberHook}
+}
+
+// StringToTimeHook parses strings into Time and time.Time fields with the
+// formats Time.UnmarshalText accepts. The empty string becomes a null Time.
+//
+// Example:
+//
+//	err := doc.DecodeInto(&event, ztype.StringToTimeHook)
+func StringToTimeHook(from, to reflect.Type, data any) (any, error) {
+	s, ok := data.(string)
+	if !ok {
+		return data, nil
+	}
+	switch to {
+	case reflect.TypeFor[Time]():
+		var t Time
+		err := t.UnmarshalText([]byte(s))
+		return t, err
+	case reflect.TypeFor[time.Time]():
+		return ParseTimeFlexible(s)
+	}
+	return data, nil
+}
+
+// StringToDurationHook parses strings such as "30s" into Duration and
+// time.Duration fields, see ParseDurationFlexible. The empty string becomes a null Duration.
+//
+// Example:
+//
+//	err := doc.DecodeInto(&job, ztype.StringToDurationHook)
+func StringToDurationHook(from, to reflect.Type, data any) (any, error) {
+	s, ok := data.(string)
+	if !ok {
+		return data, nil
+	}
+	switch to {
+	case reflect.TypeFor[Duration]():
+		var d Duration
+		err := d.UnmarshalText([]byte(s))
+		return d, err
+	case reflect.TypeFor[time.Duration]():
+		return ParseDurationFlexible(s)
+	}
+	return data, nil
+}
+
+// StringToNumberHook turns numeric strings such as "42" or "1.5" into
+// json.Number when the destination is a Numeric or a Go number, keeping
+// integers that do not fit a float64 exact. Other strings are left alone.
+//
+// Example:
+//
+//	err := doc.DecodeInto(&item, ztype.StringToNumberHook) // {"qty":"3"}
+func StringToNumberHook(from, to reflect.Type, data any) (any, error) {
+	s, ok := data.(string)
+	if !ok || !isNumberDecodeTarget(to) {
+		return data, nil
+	}
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) || !json.Valid([]byte(s)) {
+		return data, nil
+	}
+	return json.Number(s), nil
+}
+
+// isNumberDecodeTarget reports whether t is a Go number or a Numeric.
+func isNumberDecodeTarget(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return t != reflect.TypeFor[time.Duration]()
+	}
+	return t.PkgPath() == packagePath && strings.HasPrefix(t.Name(), "Numeric[")
+}
+
+// DecodeInto fills the struct, map, slice or value pointed to by dest from
+// the map, as encoding/json would from the same document, without encoding
+// it back to JSON first. Struct fields are matched by their json name, then
+// case-insensitively; unknown keys are ignored. Before each value is stored,
+// hooks convert it for its destination; with no hooks, DefaultDecodeHooks is
+// used, so strings hydrate Time, Duration and Numeric fields.
+//
+// Values that reach a type implementing json.Unmarshaler, such as the ztype
+// types, are handed to it as their JSON encoding, so null produces a null
+// field marked as unmarshaled. A null Map leaves dest untouched. Numbers
+// decoded under JSONNumberExact are converted from their text, so integers
+// beyond 2^53 keep every digit.
+//
+// Example:
+//
+//	type Job struct {
+//		Started ztype.Time         `json:"started"`
+//		Timeout ztype.Duration     `json:"timeout"`
+//		Retries ztype.Numeric[int] `json:"retries"`
+//	}
+//	var job Job
+//	err := doc.DecodeInto(&job) // {"started":"2023-01-01T00:00:00Z","timeout":"30s","retries":"3"}
+func (m Map[K, V]) DecodeInto(dest any, hooks ...DecodeHook) error {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer, got %T", dest)
+	}
+	if !m.valid {
+		return nil
+	}
+	if len(hooks) == 0 {
+		hooks = DefaultDecodeHooks()
+	}
+	decoder := mapDecoder{hooks: hooks}
+	return decoder.decode("", m.value, target.Elem())
+}
+
+// mapDecoder stores decoded values into reflect values for DecodeInto.
+type mapDecoder struct {
+	hooks []DecodeHook
+}
+
+// decode stores data into the settable target, reporting errors prefixed
+// with path.
+func (d mapDecoder) decode(path string, data any, target reflect.Value) error {
+	for _, hook := range d.hooks {
+		converted, err := hook(reflect.TypeOf(data), target.Type(), data)
+		if err != nil {
+			return decodeError(path, err)
+		}
+		data = converted
+	}
+
+	pointer := reflect.PointerTo(target.Type())
+	if data == nil {
+		if pointer.Implements(jsonUnmarshalerType) {
+			err := target.Addr().Interface().(json.Unmarshaler).UnmarshalJSON([]byte("null"))
+			return decodeError(path, err)
+		}
+		target.SetZero()
+		return nil
+	}
+
+	source := reflect.ValueOf(data)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		if nullable, ok := target.Addr().Interface().(Nullable); ok {
+			nullable.SetUnmarshaled(true)
+		}
+		return nil
+	}
+
+	if target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return d.decode(path, data, target.Elem())
+	}
+	if pointer.Implements(jsonUnmarshalerType) {
+		encoded, err := json.Marshal(data)
+		if err == nil {
+			err = target.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(encoded)
+		}
+		return decodeError(path, err)
+	}
+	if s, ok := data.(string); ok && pointer.Implements(textUnmarshalerType) {
+		err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		return decodeError(path, err)
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		if source.Kind() == reflect.Map && source.Type().Key().Kind() == reflect.String {
+			return d.decodeStruct(path, source, target)
+		}
+	case reflect.Map:
+		if source.Kind() == reflect.Map && target.Type().Key().Kind() == reflect.String &&
+			source.Type().Key().Kind() == reflect.String {
+			return d.decodeMap(path, source, target)
+		}
+	case reflect.Slice:
+		if source.Kind() == reflect.Slice || source.Kind() == reflect.Array {
+			items := reflect.MakeSlice(target.Type(), source.Len(), source.Len())
+			for i := range source.Len() {
+				if err := d.decode(fmt.Sprintf("%s[%d]", path, i), source.Index(i).Interface(), items.Index(i)); err != nil {
+					return err
+				}
+			}
+			target.Set(items)
+			return nil
+		}
+	case reflect.Array:
+		if source.Kind() == reflect.Slice || source.Kind() == reflect.Array {
+			target.SetZero()
+			for i := range min(source.Len(), target.Len()) {
+				if err := d.decode(fmt.Sprintf("%s[%d]", path, i), source.Index(i).Interface(), target.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case reflect.String:
+		if source.Kind() == reflect.String {
+			target.SetString(source.String())
+			return nil
+		}
+	case reflect.Bool:
+		if source.Kind() == reflect.Bool {
+			target.SetBool(source.Bool())
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if number, ok := data.(json.Number); ok {
+			return decodeError(path, convertNumber(number, target))
+		}
+		if number, ok := decodeNumberText(source); ok {
+			return decodeError(path, setDecodedNumber(number, target))
+		}
+	}
+	return decodeError(path, fmt.Errorf("cannot decode %T into %v", data, target.Type()))
+}
+
+// decodeStruct stores the entries of the string-keyed map source into the
+// fields of target with matching json names.
+func (d mapDecoder) decodeStruct(path string, source, target reflect.Value) error {
+	for i := range target.NumField() {
+		field := target.Type().Field(i)
+		if field.Anonymous && !hasJSONName(field) {
+			embedded := target.Field(i)
+			if field.Type.Kind() == reflect.Pointer {
+				if field.Type.Elem().Kind() != reflect.Struct || !field.IsExported() {
+					continue
+				}
+				if embedded.IsNil() {
+					embedded.Set(reflect.New(field.Type.Elem()))
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && !reflect.PointerTo(embedded.Type()).Implements(jsonUnmarshalerType) {
+				if err := d.decodeStruct(path, source, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		item, ok := lookupDecodeKey(source, name)
+		if !ok {
+			continue
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		if err := d.decode(fieldPath, item, target.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMap stores the entries of the string-keyed map source into the
+// string-keyed map target.
+func (d mapDecoder) decodeMap(path string, source, target reflect.Value) error {
+	if target.IsNil() {
+		target.Set(reflect.MakeMapWithSize(target.Type(), source.Len()))
+	}
+	iter := source.MapRange()
+	for iter.Next() {
+		name := iter.Key().String()
+		item := reflect.New(target.Type().Elem()).Elem()
+		itemPath := name
+		if path != "" {
+			itemPath = path + "." + name
+		}
+		if err := d.decode(itemPath, iter.Value().Interface(), item); err != nil {
+			return err
+		}
+		target.SetMapIndex(reflect.ValueOf(name).Convert(target.Type().Key()), item)
+	}
+	return nil
+}
+
+// lookupDecodeKey returns the entry of the string-keyed map source named
+// name, falling back to a case-insensitive match like encoding/json.
+func lookupDecodeKey(source reflect.Value, name string) (any, bool) {
+	key := reflect.ValueOf(name).Convert(source.Type().Key())
+	if item := source.MapIndex(key); item.IsValid() {
+		return item.Interface(), true
+	}
+	iter := source.MapRange()
+	for iter.Next() {
+		if strings.EqualFold(iter.Key().String(), name) {
+			return iter.Value().Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// decodeNumberText returns the text of a number held by source, which may
+// be any Go number.
+func decodeNumberText(source reflect.Value) (string, bool) {
+	switch source.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(source.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(source.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(source.Float(), 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// setDecodedNumber parses number into the Go number target.
+func setDecodedNumber(number string, target reflect.Value) error {
+	bits := target.Type().Bits()
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(number, 10, bits)
+		if err != nil {
+			return wrapScanError(target.Type().String(), number, err)
+		}
+		target.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(number, 10, bits)
+		if err != nil {
+			return wrapScanError(target.Type().String(), number, err)
+		}
+		target.SetUint(parsed)
+	default:
+		parsed, err := strconv.ParseFloat(number, bits)
+		if err != nil {
+			return wrapScanError(target.Type().String(), number, err)
+		}
+		target.SetFloat(parsed)
+	}
+	return nil
+}
+
+// decodeError prefixes err with path, the dotted location of the value.
+func decodeError(path string, err error) error {
+	if err == nil || path == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
@@ -0,0 +1,46 @@
+// Package ztype provides nullable types that carry SQL NULL and JSON null
+// through database/sql, encoding/json and the other encodings the package
+// supports, and remember whether they were present in a decoded payload.
+//
+// The zero value of every type is a usable null: it reads as the zero value
+// of the underlying Go type, encodes as null and accepts Set, Scan and
+// UnmarshalJSON without a constructor. Collections allocate their storage on
+// first write, and a valid Map never holds a nil map.
+//
+// Each scalar type has three constructors: New<Type> for a valid value,
+// NewNull<Type> for null, and NewNull<Type>IfZero for a value that is null
+// when its Go value is the zero value.
+//
+// Get returns the zero value for null, so a missed null check reads as a
+// real zero. Val, the comma-ok form on every type with a Get, is the
+// preferred accessor: its two results cannot be used as a plain value, so
+// the null case stays visible in code review.
+//
+//	if name, ok := user.Name.Val(); ok { greet(name) }
+//
+// Scan accepts the driver values of the matching column type, and a few
+// coercions between booleans and numbers for drivers and views that expose
+// flags either way:
+//
+//	Scan into       bool      int64 / float64            nil
+//	Bool            as is     non-zero true, 0 false     null
+//	Byte            1 or 0    as is, range checked       null
+//	Numeric[T]      1 or 0    as is, range checked       null
+//
+// Methods report failures with errors rather than panics. The only ones that
+// panic are the Must methods, such as Map.MustGetItem, and Numeric.Div and
+// DivRaw; each has a non-panicking counterpart (GetItem, SafeDiv, DivOr).
+// Likewise, a method that cannot return an error and so may lose one, such
+// as String falling back to %v formatting, has a sibling that reports it:
+// MarshalJSON or MarshalText, or an E-suffixed variant like Map.JsonStringE.
+// New convenience methods follow the same rule.
+//
+// Example:
+//
+//	var user struct {
+//		Name  ztype.String       `json:"name"`
+//		Age   ztype.Numeric[int] `json:"age"`
+//		Roles ztype.Map[string, bool]
+//	}
+//	user.Roles.SetItem("admin", true) // no constructor needed
+package ztype
@@ -0,0 +1,469 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// enumTypes holds the registered *EnumType[T] for each T, so that zero-value
+// Enum fields (e.g. inside a struct being decoded) can validate themselves.
+var enumTypes sync.Map
+
+// EnumType describes the allowed values of an Enum[T]. It is created with
+// NewEnumType, usually once in a package-level variable, and acts as a
+// factory for Enum values.
+//
+// Example:
+//
+//	type Status string
+//	var StatusType = ztype.NewEnumType[Status]("active", "blocked", "deleted")
+//	s, err := StatusType.New("active")
+type EnumType[T ~string] struct {
+	values          []T
+	caseInsensitive bool
+}
+
+// NewEnumType registers the allowed values for T and returns its EnumType.
+// Registering T again replaces the previous set.
+//
+// Example:
+//
+//	var StatusType = ztype.NewEnumType[Status]("active", "blocked")
+func NewEnumType[T ~string](values ...T) *EnumType[T] {
+	enumType := &EnumType[T]{values: slices.Clone(values)}
+	enumTypes.Store(reflect.TypeFor[T](), enumType)
+	return enumType
+}
+
+// CaseInsensitive makes validation ignore case. Matching input is stored
+// using the registered spelling. Call it during initialization.
+//
+// Example:
+//
+//	var StatusType = ztype.NewEnumType[Status]("active").CaseInsensitive()
+//	s, _ := StatusType.New("ACTIVE")
+//	s.Get() // "active"
+func (t *EnumType[T]) CaseInsensitive() *EnumType[T] {
+	t.caseInsensitive = true
+	return t
+}
+
+// Values returns a copy of the allowed values, in registration order.
+//
+// Example:
+//
+//	StatusType.Values() // [active blocked]
+func (t *EnumType[T]) Values() []T {
+	return slices.Clone(t.values)
+}
+
+// Parse validates value and returns it using the registered spelling.
+//
+// Example:
+//
+//	status, err := StatusType.Parse("blocked")
+func (t *EnumType[T]) Parse(value string) (T, error) {
+	for _, allowed := range t.values {
+		if string(allowed) == value || (t.caseInsensitive && strings.EqualFold(string(allowed), value)) {
+			return allowed, nil
+		}
+	}
+	names := make([]string, len(t.values))
+	for i, allowed := range t.values {
+		names[i] = string(allowed)
+	}
+	return "", newInvalidFormat(enumTypeName[T](), value, "allowed values are %s", strings.Join(names, ", "))
+}
+
+// New creates a valid Enum, returning an error when value is not allowed.
+//
+// Example:
+//
+//	s, err := StatusType.New("active")
+func (t *EnumType[T]) New(value T) (Enum[T], error) {
+	parsed, err := t.Parse(string(value))
+	if err != nil {
+		return Enum[T]{}, err
+	}
+	return Enum[T]{value: parsed, valid: true}, nil
+}
+
+// MustNew is like New but panics when value is not allowed.
+//
+// Example:
+//
+//	s := StatusType.MustNew("active")
+func (t *EnumType[T]) MustNew(value T) Enum[T] {
+	enum, err := t.New(value)
+	if err != nil {
+		panic(err)
+	}
+	return enum
+}
+
+// NewNull creates a null Enum.
+//
+// Example:
+//
+//	s := StatusType.NewNull()
+//	s.IsNull() // true
+func (t *EnumType[T]) NewNull() Enum[T] {
+	return Enum[T]{valid: false}
+}
+
+// enumTypeName returns the name of Enum[T] used in errors.
+func enumTypeName[T ~string]() string {
+	return "Enum[" + reflect.TypeFor[T]().String() + "]"
+}
+
+// lookupEnumType returns the EnumType registered for T.
+func lookupEnumType[T ~string]() (*EnumType[T], error) {
+	enumType, ok := enumTypes.Load(reflect.TypeFor[T]())
+	if !ok {
+		return nil, fmt.Errorf("enum %v has no registered values", reflect.TypeFor[T]())
+	}
+	return enumType.(*EnumType[T]), nil
+}
+
+// Enum represents a nullable string restricted to the values registered for T
+// with NewEnumType. Set, UnmarshalText, UnmarshalJSON and Scan reject values
+// outside the set with an error listing the allowed values.
+//
+// Example:
+//
+//	type Status string
+//	var StatusType = ztype.NewEnumType[Status]("active", "blocked")
+//
+//	var payload struct{ Status ztype.Enum[Status] }
+//	err := json.Unmarshal([]byte(`{"Status":"gone"}`), &payload) // error
+type Enum[T ~string] struct {
+	value       T
+	valid       bool
+	unmarshaled bool
+}
+
+// Get returns the value. When null, returns the empty string.
+//
+// Example:
+//
+//	s := StatusType.MustNew("active")
+//	s.Get() // "active"
+func (e *Enum[T]) Get() T {
+	return e.value
+}
+
+// Val returns the value and whether it is valid.
+//
+// Example:
+//
+//	if status, ok := e.Val(); ok { fmt.Println(status) }
+func (e Enum[T]) Val() (T, bool) {
+	return e.value, e.valid
+}
+
+// Set validates value and marks the Enum as valid.
+// On error the current value is left untouched.
+//
+// Example:
+//
+//	var s ztype.Enum[Status]
+//	err := s.Set("blocked")
+func (e *Enum[T]) Set(value T) error {
+	return e.parse(string(value))
+}
+
+// parse validates value against the registered set and stores it.
+func (e *Enum[T]) parse(value string) error {
+	enumType, err := lookupEnumType[T]()
+	if err != nil {
+		return err
+	}
+	parsed, err := enumType.Parse(value)
+	if err != nil {
+		return err
+	}
+	e.value = parsed
+	e.valid = true
+	return nil
+}
+
+// SetNull marks the value as null and resets it.
+//
+// Example:
+//
+//	s.SetNull()
+//	s.IsNull() // true
+func (e *Enum[T]) SetNull() {
+	e.value = ""
+	e.valid = false
+}
+
+// IsNull returns true if the value is null.
+//
+// Example:
+//
+//	StatusType.NewNull().IsNull() // true
+func (e *Enum[T]) IsNull() bool {
+	return !e.valid
+}
+
+// IsEmpty returns true if the value is null or the empty string.
+//
+// Example:
+//
+//	StatusType.NewNull().IsEmpty() // true
+func (e Enum[T]) IsEmpty() bool {
+	return !e.valid || e.value == ""
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	StatusType.MustNew("active").IsZero() // false
+func (e Enum[T]) IsZero() bool {
+	return e.IsEmpty()
+}
+
+// Is returns true if the Enum is valid and holds value.
+//
+// Example:
+//
+//	if s.Is("active") { /* ... */ }
+func (e *Enum[T]) Is(value T) bool {
+	return e.valid && e.value == value
+}
+
+// Values returns the allowed values registered for T,
+// or nil when T has not been registered.
+//
+// Example:
+//
+//	var s ztype.Enum[Status]
+//	s.Values() // [active blocked]
+func (e *Enum[T]) Values() []T {
+	enumType, err := lookupEnumType[T]()
+	if err != nil {
+		return nil
+	}
+	return enumType.Values()
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values.
+//
+// Example:
+//
+//	s.Unmarshaled()
+func (e *Enum[T]) Unmarshaled() bool {
+	return e.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	s.SetUnmarshaled(true)
+func (e *Enum[T]) SetUnmarshaled(value bool) {
+	e.unmarshaled = value
+}
+
+// Equal performs equality check including null state.
+//
+// Example:
+//
+//	a.Equal(b)
+func (e *Enum[T]) Equal(other Enum[T]) bool {
+	return e.valid == other.valid && e.value == other.value
+}
+
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	e := statusType.MustNew("active")
+//	fmt.Println(e.EqualValues(statusType.MustNew("active")))  // Output: true
+func (e Enum[T]) EqualValues(other Enum[T]) bool {
+	return e.Equal(other)
+}
+
+// Clone returns a copy of the Enum, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	e := StatusType.MustNew("active")
+//	c := e.Clone()
+func (e Enum[T]) Clone() Enum[T] {
+	return e
+}
+
+// EqualRaw compares the value while ignoring null state.
+//
+// Example:
+//
+//	s.EqualRaw("active")
+func (e *Enum[T]) EqualRaw(other T) bool {
+	return e.value == other
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// Example:
+//
+//	data, _ := s.MarshalText()
+func (e Enum[T]) MarshalText() ([]byte, error) {
+	if e.valid {
+		return []byte(e.value), nil
+	}
+	return nil, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, validating the input.
+//
+// Example:
+//
+//	err := s.UnmarshalText([]byte("active"))
+func (e *Enum[T]) UnmarshalText(data []byte) error {
+	e.unmarshaled = true
+	return e.parse(string(data))
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// Example:
+//
+//	data, _ := json.Marshal(s) // "active"
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	return e.AppendJSON(make([]byte, 0, jsonStringCapacity(len(e.value))))
+}
+
+// AppendJSON appends the JSON encoding of the Enum to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = StatusType.MustNew("active").AppendJSON(buf) // "active"
+func (e Enum[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if !e.valid {
+		return append(dst, "null"...), nil
+	}
+	return appendJSONString(dst, string(e.value)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, validating the input.
+//
+// Example:
+//
+//	err := json.Unmarshal([]byte(`"active"`), &s)
+func (e *Enum[T]) UnmarshalJSON(data []byte) error {
+	e.unmarshaled = true
+	if bytes.Equal(data, []byte("null")) {
+		e.SetNull()
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return wrapJSONError(enumTypeName[T](), data, err)
+	}
+	return e.parse(value)
+}
+
+// Scan implements sql.Scanner for database integration, validating the input.
+//
+// Example:
+//
+//	var s ztype.Enum[Status]
+//	err := db.QueryRow("SELECT status FROM users WHERE id = 1").Scan(&s)
+func (e *Enum[T]) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		e.SetNull()
+		return nil
+	case string:
+		return e.parse(v)
+	case []byte:
+		return e.parse(string(v))
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements driver.Valuer for database integration.
+//
+// Example:
+//
+//	val, _ := s.Value() // "active"
+func (e Enum[T]) Value() (driver.Value, error) {
+	if !e.valid {
+		return nil, nil
+	}
+	return string(e.value), nil
+}
+
+// ValueOrZero is like Value, but returns the empty string instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := StatusType.NewNull().ValueOrZero() // ""
+func (e Enum[T]) ValueOrZero() (driver.Value, error) {
+	if !e.valid {
+		return "", nil
+	}
+	return e.Value()
+}
+
+// String implements fmt.Stringer for human-readable output.
+//
+// Example:
+//
+//	fmt.Println(StatusType.NewNull()) // "<NULL>"
+func (e Enum[T]) String() string {
+	if !e.valid {
+		return nullToken()
+	}
+	return string(e.value)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(StatusType.NewNull().StringOr("unknown"))  // Output: unknown
+func (e Enum[T]) StringOr(fallback string) string {
+	if !e.valid {
+		return fallback
+	}
+	return e.String()
+}
+
+// SchemaType reports Enum as a nullable string. JSONSchemaFor also lists
+// the registered values.
+//
+// Example:
+//
+//	ztype.Enum[Status]{}.SchemaType() // "string", "", true
+func (e Enum[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "", true
+}
+
+// schemaEnum returns the registered values of T, or nil when none are.
+func (e Enum[T]) schemaEnum() []string {
+	enumType, err := lookupEnumType[T]()
+	if err != nil {
+		return nil
+	}
+	values := make([]string, len(enumType.values))
+	for i, value := range enumType.values {
+		values[i] = string(value)
+	}
+	return values
+}
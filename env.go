@@ -0,0 +1,136 @@
+package ztype
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// textUnmarshalerType is the reflect type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// FromEnv fills the ztype fields of the struct pointed to by dest from
+// environment variables. Each variable is named prefix + "_" + the field's
+// `env` tag, or its name in upper snake case (MaxConns becomes MAX_CONNS);
+// `env:"-"` skips a field. Nested structs extend the prefix with their own
+// name, while embedded structs without an env tag share the parent prefix.
+//
+// Values are parsed with each type's UnmarshalText, so Time and Duration
+// accept the same formats as everywhere else. Only variables that are set
+// mark their field as unmarshaled; fields of unset variables are left as is,
+// so a null can be replaced by a default later with GetOr. A variable set to
+// the empty string makes its field null. Malformed values are reported
+// together, naming each variable.
+//
+// Example:
+//
+//	type Config struct {
+//		Port    ztype.Numeric[int] // APP_PORT
+//		Debug   ztype.Bool         // APP_DEBUG
+//		Timeout ztype.Duration     `env:"TIMEOUT"`
+//		DB      struct {
+//			URL ztype.String // APP_DB_URL
+//		}
+//	}
+//	var cfg Config
+//	err := ztype.FromEnv("APP", &cfg)
+//	debug := cfg.Debug.GetOr(false)
+func FromEnv(prefix string, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", dest)
+	}
+	value = value.Elem()
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", dest)
+	}
+	return errors.Join(loadEnvFields(strings.TrimSuffix(prefix, "_"), value)...)
+}
+
+// loadEnvFields fills the fields of the addressable struct value and returns
+// the errors of malformed variables.
+func loadEnvFields(prefix string, value reflect.Value) []error {
+	var errs []error
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("env")
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = envName(field.Name)
+		}
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		fieldValue := value.Field(i)
+		if reflect.PointerTo(field.Type).Implements(nullableType) &&
+			reflect.PointerTo(field.Type).Implements(textUnmarshalerType) {
+			if !field.IsExported() {
+				continue
+			}
+			if err := loadEnvField(name, fieldValue.Addr().Interface()); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if fieldValue.Kind() != reflect.Struct {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			errs = append(errs, loadEnvFields(prefix, fieldValue)...)
+			continue
+		}
+		if field.IsExported() {
+			errs = append(errs, loadEnvFields(name, fieldValue)...)
+		}
+	}
+	return errs
+}
+
+// loadEnvField parses the variable name into target when it is set.
+func loadEnvField(name string, target any) error {
+	text, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	nullable := target.(Nullable)
+	if text == "" {
+		nullable.SetNull()
+		nullable.SetUnmarshaled(true)
+		return nil
+	}
+	if err := target.(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	nullable.SetUnmarshaled(true)
+	return nil
+}
+
+// envName converts a Go field name to upper snake case, keeping acronyms
+// together: MaxConns becomes MAX_CONNS and DBHost becomes DB_HOST.
+func envName(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				builder.WriteByte('_')
+			}
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	return builder.String()
+}
@@ -0,0 +1,229 @@
+package ztype
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// The package reports failures with the following errors, so callers can
+// branch on them with errors.Is and errors.As instead of matching messages,
+// and can build their own messages without echoing raw input:
+//
+//   - *ErrInvalidFormat: UnmarshalJSON, UnmarshalText, Scan and the Parse
+//     functions got input that is not a valid value of the type.
+//   - *ErrOverflow: a parsed or computed value does not fit the type.
+//   - *ErrUnsupportedScanType: Scan got a driver value of the wrong Go type.
+//   - ErrNullValue: an operation that needs a valid value, such as Compare,
+//     got null.
+//   - ErrDivisionByZero: SafeDiv and similar got a zero divisor.
+//   - *ErrPanic: the value Div and DivRaw panic with, so a recover can tell
+//     it apart from other panics.
+//   - ErrJSONTooLarge, ErrJSONTooDeep: a document went over the limits set
+//     with SetMaxJSONSize and SetMaxJSONDepth.
+//
+// The struct errors match any error of the same type with errors.Is when
+// their fields are left empty, and the same Type otherwise:
+//
+//	if errors.Is(err, &ztype.ErrInvalidFormat{}) { /* 400 Bad Request */ }
+//	if errors.Is(err, &ztype.ErrInvalidFormat{Type: "Time"}) { /* bad date */ }
+//	var invalid *ztype.ErrInvalidFormat
+//	if errors.As(err, &invalid) { log.Printf("bad %s input", invalid.Type) }
+//
+// When UnmarshalJSON, UnmarshalText or Scan fails, the receiver keeps the
+// value and null state it had before the call, so a caller can retry or fall
+// back to it; only the unmarshaled flag may have been set. Documents rejected
+// by the JSON size and depth limits are the exception: they leave the value
+// null.
+var (
+	// ErrNullValue is returned when an operation needs a valid value and
+	// got null.
+	ErrNullValue = errors.New("null value")
+
+	// ErrDivisionByZero is returned by divisions with a zero divisor.
+	ErrDivisionByZero = errors.New("cannot divide by zero")
+)
+
+// ErrInvalidFormat reports input that could not be parsed as Type, such as
+// "Time" or "Bool". Err holds the underlying cause, if any.
+//
+// Example:
+//
+//	var invalid *ztype.ErrInvalidFormat
+//	if errors.As(json.Unmarshal(data, &t), &invalid) {
+//		fmt.Println(invalid.Type)  // Output: Time
+//	}
+type ErrInvalidFormat struct {
+	Type  string
+	Input string
+	Err   error
+}
+
+// newInvalidFormat returns an *ErrInvalidFormat for input, with an optional
+// cause formatted from format and args.
+func newInvalidFormat(typeName, input string, format string, args ...any) *ErrInvalidFormat {
+	err := &ErrInvalidFormat{Type: typeName, Input: input}
+	if format != "" {
+		err.Err = fmt.Errorf(format, args...)
+	}
+	return err
+}
+
+// wrapInvalidFormat returns err as the cause of an *ErrInvalidFormat for
+// input, or nil when err is nil.
+func wrapInvalidFormat(typeName, input string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ErrInvalidFormat{Type: typeName, Input: input, Err: err}
+}
+
+// wrapJSONError classifies an error from decoding the JSON data into
+// typeName with encoding/json: integers that do not fit become
+// *ErrOverflow, anything else *ErrInvalidFormat. Errors already reported by
+// a nested ztype value are returned as they are.
+func wrapJSONError(typeName string, data []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	var invalid *ErrInvalidFormat
+	var overflow *ErrOverflow
+	if errors.As(err, &invalid) || errors.As(err, &overflow) {
+		return err
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if number, ok := strings.CutPrefix(typeErr.Value, "number "); ok && !strings.ContainsAny(number, ".eE") {
+			return &ErrOverflow{Type: typeName, Value: number}
+		}
+	}
+	return &ErrInvalidFormat{Type: typeName, Input: string(data), Err: err}
+}
+
+// wrapScanError classifies an error from scanning value into typeName
+// through a database/sql type, which reports every failure as a plain
+// message: text and numbers become *ErrOverflow or *ErrInvalidFormat, and
+// other driver types *ErrUnsupportedScanType.
+func wrapScanError(typeName string, value any, err error) error {
+	if err == nil {
+		return nil
+	}
+	var input string
+	switch v := value.(type) {
+	case string:
+		input = v
+	case []byte:
+		input = string(v)
+	case int64, float64:
+		input = fmt.Sprint(v)
+	default:
+		return newUnsupportedScanType(value)
+	}
+	if strings.Contains(err.Error(), "out of range") {
+		return &ErrOverflow{Type: typeName, Value: input}
+	}
+	return &ErrInvalidFormat{Type: typeName, Input: input, Err: err}
+}
+
+// Error returns a message naming the type and quoting the input.
+func (e *ErrInvalidFormat) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("invalid %s %q: %v", e.Type, e.Input, e.Err)
+	}
+	return fmt.Sprintf("invalid %s %q", e.Type, e.Input)
+}
+
+// Unwrap returns the underlying cause.
+func (e *ErrInvalidFormat) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *ErrInvalidFormat with an empty or equal
+// Type.
+func (e *ErrInvalidFormat) Is(target error) bool {
+	other, ok := target.(*ErrInvalidFormat)
+	return ok && (other.Type == "" || other.Type == e.Type)
+}
+
+// ErrOverflow reports a Value that does not fit Type, such as "int8" or
+// "Money".
+//
+// Example:
+//
+//	var n ztype.Numeric[int8]
+//	err := n.UnmarshalText([]byte("300"))
+//	errors.Is(err, &ztype.ErrOverflow{}) // true
+type ErrOverflow struct {
+	Type  string
+	Value string
+}
+
+// Error returns a message naming the value and the type.
+func (e *ErrOverflow) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s overflow", e.Type)
+	}
+	return fmt.Sprintf("value %s overflows %s", e.Value, e.Type)
+}
+
+// Is reports whether target is an *ErrOverflow with an empty or equal Type.
+func (e *ErrOverflow) Is(target error) bool {
+	other, ok := target.(*ErrOverflow)
+	return ok && (other.Type == "" || other.Type == e.Type)
+}
+
+// ErrUnsupportedScanType reports a value of type Got passed to Scan or to
+// another method that accepts a fixed set of Go types.
+//
+// Example:
+//
+//	var s ztype.String
+//	err := s.Scan(struct{}{})
+//	errors.Is(err, &ztype.ErrUnsupportedScanType{}) // true
+type ErrUnsupportedScanType struct {
+	Got reflect.Type
+}
+
+// newUnsupportedScanType returns an *ErrUnsupportedScanType for value.
+func newUnsupportedScanType(value any) *ErrUnsupportedScanType {
+	return &ErrUnsupportedScanType{Got: reflect.TypeOf(value)}
+}
+
+// Error returns a message naming the unsupported type.
+func (e *ErrUnsupportedScanType) Error() string {
+	return fmt.Sprintf("unsupported type: %v", e.Got)
+}
+
+// Is reports whether target is an *ErrUnsupportedScanType with a nil or
+// equal Got.
+func (e *ErrUnsupportedScanType) Is(target error) bool {
+	other, ok := target.(*ErrUnsupportedScanType)
+	return ok && (other.Got == nil || other.Got == e.Got)
+}
+
+// ErrPanic is the value Div and DivRaw panic with. Op names the method and
+// Err is the error its Safe counterpart would have returned.
+//
+// Example:
+//
+//	defer func() {
+//		if p, ok := recover().(*ztype.ErrPanic); ok && errors.Is(p, ztype.ErrDivisionByZero) {
+//			status = http.StatusBadRequest
+//		}
+//	}()
+type ErrPanic struct {
+	Op  string
+	Err error
+}
+
+// Error returns the message of Err prefixed with the method name.
+func (e *ErrPanic) Error() string {
+	return fmt.Sprintf("%s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns Err.
+func (e *ErrPanic) Unwrap() error {
+	return e.Err
+}
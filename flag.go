@@ -0,0 +1,107 @@
+package ztype
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// flagTarget is implemented by the nullable types that can be bound to a
+// command-line flag through their text unmarshaling logic.
+type flagTarget interface {
+	encoding.TextUnmarshaler
+	fmt.Stringer
+	IsNull() bool
+}
+
+// FlagValue adapts a nullable type to flag.Value (and pflag.Value) so that a
+// flag that is never passed leaves the target null, while a passed flag
+// makes it valid and unmarshaled.
+//
+// The nullable types are bound through this adapter rather than passed to
+// flag.Var directly because they cannot implement flag.Value: their Set is
+// the typed setter, as in Bool.Set(bool), which conflicts with the
+// Set(string) error that flag.Value requires. Renaming the setter would
+// break every caller, so the adapter carries the string-based Set instead.
+//
+// Example:
+//
+//	var dryRun ztype.Bool
+//	flag.Var(ztype.BoolFlag(&dryRun), "dry-run", "only print actions")
+//	flag.Parse()
+//	fmt.Println(dryRun.IsNull()) // Output: true when -dry-run is absent
+type FlagValue struct {
+	target   flagTarget
+	typeName string
+	isBool   bool
+}
+
+// BoolFlag binds a Bool to a flag. The flag may be passed without a value,
+// as in -dry-run, which sets it to true.
+//
+// Example:
+//
+//	fs.Var(ztype.BoolFlag(&cfg.DryRun), "dry-run", "only print actions")
+func BoolFlag(target *Bool) *FlagValue {
+	return &FlagValue{target: target, typeName: "bool", isBool: true}
+}
+
+// StringFlag binds a String to a flag.
+//
+// Example:
+//
+//	fs.Var(ztype.StringFlag(&cfg.Name), "name", "user name")
+func StringFlag(target *String) *FlagValue {
+	return &FlagValue{target: target, typeName: "string"}
+}
+
+// NumberFlag binds a Numeric to a flag.
+//
+// Example:
+//
+//	fs.Var(ztype.NumberFlag(&cfg.Workers), "workers", "worker count")
+func NumberFlag[T NumberType](target *Numeric[T]) *FlagValue {
+	var zero T
+	return &FlagValue{target: target, typeName: fmt.Sprintf("%T", zero)}
+}
+
+// DurationFlag binds a Duration to a flag, parsed with ParseDurationFlexible.
+//
+// Example:
+//
+//	fs.Var(ztype.DurationFlag(&cfg.Timeout), "timeout", "request timeout")
+func DurationFlag(target *Duration) *FlagValue {
+	return &FlagValue{target: target, typeName: "duration"}
+}
+
+// TimeFlag binds a Time to a flag, accepting the formats of Time.UnmarshalText.
+//
+// Example:
+//
+//	fs.Var(ztype.TimeFlag(&cfg.Since), "since", "start date")
+func TimeFlag(target *Time) *FlagValue {
+	return &FlagValue{target: target, typeName: "time"}
+}
+
+// Set implements flag.Value by parsing value with the target's UnmarshalText,
+// which marks the target as valid and unmarshaled.
+func (f *FlagValue) Set(value string) error {
+	return f.target.UnmarshalText([]byte(value))
+}
+
+// String implements flag.Value. Returns an empty string while the target is null.
+func (f *FlagValue) String() string {
+	if f == nil || f.target == nil || f.target.IsNull() {
+		return ""
+	}
+	return f.target.String()
+}
+
+// Type implements pflag.Value.
+func (f *FlagValue) Type() string {
+	return f.typeName
+}
+
+// IsBoolFlag reports whether the flag can be passed without a value.
+func (f *FlagValue) IsBoolFlag() bool {
+	return f.isBool
+}
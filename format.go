@@ -0,0 +1,65 @@
+package ztype
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetNullToken sets the text that String and the fmt verbs write for null
+// values, "<NULL>" by default. Use StringOr to override it at a single call
+// site instead. The maps are the exception: the String of a null Map,
+// OrderedMap or SyncMap writes "{}" whatever the token.
+//
+// Example:
+//
+//	ztype.SetNullToken("")
+//	cell := ztype.NewNullString().String() // ""
+func SetNullToken(token string) {
+	defaultCodec.nullToken.Store(&token)
+}
+
+// nullToken returns the text written for null values.
+func nullToken() string {
+	return defaultCodec.nullTokenText()
+}
+
+// nullTokenText returns the text the Codec writes for null values.
+func (c *Codec) nullTokenText() string {
+	if token := c.nullToken.Load(); token != nil {
+		return *token
+	}
+	return "<NULL>"
+}
+
+// formatNullable implements fmt.Formatter for the scalar types. Null values
+// print the null token for every verb, honouring only the width and the '-' flag.
+// %v and %s print text, the String output of a valid value; any other verb
+// is applied to the underlying value with the original flags.
+func formatNullable(f fmt.State, verb rune, valid bool, text string, value any) {
+	if !valid {
+		formatPadded(f, nullToken())
+		return
+	}
+	switch verb {
+	case 'v', 's':
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), text)
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), value)
+	}
+}
+
+// formatPadded writes text padded to the width of f, on the right when the
+// '-' flag is set.
+func formatPadded(f fmt.State, text string) {
+	width, ok := f.Width()
+	if !ok || width <= len(text) {
+		fmt.Fprint(f, text)
+		return
+	}
+	padding := strings.Repeat(" ", width-len(text))
+	if f.Flag('-') {
+		fmt.Fprint(f, text+padding)
+		return
+	}
+	fmt.Fprint(f, padding+text)
+}
@@ -0,0 +1,754 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// IP represents a nullable IP address backed by netip.Addr, suited for
+// Postgres inet columns. It distinguishes between:
+// - Explicit database/SQL NULL values
+// - Absent values in JSON unmarshaling
+// - Valid addresses
+//
+// Example Usage:
+//
+//	ip, err := ztype.ParseIP("192.168.0.1")
+//	ip.IsPrivate() // true
+//	data, _ := json.Marshal(ip) // "192.168.0.1"
+type IP struct {
+	value       netip.Addr
+	valid       bool
+	unmarshaled bool
+}
+
+// NewIP creates a new valid IP instance.
+//
+// Example:
+//
+//	ip := ztype.NewIP(netip.MustParseAddr("::1"))
+func NewIP(value netip.Addr) IP {
+	return IP{value: value, valid: true}
+}
+
+// NewNullIP creates a new null IP instance.
+//
+// Example:
+//
+//	ip := ztype.NewNullIP()
+//	ip.IsNull() // true
+func NewNullIP() IP {
+	return IP{valid: false}
+}
+
+// ParseIP parses an address and returns a valid IP. A trailing prefix
+// length, as printed by Postgres for inet values, is accepted and dropped.
+//
+// Example:
+//
+//	ip, err := ztype.ParseIP("10.0.0.1/32")
+//	ip.String() // "10.0.0.1"
+func ParseIP(value string) (IP, error) {
+	addr, _, err := parseInet("IP", value)
+	if err != nil {
+		return IP{}, err
+	}
+	return NewIP(addr), nil
+}
+
+// parseInet splits Postgres' textual inet/cidr output ("addr[%zone][/bits]")
+// into an address and prefix length. Bits is -1 when no suffix is present.
+// Errors are *ErrInvalidFormat for typeName.
+func parseInet(typeName, value string) (netip.Addr, int, error) {
+	input := value
+	value = strings.TrimSpace(value)
+	bits := -1
+	if before, after, found := strings.Cut(value, "/"); found {
+		parsed, err := strconv.Atoi(after)
+		if err != nil {
+			return netip.Addr{}, 0, newInvalidFormat(typeName, input, "invalid prefix length %q", after)
+		}
+		value, bits = before, parsed
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, 0, wrapInvalidFormat(typeName, input, err)
+	}
+	if bits > addr.BitLen() || bits < -1 {
+		return netip.Addr{}, 0, newInvalidFormat(typeName, input, "invalid prefix length /%d", bits)
+	}
+	return addr, bits, nil
+}
+
+// Get returns the address. When null, returns the zero netip.Addr.
+//
+// Example:
+//
+//	addr := ip.Get()
+func (ip *IP) Get() netip.Addr {
+	return ip.value
+}
+
+// Val returns the address and whether it is valid.
+//
+// Example:
+//
+//	if addr, ok := ip.Val(); ok { fmt.Println(addr.Is4()) }
+func (ip IP) Val() (netip.Addr, bool) {
+	return ip.value, ip.valid
+}
+
+// Set updates the value and marks it as valid.
+//
+// Example:
+//
+//	var ip ztype.IP
+//	ip.Set(netip.MustParseAddr("10.0.0.1"))
+func (ip *IP) Set(value netip.Addr) {
+	ip.value = value
+	ip.valid = true
+}
+
+// SetNull marks the value as null and resets the address.
+//
+// Example:
+//
+//	ip.SetNull()
+//	ip.IsNull() // true
+func (ip *IP) SetNull() {
+	ip.value = netip.Addr{}
+	ip.valid = false
+}
+
+// IsNull returns true if the value is null.
+//
+// Example:
+//
+//	ztype.NewNullIP().IsNull() // true
+func (ip *IP) IsNull() bool {
+	return !ip.valid
+}
+
+// IsEmpty returns true if the value is null or the zero netip.Addr.
+//
+// Example:
+//
+//	ztype.NewIP(netip.Addr{}).IsEmpty() // true
+func (ip IP) IsEmpty() bool {
+	return !ip.valid || !ip.value.IsValid()
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	ztype.NewNullIP().IsZero() // true
+func (ip IP) IsZero() bool {
+	return ip.IsEmpty()
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values.
+//
+// Example:
+//
+//	ip.Unmarshaled()
+func (ip *IP) Unmarshaled() bool {
+	return ip.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	ip.SetUnmarshaled(true)
+func (ip *IP) SetUnmarshaled(value bool) {
+	ip.unmarshaled = value
+}
+
+// Is4 returns true for IPv4 addresses, including IPv4-mapped IPv6
+// addresses such as ::ffff:10.0.0.1.
+//
+// Example:
+//
+//	ip, _ := ztype.ParseIP("::ffff:10.0.0.1")
+//	ip.Is4() // true
+func (ip *IP) Is4() bool {
+	return ip.valid && (ip.value.Is4() || ip.value.Is4In6())
+}
+
+// Is6 returns true for IPv6 addresses that are not IPv4-mapped.
+//
+// Example:
+//
+//	ip, _ := ztype.ParseIP("2001:db8::1")
+//	ip.Is6() // true
+func (ip *IP) Is6() bool {
+	return ip.valid && ip.value.Is6() && !ip.value.Is4In6()
+}
+
+// IsPrivate returns true for private-use addresses (RFC 1918 and RFC 4193).
+// Null values are not private.
+//
+// Example:
+//
+//	ip, _ := ztype.ParseIP("192.168.0.1")
+//	ip.IsPrivate() // true
+func (ip *IP) IsPrivate() bool {
+	return ip.valid && ip.value.Unmap().IsPrivate()
+}
+
+// Equal performs equality check including null state.
+//
+// Example:
+//
+//	a.Equal(b)
+func (ip *IP) Equal(other IP) bool {
+	return ip.valid == other.valid && ip.value == other.value
+}
+
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	ip := ztype.NewIP(netip.MustParseAddr("10.0.0.1"))
+//	fmt.Println(ip.EqualValues(ztype.NewIP(netip.MustParseAddr("10.0.0.1"))))  // Output: true
+func (ip IP) EqualValues(other IP) bool {
+	return ip.Equal(other)
+}
+
+// Clone returns a copy of the IP, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	ip := ztype.NewIP(netip.MustParseAddr("10.0.0.1"))
+//	c := ip.Clone()
+func (ip IP) Clone() IP {
+	return ip
+}
+
+// EqualRaw compares the address while ignoring null state.
+//
+// Example:
+//
+//	ip.EqualRaw(netip.MustParseAddr("10.0.0.1"))
+func (ip *IP) EqualRaw(other netip.Addr) bool {
+	return ip.value == other
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// Example:
+//
+//	data, _ := ip.MarshalText()
+func (ip IP) MarshalText() ([]byte, error) {
+	if ip.valid {
+		return ip.value.MarshalText()
+	}
+	return nil, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// Example:
+//
+//	err := ip.UnmarshalText([]byte("10.0.0.1"))
+func (ip *IP) UnmarshalText(data []byte) error {
+	ip.unmarshaled = true
+	return ip.parse(string(data))
+}
+
+// parse stores the parsed address, leaving the value untouched on error.
+func (ip *IP) parse(value string) error {
+	addr, _, err := parseInet("IP", value)
+	if err != nil {
+		return err
+	}
+	ip.Set(addr)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler using the canonical string form.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ip) // "10.0.0.1"
+func (ip IP) MarshalJSON() ([]byte, error) {
+	return ip.AppendJSON(make([]byte, 0, 48))
+}
+
+// AppendJSON appends the JSON encoding of the IP to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewIP(netip.MustParseAddr("10.0.0.1")).AppendJSON(buf) // "10.0.0.1"
+func (ip IP) AppendJSON(dst []byte) ([]byte, error) {
+	if !ip.valid {
+		return append(dst, "null"...), nil
+	}
+	dst = append(dst, '"')
+	dst = ip.value.AppendTo(dst)
+	return append(dst, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Example:
+//
+//	err := json.Unmarshal([]byte(`"::1"`), &ip)
+func (ip *IP) UnmarshalJSON(data []byte) error {
+	ip.unmarshaled = true
+	if bytes.Equal(data, []byte("null")) {
+		ip.SetNull()
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return wrapJSONError("IP", data, err)
+	}
+	return ip.parse(value)
+}
+
+// Scan implements sql.Scanner for database integration.
+// Accepts string and []byte, including Postgres' inet output.
+//
+// Example:
+//
+//	var ip ztype.IP
+//	err := db.QueryRow("SELECT client_ip FROM logins WHERE id = 1").Scan(&ip)
+func (ip *IP) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		ip.SetNull()
+		return nil
+	case string:
+		return ip.parse(v)
+	case []byte:
+		return ip.parse(string(v))
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements driver.Valuer for database integration.
+//
+// Example:
+//
+//	val, _ := ip.Value() // "10.0.0.1"
+func (ip IP) Value() (driver.Value, error) {
+	if !ip.valid {
+		return nil, nil
+	}
+	return ip.value.String(), nil
+}
+
+// ValueOrZero is like Value, but returns the unspecified address 0.0.0.0 instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullIP().ValueOrZero() // "0.0.0.0"
+func (ip IP) ValueOrZero() (driver.Value, error) {
+	if !ip.valid {
+		return netip.IPv4Unspecified().String(), nil
+	}
+	return ip.Value()
+}
+
+// String implements fmt.Stringer for human-readable output.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullIP()) // "<NULL>"
+func (ip IP) String() string {
+	if !ip.valid {
+		return nullToken()
+	}
+	return ip.value.String()
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullIP().StringOr("n/a"))  // Output: n/a
+func (ip IP) StringOr(fallback string) string {
+	if !ip.valid {
+		return fallback
+	}
+	return ip.String()
+}
+
+// SchemaType reports IP as a nullable string in the "ip" format.
+//
+// Example:
+//
+//	ztype.IP{}.SchemaType() // "string", "ip", true
+func (ip IP) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "ip", true
+}
+
+// CIDR represents a nullable network prefix backed by netip.Prefix, suited
+// for Postgres cidr and inet columns.
+//
+// Example Usage:
+//
+//	network, err := ztype.ParseCIDR("10.0.0.0/8")
+//	ip, _ := ztype.ParseIP("10.1.2.3")
+//	network.Contains(ip) // true
+type CIDR struct {
+	value       netip.Prefix
+	valid       bool
+	unmarshaled bool
+}
+
+// NewCIDR creates a new valid CIDR instance.
+//
+// Example:
+//
+//	network := ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8"))
+func NewCIDR(value netip.Prefix) CIDR {
+	return CIDR{value: value, valid: true}
+}
+
+// NewNullCIDR creates a new null CIDR instance.
+//
+// Example:
+//
+//	network := ztype.NewNullCIDR()
+//	network.IsNull() // true
+func NewNullCIDR() CIDR {
+	return CIDR{valid: false}
+}
+
+// ParseCIDR parses a prefix and returns a valid CIDR. A bare address, as
+// printed by Postgres for host inet values, is read as a single-host prefix.
+//
+// Example:
+//
+//	network, err := ztype.ParseCIDR("10.0.0.1")
+//	network.String() // "10.0.0.1/32"
+func ParseCIDR(value string) (CIDR, error) {
+	prefix, err := parseCIDR(value)
+	if err != nil {
+		return CIDR{}, err
+	}
+	return NewCIDR(prefix), nil
+}
+
+// parseCIDR builds a prefix from Postgres' textual inet/cidr output.
+func parseCIDR(value string) (netip.Prefix, error) {
+	addr, bits, err := parseInet("CIDR", value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if bits < 0 {
+		bits = addr.BitLen()
+	}
+	prefix, err := addr.WithZone("").Prefix(bits)
+	return prefix, wrapInvalidFormat("CIDR", value, err)
+}
+
+// Get returns the prefix. When null, returns the zero netip.Prefix.
+//
+// Example:
+//
+//	prefix := network.Get()
+func (c *CIDR) Get() netip.Prefix {
+	return c.value
+}
+
+// Val returns the prefix and whether it is valid.
+//
+// Example:
+//
+//	if prefix, ok := c.Val(); ok { fmt.Println(prefix.Bits()) }
+func (c CIDR) Val() (netip.Prefix, bool) {
+	return c.value, c.valid
+}
+
+// Set updates the value and marks it as valid.
+//
+// Example:
+//
+//	var network ztype.CIDR
+//	network.Set(netip.MustParsePrefix("10.0.0.0/8"))
+func (c *CIDR) Set(value netip.Prefix) {
+	c.value = value
+	c.valid = true
+}
+
+// SetNull marks the value as null and resets the prefix.
+//
+// Example:
+//
+//	network.SetNull()
+func (c *CIDR) SetNull() {
+	c.value = netip.Prefix{}
+	c.valid = false
+}
+
+// IsNull returns true if the value is null.
+//
+// Example:
+//
+//	ztype.NewNullCIDR().IsNull() // true
+func (c *CIDR) IsNull() bool {
+	return !c.valid
+}
+
+// IsEmpty returns true if the value is null or the zero netip.Prefix.
+//
+// Example:
+//
+//	ztype.NewCIDR(netip.Prefix{}).IsEmpty() // true
+func (c CIDR) IsEmpty() bool {
+	return !c.valid || c.value == netip.Prefix{}
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	ztype.NewNullCIDR().IsZero() // true
+func (c CIDR) IsZero() bool {
+	return c.IsEmpty()
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values.
+//
+// Example:
+//
+//	network.Unmarshaled()
+func (c *CIDR) Unmarshaled() bool {
+	return c.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	network.SetUnmarshaled(true)
+func (c *CIDR) SetUnmarshaled(value bool) {
+	c.unmarshaled = value
+}
+
+// Contains returns true if ip belongs to the network. IPv4-mapped IPv6
+// addresses match IPv4 networks. Returns false when either side is null.
+//
+// Example:
+//
+//	network, _ := ztype.ParseCIDR("10.0.0.0/8")
+//	ip, _ := ztype.ParseIP("::ffff:10.0.0.1")
+//	network.Contains(ip) // true
+func (c *CIDR) Contains(ip IP) bool {
+	if !c.valid || !ip.valid {
+		return false
+	}
+	addr := ip.value.WithZone("")
+	if c.value.Addr().Is4() {
+		addr = addr.Unmap()
+	}
+	return c.value.Contains(addr)
+}
+
+// Equal performs equality check including null state.
+//
+// Example:
+//
+//	a.Equal(b)
+func (c *CIDR) Equal(other CIDR) bool {
+	return c.valid == other.valid && c.value == other.value
+}
+
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	c := ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8"))
+//	fmt.Println(c.EqualValues(ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8"))))  // Output: true
+func (c CIDR) EqualValues(other CIDR) bool {
+	return c.Equal(other)
+}
+
+// Clone returns a copy of the CIDR, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	c := ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8"))
+//	c := c.Clone()
+func (c CIDR) Clone() CIDR {
+	return c
+}
+
+// EqualRaw compares the prefix while ignoring null state.
+//
+// Example:
+//
+//	network.EqualRaw(netip.MustParsePrefix("10.0.0.0/8"))
+func (c *CIDR) EqualRaw(other netip.Prefix) bool {
+	return c.value == other
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// Example:
+//
+//	data, _ := network.MarshalText()
+func (c CIDR) MarshalText() ([]byte, error) {
+	if c.valid {
+		return c.value.MarshalText()
+	}
+	return nil, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// Example:
+//
+//	err := network.UnmarshalText([]byte("10.0.0.0/8"))
+func (c *CIDR) UnmarshalText(data []byte) error {
+	c.unmarshaled = true
+	return c.parse(string(data))
+}
+
+// parse stores the parsed prefix, leaving the value untouched on error.
+func (c *CIDR) parse(value string) error {
+	prefix, err := parseCIDR(value)
+	if err != nil {
+		return err
+	}
+	c.Set(prefix)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler using the canonical string form.
+//
+// Example:
+//
+//	data, _ := json.Marshal(network) // "10.0.0.0/8"
+func (c CIDR) MarshalJSON() ([]byte, error) {
+	return c.AppendJSON(make([]byte, 0, 52))
+}
+
+// AppendJSON appends the JSON encoding of the CIDR to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8")).AppendJSON(buf) // "10.0.0.0/8"
+func (c CIDR) AppendJSON(dst []byte) ([]byte, error) {
+	if !c.valid {
+		return append(dst, "null"...), nil
+	}
+	dst = append(dst, '"')
+	dst = c.value.AppendTo(dst)
+	return append(dst, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Example:
+//
+//	err := json.Unmarshal([]byte(`"10.0.0.0/8"`), &network)
+func (c *CIDR) UnmarshalJSON(data []byte) error {
+	c.unmarshaled = true
+	if bytes.Equal(data, []byte("null")) {
+		c.SetNull()
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return wrapJSONError("CIDR", data, err)
+	}
+	return c.parse(value)
+}
+
+// Scan implements sql.Scanner for database integration.
+// Accepts string and []byte, including Postgres' inet and cidr output.
+//
+// Example:
+//
+//	var network ztype.CIDR
+//	err := db.QueryRow("SELECT network FROM rules WHERE id = 1").Scan(&network)
+func (c *CIDR) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		c.SetNull()
+		return nil
+	case string:
+		return c.parse(v)
+	case []byte:
+		return c.parse(string(v))
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements driver.Valuer for database integration.
+//
+// Example:
+//
+//	val, _ := network.Value() // "10.0.0.0/8"
+func (c CIDR) Value() (driver.Value, error) {
+	if !c.valid {
+		return nil, nil
+	}
+	return c.value.String(), nil
+}
+
+// ValueOrZero is like Value, but returns 0.0.0.0/0 instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullCIDR().ValueOrZero() // "0.0.0.0/0"
+func (c CIDR) ValueOrZero() (driver.Value, error) {
+	if !c.valid {
+		return netip.PrefixFrom(netip.IPv4Unspecified(), 0).String(), nil
+	}
+	return c.Value()
+}
+
+// String implements fmt.Stringer for human-readable output.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullCIDR()) // "<NULL>"
+func (c CIDR) String() string {
+	if !c.valid {
+		return nullToken()
+	}
+	return c.value.String()
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullCIDR().StringOr("n/a"))  // Output: n/a
+func (c CIDR) StringOr(fallback string) string {
+	if !c.valid {
+		return fallback
+	}
+	return c.String()
+}
+
+// SchemaType reports CIDR as a nullable string in the "cidr" format.
+//
+// Example:
+//
+//	ztype.CIDR{}.SchemaType() // "string", "cidr", true
+func (c CIDR) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "cidr", true
+}
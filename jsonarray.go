@@ -0,0 +1,113 @@
+package ztype
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// JSONArrayAs returns the elements of the array stored at key in m, each
+// passed through convert. A missing key, a null value and a null JSON all
+// give an empty result without error. A value that is not an array, or an
+// element convert rejects, fails with an error naming the key and the index.
+//
+// Example:
+//
+//	var payload ztype.JSON
+//	json.Unmarshal([]byte(`{"items":[{"sku":"A1"},{"sku":"B2"}]}`), &payload)
+//	skus, err := ztype.JSONArrayAs(payload, "items", func(v any) (string, error) {
+//		item, _ := v.(map[string]any)
+//		sku, ok := item["sku"].(string)
+//		if !ok {
+//			return "", errors.New("missing sku")
+//		}
+//		return sku, nil
+//	}) // []string{"A1", "B2"}
+func JSONArrayAs[T any](m JSON, key string, convert func(any) (T, error)) ([]T, error) {
+	return mapArrayAs(m, key, convert)
+}
+
+// GetStringSlice returns the array stored at key as Strings. JSON null
+// elements become null Strings; any other non-string element is an error
+// naming its index. A missing key or null value gives an empty result.
+//
+// Example:
+//
+//	var payload ztype.JSON
+//	json.Unmarshal([]byte(`{"tags":["a",null]}`), &payload)
+//	tags, err := payload.GetStringSlice("tags") // ["a", null]
+func (m Map[K, V]) GetStringSlice(key K) ([]String, error) {
+	return mapArrayAs(m, key, func(item any) (String, error) {
+		switch v := item.(type) {
+		case nil:
+			return NewNullString(), nil
+		case string:
+			return NewString(v), nil
+		}
+		return String{}, newUnsupportedScanType(item)
+	})
+}
+
+// GetNumberSlice returns the array stored at key as float64 Numerics. It
+// accepts the float64 values encoding/json decodes numbers to, json.Number
+// and any Go integer or float; JSON null elements become null Numerics. A
+// missing key or null value gives an empty result.
+//
+// Example:
+//
+//	var payload ztype.JSON
+//	json.Unmarshal([]byte(`{"scores":[1.5,2]}`), &payload)
+//	scores, err := payload.GetNumberSlice("scores") // [1.5, 2]
+func (m Map[K, V]) GetNumberSlice(key K) ([]Numeric[float64], error) {
+	return mapArrayAs(m, key, func(item any) (Numeric[float64], error) {
+		switch v := item.(type) {
+		case nil:
+			return NewNullNumber[float64](), nil
+		case json.Number:
+			parsed, err := strconv.ParseFloat(string(v), 64)
+			if err != nil {
+				return Numeric[float64]{}, newInvalidFormat("Numeric[float64]", string(v), "%w", err)
+			}
+			return NewNumber(parsed), nil
+		}
+		value := reflect.ValueOf(item)
+		switch {
+		case value.CanFloat():
+			return NewNumber(value.Float()), nil
+		case value.CanInt():
+			return NewNumber(float64(value.Int())), nil
+		case value.CanUint():
+			return NewNumber(float64(value.Uint())), nil
+		}
+		return Numeric[float64]{}, newUnsupportedScanType(item)
+	})
+}
+
+// mapArrayAs converts the elements of the slice or array stored at key.
+func mapArrayAs[K comparable, V any, T any](m Map[K, V], key K, convert func(any) (T, error)) ([]T, error) {
+	item, ok := m.GetItem(key)
+	if !ok || !m.valid {
+		return nil, nil
+	}
+	value := reflect.ValueOf(any(item))
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("key %#v: expected an array, got %T", key, item)
+	}
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		return nil, nil
+	}
+
+	result := make([]T, 0, value.Len())
+	for i := range value.Len() {
+		converted, err := convert(value.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("key %#v: element %d: %w", key, i, err)
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package ztype
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	// ErrJSONTooLarge is returned when a document is longer than the limit
+	// set with SetMaxJSONSize.
+	ErrJSONTooLarge = errors.New("json document too large")
+
+	// ErrJSONTooDeep is returned when a document nests objects and arrays
+	// deeper than the limit set with SetMaxJSONDepth.
+	ErrJSONTooDeep = errors.New("json document too deep")
+)
+
+// maxJSONSize and maxJSONDepth hold the limits checked by checkJSONLimits;
+// zero means unlimited.
+var (
+	maxJSONSize  atomic.Int64
+	maxJSONDepth atomic.Int64
+)
+
+// SetMaxJSONSize limits the length in bytes of the documents Map and RawJSON
+// accept in UnmarshalJSON and Scan. Longer documents fail with
+// ErrJSONTooLarge before they are decoded. Zero or a negative value, the
+// default, means unlimited.
+//
+// When a Map or RawJSON is a field of a larger document, encoding/json has
+// already scanned the whole input before the field sees its fragment, so
+// also bound the request body, e.g. with http.MaxBytesReader.
+//
+// Example:
+//
+//	ztype.SetMaxJSONSize(1 << 20) // 1 MiB
+func SetMaxJSONSize(bytes int) {
+	maxJSONSize.Store(int64(max(bytes, 0)))
+}
+
+// SetMaxJSONDepth limits how deeply the documents Map and RawJSON accept in
+// UnmarshalJSON and Scan may nest objects and arrays; a flat object has depth
+// 1. The depth is checked with a single pass over the bytes before decoding,
+// and deeper documents fail with ErrJSONTooDeep. Zero or a negative value,
+// the default, means unlimited.
+//
+// Example:
+//
+//	ztype.SetMaxJSONDepth(16)
+func SetMaxJSONDepth(depth int) {
+	maxJSONDepth.Store(int64(max(depth, 0)))
+}
+
+// checkJSONLimits returns an error wrapping ErrJSONTooLarge or
+// ErrJSONTooDeep when data exceeds the limits set for typeName.
+func checkJSONLimits(typeName string, data []byte) error {
+	if limit := maxJSONSize.Load(); limit > 0 && int64(len(data)) > limit {
+		return fmt.Errorf("%s: %w: %d bytes, limit is %d", typeName, ErrJSONTooLarge, len(data), limit)
+	}
+	if limit := maxJSONDepth.Load(); limit > 0 && exceedsJSONDepth(data, limit) {
+		return fmt.Errorf("%s: %w: more than %d levels", typeName, ErrJSONTooDeep, limit)
+	}
+	return nil
+}
+
+// exceedsJSONDepth reports whether data opens more than limit nested objects
+// or arrays, skipping brackets inside strings. It stops at the first level
+// past limit and does not validate the document.
+func exceedsJSONDepth(data []byte, limit int64) bool {
+	var depth int64
+	inString, escaped := false, false
+	for _, c := range data {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			if depth > limit {
+				return true
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package ztype
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// JSONNumberMode selects how numbers are decoded into values of type any
+// inside Map, OrderedMap, PairMap and Slice. See SetJSONNumberMode.
+type JSONNumberMode int32
+
+const (
+	// JSONNumberFloat64 decodes numbers to float64, as encoding/json does,
+	// the default. Integers above 2^53 lose precision.
+	JSONNumberFloat64 JSONNumberMode = iota
+	// JSONNumberExact decodes numbers to json.Number, keeping their text, so
+	// int64 IDs survive a trip through a JSON document.
+	JSONNumberExact
+)
+
+// SetJSONNumberMode selects how Map, OrderedMap, PairMap and Slice decode
+// numbers held as any, in UnmarshalJSON and Scan alike. With
+// JSONNumberExact they hold json.Number values, which DecodeInto, AsMapOf,
+// GetNumberSlice and the Numeric types convert exactly, so an int64 such as
+// 1152921504606846977 reaches a Numeric[int64] field intact. Values with a
+// concrete type, such as a Map[string, int64], are decoded exactly in
+// either mode.
+//
+// Example:
+//
+//	ztype.SetJSONNumberMode(ztype.JSONNumberExact)
+//	var doc ztype.JSON
+//	json.Unmarshal([]byte(`{"id":1152921504606846977}`), &doc)
+//	doc.Get()["id"] // json.Number("1152921504606846977")
+func SetJSONNumberMode(mode JSONNumberMode) {
+	defaultCodec.jsonNumberMode.Store(int32(mode))
+}
+
+// useJSONNumber reports whether c decodes numbers held as any to
+// json.Number.
+func (c *Codec) useJSONNumber() bool {
+	return JSONNumberMode(c.jsonNumberMode.Load()) == JSONNumberExact
+}
+
+// unmarshalJSONValue works like json.Unmarshal with the options of c,
+// decoding numbers held as any to json.Number when c uses JSONNumberExact.
+func (c *Codec) unmarshalJSONValue(data []byte, dest any) error {
+	switch {
+	case c != &defaultCodec:
+		return c.Unmarshal(data, dest)
+	case !c.useJSONNumber():
+		return json.Unmarshal(data, dest)
+	}
+	return unmarshalJSONNumbers(data, dest)
+}
+
+// unmarshalJSONNumbers works like json.Unmarshal, decoding numbers held as
+// any to json.Number.
+func unmarshalJSONNumbers(data []byte, dest any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(dest); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package ztype
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// parseOptions holds the separators of ParseNumberLenient.
+type parseOptions struct {
+	thousands rune
+	decimal   rune
+}
+
+// ParseOption configures ParseNumberLenient and SetNumberTextLenient.
+type ParseOption func(*parseOptions)
+
+// WithSeparators sets the thousands and decimal separators. The defaults
+// are ',' and '.', as in "1,234.56"; WithSeparators('.', ',') reads the
+// "1.234,56" convention and WithSeparators(' ', ',') reads "1 234,56".
+//
+// Example:
+//
+//	n, err := ztype.ParseNumberLenient[float64]("1.234,56", ztype.WithSeparators('.', ','))
+func WithSeparators(thousands, decimal rune) ParseOption {
+	return func(o *parseOptions) {
+		o.thousands = thousands
+		o.decimal = decimal
+	}
+}
+
+// newParseOptions applies opts over the defaults.
+func newParseOptions(opts []ParseOption) parseOptions {
+	options := parseOptions{thousands: ',', decimal: '.'}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// SetNumberTextLenient makes Numeric.UnmarshalText read display formatted
+// numbers as ParseNumberLenient does with opts, for CSV files exported from
+// spreadsheets. Disabling it restores the strict parser, the default.
+// UnmarshalJSON and Scan are not affected.
+//
+// Example:
+//
+//	ztype.SetNumberTextLenient(true, ztype.WithSeparators('.', ','))
+//	var n ztype.Numeric[float64]
+//	err := n.UnmarshalText([]byte("1.234,56")) // 1234.56
+func SetNumberTextLenient(lenient bool, opts ...ParseOption) {
+	defaultCodec.numberTextLenient.Store(lenientOptions(lenient, opts))
+}
+
+// lenientOptions returns the options of lenient Numeric.UnmarshalText, or
+// nil, the strict parser, when lenient is false.
+func lenientOptions(lenient bool, opts []ParseOption) *parseOptions {
+	if !lenient {
+		return nil
+	}
+	options := newParseOptions(opts)
+	return &options
+}
+
+// ParseNumberLenient parses display formatted numbers such as "1,234.56",
+// " 42 ", "15%" and "(123)". It accepts surrounding whitespace, a leading
+// sign, thousands separators in groups of three, a decimal separator, a
+// trailing percent sign, which divides by 100 and needs a float T, and
+// parentheses for negatives. Separators are set with WithSeparators. Blank
+// input gives a null Numeric; anything else that does not match, such as
+// "1,23.4" or "(-5)", is an *ErrInvalidFormat, and values out of range for
+// T are an *ErrOverflow.
+//
+// Example:
+//
+//	n, _ := ztype.ParseNumberLenient[float64]("(1,234.50)")
+//	fmt.Println(n.Get()) // Output: -1234.5
+//	p, _ := ztype.ParseNumberLenient[float64]("15%")
+//	fmt.Println(p.Get()) // Output: 0.15
+func ParseNumberLenient[T NumberType](s string, opts ...ParseOption) (Numeric[T], error) {
+	return parseNumberLenient[T](s, newParseOptions(opts))
+}
+
+// parseNumberLenient rewrites s into the strict syntax and parses it.
+func parseNumberLenient[T NumberType](s string, options parseOptions) (Numeric[T], error) {
+	typeName := numericTypeName[T]()
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return NewNullNumber[T](), nil
+	}
+	if options.thousands == options.decimal {
+		return Numeric[T]{}, newInvalidFormat(typeName, s, "thousands and decimal separators are both %q", options.decimal)
+	}
+
+	negative := false
+	if inner, ok := strings.CutPrefix(text, "("); ok {
+		if inner, ok = strings.CutSuffix(inner, ")"); !ok {
+			return Numeric[T]{}, newInvalidFormat(typeName, s, "unbalanced parenthesis")
+		}
+		text, negative = strings.TrimSpace(inner), true
+	}
+	percent := false
+	if inner, ok := strings.CutSuffix(text, "%"); ok {
+		text, percent = strings.TrimSpace(inner), true
+	}
+	if !negative {
+		if rest, ok := strings.CutPrefix(text, "-"); ok {
+			text, negative = rest, true
+		} else {
+			text = strings.TrimPrefix(text, "+")
+		}
+	}
+
+	integer, fraction, hasFraction := strings.Cut(text, string(options.decimal))
+	digits, ok := lenientDigits(integer, options.thousands)
+	if !ok || (hasFraction && (fraction == "" || !isDecimalDigits(fraction))) || (digits == "" && !hasFraction) {
+		return Numeric[T]{}, newInvalidFormat(typeName, s, "")
+	}
+
+	isFloat := reflect.TypeFor[T]().Kind() == reflect.Float32 || reflect.TypeFor[T]().Kind() == reflect.Float64
+	if percent {
+		if !isFloat {
+			return Numeric[T]{}, newInvalidFormat(typeName, s, "percent needs a float type")
+		}
+		// Move the decimal point in the text rather than dividing, so "15%"
+		// is exactly the float nearest to 0.15.
+		digits = strings.Repeat("0", max(0, 3-len(digits))) + digits
+		fraction = digits[len(digits)-2:] + fraction
+		digits, hasFraction = digits[:len(digits)-2], true
+	}
+
+	canonical := cmp.Or(digits, "0")
+	if hasFraction && strings.Trim(fraction, "0") != "" {
+		if !isFloat {
+			return Numeric[T]{}, newInvalidFormat(typeName, s, "not an integer")
+		}
+		canonical += "." + fraction
+	}
+	if negative {
+		canonical = "-" + canonical
+	}
+
+	value, err := parseNumberText[T]([]byte(canonical))
+	if err != nil {
+		if errors.Is(err, &ErrOverflow{}) {
+			return Numeric[T]{}, &ErrOverflow{Type: typeName, Value: s}
+		}
+		return Numeric[T]{}, newInvalidFormat(typeName, s, "")
+	}
+	return NewNumber(value), nil
+}
+
+// lenientDigits removes the thousands separators from integer, which must
+// be either plain digits or digits grouped in threes.
+func lenientDigits(integer string, thousands rune) (string, bool) {
+	if !strings.ContainsRune(integer, thousands) {
+		return integer, isDecimalDigits(integer)
+	}
+	groups := strings.Split(integer, string(thousands))
+	for i, group := range groups {
+		if !isDecimalDigits(group) || group == "" || len(group) > 3 || (i > 0 && len(group) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// isDecimalDigits reports whether s holds only ASCII digits.
+func isDecimalDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -3,10 +3,16 @@ package ztype
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // JSON is a convenience alias for Map with string keys and any values,
@@ -21,6 +27,12 @@ type JSON = Map[string, any]
 // Map is a generic type that wraps a map with keys of type K and values of type V.
 // It tracks validity (null state) and whether it has been unmarshaled from JSON.
 //
+// Methods deriving a new Map (Filter, Clone, MapValues, MapKeys, IntersectKeys,
+// SubtractKeys, TransformMap, ...) follow one rule: a null receiver produces a
+// null Map and a valid receiver produces a valid Map, even when empty. Merge and
+// MergeFunc treat null inputs as empty and produce a null Map only when every
+// input is null.
+//
 // Example:
 //
 //	m := NewMap(map[string]int{"one": 1, "two": 2})
@@ -33,12 +45,15 @@ type Map[K comparable, V any] struct {
 }
 
 // NewMap creates a new Map with the given map value and marks it as valid.
+// A nil map is replaced by an empty one, so a valid Map never holds nil.
 //
 // Example:
 //
 //	m := NewMap(map[string]int{"a": 1, "b": 2})
 func NewMap[K comparable, V any](value map[K]V) Map[K, V] {
-	return Map[K, V]{value: value, valid: true}
+	m := Map[K, V]{value: value, valid: true}
+	m.allocate()
+	return m
 }
 
 // NewNullMap creates a new Map that is marked as null (invalid).
@@ -74,7 +89,18 @@ func (m Map[K, V]) Get() map[K]V {
 	return m.value
 }
 
-// Set sets the internal map value and marks the Map as valid.
+// Val returns the map and whether the Map is valid. A valid Map never holds
+// nil, so ok=true with an empty map is an empty object, not null.
+//
+// Example:
+//
+//	if labels, ok := m.Val(); ok { fmt.Println(len(labels)) }
+func (m Map[K, V]) Val() (map[K]V, bool) {
+	return m.value, m.valid
+}
+
+// Set sets the internal map value and marks the Map as valid. A nil map is
+// replaced by an empty one.
 //
 // Example:
 //
@@ -82,6 +108,7 @@ func (m Map[K, V]) Get() map[K]V {
 //	m.Set(map[string]int{"a": 1})
 func (m *Map[K, V]) Set(value map[K]V) {
 	m.value = value
+	m.allocate()
 	m.valid = true
 }
 
@@ -96,13 +123,64 @@ func (m Map[K, V]) GetItem(key K) (V, bool) {
 	return item, ok
 }
 
+// GetOr returns the underlying map, or fallback when the Map is null.
+//
+// Example:
+//
+//	m := NewNullMap[string, int]()
+//	v := m.GetOr(map[string]int{"a": 1}) // map[string]int{"a": 1}
+func (m Map[K, V]) GetOr(fallback map[K]V) map[K]V {
+	if !m.valid {
+		return fallback
+	}
+	return m.value
+}
+
+// GetItemOr returns the value associated with the given key, or fallback when
+// the key is missing or the Map is null. Present keys holding the zero value
+// are returned as-is.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 0})
+//	m.GetItemOr("a", 5) // 0
+//	m.GetItemOr("b", 5) // 5
+func (m Map[K, V]) GetItemOr(key K, fallback V) V {
+	if !m.valid {
+		return fallback
+	}
+	if item, ok := m.value[key]; ok {
+		return item
+	}
+	return fallback
+}
+
+// MustGetItem returns the value associated with the given key.
+// Panics naming the key when it is missing or the Map is null.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	v := m.MustGetItem("a") // 1
+//	m.MustGetItem("b")      // panics: key "b" not found
+func (m Map[K, V]) MustGetItem(key K) V {
+	if m.valid {
+		if item, ok := m.value[key]; ok {
+			return item
+		}
+	}
+	panic(fmt.Errorf("key %#v not found", key))
+}
+
 // SetItem sets the value for the given key and marks the Map as valid.
+// The underlying map is allocated on demand, so it is safe on the zero value.
 //
 // Example:
 //
-//	m := NewMap(map[string]int{})
+//	var m Map[string, int]
 //	m.SetItem("a", 42)
 func (m *Map[K, V]) SetItem(key K, value V) {
+	m.allocate()
 	m.value[key] = value
 	m.valid = true
 }
@@ -136,7 +214,240 @@ func (m *Map[K, V]) DeleteItem(key K) (V, bool) {
 	return zero, false
 }
 
-// SetNull marks the Map as null and clears its content.
+// Pop removes the item with the given key and returns its value,
+// or fallback if the key does not exist.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	m.Pop("a", 0) // 1
+//	m.Pop("a", 0) // 0
+func (m *Map[K, V]) Pop(key K, fallback V) V {
+	if item, ok := m.DeleteItem(key); ok {
+		return item
+	}
+	return fallback
+}
+
+// GetPath walks nested maps following the given path and returns the value
+// found at the end of it.
+//
+// Intermediate values must be map[K]any, Map[K, any] or []any. When a segment
+// is applied to a []any it is read as a decimal index ("0", "1", ...) for string
+// keys, or used directly for int keys. Missing segments, out of range indexes
+// and type conflicts (e.g. a string where a map is expected) return ok=false.
+//
+// Example:
+//
+//	m := NewMap(map[string]any{"data": map[string]any{"items": []any{"a", "b"}}})
+//	v, ok := m.GetPath("data", "items", "1") // v="b", ok=true
+func (m Map[K, V]) GetPath(path ...K) (any, bool) {
+	if !m.valid || len(path) == 0 {
+		return nil, false
+	}
+	item, ok := m.value[path[0]]
+	if !ok {
+		return nil, false
+	}
+	var current any = item
+	for _, segment := range path[1:] {
+		current, ok = pathStep(current, segment)
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// GetDotted is a convenience over GetPath for string keyed maps such as JSON.
+// The path is split on "." and array indexes use the same syntax as GetPath.
+// It always returns ok=false when K is not string.
+//
+// Example:
+//
+//	var payload JSON
+//	json.Unmarshal([]byte(`{"items":[{"sku":"A1"}]}`), &payload)
+//	sku, ok := payload.GetDotted("items.0.sku") // sku="A1", ok=true
+func (m Map[K, V]) GetDotted(path string) (any, bool) {
+	segments := strings.Split(path, ".")
+	keys := make([]K, 0, len(segments))
+	for _, segment := range segments {
+		key, ok := any(segment).(K)
+		if !ok {
+			return nil, false
+		}
+		keys = append(keys, key)
+	}
+	return m.GetPath(keys...)
+}
+
+// SetPath stores value at the end of the given path, creating intermediate
+// map[K]any values as needed and marking the Map as valid.
+//
+// Existing []any values can be traversed using the GetPath index syntax, but
+// they are never grown: an out of range index returns an error. An error is also
+// returned when an intermediate value is neither a map nor a slice, or when the
+// resulting top-level value can't be stored as V.
+//
+// Example:
+//
+//	var m JSON
+//	err := m.SetPath(42, "data", "user", "id")
+//	// m = {"data": {"user": {"id": 42}}}
+func (m *Map[K, V]) SetPath(value any, path ...K) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+
+	var current any
+	if item, ok := m.value[path[0]]; ok {
+		current = item
+	}
+	result, err := setPathNode(current, value, path[1:])
+	if err != nil {
+		return err
+	}
+
+	var item V
+	if result != nil {
+		typed, ok := result.(V)
+		if !ok {
+			return fmt.Errorf("path segment %v: cannot store %T", path[0], result)
+		}
+		item = typed
+	}
+	m.allocate()
+	m.value[path[0]] = item
+	m.valid = true
+	return nil
+}
+
+// DeletePath removes the key at the end of the given path. It returns true
+// if the key existed and was removed. Elements of []any values can't be deleted.
+//
+// Example:
+//
+//	m := NewMap(map[string]any{"data": map[string]any{"id": 1}})
+//	deleted := m.DeletePath("data", "id") // true
+func (m *Map[K, V]) DeletePath(path ...K) bool {
+	if len(path) == 0 {
+		return false
+	}
+	if len(path) == 1 {
+		_, ok := m.DeleteItem(path[0])
+		return ok
+	}
+
+	parent, ok := m.GetPath(path[:len(path)-1]...)
+	if !ok {
+		return false
+	}
+	key := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[K]any:
+		if _, ok := node[key]; ok {
+			delete(node, key)
+			return true
+		}
+	case Map[K, any]:
+		if _, ok := node.value[key]; ok {
+			delete(node.value, key)
+			return true
+		}
+	}
+	return false
+}
+
+// pathStep resolves a single path segment against a nested value.
+func pathStep[K comparable](current any, segment K) (any, bool) {
+	switch node := current.(type) {
+	case map[K]any:
+		item, ok := node[segment]
+		return item, ok
+	case Map[K, any]:
+		if !node.valid {
+			return nil, false
+		}
+		item, ok := node.value[segment]
+		return item, ok
+	case []any:
+		index, ok := pathIndex(segment)
+		if !ok || index < 0 || index >= len(node) {
+			return nil, false
+		}
+		return node[index], true
+	}
+	return nil, false
+}
+
+// setPathNode stores value below node following path and returns the updated node.
+func setPathNode[K comparable](node any, value any, path []K) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	segment := path[0]
+	switch current := node.(type) {
+	case nil:
+		child, err := setPathNode(nil, value, path[1:])
+		if err != nil {
+			return nil, err
+		}
+		return map[K]any{segment: child}, nil
+	case map[K]any:
+		child, err := setPathNode(current[segment], value, path[1:])
+		if err != nil {
+			return nil, err
+		}
+		current[segment] = child
+		return current, nil
+	case Map[K, any]:
+		child, err := setPathNode(current.value[segment], value, path[1:])
+		if err != nil {
+			return nil, err
+		}
+		if current.value == nil {
+			current.value = map[K]any{}
+		}
+		current.value[segment] = child
+		current.valid = true
+		return current, nil
+	case []any:
+		index, ok := pathIndex(segment)
+		if !ok || index < 0 || index >= len(current) {
+			return nil, fmt.Errorf("path segment %v: index out of range", segment)
+		}
+		child, err := setPathNode(current[index], value, path[1:])
+		if err != nil {
+			return nil, err
+		}
+		current[index] = child
+		return current, nil
+	}
+	return nil, fmt.Errorf("path segment %v: cannot traverse %T", segment, node)
+}
+
+// pathIndex converts a path segment into a slice index.
+func pathIndex(segment any) (int, bool) {
+	switch s := segment.(type) {
+	case string:
+		index, err := strconv.Atoi(s)
+		return index, err == nil
+	case int:
+		return s, true
+	}
+	return 0, false
+}
+
+// allocate initializes the underlying map when it is nil.
+func (m *Map[K, V]) allocate() {
+	if m.value == nil {
+		m.value = map[K]V{}
+	}
+}
+
+// SetNull marks the Map as null and clears its content. The Map stays
+// usable: a later SetItem marks it valid again.
 //
 // Example:
 //
@@ -157,7 +468,8 @@ func (m Map[K, V]) IsNull() bool {
 	return !m.valid
 }
 
-// IsZero returns true if the internal map is empty.
+// IsZero returns true if the internal map is empty, which includes null
+// Maps.
 //
 // Example:
 //
@@ -167,7 +479,42 @@ func (m Map[K, V]) IsZero() bool {
 	return len(m.value) == 0
 }
 
+// IsEmpty returns true if the Map holds no items, which includes null Maps.
+// Alias for IsZero.
+//
+// Example:
+//
+//	m := NewNullMap[string, int]()
+//	fmt.Println(m.IsEmpty()) // true
+func (m Map[K, V]) IsEmpty() bool {
+	return m.IsZero()
+}
+
+// Clear removes all items without changing the null state: a valid Map
+// stays valid and empty, while SetNull would also invalidate it.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	m.Clear()
+//	fmt.Println(m.IsNull(), m.Len()) // false 0
+func (m *Map[K, V]) Clear() {
+	clear(m.value)
+}
+
+// CopyInto copies every item into the caller-owned dst map, overwriting
+// existing keys. A null Map copies nothing.
+//
+// Example:
+//
+//	dst := map[string]int{"b": 2}
+//	NewMap(map[string]int{"a": 1}).CopyInto(dst) // dst = {"a": 1, "b": 2}
+func (m Map[K, V]) CopyInto(dst map[K]V) {
+	maps.Copy(dst, m.value)
+}
+
 // Len returns the number of items in the internal map.
+// Null Maps always report 0.
 //
 // Example:
 //
@@ -198,6 +545,36 @@ func (m *Map[K, V]) SetUnmarshaled(value bool) {
 	m.unmarshaled = value
 }
 
+// EqualValues reports whether both hold deeply equal values and the same
+// null state, ignoring the unmarshaled flag. Two null Maps are equal, and
+// a nil map equals an empty one.
+//
+// Example:
+//
+//	m := ztype.NewMap(map[string]int{"a": 1})
+//	fmt.Println(m.EqualValues(ztype.NewMap(map[string]int{"a": 1})))  // Output: true
+func (m Map[K, V]) EqualValues(other Map[K, V]) bool {
+	if m.valid != other.valid {
+		return false
+	}
+	return !m.valid || deepEqualMaps(m.value, other.value)
+}
+
+// deepEqualMaps reports whether a and b hold the same keys with deeply equal
+// values, treating a nil map as empty.
+func deepEqualMaps[K comparable, V any](a, b map[K]V) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		otherValue, ok := b[key]
+		if !ok || !reflect.DeepEqual(value, otherValue) {
+			return false
+		}
+	}
+	return true
+}
+
 // Has returns true if the key exists in the Map and the Map is valid.
 //
 // Example:
@@ -212,6 +589,83 @@ func (m Map[K, V]) Has(key K) bool {
 	return ok
 }
 
+// HasAll returns true if every given key exists in the Map.
+// Null Maps behave as empty.
+//
+// Example:
+//
+//	payload := NewMap(map[string]any{"name": "a", "email": "b"})
+//	payload.HasAll("name", "email") // true
+func (m Map[K, V]) HasAll(keys ...K) bool {
+	for _, key := range keys {
+		if !m.Has(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny returns true if at least one of the given keys exists in the Map.
+// Null Maps behave as empty.
+//
+// Example:
+//
+//	payload := NewMap(map[string]any{"name": "a"})
+//	payload.HasAny("email", "name") // true
+func (m Map[K, V]) HasAny(keys ...K) bool {
+	for _, key := range keys {
+		if m.Has(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingKeys returns the given keys that don't exist in the Map, preserving
+// their order. Null Maps behave as empty.
+//
+// Example:
+//
+//	payload := NewMap(map[string]any{"name": "a"})
+//	missing := payload.MissingKeys("name", "email") // []string{"email"}
+func (m Map[K, V]) MissingKeys(keys ...K) []K {
+	missing := []K{}
+	for _, key := range keys {
+		if !m.Has(key) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// IntersectKeys returns a new Map holding the items of this Map whose keys
+// also exist in other. Null Maps behave as empty.
+//
+// Example:
+//
+//	m1 := NewMap(map[string]int{"a": 1, "b": 2})
+//	m2 := NewMap(map[string]int{"b": 0})
+//	both := m1.IntersectKeys(m2) // {"b": 2}
+func (m Map[K, V]) IntersectKeys(other Map[K, V]) Map[K, V] {
+	return m.Filter(func(key K, _ V) bool {
+		return other.Has(key)
+	})
+}
+
+// SubtractKeys returns a new Map holding the items of this Map whose keys
+// don't exist in other. Null Maps behave as empty.
+//
+// Example:
+//
+//	m1 := NewMap(map[string]int{"a": 1, "b": 2})
+//	m2 := NewMap(map[string]int{"b": 0})
+//	rest := m1.SubtractKeys(m2) // {"a": 1}
+func (m Map[K, V]) SubtractKeys(other Map[K, V]) Map[K, V] {
+	return m.Filter(func(key K, _ V) bool {
+		return !other.Has(key)
+	})
+}
+
 // All returns a sequence of all key-value pairs.
 //
 // Example:
@@ -223,12 +677,14 @@ func (m Map[K, V]) All() iter.Seq2[K, V] {
 }
 
 // Insert adds all items from the given sequence to the Map and marks it valid.
+// The underlying map is allocated on demand, so it is safe on the zero value.
 //
 // Example:
 //
 //	m := NewMap(map[string]int{})
 //	m.Insert(iter.Of2([][2]interface{}{{"a", 1}, {"b", 2}}))
 func (m *Map[K, V]) Insert(items iter.Seq2[K, V]) {
+	m.allocate()
 	maps.Insert(m.value, items)
 	m.valid = true
 }
@@ -253,6 +709,89 @@ func (m Map[K, V]) Values() iter.Seq[V] {
 	return maps.Values(m.value)
 }
 
+// KeysSlice returns a new slice holding all keys, in map iteration order.
+// Null Maps return an empty, non-nil slice.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	keys := m.KeysSlice() // []string{"a"}
+func (m Map[K, V]) KeysSlice() []K {
+	keys := make([]K, 0, len(m.value))
+	for key := range m.value {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// ValuesSlice returns a new slice holding all values, in map iteration order.
+// Null Maps return an empty, non-nil slice.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	values := m.ValuesSlice() // []int{1}
+func (m Map[K, V]) ValuesSlice() []V {
+	values := make([]V, 0, len(m.value))
+	for _, value := range m.value {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Items returns a new slice holding all key-value pairs, in map iteration order.
+// Null Maps return an empty, non-nil slice.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	items := m.Items() // []MapItem[string, int]{{Key: "a", Value: 1}}
+func (m Map[K, V]) Items() []MapItem[K, V] {
+	items := make([]MapItem[K, V], 0, len(m.value))
+	for key, value := range m.value {
+		items = append(items, MapItem[K, V]{Key: key, Value: value})
+	}
+	return items
+}
+
+// SortedKeys returns a new slice holding all keys ordered by less.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"b": 2, "a": 1})
+//	keys := m.SortedKeys(func(a, b string) bool { return a < b }) // []string{"a", "b"}
+func (m Map[K, V]) SortedKeys(less func(a, b K) bool) []K {
+	keys := m.KeysSlice()
+	slices.SortStableFunc(keys, func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	})
+	return keys
+}
+
+// AllSorted returns a sequence of all key-value pairs with keys ordered by less.
+// The order is computed once, when iteration starts.
+//
+// Example:
+//
+//	for key, value := range m.AllSorted(func(a, b string) bool { return a < b }) {
+//	    fmt.Println(key, value)
+//	}
+func (m Map[K, V]) AllSorted(less func(a, b K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range m.SortedKeys(less) {
+			if !yield(key, m.value[key]) {
+				return
+			}
+		}
+	}
+}
+
 // Collect creates a Map from the given sequence and marks it valid.
 //
 // Example:
@@ -266,12 +805,16 @@ func (m *Map[K, V]) Collect(items iter.Seq2[K, V]) {
 }
 
 // Filter returns a new Map containing only items where filter(key, value) is true.
+// A null Map produces a null Map.
 //
 // Example:
 //
 //	m := NewMap(map[string]int{"a": 1, "b": 2})
 //	filtered := m.Filter(func(k string, v int) bool { return v > 1 })
 func (m Map[K, V]) Filter(filter func(K, V) bool) Map[K, V] {
+	if !m.valid {
+		return NewNullMap[K, V]()
+	}
 	result := map[K]V{}
 	for key, value := range m.value {
 		if filter(key, value) {
@@ -282,7 +825,54 @@ func (m Map[K, V]) Filter(filter func(K, V) bool) Map[K, V] {
 	return m
 }
 
+// MapValues returns a new Map with every value replaced by fn(key, value).
+// A null Map produces a null Map.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1, "b": 2})
+//	doubled := m.MapValues(func(k string, v int) int { return v * 2 })
+func (m Map[K, V]) MapValues(fn func(K, V) V) Map[K, V] {
+	if !m.valid {
+		return NewNullMap[K, V]()
+	}
+	result := make(map[K]V, len(m.value))
+	for key, value := range m.value {
+		result[key] = fn(key, value)
+	}
+	m.value = result
+	return m
+}
+
+// MapKeys returns a new Map with every key replaced by fn(key, value).
+// When two entries map to the same key an error naming the key is returned,
+// since the surviving entry would depend on iteration order.
+// A null Map produces a null Map.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1, "b": 2})
+//	upper, err := m.MapKeys(func(k string, v int) string { return strings.ToUpper(k) })
+func (m Map[K, V]) MapKeys(fn func(K, V) K) (Map[K, V], error) {
+	if !m.valid {
+		return NewNullMap[K, V](), nil
+	}
+	result := make(map[K]V, len(m.value))
+	for key, value := range m.value {
+		mapped := fn(key, value)
+		if _, ok := result[mapped]; ok {
+			return NewNullMap[K, V](), fmt.Errorf("key collision: %#v", mapped)
+		}
+		result[mapped] = value
+	}
+	m.value = result
+	return m, nil
+}
+
 // Merge merges other Maps into this Map, returning a new merged Map.
+// When several Maps hold the same key, the last one wins. Null Maps are
+// treated as empty, and the result is null only when every input is null.
+// The result keeps the receiver's unmarshaled flag.
 //
 // Example:
 //
@@ -290,15 +880,74 @@ func (m Map[K, V]) Filter(filter func(K, V) bool) Map[K, V] {
 //	m2 := NewMap(map[string]int{"b": 2})
 //	merged := m1.Merge(m2)
 func (m Map[K, V]) Merge(others ...Map[K, V]) Map[K, V] {
-	merged := maps.Clone(m.value)
+	merged := map[K]V{}
+	valid := m.valid
+	if m.valid {
+		maps.Copy(merged, m.value)
+	}
 	for _, other := range others {
+		if !other.valid {
+			continue
+		}
 		maps.Copy(merged, other.value)
+		valid = true
 	}
 	m.value = merged
-	m.valid = true
+	m.valid = valid
 	return m
 }
 
+// MergeFunc merges other Maps into a clone of this Map, calling resolve to
+// pick the value whenever a key is already present. Null Maps are treated as
+// empty, and the result is null only when every input is null. The result
+// keeps the receiver's unmarshaled flag.
+//
+// Example:
+//
+//	m1 := NewMap(map[string]int{"a": 1})
+//	m2 := NewMap(map[string]int{"a": 2})
+//	sum := m1.MergeFunc(func(k string, existing, incoming int) int {
+//	    return existing + incoming
+//	}, m2) // {"a": 3}
+func (m Map[K, V]) MergeFunc(resolve func(key K, existing, incoming V) V, others ...Map[K, V]) Map[K, V] {
+	merged := map[K]V{}
+	valid := m.valid
+	if m.valid {
+		maps.Copy(merged, m.value)
+	}
+	for _, other := range others {
+		if !other.valid {
+			continue
+		}
+		for key, incoming := range other.value {
+			if existing, ok := merged[key]; ok {
+				incoming = resolve(key, existing, incoming)
+			}
+			merged[key] = incoming
+		}
+		valid = true
+	}
+	m.value = merged
+	m.valid = valid
+	return m
+}
+
+// MergeInto copies every item of this Map into dst in place, overwriting
+// existing keys and marking dst as valid. Unlike Merge it doesn't clone,
+// which suits hot paths. A null receiver leaves dst untouched, and dst keeps
+// its own unmarshaled flag.
+//
+// Example:
+//
+//	var dst Map[string, int]
+//	NewMap(map[string]int{"a": 1}).MergeInto(&dst)
+func (m Map[K, V]) MergeInto(dst *Map[K, V]) {
+	if !m.valid {
+		return
+	}
+	dst.Insert(maps.All(m.value))
+}
+
 // MergeRaw merges raw maps into this Map and returns a raw map.
 //
 // Example:
@@ -313,7 +962,9 @@ func (m Map[K, V]) MergeRaw(others ...map[K]V) map[K]V {
 	return merged
 }
 
-// Clone returns a deep copy of the Map.
+// Clone returns a copy of the Map with its own underlying map, keeping its
+// null and unmarshaled state. Values are copied by assignment, so nested
+// maps and slices are shared; use CloneDeep or CloneFunc for those.
 //
 // Example:
 //
@@ -324,7 +975,42 @@ func (m Map[K, V]) Clone() Map[K, V] {
 	return m
 }
 
-// CloneRaw returns a deep copy of the underlying map.
+// CloneDeep returns a copy of the Map whose keys and values share no maps,
+// slices or pointers with the original, keeping its null and unmarshaled
+// state. Nested ztype values are copied with their own CloneDeep or Clone.
+// Values must not contain cycles.
+//
+// Example:
+//
+//	m := NewMap(map[string]any{"tags": []any{"a"}})
+//	c := m.CloneDeep()
+//	c.Get()["tags"].([]any)[0] = "b" // m is unchanged
+func (m Map[K, V]) CloneDeep() Map[K, V] {
+	m.value = deepCopy(m.value)
+	return m
+}
+
+// CloneFunc returns a copy of the Map with every value copied by fn,
+// keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	m := NewMap(map[string][]int{"a": {1}})
+//	c := m.CloneFunc(slices.Clone)
+func (m Map[K, V]) CloneFunc(fn func(V) V) Map[K, V] {
+	if m.value == nil {
+		return m
+	}
+	cloned := make(map[K]V, len(m.value))
+	for key, value := range m.value {
+		cloned[key] = fn(value)
+	}
+	m.value = cloned
+	return m
+}
+
+// CloneRaw returns a copy of the underlying map. Values are copied by
+// assignment, as in Clone.
 //
 // Example:
 //
@@ -356,7 +1042,41 @@ func (m Map[K, V]) EqualRawFunc(other map[K]V, equal func(V, V) bool) bool {
 	return maps.EqualFunc(m.value, other, equal)
 }
 
+// DiffFunc compares this Map (the old state) against other (the new state) using
+// the provided equality function. Null Maps are treated as empty.
+//
+// Example:
+//
+//	before := NewMap(map[string][]int{"a": {1}, "b": {2}})
+//	after := NewMap(map[string][]int{"b": {3}, "c": {4}})
+//	diff := before.DiffFunc(after, slices.Equal[[]int])
+//	// diff.Added={"c"}, diff.Removed={"a"}, diff.Changed={"b": {Old: [2], New: [3]}}
+func (m Map[K, V]) DiffFunc(other Map[K, V], equal func(V, V) bool) MapDiff[K, V] {
+	diff := MapDiff[K, V]{
+		Added:   map[K]V{},
+		Removed: map[K]V{},
+		Changed: map[K]MapChange[V]{},
+	}
+	for key, old := range m.value {
+		current, ok := other.value[key]
+		if !ok {
+			diff.Removed[key] = old
+			continue
+		}
+		if !equal(old, current) {
+			diff.Changed[key] = MapChange[V]{Old: old, New: current}
+		}
+	}
+	for key, current := range other.value {
+		if _, ok := m.value[key]; !ok {
+			diff.Added[key] = current
+		}
+	}
+	return diff
+}
+
 // DeleteFunc deletes all items from the Map where the delete function returns true.
+// It never changes the null state; on a null Map it does nothing.
 //
 // Example:
 //
@@ -367,20 +1087,88 @@ func (m *Map[K, V]) DeleteFunc(delete func(K, V) bool) {
 }
 
 // JsonString returns a JSON string representation of the Map or "{}" if invalid.
+// Keys are emitted in the order used by encoding/json (sorted by their encoded
+// string), so the output is stable across runs. "{}" is returned only for a
+// null Map; a Map that can't be marshaled, such as one holding a channel,
+// gives "", never "{}", so the failure is not mistaken for an empty Map.
+//
+// Deprecated: JsonString returns "" when the Map can't be marshaled.
+// Use JsonStringE to get the error instead.
 //
 // Example:
 //
 //	m := NewMap(map[string]int{"a": 1})
 //	s := m.JsonString() // "{\"a\":1}"
 func (m Map[K, V]) JsonString() string {
+	value, _ := m.JsonStringE()
+	return value
+}
+
+// JsonStringE returns a JSON string representation of the Map or "{}" if invalid,
+// reporting marshal errors instead of swallowing them.
+//
+// Example:
+//
+//	m := NewMap(map[string]any{"ch": make(chan int)})
+//	_, err := m.JsonStringE() // json: unsupported type: chan int
+func (m Map[K, V]) JsonStringE() (string, error) {
 	if !m.valid {
-		return "{}"
+		return "{}", nil
 	}
 	data, erro := json.Marshal(m.value)
 	if erro != nil {
-		return ""
+		return "", erro
 	}
-	return string(data)
+	return string(data), nil
+}
+
+// JsonStringIndent is like JsonStringE but applies json.MarshalIndent
+// formatting with the given prefix and indent.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	s, err := m.JsonStringIndent("", "  ") // "{\n  \"a\": 1\n}"
+func (m Map[K, V]) JsonStringIndent(prefix, indent string) (string, error) {
+	if !m.valid {
+		return "{}", nil
+	}
+	data, erro := json.MarshalIndent(m.value, prefix, indent)
+	if erro != nil {
+		return "", erro
+	}
+	return string(data), nil
+}
+
+// JsonStringSorted returns a JSON string representation of the Map with keys
+// ordered by compare instead of by their encoded string. This is useful for
+// non-string keys, e.g. ordering int keys numerically ("2" before "10").
+// Keys and values are encoded exactly as encoding/json would encode them.
+//
+// Example:
+//
+//	m := NewMap(map[int]string{10: "b", 2: "a"})
+//	s, err := m.JsonStringSorted(cmp.Compare[int]) // {"2":"a","10":"b"}
+func (m Map[K, V]) JsonStringSorted(compare func(a, b K) int) (string, error) {
+	if !m.valid {
+		return "{}", nil
+	}
+	keys := slices.SortedFunc(maps.Keys(m.value), compare)
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range keys {
+		entry, erro := json.Marshal(map[K]V{key: m.value[key]})
+		if erro != nil {
+			return "", erro
+		}
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(entry[1 : len(entry)-1])
+	}
+	buffer.WriteByte('}')
+	return buffer.String(), nil
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -389,18 +1177,33 @@ func (m Map[K, V]) JsonString() string {
 //
 //	json.Marshal(m)
 func (n Map[K, V]) MarshalJSON() ([]byte, error) {
+	return n.appendJSONCodec(&defaultCodec, nil)
+}
+
+// appendJSONCodec implements MarshalJSON with the options of c.
+func (n Map[K, V]) appendJSONCodec(c *Codec, dst []byte) ([]byte, error) {
 	if n.valid {
-		return json.Marshal(n.value)
+		return c.appendJSONValue(dst, reflect.ValueOf(n.value))
 	}
-	return []byte("null"), nil
+	return append(dst, "null"...), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 //
+// Object keys are converted into K explicitly: string kinds are used as-is,
+// integer kinds are parsed in base 10 and other types must implement
+// encoding.TextUnmarshaler. Keys that can't be parsed, or that collide after
+// conversion (e.g. "1" and "01" for int keys), produce an error naming the key.
+//
 // Example:
 //
 //	json.Unmarshal(data, &m)
 func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	return m.unmarshalJSONCodec(&defaultCodec, data)
+}
+
+// unmarshalJSONCodec implements UnmarshalJSON with the options of c.
+func (m *Map[K, V]) unmarshalJSONCodec(c *Codec, data []byte) error {
 	m.unmarshaled = true
 	if bytes.Equal(data, []byte("null")) {
 		m.valid = false
@@ -408,9 +1211,12 @@ func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	var result map[K]V
-	if err := json.Unmarshal(data, &result); err != nil {
-		m.valid = false
+	if err := checkJSONLimits("Map", data); err != nil {
+		m.SetNull()
+		return err
+	}
+	result, err := unmarshalMap[K, V](c, data)
+	if err != nil {
 		return err
 	}
 
@@ -419,6 +1225,90 @@ func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// unmarshalMap decodes a JSON object converting every key into K, and every
+// value with the options of c.
+func unmarshalMap[K comparable, V any](c *Codec, data []byte) (map[K]V, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, wrapJSONError("Map", data, err)
+	}
+
+	result := make(map[K]V, len(raw))
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		key, err := parseMapKey[K](name)
+		if err != nil {
+			return nil, mapKeyError[K]("Map", name, err)
+		}
+		if _, ok := result[key]; ok {
+			return nil, newInvalidFormat("Map", name, "duplicate map key after conversion to %v", key)
+		}
+		var item V
+		if err := c.unmarshalJSONValue(raw[name], &item); err != nil {
+			return nil, fmt.Errorf("map key %q: %w", name, err)
+		}
+		result[key] = item
+	}
+	return result, nil
+}
+
+// mapKeyError reports a JSON object key that parseMapKey rejected: keys out
+// of the range of K become *ErrOverflow, anything else *ErrInvalidFormat.
+func mapKeyError[K comparable](typeName, name string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return &ErrOverflow{Type: reflect.TypeFor[K]().String(), Value: name}
+	}
+	return newInvalidFormat(typeName, name, "invalid map key: %w", err)
+}
+
+// parseMapKey converts a JSON object key into K following encoding/json rules.
+func parseMapKey[K comparable](name string) (K, error) {
+	var key K
+	if unmarshaler, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText([]byte(name))
+		return key, err
+	}
+
+	value := reflect.ValueOf(&key).Elem()
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(name, 10, value.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err := strconv.ParseUint(name, 10, value.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		value.SetUint(parsed)
+	default:
+		return key, fmt.Errorf("unsupported key type %T", key)
+	}
+	return key, nil
+}
+
+// formatMapKey converts a key into its JSON object key following encoding/json rules.
+func formatMapKey[K comparable](key K) (string, error) {
+	value := reflect.ValueOf(&key).Elem()
+	if value.Kind() == reflect.String {
+		return value.String(), nil
+	}
+	if marshaler, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported key type %T", key)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 //
 // Example:
@@ -442,6 +1332,11 @@ func (m *Map[K, V]) UnmarshalText(data []byte) error {
 
 // Scan implements the sql.Scanner interface for database deserialization.
 //
+// Besides JSON encoded string and []byte values, it accepts maps that were
+// already decoded by the driver: map[K]V is copied as-is and map[string]any
+// is converted through a JSON round trip. The jsonb "null" document is scanned
+// as a null Map, just like SQL NULL.
+//
 // Example:
 //
 //	var m Map[string]int
@@ -455,17 +1350,36 @@ func (m *Map[K, V]) Scan(value any) error {
 
 	var data []byte
 	switch v := value.(type) {
+	case map[K]V:
+		m.valid = true
+		m.value = maps.Clone(v)
+		return nil
+	case map[string]any:
+		encoded, erro := json.Marshal(v)
+		if erro != nil {
+			return erro
+		}
+		data = encoded
 	case string:
 		data = []byte(v)
 	case []byte:
 		data = v
 	default:
-		return fmt.Errorf("invalid type: %T", value)
+		return newUnsupportedScanType(value)
 	}
 
-	result := map[K]V{}
-	if erro := json.Unmarshal(data, &result); erro != nil {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
 		m.valid = false
+		m.value = map[K]V{}
+		return nil
+	}
+
+	if erro := checkJSONLimits("Map", data); erro != nil {
+		m.SetNull()
+		return erro
+	}
+	result, erro := unmarshalMap[K, V](&defaultCodec, data)
+	if erro != nil {
 		return erro
 	}
 
@@ -475,33 +1389,194 @@ func (m *Map[K, V]) Scan(value any) error {
 }
 
 // Value implements the driver.Valuer interface for database serialization.
+// Valid Maps are encoded as a JSON string, or as []byte when enabled through
+// SetMapValueAsBytes. Null Maps are written as SQL NULL; see ValueOrEmptyObject
+// and ValueOrNullDocument for NOT NULL columns.
 //
 // Example:
 //
 //	val, err := m.Value()
 func (m Map[K, V]) Value() (driver.Value, error) {
+	return m.valueCodec(&defaultCodec)
+}
+
+// valueCodec implements Value with the options of c.
+func (m Map[K, V]) valueCodec(c *Codec) (driver.Value, error) {
 	if !m.valid {
 		return nil, nil
 	}
-	value, erro := json.Marshal(m.value)
+	value, erro := c.appendJSONValue(nil, reflect.ValueOf(m.value))
 	if erro != nil {
 		return nil, erro
 	}
-	return string(value), nil
+	return c.mapDriverValue(value), nil
+}
+
+// ValueOrZero is like Value, but returns an empty JSON object instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullMap[string, any]().ValueOrZero() // `{}`
+func (m Map[K, V]) ValueOrZero() (driver.Value, error) {
+	if !m.valid {
+		return NewMap(map[K]V{}).Value()
+	}
+	return m.Value()
+}
+
+// ValueOrEmptyObject is like Value, but writes the JSON document {} instead
+// of SQL NULL when the Map is null.
+//
+// Example:
+//
+//	m := NewNullMap[string, int]()
+//	val, _ := m.ValueOrEmptyObject() // "{}"
+func (m Map[K, V]) ValueOrEmptyObject() (driver.Value, error) {
+	if !m.valid {
+		return defaultCodec.mapDriverValue([]byte("{}")), nil
+	}
+	return m.Value()
+}
+
+// ValueOrNullDocument is like Value, but writes the JSON document null instead
+// of SQL NULL when the Map is null.
+//
+// Example:
+//
+//	m := NewNullMap[string, int]()
+//	val, _ := m.ValueOrNullDocument() // "null"
+func (m Map[K, V]) ValueOrNullDocument() (driver.Value, error) {
+	if !m.valid {
+		return defaultCodec.mapDriverValue([]byte("null")), nil
+	}
+	return m.Value()
+}
+
+// SetMapValueAsBytes controls the concrete type returned by Map.Value and its
+// variants: string (the default) or []byte, which the pq and pgx drivers
+// prefer for jsonb parameters.
+//
+// Example:
+//
+//	ztype.SetMapValueAsBytes(true)
+//	val, _ := m.Value() // []byte(`{"a":1}`)
+func SetMapValueAsBytes(value bool) {
+	defaultCodec.mapValueAsBytes.Store(value)
+}
+
+// mapDriverValue converts encoded JSON into the driver value type c selects.
+func (c *Codec) mapDriverValue(data []byte) driver.Value {
+	if c.mapValueAsBytes.Load() {
+		return data
+	}
+	return string(data)
 }
 
 // String returns the JSON string representation of the Map.
-// If the Map is invalid (null), it returns "{}".
+// If the Map is invalid (null), it returns "{}". Maps that can't be
+// marshaled fall back to Go's %v formatting.
 //
 // Example:
 //
 //	m := NewMap(map[string]int{"a": 1})
 //	fmt.Println(m.String()) // Output: {"a":1}
 func (m Map[K, V]) String() string {
+	value, erro := m.JsonStringE()
+	if erro != nil {
+		return fmt.Sprintf("%v", m.value)
+	}
+	return value
+}
+
+// StringOr returns String for valid values and fallback for null, where
+// String writes "{}".
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullMap[string, any]().StringOr("null"))  // Output: null
+func (m Map[K, V]) StringOr(fallback string) string {
+	if !m.valid {
+		return fallback
+	}
+	return m.String()
+}
+
+// SchemaType reports Map as a nullable JSON object.
+//
+// Example:
+//
+//	ztype.JSON{}.SchemaType() // "object", "", true
+func (m Map[K, V]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "object", "", true
+}
+
+// schemaItem returns the value type, described as additionalProperties.
+func (m Map[K, V]) schemaItem() reflect.Type {
+	return reflect.TypeFor[V]()
+}
+
+// TransformMap returns a new Map with every value of m converted by fn.
+// It is a function rather than a method because methods can't introduce
+// new type parameters. A null Map produces a null Map.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1})
+//	labels := TransformMap(m, func(k string, v int) string { return strconv.Itoa(v) })
+func TransformMap[K comparable, V1 any, V2 any](m Map[K, V1], fn func(K, V1) V2) Map[K, V2] {
+	if !m.valid {
+		return NewNullMap[K, V2]()
+	}
+	result := make(map[K]V2, len(m.value))
+	for key, value := range m.value {
+		result[key] = fn(key, value)
+	}
+	return Map[K, V2]{value: result, valid: true, unmarshaled: m.unmarshaled}
+}
+
+// Reduce folds every item of m into an accumulator starting at init.
+// Items are visited in map iteration order, so fn should not depend on it.
+// A null Map returns init.
+//
+// Example:
+//
+//	m := NewMap(map[string]int{"a": 1, "b": 2})
+//	sum := Reduce(m, 0, func(acc int, k string, v int) int { return acc + v }) // 3
+func Reduce[K comparable, V any, A any](m Map[K, V], init A, fn func(A, K, V) A) A {
+	acc := init
 	if !m.valid {
-		return "null"
+		return acc
 	}
-	return fmt.Sprintf("%v", m.value)
+	for key, value := range m.value {
+		acc = fn(acc, key, value)
+	}
+	return acc
+}
+
+// GroupBy groups the values of m by the key returned from fn.
+// Values within a group are ordered by their original keys, naturally for
+// string and number kinds and by their JSON encoding otherwise, so the output
+// is stable across runs. A null Map produces a null Map.
+//
+// Example:
+//
+//	statuses := NewMap(map[string]string{"a": "active", "b": "blocked", "c": "active"})
+//	byStatus := GroupBy(statuses, func(k, v string) string { return v })
+//	// {"active": ["active", "active"], "blocked": ["blocked"]}
+func GroupBy[K comparable, V any, G comparable](m Map[K, V], fn func(K, V) G) Map[G, []V] {
+	if !m.valid {
+		return NewNullMap[G, []V]()
+	}
+	keys := slices.Collect(maps.Keys(m.value))
+	sortSetItems(keys)
+	groups := map[G][]V{}
+	for _, key := range keys {
+		value := m.value[key]
+		group := fn(key, value)
+		groups[group] = append(groups[group], value)
+	}
+	return NewMap(groups)
 }
 
 // ComparableJSON is a convenience alias for MapComparable with string keys and any values,
@@ -530,6 +1605,17 @@ type MapComparable[K comparable, V comparable] struct {
 	Map[K, V]
 }
 
+// Clone returns a copy of the MapComparable with its own underlying map.
+// Comparable values hold no shared state worth deep-copying beyond pointers,
+// which are copied as is.
+//
+// Example:
+//
+//	c := m1.Clone()
+func (m MapComparable[K, V]) Clone() MapComparable[K, V] {
+	return MapComparable[K, V]{Map: m.Map.Clone()}
+}
+
 // Equal returns true if m and other have exactly the same keys and values.
 //
 // Example:
@@ -578,3 +1664,128 @@ func (m *MapComparable[K, V]) DeleteIfEquals(key K, value V) bool {
 	}
 	return false
 }
+
+// Diff compares this Map (the old state) against other (the new state)
+// using ==. Null Maps are treated as empty.
+//
+// Example:
+//
+//	diff := before.Diff(after)
+//	for key, change := range diff.Changed { /* change.Old, change.New */ }
+func (m MapComparable[K, V]) Diff(other MapComparable[K, V]) MapDiff[K, V] {
+	return m.DiffFunc(other.Map, func(a, b V) bool { return a == b })
+}
+
+// MapItem is a single key-value pair of a Map.
+type MapItem[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Invert returns a new MapComparable with keys and values swapped. An error
+// naming the value is returned when two keys share it, since only one of them
+// could survive. A null Map produces a null Map.
+//
+// Example:
+//
+//	codes := MapComparable[string, int]{}
+//	codes.Set(map[string]int{"active": 1, "blocked": 2})
+//	names, err := codes.Invert() // {1: "active", 2: "blocked"}
+func (m MapComparable[K, V]) Invert() (MapComparable[V, K], error) {
+	if !m.valid {
+		return MapComparable[V, K]{}, nil
+	}
+	inverted := make(map[V]K, len(m.value))
+	for key, value := range m.value {
+		if _, ok := inverted[value]; ok {
+			return MapComparable[V, K]{}, fmt.Errorf("value %#v is shared by more than one key", value)
+		}
+		inverted[value] = key
+	}
+	return MapComparable[V, K]{Map: NewMap(inverted)}, nil
+}
+
+// MapChange holds the old and new values of a changed key.
+type MapChange[V any] struct {
+	Old V
+	New V
+}
+
+// MapDiff describes the differences between two Maps.
+//
+// Example:
+//
+//	diff := before.DiffFunc(after, equal)
+//	if !diff.IsEmpty() { /* audit diff.Added, diff.Removed and diff.Changed */ }
+type MapDiff[K comparable, V any] struct {
+	Added   map[K]V
+	Removed map[K]V
+	Changed map[K]MapChange[V]
+}
+
+// IsEmpty returns true if the diff holds no added, removed or changed keys.
+//
+// Example:
+//
+//	same := m.DiffFunc(m, equal).IsEmpty() // true
+func (d MapDiff[K, V]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DeepDiff compares two JSON documents, recursing into nested objects and
+// reporting keys as dotted paths (e.g. "data.user.id"). Arrays and other
+// values are compared as a whole using reflect.DeepEqual. Null inputs are
+// treated as empty objects.
+//
+// Example:
+//
+//	before := NewMap(map[string]any{"user": map[string]any{"id": 1, "name": "a"}})
+//	after := NewMap(map[string]any{"user": map[string]any{"id": 2}})
+//	diff := DeepDiff(before, after)
+//	// diff.Changed={"user.id": {Old: 1, New: 2}}, diff.Removed={"user.name": "a"}
+func DeepDiff(before, after JSON) MapDiff[string, any] {
+	diff := MapDiff[string, any]{
+		Added:   map[string]any{},
+		Removed: map[string]any{},
+		Changed: map[string]MapChange[any]{},
+	}
+	deepDiff("", before.value, after.value, diff)
+	return diff
+}
+
+// deepDiff records the differences between two objects below prefix.
+func deepDiff(prefix string, before, after map[string]any, diff MapDiff[string, any]) {
+	for key, oldValue := range before {
+		path := prefix + key
+		newValue, ok := after[key]
+		if !ok {
+			diff.Removed[path] = oldValue
+			continue
+		}
+		oldObject, oldIsObject := jsonObject(oldValue)
+		newObject, newIsObject := jsonObject(newValue)
+		if oldIsObject && newIsObject {
+			deepDiff(path+".", oldObject, newObject, diff)
+			continue
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diff.Changed[path] = MapChange[any]{Old: oldValue, New: newValue}
+		}
+	}
+	for key, newValue := range after {
+		if _, ok := before[key]; !ok {
+			diff.Added[prefix+key] = newValue
+		}
+	}
+}
+
+// jsonObject returns the content of a nested JSON object value.
+func jsonObject(value any) (map[string]any, bool) {
+	switch object := value.(type) {
+	case map[string]any:
+		return object, true
+	case JSON:
+		return object.value, object.valid
+	}
+	return nil, false
+}
@@ -0,0 +1,734 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// SetMoneyJSONCompact selects how Money marshals to JSON: as the object
+// {"amount":"19.90","currency":"BRL"} (the default) or as the compact string
+// "19.90 BRL". UnmarshalJSON and Scan always accept both forms.
+//
+// Example:
+//
+//	ztype.SetMoneyJSONCompact(true)
+//	data, _ := json.Marshal(ztype.NewMoney(1990, "BRL")) // "19.90 BRL"
+func SetMoneyJSONCompact(compact bool) {
+	defaultCodec.moneyJSONCompact.Store(compact)
+}
+
+// currencyExponents lists ISO-4217 currencies whose minor unit is not 1/100.
+var currencyExponents = map[string]int{
+	"BHD": 3, "BIF": 0, "CLF": 4, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3,
+	"ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3,
+	"OMR": 3, "PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "UYW": 4,
+	"VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// CurrencyExponent returns the number of decimal places of the currency's
+// minor unit according to ISO-4217. Unknown currencies use 2.
+//
+// Example:
+//
+//	ztype.CurrencyExponent("BRL") // 2
+//	ztype.CurrencyExponent("JPY") // 0
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// normalizeCurrency upper-cases currency and checks it is a 3-letter code.
+func normalizeCurrency(currency string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", newInvalidFormat("Money", currency, "invalid currency code")
+	}
+	return code, nil
+}
+
+// Money represents a nullable monetary amount stored as an integer number of
+// minor units (cents for BRL/USD, yen for JPY) plus an ISO-4217 currency code.
+//
+// Money never rounds implicitly: parsing rejects amounts with more decimal
+// places than the currency allows, arithmetic is exact and fails on overflow,
+// and Allocate hands out leftover minor units one at a time to the first
+// shares, so the parts always add up to the original amount.
+//
+// Example Usage:
+//
+//	price, _ := ztype.ParseMoney("19.90", "BRL")
+//	total, _ := price.Add(ztype.NewMoney(100, "BRL"))
+//	total.String() // "20.90 BRL"
+type Money struct {
+	amount      int64
+	currency    string
+	valid       bool
+	unmarshaled bool
+}
+
+// NewMoney creates a new valid Money from an amount in minor units.
+// The currency code is upper-cased but not validated.
+//
+// Example:
+//
+//	m := ztype.NewMoney(1990, "BRL") // 19.90 BRL
+func NewMoney(minorUnits int64, currency string) Money {
+	return Money{amount: minorUnits, currency: strings.ToUpper(currency), valid: true}
+}
+
+// NewNullMoney creates a new null Money instance.
+//
+// Example:
+//
+//	m := ztype.NewNullMoney()
+//	m.IsNull() // true
+func NewNullMoney() Money {
+	return Money{valid: false}
+}
+
+// ParseMoney creates a new valid Money from a decimal string such as "19.90".
+// Returns an error for malformed input, invalid currency codes, amounts that
+// overflow int64 minor units, or more decimal places than the currency allows.
+//
+// Example:
+//
+//	m, err := ztype.ParseMoney("19.90", "BRL")
+//	m.Amount() // 1990
+func ParseMoney(amount string, currency string) (Money, error) {
+	code, err := normalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	minor, err := parseMinorUnits(amount, CurrencyExponent(code))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minor, currency: code, valid: true}, nil
+}
+
+// parseMinorUnits converts a decimal string into minor units without rounding.
+func parseMinorUnits(amount string, exponent int) (int64, error) {
+	text := strings.TrimSpace(amount)
+	sign := ""
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		sign, text = text[:1], text[1:]
+	}
+	whole, fraction, hasPoint := strings.Cut(text, ".")
+	if whole+fraction == "" || (hasPoint && fraction == "") ||
+		strings.Trim(whole+fraction, "0123456789") != "" {
+		return 0, newInvalidFormat("Money", amount, "invalid amount")
+	}
+	if len(fraction) > exponent {
+		return 0, newInvalidFormat("Money", amount, "more than %d decimal places", exponent)
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+	minor, err := strconv.ParseInt(sign+whole+fraction, 10, 64)
+	if err != nil {
+		return 0, &ErrOverflow{Type: "Money", Value: amount}
+	}
+	return minor, nil
+}
+
+// formatMinorUnits renders minor units as a decimal string with exponent places.
+func formatMinorUnits(minor int64, exponent int) string {
+	sign := ""
+	magnitude := uint64(minor)
+	if minor < 0 {
+		sign = "-"
+		magnitude = uint64(-(minor + 1)) + 1
+	}
+	digits := strconv.FormatUint(magnitude, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	split := len(digits) - exponent
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// Amount returns the amount in minor units. When null, returns 0.
+//
+// Example:
+//
+//	ztype.NewMoney(1990, "BRL").Amount() // 1990
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the ISO-4217 currency code. When null, returns "".
+//
+// Example:
+//
+//	ztype.NewMoney(1990, "BRL").Currency() // "BRL"
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Decimal returns the amount as a decimal string using the currency's
+// minor unit, e.g. "19.90". When null, returns "".
+//
+// Example:
+//
+//	ztype.NewMoney(-5, "USD").Decimal() // "-0.05"
+func (m Money) Decimal() string {
+	if !m.valid {
+		return ""
+	}
+	return formatMinorUnits(m.amount, CurrencyExponent(m.currency))
+}
+
+// Set updates the amount and currency and marks the Money as valid.
+//
+// Example:
+//
+//	var m ztype.Money
+//	m.Set(500, "EUR")
+func (m *Money) Set(minorUnits int64, currency string) {
+	m.amount = minorUnits
+	m.currency = strings.ToUpper(currency)
+	m.valid = true
+}
+
+// SetNull marks the Money as null and resets its amount and currency.
+//
+// Example:
+//
+//	m.SetNull()
+func (m *Money) SetNull() {
+	m.amount = 0
+	m.currency = ""
+	m.valid = false
+}
+
+// IsNull returns true if the Money is null.
+//
+// Example:
+//
+//	ztype.NewNullMoney().IsNull() // true
+func (m Money) IsNull() bool {
+	return !m.valid
+}
+
+// IsEmpty returns true if the Money is null or its amount is zero, whatever
+// the currency.
+//
+// Example:
+//
+//	ztype.NewMoney(0, "BRL").IsEmpty() // true
+func (m Money) IsEmpty() bool {
+	return !m.valid || m.amount == 0
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	ztype.NewMoney(1990, "BRL").IsZero() // false
+func (m Money) IsZero() bool {
+	return m.IsEmpty()
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values.
+//
+// Example:
+//
+//	m.Unmarshaled()
+func (m Money) Unmarshaled() bool {
+	return m.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	m.SetUnmarshaled(true)
+func (m *Money) SetUnmarshaled(value bool) {
+	m.unmarshaled = value
+}
+
+// Equal performs equality check including null state and currency.
+//
+// Example:
+//
+//	ztype.NewMoney(1, "BRL").Equal(ztype.NewMoney(1, "USD")) // false
+func (m Money) Equal(other Money) bool {
+	return m.valid == other.valid && m.amount == other.amount && m.currency == other.currency
+}
+
+// EqualValues is Equal under the name shared by every type: it compares
+// value and null state and ignores the unmarshaled flag.
+//
+// Example:
+//
+//	m := ztype.NewMoney(1990, "BRL")
+//	fmt.Println(m.EqualValues(ztype.NewMoney(1990, "BRL")))  // Output: true
+func (m Money) EqualValues(other Money) bool {
+	return m.Equal(other)
+}
+
+// Clone returns a copy of the Money, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	m := ztype.NewMoney(1990, "BRL")
+//	c := m.Clone()
+func (m Money) Clone() Money {
+	return m
+}
+
+// sameCurrency returns an error when the operands use different currencies.
+func (m Money) sameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("currency mismatch: %s and %s", m.currency, other.currency)
+	}
+	return nil
+}
+
+// Add returns the sum of both amounts. Returns null if either operand is null,
+// and an error on currency mismatch or overflow.
+//
+// Example:
+//
+//	total, err := ztype.NewMoney(100, "BRL").Add(ztype.NewMoney(50, "BRL")) // 1.50 BRL
+func (m Money) Add(other Money) (Money, error) {
+	if !m.valid || !other.valid {
+		return NewNullMoney(), nil
+	}
+	if err := m.sameCurrency(other); err != nil {
+		return NewNullMoney(), err
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return NewNullMoney(), &ErrOverflow{Type: "Money"}
+	}
+	return Money{amount: sum, currency: m.currency, valid: true}, nil
+}
+
+// Sub returns the difference of both amounts. Returns null if either operand
+// is null, and an error on currency mismatch or overflow.
+//
+// Example:
+//
+//	change, err := ztype.NewMoney(100, "BRL").Sub(ztype.NewMoney(30, "BRL")) // 0.70 BRL
+func (m Money) Sub(other Money) (Money, error) {
+	if !m.valid || !other.valid {
+		return NewNullMoney(), nil
+	}
+	if other.amount == math.MinInt64 {
+		return NewNullMoney(), &ErrOverflow{Type: "Money"}
+	}
+	return m.Add(Money{amount: -other.amount, currency: other.currency, valid: true})
+}
+
+// Mult multiplies the amount by an integer factor. Returns null if the Money
+// is null, and an error on overflow.
+//
+// Example:
+//
+//	total, err := ztype.NewMoney(1990, "BRL").Mult(3) // 59.70 BRL
+func (m Money) Mult(factor int64) (Money, error) {
+	if !m.valid {
+		return NewNullMoney(), nil
+	}
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(factor))
+	if !product.IsInt64() {
+		return NewNullMoney(), &ErrOverflow{Type: "Money"}
+	}
+	return Money{amount: product.Int64(), currency: m.currency, valid: true}, nil
+}
+
+// Compare compares two amounts of the same currency. Returns an error if either
+// value is null or the currencies differ.
+//
+// Example:
+//
+//	result, _ := ztype.NewMoney(1, "BRL").Compare(ztype.NewMoney(2, "BRL")) // -1
+func (m Money) Compare(other Money) (int, error) {
+	if !m.valid || !other.valid {
+		return 0, fmt.Errorf("cannot compare %w", ErrNullValue)
+	}
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Allocate splits the amount proportionally to ratios. Shares are truncated
+// towards zero and the leftover minor units are handed out one at a time to
+// the first shares with a non-zero ratio, so the result always sums to the
+// original amount.
+//
+// Example:
+//
+//	parts, _ := ztype.NewMoney(100, "BRL").Allocate(1, 1, 1) // 0.34, 0.33, 0.33
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	if !m.valid {
+		return nil, fmt.Errorf("cannot allocate %w", ErrNullValue)
+	}
+	total := int64(0)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("invalid ratio %d: ratios must not be negative", ratio)
+		}
+		total += int64(ratio)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("cannot allocate: ratios sum to zero")
+	}
+
+	amount := big.NewInt(m.amount)
+	parts := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(amount, big.NewInt(int64(ratio)))
+		share.Quo(share, big.NewInt(total))
+		parts[i] = Money{amount: share.Int64(), currency: m.currency, valid: true}
+		remainder -= parts[i].amount
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(ratios) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].amount += step
+		remainder -= step
+	}
+	return parts, nil
+}
+
+// Split divides the amount into n equal shares, distributing the remainder
+// like Allocate.
+//
+// Example:
+//
+//	parts, _ := ztype.NewMoney(1000, "BRL").Split(3) // 3.34, 3.33, 3.33
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("cannot split into %d parts", n)
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// moneyJSON is the object form of Money in JSON and jsonb columns.
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON implements json.Marshaler. See SetMoneyJSONCompact for the format.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ztype.NewMoney(1990, "BRL")) // {"amount":"19.90","currency":"BRL"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	return m.AppendJSON(make([]byte, 0, jsonStringCapacity(len(m.currency))+40))
+}
+
+// AppendJSON appends the JSON encoding of the Money to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewMoney(1990, "BRL").AppendJSON(buf) // {"amount":"19.90","currency":"BRL"}
+func (m Money) AppendJSON(dst []byte) ([]byte, error) {
+	return m.appendJSONCodec(&defaultCodec, dst)
+}
+
+// appendJSONCodec implements AppendJSON with the options of c.
+func (m Money) appendJSONCodec(c *Codec, dst []byte) ([]byte, error) {
+	if !m.valid {
+		return append(dst, "null"...), nil
+	}
+	if c.moneyJSONCompact.Load() {
+		return appendJSONString(dst, m.String()), nil
+	}
+	dst = append(dst, `{"amount":`...)
+	dst = appendJSONString(dst, m.Decimal())
+	dst = append(dst, `,"currency":`...)
+	dst = appendJSONString(dst, m.currency)
+	return append(dst, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the object form
+// (with the amount as a string or number) and the compact string form.
+//
+// Example:
+//
+//	var m ztype.Money
+//	json.Unmarshal([]byte(`"19.90 BRL"`), &m)
+func (m *Money) UnmarshalJSON(data []byte) error {
+	m.unmarshaled = true
+	return m.decode(data)
+}
+
+// decode parses either JSON form, treating null as a null Money.
+func (m *Money) decode(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		m.SetNull()
+		return nil
+	}
+
+	var amount, currency string
+	if len(data) > 0 && data[0] == '"' {
+		var compact string
+		if err := json.Unmarshal(data, &compact); err != nil {
+			return wrapJSONError("Money", data, err)
+		}
+		fields := strings.Fields(compact)
+		if len(fields) != 2 {
+			return newInvalidFormat("Money", compact, `expected "<amount> <currency>"`)
+		}
+		amount, currency = fields[0], fields[1]
+	} else {
+		var object moneyJSON
+		if err := json.Unmarshal(data, &object); err != nil {
+			return wrapJSONError("Money", data, err)
+		}
+		if err := json.Unmarshal(object.Amount, &amount); err != nil {
+			amount = string(object.Amount)
+		}
+		currency = object.Currency
+	}
+
+	parsed, err := ParseMoney(amount, currency)
+	if err != nil {
+		return err
+	}
+	m.amount, m.currency, m.valid = parsed.amount, parsed.currency, true
+	return nil
+}
+
+// Scan implements sql.Scanner for a single json/jsonb column holding either
+// JSON form. For a pair of columns, scan into AmountColumn and CurrencyColumn.
+//
+// Example:
+//
+//	var m ztype.Money
+//	err := db.QueryRow("SELECT price FROM products WHERE id = 1").Scan(&m)
+func (m *Money) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		m.SetNull()
+		return nil
+	case []byte:
+		return m.decode(v)
+	case string:
+		return m.decode([]byte(v))
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements driver.Valuer for a single json/jsonb column, always
+// writing the object form.
+//
+// Example:
+//
+//	val, _ := m.Value() // {"amount":"19.90","currency":"BRL"}
+func (m Money) Value() (driver.Value, error) {
+	if !m.valid {
+		return nil, nil
+	}
+	amount, err := json.Marshal(m.Decimal())
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(moneyJSON{Amount: amount, Currency: m.currency})
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// ValueOrZero is like Value, but returns a zero amount with an empty currency instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullMoney().ValueOrZero() // `{"amount":"0.00","currency":""}`
+func (m Money) ValueOrZero() (driver.Value, error) {
+	if !m.valid {
+		return NewMoney(0, "").Value()
+	}
+	return m.Value()
+}
+
+// String returns "<amount> <currency>", e.g. "19.90 BRL", or "<NULL>".
+//
+// Example:
+//
+//	fmt.Println(ztype.NewMoney(1990, "BRL")) // 19.90 BRL
+func (m Money) String() string {
+	if !m.valid {
+		return nullToken()
+	}
+	return m.Decimal() + " " + m.currency
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullMoney().StringOr("-"))  // Output: -
+func (m Money) StringOr(fallback string) string {
+	if !m.valid {
+		return fallback
+	}
+	return m.String()
+}
+
+// SchemaType reports Money as a nullable object with amount and currency, or
+// as a string while SetMoneyJSONCompact is enabled.
+//
+// Example:
+//
+//	ztype.Money{}.SchemaType() // "object", "", true
+func (m Money) SchemaType() (jsonType string, format string, nullable bool) {
+	if defaultCodec.moneyJSONCompact.Load() {
+		return "string", "", true
+	}
+	return "object", "", true
+}
+
+// AmountColumn returns the Scanner/Valuer for the minor-units column when
+// Money is stored as two columns (e.g. amount BIGINT, currency CHAR(3)).
+// A NULL amount makes the Money null.
+//
+// Example:
+//
+//	var m ztype.Money
+//	db.QueryRow("SELECT amount, currency FROM prices").Scan(m.AmountColumn(), m.CurrencyColumn())
+//	db.Exec("INSERT INTO prices VALUES ($1, $2)", m.AmountColumn(), m.CurrencyColumn())
+func (m *Money) AmountColumn() *MoneyAmount {
+	return &MoneyAmount{money: m}
+}
+
+// CurrencyColumn returns the Scanner/Valuer for the currency column when
+// Money is stored as two columns. See AmountColumn.
+//
+// Example:
+//
+//	db.QueryRow(...).Scan(m.AmountColumn(), m.CurrencyColumn())
+func (m *Money) CurrencyColumn() *MoneyCurrency {
+	return &MoneyCurrency{money: m}
+}
+
+// MoneyAmount reads and writes the minor-units column of a Money.
+type MoneyAmount struct {
+	money *Money
+}
+
+// Scan implements sql.Scanner, accepting integers and integer strings.
+//
+// Example:
+//
+//	err := m.AmountColumn().Scan(int64(1990))
+func (a *MoneyAmount) Scan(value any) error {
+	var amount int64
+	switch v := value.(type) {
+	case nil:
+		a.money.amount = 0
+		a.money.valid = false
+		return nil
+	case int64:
+		amount = v
+	case []byte:
+		parsed, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return wrapScanError("MoneyAmount", value, err)
+		}
+		amount = parsed
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return wrapScanError("MoneyAmount", value, err)
+		}
+		amount = parsed
+	default:
+		return newUnsupportedScanType(value)
+	}
+	a.money.amount = amount
+	a.money.valid = true
+	return nil
+}
+
+// Value implements driver.Valuer, returning the minor units or nil when null.
+//
+// Example:
+//
+//	val, _ := m.AmountColumn().Value() // int64(1990)
+func (a MoneyAmount) Value() (driver.Value, error) {
+	if !a.money.valid {
+		return nil, nil
+	}
+	return a.money.amount, nil
+}
+
+// MoneyCurrency reads and writes the currency column of a Money.
+type MoneyCurrency struct {
+	money *Money
+}
+
+// Scan implements sql.Scanner, accepting string and []byte currency codes.
+//
+// Example:
+//
+//	err := m.CurrencyColumn().Scan("BRL")
+func (c *MoneyCurrency) Scan(value any) error {
+	var currency string
+	switch v := value.(type) {
+	case nil:
+		c.money.currency = ""
+		return nil
+	case string:
+		currency = v
+	case []byte:
+		currency = string(v)
+	default:
+		return newUnsupportedScanType(value)
+	}
+	code, err := normalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+	c.money.currency = code
+	return nil
+}
+
+// Value implements driver.Valuer, returning the currency code or nil when null.
+//
+// Example:
+//
+//	val, _ := m.CurrencyColumn().Value() // "BRL"
+func (c MoneyCurrency) Value() (driver.Value, error) {
+	if !c.money.valid {
+		return nil, nil
+	}
+	return c.money.currency, nil
+}
@@ -0,0 +1,250 @@
+package ztype
+
+import (
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// encodeMsgpackNullable writes value, or the MessagePack nil when not valid.
+func encodeMsgpackNullable(enc *msgpack.Encoder, valid bool, value any) error {
+	if !valid {
+		return enc.EncodeNil()
+	}
+	return enc.Encode(value)
+}
+
+// The msgpack decoder resolves nil itself, without calling DecodeMsgpack, so
+// a field decoded from nil is left null but is not marked as unmarshaled.
+
+// decodeMsgpackNullable decodes the next value into target and reports
+// whether it was the MessagePack nil, in which case target is untouched.
+func decodeMsgpackNullable(dec *msgpack.Decoder, target any) (bool, error) {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return false, err
+	}
+	if code == msgpcode.Nil {
+		return true, dec.DecodeNil()
+	}
+	return false, dec.Decode(target)
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. Null is encoded as nil.
+//
+// Example:
+//
+//	data, _ := msgpack.Marshal(ztype.NewBool(true))
+func (b Bool) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return encodeMsgpackNullable(enc, b.value.Valid, b.value.Bool)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+//
+// Example:
+//
+//	var b ztype.Bool
+//	err := msgpack.Unmarshal(data, &b)
+func (b *Bool) DecodeMsgpack(dec *msgpack.Decoder) error {
+	b.unmarshaled = true
+	var value bool
+	isNull, err := decodeMsgpackNullable(dec, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		b.SetNull()
+		return nil
+	}
+	b.Set(value)
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. Null is encoded as nil.
+//
+// Example:
+//
+//	data, _ := msgpack.Marshal(ztype.NewByte(7))
+func (b Byte) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return encodeMsgpackNullable(enc, b.value.Valid, b.value.Byte)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+//
+// Example:
+//
+//	var b ztype.Byte
+//	err := msgpack.Unmarshal(data, &b)
+func (b *Byte) DecodeMsgpack(dec *msgpack.Decoder) error {
+	b.unmarshaled = true
+	var value byte
+	isNull, err := decodeMsgpackNullable(dec, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		b.SetNull()
+		return nil
+	}
+	b.Set(value)
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. Null is encoded as nil.
+//
+// Example:
+//
+//	data, _ := msgpack.Marshal(ztype.NewBytes([]byte{1, 2}))
+func (b Bytes) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if !b.valid {
+		return enc.EncodeNil()
+	}
+	return enc.EncodeBytes(append([]byte{}, b.value...))
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+//
+// Example:
+//
+//	var b ztype.Bytes
+//	err := msgpack.Unmarshal(data, &b)
+func (b *Bytes) DecodeMsgpack(dec *msgpack.Decoder) error {
+	b.unmarshaled = true
+	var value []byte
+	isNull, err := decodeMsgpackNullable(dec, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		b.SetNull()
+		return nil
+	}
+	b.Set(append([]byte{}, value...))
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. Null is encoded as nil.
+//
+// Example:
+//
+//	data, _ := msgpack.Marshal(ztype.NewString("a"))
+func (s String) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return encodeMsgpackNullable(enc, s.value.Valid, s.value.String)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+//
+// Example:
+//
+//	var s ztype.String
+//	err := msgpack.Unmarshal(data, &s)
+func (s *String) DecodeMsgpack(dec *msgpack.Decoder) error {
+	s.unmarshaled = true
+	var value string
+	isNull, err := decodeMsgpackNullable(dec, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		s.SetNull()
+		return nil
+	}
+	s.Set(value)
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. Null is encoded as nil.
+//
+// Example:
+//
+//	data, _ := msgpack.Marshal(ztype.NewNumber[uint64](math.MaxUint64))
+func (n Numeric[T]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return encodeMsgpackNullable(enc, n.value.Valid, n.value.V)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+//
+// Example:
+//
+//	var n ztype.Numeric[int64]
+//	err := msgpack.Unmarshal(data, &n)
+func (n *Numeric[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n.unmarshaled = true
+	var value T
+	isNull, err := decodeMsgpackNullable(dec, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		n.SetNull()
+		return nil
+	}
+	n.Set(value)
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. Valid values use the
+// MessagePack timestamp extension, keeping nanosecond precision.
+//
+// Example:
+//
+//	data, _ := msgpack.Marshal(ztype.NewTime(time.Now()))
+func (t Time) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if !t.value.Valid {
+		return enc.EncodeNil()
+	}
+	return enc.EncodeTime(t.value.Time)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+//
+// Example:
+//
+//	var t ztype.Time
+//	err := msgpack.Unmarshal(data, &t)
+func (t *Time) DecodeMsgpack(dec *msgpack.Decoder) error {
+	t.unmarshaled = true
+	var value time.Time
+	isNull, err := decodeMsgpackNullable(dec, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		t.SetNull()
+		return nil
+	}
+	t.Set(value)
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. Valid values are encoded
+// as int64 nanoseconds, like Value does.
+//
+// Example:
+//
+//	data, _ := msgpack.Marshal(ztype.NewDuration(time.Second))
+func (d Duration) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return encodeMsgpackNullable(enc, d.valid, int64(d.value))
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+//
+// Example:
+//
+//	var d ztype.Duration
+//	err := msgpack.Unmarshal(data, &d)
+func (d *Duration) DecodeMsgpack(dec *msgpack.Decoder) error {
+	d.unmarshaled = true
+	var value int64
+	isNull, err := decodeMsgpackNullable(dec, &value)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		d.SetNull()
+		return nil
+	}
+	d.Set(time.Duration(value))
+	return nil
+}
@@ -0,0 +1,176 @@
+package ztype
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ZeroValuer is implemented by every ztype type. ValueOrZero returns the
+// driver value to write into a NOT NULL column when the value is null.
+type ZeroValuer interface {
+	driver.Valuer
+	ValueOrZero() (driver.Value, error)
+}
+
+// NotNullColumn wraps a ztype value bound to a NOT NULL column: Value writes
+// the type's zero value (false, 0, "", the Unix epoch, {} for maps, ...)
+// instead of NULL when the wrapped value is null. Everything else, including
+// JSON, Scan and the null and unmarshaled state, goes to the wrapped value in
+// V, so the policy stays local to the field.
+//
+// Example:
+//
+//	type User struct {
+//		Name     ztype.String                     `db:"name"`
+//		Nickname ztype.NotNullColumn[ztype.String] `db:"nickname"` // NOT NULL DEFAULT ''
+//	}
+//	u.Nickname.V.SetNull()
+//	v, _ := u.Nickname.Value() // ""
+type NotNullColumn[T ZeroValuer] struct {
+	V T
+}
+
+// WithZeroOnNull wraps value so that it is written as its zero value
+// instead of NULL, e.g. for a single query argument.
+//
+// Example:
+//
+//	_, err := db.Exec("UPDATE users SET nickname = $1", ztype.WithZeroOnNull(nickname))
+func WithZeroOnNull[T ZeroValuer](value T) NotNullColumn[T] {
+	return NotNullColumn[T]{V: value}
+}
+
+// Value implements driver.Valuer, returning the zero value when null.
+//
+// Example:
+//
+//	v, _ := ztype.WithZeroOnNull(ztype.NewNullBool()).Value() // false
+func (c NotNullColumn[T]) Value() (driver.Value, error) {
+	return c.V.ValueOrZero()
+}
+
+// Scan implements sql.Scanner by scanning into the wrapped value.
+//
+// Example:
+//
+//	err := row.Scan(&u.Nickname)
+func (c *NotNullColumn[T]) Scan(value any) error {
+	scanner, ok := any(&c.V).(sql.Scanner)
+	if !ok {
+		return fmt.Errorf("%T does not implement sql.Scanner", &c.V)
+	}
+	return scanner.Scan(value)
+}
+
+// MarshalJSON implements json.Marshaler by marshaling the wrapped value, so
+// null is still written as null.
+//
+// Example:
+//
+//	data, _ := json.Marshal(u.Nickname) // null
+func (c NotNullColumn[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding into the wrapped value.
+//
+// Example:
+//
+//	err := json.Unmarshal([]byte(`"ana"`), &u.Nickname)
+func (c *NotNullColumn[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &c.V)
+}
+
+// SchemaType reports the schema of the wrapped value, which still encodes
+// null as null in JSON.
+//
+// Example:
+//
+//	ztype.NotNullColumn[ztype.String]{}.SchemaType() // "string", "", true
+func (c NotNullColumn[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	if typer, ok := any(c.V).(SchemaTyper); ok {
+		return typer.SchemaType()
+	}
+	if typer, ok := any(&c.V).(SchemaTyper); ok {
+		return typer.SchemaType()
+	}
+	return "", "", true
+}
+
+// schemaValue returns T, so JSONSchemaFor describes the wrapped value.
+func (c NotNullColumn[T]) schemaValue() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// nullable returns the Nullable view of the wrapped value.
+func (c *NotNullColumn[T]) nullable() Nullable {
+	if nullable, ok := any(&c.V).(Nullable); ok {
+		return nullable
+	}
+	return any(c.V).(Nullable)
+}
+
+// IsNull returns true if the wrapped value is null.
+//
+// Example:
+//
+//	u.Nickname.IsNull()
+func (c *NotNullColumn[T]) IsNull() bool {
+	return c.nullable().IsNull()
+}
+
+// IsEmpty reports whether the wrapped value is empty, using its IsEmpty
+// method when it has one and its Go zero value otherwise.
+//
+// Example:
+//
+//	u.Nickname.IsEmpty()
+func (c NotNullColumn[T]) IsEmpty() bool {
+	if empty, ok := any(c.V).(interface{ IsEmpty() bool }); ok {
+		return empty.IsEmpty()
+	}
+	return reflect.ValueOf(&c.V).Elem().IsZero()
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	u.Nickname.IsZero()
+func (c NotNullColumn[T]) IsZero() bool {
+	return c.IsEmpty()
+}
+
+// SetNull marks the wrapped value as null.
+//
+// Example:
+//
+//	u.Nickname.SetNull()
+func (c *NotNullColumn[T]) SetNull() {
+	c.nullable().SetNull()
+}
+
+// Unmarshaled returns true if the wrapped value was present in the decoded input.
+//
+// Example:
+//
+//	u.Nickname.Unmarshaled()
+func (c *NotNullColumn[T]) Unmarshaled() bool {
+	return c.nullable().Unmarshaled()
+}
+
+// SetUnmarshaled sets the unmarshaled state of the wrapped value.
+//
+// Example:
+//
+//	u.Nickname.SetUnmarshaled(true)
+func (c *NotNullColumn[T]) SetUnmarshaled(value bool) {
+	c.nullable().SetUnmarshaled(value)
+}
+
+// writesZeroOnNull marks NotNullColumn for BuildUpdate, which binds its
+// zero value instead of writing NULL.
+func (c *NotNullColumn[T]) writesZeroOnNull() {}
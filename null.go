@@ -0,0 +1,474 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Null is a generic nullable wrapper for arbitrary values such as small value
+// objects (an Address, a Money struct) that don't deserve a bespoke type.
+// It tracks null state and whether the value was present during unmarshaling.
+//
+// JSON is delegated to T. For SQL, Scan and Value delegate to T when it
+// implements sql.Scanner / driver.Valuer and fall back to a JSON document
+// otherwise.
+//
+// Example:
+//
+//	type Address struct{ City string }
+//	addr := ztype.New(Address{City: "Lisbon"})
+//	data, _ := json.Marshal(addr) // {"City":"Lisbon"}
+type Null[T any] struct {
+	value       T
+	valid       bool
+	unmarshaled bool
+}
+
+// New creates a new valid Null holding value.
+//
+// Example:
+//
+//	n := ztype.New(Address{City: "Lisbon"})
+func New[T any](value T) Null[T] {
+	return Null[T]{value: value, valid: true}
+}
+
+// NewNull creates a new Null that is marked as null (invalid).
+//
+// Example:
+//
+//	n := ztype.NewNull[Address]()
+func NewNull[T any]() Null[T] {
+	return Null[T]{valid: false}
+}
+
+// Get returns the wrapped value. When null, returns the zero value of T.
+//
+// Example:
+//
+//	addr := n.Get()
+func (n Null[T]) Get() T {
+	return n.value
+}
+
+// Val returns the wrapped value and whether it is valid.
+//
+// Example:
+//
+//	if addr, ok := n.Val(); ok { fmt.Println(addr.City) }
+func (n Null[T]) Val() (T, bool) {
+	return n.value, n.valid
+}
+
+// GetOr returns the wrapped value, or fallback when null.
+//
+// Example:
+//
+//	addr := n.GetOr(Address{City: "unknown"})
+func (n Null[T]) GetOr(fallback T) T {
+	if !n.valid {
+		return fallback
+	}
+	return n.value
+}
+
+// Set updates the value and marks it as valid.
+//
+// Example:
+//
+//	var n ztype.Null[Address]
+//	n.Set(Address{City: "Porto"})
+func (n *Null[T]) Set(value T) {
+	n.value = value
+	n.valid = true
+}
+
+// SetNull marks the value as null and resets it to the zero value of T.
+//
+// Example:
+//
+//	n.SetNull()
+func (n *Null[T]) SetNull() {
+	var zero T
+	n.value = zero
+	n.valid = false
+}
+
+// IsNull returns true if the value is null.
+//
+// Example:
+//
+//	if n.IsNull() { /* handle null case */ }
+func (n Null[T]) IsNull() bool {
+	return !n.valid
+}
+
+// IsEmpty returns true if the value is null or equals the zero value of T.
+//
+// Example:
+//
+//	ztype.New(Address{}).IsEmpty() // true
+func (n Null[T]) IsEmpty() bool {
+	return !n.valid || reflect.ValueOf(&n.value).Elem().IsZero()
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	ztype.New(Address{City: "Porto"}).IsZero() // false
+func (n Null[T]) IsZero() bool {
+	return n.IsEmpty()
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values. Returns false if the field was absent.
+//
+// Example:
+//
+//	fmt.Println(n.Unmarshaled())
+func (n Null[T]) Unmarshaled() bool {
+	return n.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	n.SetUnmarshaled(true)
+func (n *Null[T]) SetUnmarshaled(value bool) {
+	n.unmarshaled = value
+}
+
+// EqualValues reports whether both hold deeply equal values and the same
+// null state, ignoring the unmarshaled flag.
+//
+// Example:
+//
+//	n := ztype.New(3)
+//	fmt.Println(n.EqualValues(ztype.New(3)))  // Output: true
+func (n Null[T]) EqualValues(other Null[T]) bool {
+	return n.valid == other.valid && reflect.DeepEqual(n.value, other.value)
+}
+
+// Clone returns a copy of the Null, keeping its null and unmarshaled state.
+// The value is copied by assignment; use CloneDeep when T holds references.
+//
+// Example:
+//
+//	n := ztype.New(42)
+//	c := n.Clone()
+func (n Null[T]) Clone() Null[T] {
+	return n
+}
+
+// CloneDeep returns a copy of the Null whose value shares no maps, slices or
+// pointers with the original. The value must not contain cycles.
+//
+// Example:
+//
+//	n := ztype.New(map[string]any{"tags": []any{"a"}})
+//	c := n.CloneDeep()
+func (n Null[T]) CloneDeep() Null[T] {
+	n.value = deepCopy(n.value)
+	return n
+}
+
+// EqualFunc reports whether both values have the same null state and, when
+// valid, equal(n.Get(), other.Get()) is true.
+//
+// Example:
+//
+//	same := a.EqualFunc(b, func(x, y Address) bool { return x.City == y.City })
+func (n Null[T]) EqualFunc(other Null[T], equal func(T, T) bool) bool {
+	if n.valid != other.valid {
+		return false
+	}
+	return !n.valid || equal(n.value, other.value)
+}
+
+// MarshalJSON implements json.Marshaler, delegating to T for valid values.
+//
+// Example:
+//
+//	data, _ := json.Marshal(n)
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, delegating to T for non-null input.
+//
+// Example:
+//
+//	var n ztype.Null[Address]
+//	json.Unmarshal([]byte(`{"City":"Porto"}`), &n)
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	n.unmarshaled = true
+	return n.decode(data)
+}
+
+// decode parses a JSON document into T, treating null as a null value.
+func (n *Null[T]) decode(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.SetNull()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return wrapJSONError("Null", data, err)
+	}
+	n.value = value
+	n.valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner. SQL NULL always produces a null value.
+// Otherwise it delegates to *T when it implements sql.Scanner and accepts a
+// value that already is a T. When T is a string, []byte, bool or number
+// kind, driver values are converted the way database/sql converts them: an
+// int64 fits any integer kind within range, text parses into numbers and
+// bools, and strings and []byte convert into each other. Other T decode
+// []byte/string as JSON as a last resort. Scanned byte slices are copied,
+// since drivers reuse their buffers.
+//
+// Example:
+//
+//	var n ztype.Null[Address]
+//	err := db.QueryRow("SELECT address FROM users WHERE id = 1").Scan(&n)
+func (n *Null[T]) Scan(value any) error {
+	if value == nil {
+		n.SetNull()
+		return nil
+	}
+
+	var result T
+	if scanner, ok := any(&result).(sql.Scanner); ok {
+		if err := scanner.Scan(value); err != nil {
+			return err
+		}
+		n.value = result
+		n.valid = true
+		return nil
+	}
+
+	if v, ok := value.(T); ok {
+		if raw, ok := value.([]byte); ok {
+			v = any(bytes.Clone(raw)).(T)
+		}
+		n.value = v
+		n.valid = true
+		return nil
+	}
+	if handled, err := scanDriverPrimitive(value, reflect.ValueOf(&result).Elem()); handled {
+		if err != nil {
+			return err
+		}
+		n.value = result
+		n.valid = true
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return n.decode(v)
+	case string:
+		return n.decode([]byte(v))
+	}
+	return newUnsupportedScanType(value)
+}
+
+// scanDriverPrimitive converts the driver value into target when target has
+// a string, []byte, bool or number kind, following the conversions of
+// database/sql. It reports false for the other kinds.
+func scanDriverPrimitive(value any, target reflect.Value) (bool, error) {
+	typeName := target.Type().String()
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		text = strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		text = strconv.FormatBool(v)
+	case time.Time:
+		text = v.Format(time.RFC3339Nano)
+	default:
+		return false, nil
+	}
+
+	switch kind := target.Kind(); {
+	case kind == reflect.String:
+		target.SetString(text)
+	case kind == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8:
+		target.SetBytes([]byte(text))
+	case kind == reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return true, newInvalidFormat(typeName, text, "")
+		}
+		target.SetBool(parsed)
+	case target.CanInt() || target.CanUint() || target.CanFloat():
+		switch v := value.(type) {
+		case int64, float64:
+			return true, convertNumber(v, target)
+		case string, []byte:
+			return true, convertNumberText(text, target)
+		}
+		return true, newUnsupportedScanType(value)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// Value implements driver.Valuer. Null values are written as SQL NULL.
+// Otherwise it delegates to T when it implements driver.Valuer, passes through
+// values the driver already understands, and writes a JSON document for the rest.
+//
+// Example:
+//
+//	value, _ := n.Value()
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.valid {
+		return nil, nil
+	}
+	if valuer, ok := any(n.value).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if valuer, ok := any(&n.value).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if driver.IsValue(n.value) {
+		return n.value, nil
+	}
+	data, err := json.Marshal(n.value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// ValueOrZero is like Value, but returns the driver value of the zero T instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNull[int64]().ValueOrZero() // int64(0)
+func (n Null[T]) ValueOrZero() (driver.Value, error) {
+	if !n.valid {
+		var zero T
+		return New(zero).Value()
+	}
+	return n.Value()
+}
+
+// String returns human-readable representation.
+// Returns "<NULL>" for null values, fmt's %v formatting of T otherwise.
+//
+// Example:
+//
+//	fmt.Println(n.String())
+func (n Null[T]) String() string {
+	if !n.valid {
+		return nullToken()
+	}
+	return fmt.Sprintf("%v", n.value)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNull[int]().StringOr("-"))  // Output: -
+func (n Null[T]) StringOr(fallback string) string {
+	if !n.valid {
+		return fallback
+	}
+	return n.String()
+}
+
+// SchemaType reports the JSON type of T, marked nullable.
+//
+// Example:
+//
+//	ztype.Null[int32]{}.SchemaType() // "integer", "int32", true
+func (n Null[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	jsonType, format = schemaTypeOf[T]()
+	return jsonType, format, true
+}
+
+// schemaValue returns T, so JSONSchemaFor can describe it in full.
+func (n Null[T]) schemaValue() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// NullComparable embeds Null[T] and adds Equal for comparable T.
+//
+// Example:
+//
+//	a := ztype.NewComparable(Point{1, 2})
+//	b := ztype.NewComparable(Point{1, 2})
+//	fmt.Println(a.Equal(b)) // true
+type NullComparable[T comparable] struct {
+	Null[T]
+}
+
+// Clone returns a copy of the NullComparable, keeping its null and
+// unmarshaled state.
+//
+// Example:
+//
+//	c := a.Clone()
+func (n NullComparable[T]) Clone() NullComparable[T] {
+	return n
+}
+
+// NewComparable creates a new valid NullComparable holding value.
+//
+// Example:
+//
+//	n := ztype.NewComparable(Point{1, 2})
+func NewComparable[T comparable](value T) NullComparable[T] {
+	return NullComparable[T]{Null: New(value)}
+}
+
+// NewNullComparable creates a new NullComparable that is marked as null.
+//
+// Example:
+//
+//	n := ztype.NewNullComparable[Point]()
+func NewNullComparable[T comparable]() NullComparable[T] {
+	return NullComparable[T]{Null: NewNull[T]()}
+}
+
+// Equal performs equality check including null state.
+//
+// Example:
+//
+//	fmt.Println(a.Equal(b))
+func (n NullComparable[T]) Equal(other NullComparable[T]) bool {
+	return n.valid == other.valid && n.value == other.value
+}
+
+// EqualRaw compares the value with a raw T while ignoring null state.
+//
+// Example:
+//
+//	fmt.Println(n.EqualRaw(Point{1, 2}))
+func (n NullComparable[T]) EqualRaw(other T) bool {
+	return n.value == other
+}
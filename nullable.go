@@ -0,0 +1,136 @@
+package ztype
+
+import "reflect"
+
+// packagePath is the import path of this package, used to recognize ztype
+// types while walking values with reflection.
+var packagePath = reflect.TypeFor[Bool]().PkgPath()
+
+// Nullable is implemented by pointers to every nullable type in the package,
+// so generic utilities can inspect and reset null and presence state without
+// reflection.
+//
+// Example:
+//
+//	var fields = []ztype.Nullable{&user.Name, &user.Age, &user.Active}
+//	if ztype.AnyNull(fields...) { /* reject incomplete payload */ }
+type Nullable interface {
+	IsNull() bool
+	SetNull()
+	Unmarshaled() bool
+	SetUnmarshaled(bool)
+}
+
+var (
+	_ Nullable = (*Bool)(nil)
+	_ Nullable = (*Byte)(nil)
+	_ Nullable = (*Char)(nil)
+	_ Nullable = (*Bytes)(nil)
+	_ Nullable = (*String)(nil)
+	_ Nullable = (*Numeric[int])(nil)
+	_ Nullable = (*Time)(nil)
+	_ Nullable = (*Duration)(nil)
+	_ Nullable = (*Rune)(nil)
+	_ Nullable = (*Enum[string])(nil)
+	_ Nullable = (*IP)(nil)
+	_ Nullable = (*CIDR)(nil)
+	_ Nullable = (*RawJSON)(nil)
+	_ Nullable = (*Money)(nil)
+	_ Nullable = (*Range[int])(nil)
+	_ Nullable = (*Map[string, any])(nil)
+	_ Nullable = (*PairMap[string, any])(nil)
+	_ Nullable = (*OrderedMap[string, any])(nil)
+	_ Nullable = (*SyncMap[string, any])(nil)
+	_ Nullable = (*Slice[any])(nil)
+	_ Nullable = (*SliceComparable[int])(nil)
+	_ Nullable = (*Set[int])(nil)
+	_ Nullable = (*Null[any])(nil)
+	_ Nullable = (*NullComparable[int])(nil)
+	_ Nullable = (*Array[String])(nil)
+	_ Nullable = (*NotNullColumn[String])(nil)
+	_ Nullable = (*StringOrEmpty)(nil)
+)
+
+// emptier is implemented by every type in the package with one rule: IsEmpty
+// is true when the value is null or equals the zero value of its type, with
+// empty collections counting as zero, and IsZero is an alias for it, except
+// on Bool, where IsZero is null-only so that omitzero keeps false. Both use
+// value receivers, so `json:",omitzero"` and interface checks see them on
+// plain values; SyncMap, which is only used through a pointer, is the
+// exception.
+type emptier interface {
+	IsEmpty() bool
+	IsZero() bool
+}
+
+var (
+	_ emptier = Bool{}
+	_ emptier = Byte{}
+	_ emptier = Char{}
+	_ emptier = Bytes{}
+	_ emptier = String{}
+	_ emptier = Numeric[int]{}
+	_ emptier = Time{}
+	_ emptier = Duration{}
+	_ emptier = Rune{}
+	_ emptier = Enum[string]{}
+	_ emptier = IP{}
+	_ emptier = CIDR{}
+	_ emptier = RawJSON{}
+	_ emptier = Money{}
+	_ emptier = Range[int]{}
+	_ emptier = Map[string, any]{}
+	_ emptier = PairMap[string, any]{}
+	_ emptier = MapComparable[string, int]{}
+	_ emptier = OrderedMap[string, any]{}
+	_ emptier = (*SyncMap[string, any])(nil)
+	_ emptier = Slice[any]{}
+	_ emptier = SliceComparable[int]{}
+	_ emptier = Set[int]{}
+	_ emptier = Null[any]{}
+	_ emptier = NullComparable[int]{}
+	_ emptier = Array[String]{}
+	_ emptier = NotNullColumn[String]{}
+)
+
+// AnyNull returns true if at least one of the values is null.
+// Returns false when no values are given.
+//
+// Example:
+//
+//	ztype.AnyNull(&name, &age) // true if name or age is null
+func AnyNull(values ...Nullable) bool {
+	for _, value := range values {
+		if value.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// AllNull returns true if every value is null.
+// Returns true when no values are given.
+//
+// Example:
+//
+//	ztype.AllNull(&name, &age) // true only if both are null
+func AllNull(values ...Nullable) bool {
+	for _, value := range values {
+		if !value.IsNull() {
+			return false
+		}
+	}
+	return true
+}
+
+// SetAllUnmarshaled sets the unmarshaled state of every value, e.g. to mark
+// all fields of a record loaded from the database as present.
+//
+// Example:
+//
+//	ztype.SetAllUnmarshaled(true, &user.Name, &user.Age)
+func SetAllUnmarshaled(unmarshaled bool, values ...Nullable) {
+	for _, value := range values {
+		value.SetUnmarshaled(unmarshaled)
+	}
+}
@@ -25,12 +25,33 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"strconv"
 )
 
+// SetNumberFormatter sets a function that Numeric.String uses to format
+// valid values, e.g. to use a locale's decimal separator in display
+// contexts. It receives the underlying value, such as an int64 or a
+// float64. Passing nil restores the default formatting. JSON, text and SQL
+// encodings are not affected.
+//
+// Example:
+//
+//	ztype.SetNumberFormatter(func(v any) string {
+//		return strings.ReplaceAll(fmt.Sprintf("%.2f", v), ".", ",")
+//	})
+//	fmt.Println(ztype.NewNumber(1.5))  // Output: 1,50
+func SetNumberFormatter(format func(any) string) {
+	if format == nil {
+		defaultCodec.numberFormatter.Store(nil)
+		return
+	}
+	defaultCodec.numberFormatter.Store(&format)
+}
+
 type NumberType interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
@@ -87,6 +108,16 @@ func (n *Numeric[T]) Get() T {
 	return n.value.V
 }
 
+// Val returns the number and whether it is valid, so a null is not read as
+// zero by mistake.
+//
+// Example:
+//
+//	if qty, ok := n.Val(); ok { total += qty }
+func (n Numeric[T]) Val() (T, bool) {
+	return n.value.V, n.value.Valid
+}
+
 // Set updates the value and marks it as valid.
 //
 // Example:
@@ -122,6 +153,24 @@ func (n Numeric[T]) IsNull() bool {
 	return !n.value.Valid
 }
 
+// IsEmpty returns true if the value is null or 0.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNumber(0).IsEmpty())  // Output: true
+func (n Numeric[T]) IsEmpty() bool {
+	return !n.value.Valid || n.value.V == 0
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNumber(1.5).IsZero())  // Output: false
+func (n Numeric[T]) IsZero() bool {
+	return n.IsEmpty()
+}
+
 // Unmarshaled indicates if the value was set through unmarshaling.
 // Used for tracking partial updates in data structures.
 func (n Numeric[T]) Unmarshaled() bool {
@@ -145,6 +194,27 @@ func (n Numeric[T]) Equal(other Numeric[T]) bool {
 	return n.value.Valid == other.value.Valid && n.value.V == other.value.V
 }
 
+// EqualValues is Equal under the name shared by every type: it compares
+// value and null state and ignores the unmarshaled flag.
+//
+// Example:
+//
+//	n := ztype.NewNumber(42)
+//	fmt.Println(n.EqualValues(ztype.NewNumber(42)))  // Output: true
+func (n Numeric[T]) EqualValues(other Numeric[T]) bool {
+	return n.Equal(other)
+}
+
+// Clone returns a copy of the Numeric, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	n := ztype.NewNumber(42)
+//	c := n.Clone()
+func (n Numeric[T]) Clone() Numeric[T] {
+	return n
+}
+
 // EqualRaw compares the Numeric value with a raw value.
 // Always returns false if the Numeric is null.
 //
@@ -243,8 +313,9 @@ func (n Numeric[T]) MultRaw(other T) T {
 	return n.value.V * other
 }
 
-// Div performs division. Panics on division by zero or null values.
-// Use SafeDiv for error handling version.
+// Div performs division. Panics on division by zero or null values with an
+// *ErrPanic wrapping ErrDivisionByZero. Use SafeDiv for the error handling
+// version, or DivOr for a fallback.
 //
 // Example:
 //
@@ -255,7 +326,7 @@ func (n Numeric[T]) MultRaw(other T) T {
 func (n Numeric[T]) Div(other Numeric[T]) Numeric[T] {
 	value, err := n.SafeDiv(other)
 	if err != nil {
-		panic(err)
+		panic(&ErrPanic{Op: "Numeric.Div", Err: err})
 	}
 	return value
 }
@@ -268,15 +339,31 @@ func (n Numeric[T]) Div(other Numeric[T]) Numeric[T] {
 //	a := NewNumber(20)
 //	b := NewNumber(0)
 //	_, err := a.SafeDiv(b)
-//	fmt.Println(err) // Output: cannot divide by zero
+//	errors.Is(err, ztype.ErrDivisionByZero) // true
 func (n Numeric[T]) SafeDiv(other Numeric[T]) (Numeric[T], error) {
 	if !other.value.Valid || other.value.V == 0 {
-		return NewNullNumber[T](), fmt.Errorf("cannot divide by zero")
+		return NewNullNumber[T](), ErrDivisionByZero
 	}
 	return NewNumber(n.value.V / other.value.V), nil
 }
 
-// DivRaw divides by a raw value. Panics on division by zero.
+// DivOr performs division like Div, returning a valid Numeric holding
+// fallback instead of panicking when other is zero or null.
+//
+// Example:
+//
+//	a := NewNumber(20)
+//	fmt.Println(a.DivOr(NewNumber(0), -1).Get()) // Output: -1
+func (n Numeric[T]) DivOr(other Numeric[T], fallback T) Numeric[T] {
+	value, err := n.SafeDiv(other)
+	if err != nil {
+		return NewNumber(fallback)
+	}
+	return value
+}
+
+// DivRaw divides by a raw value. Panics on division by zero with an
+// *ErrPanic wrapping ErrDivisionByZero.
 //
 // Example:
 //
@@ -285,7 +372,7 @@ func (n Numeric[T]) SafeDiv(other Numeric[T]) (Numeric[T], error) {
 func (n Numeric[T]) DivRaw(other T) T {
 	value, err := n.SafeDivRaw(other)
 	if err != nil {
-		panic(err)
+		panic(&ErrPanic{Op: "Numeric.DivRaw", Err: err})
 	}
 	return value
 }
@@ -296,14 +383,28 @@ func (n Numeric[T]) DivRaw(other T) T {
 //
 //	n := NewNumber(20)
 //	result, err := n.SafeDivRaw(0)
-//	fmt.Println(err) // Output: cannot divide by zero
+//	errors.Is(err, ztype.ErrDivisionByZero) // true
 func (n Numeric[T]) SafeDivRaw(other T) (T, error) {
 	if other == 0 {
-		return 0, fmt.Errorf("cannot divide by zero")
+		return 0, ErrDivisionByZero
 	}
 	return n.value.V / other, nil
 }
 
+// DivRawOr divides by a raw value like DivRaw, returning fallback instead of
+// panicking when other is zero.
+//
+// Example:
+//
+//	n := NewNumber(20)
+//	fmt.Println(n.DivRawOr(0, -1)) // Output: -1
+func (n Numeric[T]) DivRawOr(other T, fallback T) T {
+	if other == 0 {
+		return fallback
+	}
+	return n.value.V / other
+}
+
 // Compare compares two Numeric values. Returns:
 // -1 if n < other
 //
@@ -320,7 +421,7 @@ func (n Numeric[T]) SafeDivRaw(other T) (T, error) {
 //	fmt.Println(result) // Output: -1
 func (n Numeric[T]) Compare(other Numeric[T]) (int, error) {
 	if !n.value.Valid || !other.value.Valid {
-		return 0, fmt.Errorf("cannot compare null values")
+		return 0, fmt.Errorf("cannot compare %w", ErrNullValue)
 	}
 	if n.value.V < other.value.V {
 		return -1, nil
@@ -339,7 +440,7 @@ func (n Numeric[T]) Compare(other Numeric[T]) (int, error) {
 //	fmt.Println(result) // Output: 1
 func (n Numeric[T]) CompareRaw(other T) (int, error) {
 	if !n.value.Valid {
-		return 0, fmt.Errorf("cannot compare null values")
+		return 0, fmt.Errorf("cannot compare %w", ErrNullValue)
 	}
 	if n.value.V < other {
 		return -1, nil
@@ -457,7 +558,8 @@ func (n Numeric[T]) LessOrEqualRaw(other T) bool {
 	return n.value.V <= other
 }
 
-// Min returns the smaller of two Numeric values. Treats null as negative infinity.
+// Min returns the smaller of two Numeric values. A null operand is ignored,
+// so the result is the other one, and it is null only when both are null.
 //
 // Example:
 //
@@ -480,7 +582,8 @@ func (n Numeric[T]) Min(other Numeric[T]) Numeric[T] {
 	return other
 }
 
-// MinRaw returns the smaller of the Numeric value and a raw value.
+// MinRaw returns the smaller of the Numeric value and a raw value. A null
+// Numeric is ignored like in Min, so the raw value is returned.
 //
 // Example:
 //
@@ -496,7 +599,8 @@ func (n Numeric[T]) MinRaw(other T) T {
 	return other
 }
 
-// Max returns the larger of two Numeric values. Treats null as positive infinity.
+// Max returns the larger of two Numeric values. A null operand is ignored,
+// so the result is the other one, and it is null only when both are null.
 //
 // Example:
 //
@@ -519,7 +623,8 @@ func (n Numeric[T]) Max(other Numeric[T]) Numeric[T] {
 	return other
 }
 
-// MaxRaw returns the larger of the Numeric value and a raw value.
+// MaxRaw returns the larger of the Numeric value and a raw value. A null
+// Numeric is ignored like in Max, so the raw value is returned.
 //
 // Example:
 //
@@ -535,21 +640,24 @@ func (n Numeric[T]) MaxRaw(other T) T {
 	return other
 }
 
-// MarshalText implements encoding.TextMarshaler.
+// MarshalText implements encoding.TextMarshaler, writing the number in the
+// syntax UnmarshalText reads. Unlike String it ignores SetNumberFormatter and
+// any String method of T, so named types round-trip.
 //
 // Example:
 //
 //	n := NewNumber(123.456)
 //	data, _ := n.MarshalText()
-//	fmt.Println(string(data)) // Output: 123.456000
-func (n *Numeric[T]) MarshalText() ([]byte, error) {
+//	fmt.Println(string(data)) // Output: 123.456
+func (n Numeric[T]) MarshalText() ([]byte, error) {
 	if n.value.Valid {
-		return []byte(n.String()), nil
+		return appendNumberText(nil, n.value.V), nil
 	}
 	return nil, nil
 }
 
-// UnmarshalText implements encoding.TextUnmarshaler.
+// UnmarshalText implements encoding.TextUnmarshaler. With
+// SetNumberTextLenient enabled it also reads display formatted numbers.
 //
 // Example:
 //
@@ -557,40 +665,49 @@ func (n *Numeric[T]) MarshalText() ([]byte, error) {
 //	n.UnmarshalText([]byte("123.45"))
 //	fmt.Println(n.Get()) // Output: 123.45
 func (n *Numeric[T]) UnmarshalText(data []byte) error {
+	return n.unmarshalTextCodec(&defaultCodec, data)
+}
+
+// unmarshalTextCodec implements UnmarshalText with the lenient parsing
+// options of c.
+func (n *Numeric[T]) unmarshalTextCodec(c *Codec, data []byte) error {
 	n.unmarshaled = true
 	if len(data) == 0 {
 		n.value.Valid = false
 		return nil
 	}
-
-	var value T
-	var kind reflect.Kind = reflect.TypeOf(value).Kind()
-	switch kind {
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		parsed, err := parseUint[T](data, kind)
+	if options := c.numberTextLenient.Load(); options != nil {
+		parsed, err := parseNumberLenient[T](string(data), *options)
 		if err != nil {
 			return err
 		}
-		value = parsed
-	case reflect.Float32, reflect.Float64:
-		parsed, err := parseFloat[T](data, kind)
-		if err != nil {
-			return err
-		}
-		value = parsed
-	default:
-		parsed, err := parseInt[T](data, kind)
-		if err != nil {
-			return err
-		}
-		value = T(parsed)
+		n.value = parsed.value
+		return nil
 	}
 
+	value, err := parseNumberText[T](data)
+	if err != nil {
+		return err
+	}
 	n.value.V = value
 	n.value.Valid = true
 	return nil
 }
 
+// parseNumberText parses data in the strict syntax of strconv.
+func parseNumberText[T NumberType](data []byte) (T, error) {
+	var value T
+	kind := reflect.TypeOf(value).Kind()
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return parseUint[T](data, kind)
+	case reflect.Float32, reflect.Float64:
+		return parseFloat[T](data, kind)
+	}
+	parsed, err := parseInt[T](data, kind)
+	return T(parsed), err
+}
+
 // MarshalJSON implements json.Marshaler.
 //
 // Example:
@@ -598,11 +715,21 @@ func (n *Numeric[T]) UnmarshalText(data []byte) error {
 //	n := NewNumber(3.14)
 //	j, _ := json.Marshal(n)
 //	fmt.Println(string(j)) // Output: 3.14
-func (n *Numeric[T]) MarshalJSON() ([]byte, error) {
-	if n.value.Valid {
-		return json.Marshal(n.value.V)
+func (n Numeric[T]) MarshalJSON() ([]byte, error) {
+	return n.AppendJSON(make([]byte, 0, 24))
+}
+
+// AppendJSON appends the JSON encoding of the Numeric to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewNumber(1.5).AppendJSON(buf) // 1.5
+func (n Numeric[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if !n.value.Valid {
+		return append(dst, "null"...), nil
 	}
-	return []byte("null"), nil
+	return appendJSONNumber(dst, n.value.V)
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -623,8 +750,7 @@ func (n *Numeric[T]) UnmarshalJSON(data []byte) error {
 
 	var value T
 	if err := json.Unmarshal(data, &value); err != nil {
-		n.value.Valid = false
-		return err
+		return wrapJSONError(numericTypeName[T](), data, err)
 	}
 
 	n.value.Valid = true
@@ -633,43 +759,246 @@ func (n *Numeric[T]) UnmarshalJSON(data []byte) error {
 }
 
 // Scan implements sql.Scanner for database operations.
+// A bool, as some drivers return BOOLEAN columns, scans as 1 or 0; see the
+// package documentation for the full list of coercions. A float32 Numeric
+// reads float64 values and decimal text rounded to float32, and returns
+// *ErrOverflow for values beyond its range instead of storing an infinity.
 //
 // Example:
 //
 //	var n Numeric[float64]
 //	db.QueryRow("SELECT price FROM products").Scan(&n)
 func (n *Numeric[T]) Scan(value any) error {
-	return n.value.Scan(value)
+	if flag, ok := value.(bool); ok {
+		n.Set(boolNumber[T](flag))
+		return nil
+	}
+	if reflect.TypeFor[T]().Kind() == reflect.Float32 {
+		if scanned, ok, err := scanFloat32[T](value); ok {
+			if err != nil {
+				return err
+			}
+			n.Set(scanned)
+			return nil
+		}
+	}
+	scanned := n.value
+	if err := scanned.Scan(value); err != nil {
+		return wrapScanError(numericTypeName[T](), value, err)
+	}
+	n.value = scanned
+	return nil
+}
+
+// scanFloat32 converts the float and decimal text sources of a float32
+// Numeric, checking the float32 range itself rather than relying on the
+// conversions of database/sql. ok is false for the other sources.
+func scanFloat32[T NumberType](value any) (scanned T, ok bool, err error) {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		if math.Abs(v) > math.MaxFloat32 && !math.IsInf(v, 0) {
+			return 0, true, &ErrOverflow{Type: numericTypeName[T](), Value: strconv.FormatFloat(v, 'g', -1, 64)}
+		}
+		f = v
+	case float32:
+		f = float64(v)
+	case string:
+		if f, err = strconv.ParseFloat(v, 32); err != nil {
+			return 0, true, numericParseError[T]([]byte(v), err)
+		}
+	case []byte:
+		if f, err = strconv.ParseFloat(string(v), 32); err != nil {
+			return 0, true, numericParseError[T](v, err)
+		}
+	default:
+		return 0, false, nil
+	}
+	return T(f), true, nil
 }
 
-// Value implements driver.Valuer for database operations.
+// boolNumber returns 1 for true and 0 for false.
+func boolNumber[T NumberType](flag bool) T {
+	if flag {
+		return 1
+	}
+	return 0
+}
+
+// Value implements driver.Valuer for database operations. Floats, float32
+// included, are returned as float64, which holds every float32 exactly, so
+// Scan reads the value back unchanged.
 //
 // Example:
 //
 //	n := NewNumber(42)
 //	val, _ := n.Value()
-//	fmt.Printf("%T", val) // Output: int
+//	fmt.Printf("%T", val) // Output: int64
 func (n Numeric[T]) Value() (driver.Value, error) {
 	return n.value.Value()
 }
 
-// String returns a human-readable representation.
+// ValueOrZero is like Value, but returns 0 instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullNumber[float64]().ValueOrZero() // float64(0)
+func (n Numeric[T]) ValueOrZero() (driver.Value, error) {
+	if !n.value.Valid {
+		return NewNumber(T(0)).Value()
+	}
+	return n.Value()
+}
+
+// String returns a human-readable representation. Floats use the shortest
+// text that parses back to the same value at their own precision. A T with
+// its own String method, such as a named Cents type, is formatted by fmt,
+// which calls that method.
 //
 // Example:
 //
 //	n := NewNumber(123.456)
-//	fmt.Println(n.String()) // Output: 123.456000
-func (n *Numeric[T]) String() string {
+//	fmt.Println(n.String()) // Output: 123.456
+func (n Numeric[T]) String() string {
+	return n.stringCodec(&defaultCodec)
+}
+
+// stringCodec implements String with the null token and number formatter
+// of c.
+func (n Numeric[T]) stringCodec(c *Codec) string {
 	if !n.value.Valid {
-		return "<NULL>"
+		return c.nullTokenText()
+	}
+	if format := c.numberFormatter.Load(); format != nil {
+		return (*format)(underlyingNumber(n.value.V))
+	}
+	if reflect.TypeFor[T]().NumMethod() > 0 {
+		return fmt.Sprint(n.value.V)
 	}
+	return string(appendNumberText(nil, n.value.V))
+}
 
-	switch value := any(n.value.V).(type) {
-	case float32, float64:
-		return fmt.Sprintf("%f", value)
-	default:
-		return fmt.Sprintf("%v", value)
+// appendNumberText appends value in the strict syntax parseNumberText reads,
+// chosen by the kind of T so named types such as `type Cents int64` are
+// written as their underlying number. Floats use the shortest text that
+// parses back to the same value at their own precision.
+func appendNumberText[T NumberType](dst []byte, value T) []byte {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(dst, int64(value), 10)
+	case reflect.Float32:
+		return strconv.AppendFloat(dst, float64(value), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.AppendFloat(dst, float64(value), 'g', -1, 64)
 	}
+	return strconv.AppendUint(dst, uint64(value), 10)
+}
+
+// underlyingNumber converts a value of a named number type to its
+// predeclared underlying type, so a formatter switching on int64 or float64
+// sees it.
+func underlyingNumber[T NumberType](value T) any {
+	t := reflect.TypeFor[T]()
+	if t.PkgPath() == "" {
+		return value
+	}
+	switch t.Kind() {
+	case reflect.Int:
+		return int(value)
+	case reflect.Int8:
+		return int8(value)
+	case reflect.Int16:
+		return int16(value)
+	case reflect.Int32:
+		return int32(value)
+	case reflect.Int64:
+		return int64(value)
+	case reflect.Uint:
+		return uint(value)
+	case reflect.Uint8:
+		return uint8(value)
+	case reflect.Uint16:
+		return uint16(value)
+	case reflect.Uint32:
+		return uint32(value)
+	case reflect.Uint64:
+		return uint64(value)
+	case reflect.Uintptr:
+		return uintptr(value)
+	case reflect.Float32:
+		return float32(value)
+	}
+	return float64(value)
+}
+
+// AppendString appends the String output to dst. Plain numbers are
+// appended without allocating when dst has enough capacity; a formatter set
+// with SetNumberFormatter, or a T with methods such as String that fmt may
+// call, goes through String.
+//
+// Example:
+//
+//	buf = ztype.NewNumber(1.5).AppendString(buf[:0]) // "1.5"
+func (n Numeric[T]) AppendString(dst []byte) []byte {
+	if !n.value.Valid {
+		return append(dst, nullToken()...)
+	}
+	if defaultCodec.numberFormatter.Load() != nil || reflect.TypeFor[T]().NumMethod() > 0 {
+		return append(dst, n.String()...)
+	}
+	return appendNumberText(dst, n.value.V)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullNumber[int]().StringOr("-"))  // Output: -
+func (n Numeric[T]) StringOr(fallback string) string {
+	if !n.value.Valid {
+		return fallback
+	}
+	return n.String()
+}
+
+// SchemaType reports integer instantiations as "integer" and float ones as
+// "number", with the matching OpenAPI format.
+//
+// Example:
+//
+//	ztype.Numeric[int64]{}.SchemaType()   // "integer", "int64", true
+//	ztype.Numeric[float32]{}.SchemaType() // "number", "float", true
+func (n Numeric[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	jsonType, format = schemaNumberType[T]()
+	return jsonType, format, true
+}
+
+// Format implements fmt.Formatter. Numeric verbs such as %d, %x or %.2f and
+// their flags apply to the underlying value; %v and %s print String and null
+// prints "<NULL>" for every verb.
+//
+// Example:
+//
+//	fmt.Printf("%05d", ztype.NewNumber(42))            // Output: 00042
+//	fmt.Printf("%.1f", ztype.NewNullNumber[float64]()) // Output: <NULL>
+func (n Numeric[T]) Format(f fmt.State, verb rune) {
+	formatNullable(f, verb, n.value.Valid, n.String(), n.value.V)
+}
+
+// numericTypeName returns the name of Numeric[T] used in errors.
+func numericTypeName[T NumberType]() string {
+	return "Numeric[" + reflect.TypeFor[T]().String() + "]"
+}
+
+// numericParseError converts a strconv error for data into *ErrOverflow or
+// *ErrInvalidFormat.
+func numericParseError[T NumberType](data []byte, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
+	}
+	return &ErrInvalidFormat{Type: numericTypeName[T](), Input: string(data)}
 }
 
 // parseFloat converts byte data to float types with overflow checking.
@@ -678,13 +1007,13 @@ func parseFloat[T NumberType](
 	kind reflect.Kind,
 ) (T, error) {
 	var zero T
-	parsed, err := strconv.ParseFloat(string(data), 64)
-	if err != nil {
-		return zero, err
+	bitSize := 64
+	if kind == reflect.Float32 {
+		bitSize = 32
 	}
-
-	if kind == reflect.Float32 && (parsed > math.MaxFloat32 || parsed < -math.MaxFloat32) {
-		return zero, fmt.Errorf("value %f overflows float32", parsed)
+	parsed, err := strconv.ParseFloat(string(data), bitSize)
+	if err != nil {
+		return zero, numericParseError[T](data, err)
 	}
 	return T(parsed), nil
 }
@@ -697,25 +1026,25 @@ func parseUint[T NumberType](
 	var zero T
 	parsed, err := strconv.ParseUint(string(data), 10, 64)
 	if err != nil {
-		return zero, err
+		return zero, numericParseError[T](data, err)
 	}
 
 	switch kind {
 	case reflect.Uint:
 		if parsed > math.MaxUint {
-			return zero, fmt.Errorf("value %d overflows uint", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	case reflect.Uint8:
 		if parsed > math.MaxUint8 {
-			return zero, fmt.Errorf("value %d overflows uint8", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	case reflect.Uint16:
 		if parsed > math.MaxUint16 {
-			return zero, fmt.Errorf("value %d overflows uint16", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	case reflect.Uint32:
 		if parsed > math.MaxUint32 {
-			return zero, fmt.Errorf("value %d overflows uint32", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	}
 
@@ -730,25 +1059,25 @@ func parseInt[T NumberType](
 	var zero T
 	parsed, err := strconv.ParseInt(string(data), 10, 64)
 	if err != nil {
-		return zero, err
+		return zero, numericParseError[T](data, err)
 	}
 
 	switch kind {
 	case reflect.Int:
 		if parsed > math.MaxInt || parsed < math.MinInt {
-			return zero, fmt.Errorf("value %d overflows int", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	case reflect.Int8:
 		if parsed > math.MaxInt8 || parsed < math.MinInt8 {
-			return zero, fmt.Errorf("value %d overflows int8", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	case reflect.Int16:
 		if parsed > math.MaxInt16 || parsed < math.MinInt16 {
-			return zero, fmt.Errorf("value %d overflows int16", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	case reflect.Int32:
 		if parsed > math.MaxInt32 || parsed < math.MinInt32 {
-			return zero, fmt.Errorf("value %d overflows int32", parsed)
+			return zero, &ErrOverflow{Type: numericTypeName[T](), Value: string(data)}
 		}
 	}
 
@@ -0,0 +1,167 @@
+package ztype
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// MarshalOmitNull encodes a struct like json.Marshal, but leaves out every
+// ztype field that is null instead of writing an explicit null. Valid zero
+// values such as false, 0 or "" are kept. Embedded structs are flattened and
+// nested struct fields are encoded the same way; other values, including
+// slices and maps, are encoded by encoding/json. The omitempty and omitzero
+// options keep working for the remaining fields.
+//
+// Example:
+//
+//	type User struct {
+//		Name   ztype.String `json:"name"`
+//		Email  ztype.String `json:"email"`
+//		Active ztype.Bool   `json:"active"`
+//	}
+//	data, _ := ztype.MarshalOmitNull(User{Name: ztype.NewString("Ana"), Active: ztype.NewBool(false)})
+//	fmt.Println(string(data)) // Output: {"name":"Ana","active":false}
+func MarshalOmitNull(v any) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct || implementsMarshaler(value.Type()) {
+		return json.Marshal(v)
+	}
+	if !value.CanAddr() {
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		value = addressable
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	if _, err := appendOmitNullFields(&buf, value, true); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// appendOmitNullFields writes the fields of the addressable struct value as
+// JSON object members and reports whether the object is still empty.
+func appendOmitNullFields(buf *bytes.Buffer, value reflect.Value, first bool) (bool, error) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		fieldValue := value.Field(i)
+
+		if field.Anonymous && name == "" {
+			for fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct && !implementsMarshaler(fieldValue.Type()) {
+				var err error
+				if first, err = appendOmitNullFields(buf, fieldValue, first); err != nil {
+					return first, err
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if nullable, ok := fieldValue.Addr().Interface().(Nullable); ok && nullable.IsNull() {
+			continue
+		}
+		if hasJSONOption(options, "omitempty") && isEmptyJSONValue(fieldValue) {
+			continue
+		}
+		if hasJSONOption(options, "omitzero") && isZeroJSONValue(fieldValue) {
+			continue
+		}
+
+		var data []byte
+		var err error
+		nested := fieldValue
+		for nested.Kind() == reflect.Pointer && !nested.IsNil() {
+			nested = nested.Elem()
+		}
+		if nested.Kind() == reflect.Struct && !implementsMarshaler(nested.Type()) {
+			data, err = MarshalOmitNull(nested.Addr().Interface())
+		} else {
+			data, err = json.Marshal(fieldValue.Addr().Interface())
+		}
+		if err != nil {
+			return first, err
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(data)
+	}
+	return first, nil
+}
+
+// implementsMarshaler reports whether encoding/json encodes values of t
+// through a custom marshaler rather than field by field.
+func implementsMarshaler(t reflect.Type) bool {
+	pointer := reflect.PointerTo(t)
+	return pointer.Implements(jsonMarshalerType) || pointer.Implements(textMarshalerType)
+}
+
+// hasJSONOption reports whether the comma-separated tag options contain option.
+func hasJSONOption(options, option string) bool {
+	for options != "" {
+		var current string
+		current, options, _ = strings.Cut(options, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyJSONValue mirrors the omitempty rules of encoding/json.
+func isEmptyJSONValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return value.IsZero()
+	}
+	return false
+}
+
+// isZeroJSONValue mirrors the omitzero rules of encoding/json, preferring an
+// IsZero method when the addressable value has one.
+func isZeroJSONValue(value reflect.Value) bool {
+	if zeroer, ok := value.Addr().Interface().(interface{ IsZero() bool }); ok {
+		return zeroer.IsZero()
+	}
+	return value.IsZero()
+}
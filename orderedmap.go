@@ -0,0 +1,446 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// OrderedMap is a nullable map that remembers insertion order. JSON decoding
+// keeps the key order of the source document and JSON encoding emits keys in
+// that order, which is required when a third party hashes the raw body.
+//
+// Only the top-level order is tracked: nested objects keep their order when V
+// preserves it as well (e.g. json.RawMessage or another OrderedMap).
+//
+// Example:
+//
+//	var m OrderedMap[string, any]
+//	json.Unmarshal([]byte(`{"z":1,"a":2}`), &m)
+//	data, _ := json.Marshal(m) // {"z":1,"a":2}
+type OrderedMap[K comparable, V any] struct {
+	keys        []K
+	value       map[K]V
+	valid       bool
+	unmarshaled bool
+}
+
+// NewOrderedMap creates a new valid, empty OrderedMap.
+//
+// Example:
+//
+//	m := NewOrderedMap[string, int]()
+//	m.SetItem("b", 2)
+//	m.SetItem("a", 1) // iteration order: b, a
+func NewOrderedMap[K comparable, V any]() OrderedMap[K, V] {
+	return OrderedMap[K, V]{value: map[K]V{}, valid: true}
+}
+
+// NewNullOrderedMap creates a new OrderedMap that is marked as null (invalid).
+//
+// Example:
+//
+//	m := NewNullOrderedMap[string, int]()
+func NewNullOrderedMap[K comparable, V any]() OrderedMap[K, V] {
+	return OrderedMap[K, V]{valid: false}
+}
+
+// GetItem returns the value associated with the given key, and a boolean indicating existence.
+//
+// Example:
+//
+//	val, ok := m.GetItem("a")
+func (m OrderedMap[K, V]) GetItem(key K) (V, bool) {
+	item, ok := m.value[key]
+	return item, ok
+}
+
+// SetItem sets the value for the given key and marks the OrderedMap as valid.
+// New keys are appended; existing keys keep their position.
+//
+// Example:
+//
+//	var m OrderedMap[string, int]
+//	m.SetItem("a", 42)
+func (m *OrderedMap[K, V]) SetItem(key K, value V) {
+	if m.value == nil {
+		m.value = map[K]V{}
+	}
+	if _, ok := m.value[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.value[key] = value
+	m.valid = true
+}
+
+// DeleteItem removes the item with the given key and returns its value and true,
+// or zero value and false if key does not exist.
+//
+// Example:
+//
+//	val, ok := m.DeleteItem("a")
+func (m *OrderedMap[K, V]) DeleteItem(key K) (V, bool) {
+	item, ok := m.value[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	delete(m.value, key)
+	m.keys = slices.DeleteFunc(m.keys, func(k K) bool { return k == key })
+	return item, true
+}
+
+// Has returns true if the key exists in the OrderedMap and the OrderedMap is valid.
+//
+// Example:
+//
+//	if m.Has("a") { /* ... */ }
+func (m OrderedMap[K, V]) Has(key K) bool {
+	if !m.valid {
+		return false
+	}
+	_, ok := m.value[key]
+	return ok
+}
+
+// Len returns the number of items. Null OrderedMaps always report 0.
+//
+// Example:
+//
+//	fmt.Println(m.Len())
+func (m OrderedMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns a sequence of all keys in insertion order.
+//
+// Example:
+//
+//	for key := range m.Keys() { fmt.Println(key) }
+func (m OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return slices.Values(m.keys)
+}
+
+// Values returns a sequence of all values in insertion order.
+//
+// Example:
+//
+//	for value := range m.Values() { fmt.Println(value) }
+func (m OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, key := range m.keys {
+			if !yield(m.value[key]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence of all key-value pairs in insertion order.
+//
+// Example:
+//
+//	for key, value := range m.All() { fmt.Println(key, value) }
+func (m OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range m.keys {
+			if !yield(key, m.value[key]) {
+				return
+			}
+		}
+	}
+}
+
+// SetNull marks the OrderedMap as null and clears its content.
+//
+// Example:
+//
+//	m.SetNull()
+func (m *OrderedMap[K, V]) SetNull() {
+	m.keys = nil
+	m.value = map[K]V{}
+	m.valid = false
+}
+
+// IsNull returns true if the OrderedMap is null (invalid).
+//
+// Example:
+//
+//	if m.IsNull() { /* ... */ }
+func (m OrderedMap[K, V]) IsNull() bool {
+	return !m.valid
+}
+
+// IsZero returns true if the OrderedMap holds no items, which includes null
+// OrderedMaps.
+//
+// Example:
+//
+//	fmt.Println(NewOrderedMap[string, int]().IsZero()) // true
+func (m OrderedMap[K, V]) IsZero() bool {
+	return len(m.keys) == 0
+}
+
+// IsEmpty is an alias for IsZero.
+//
+// Example:
+//
+//	fmt.Println(NewNullOrderedMap[string, int]().IsEmpty()) // true
+func (m OrderedMap[K, V]) IsEmpty() bool {
+	return m.IsZero()
+}
+
+// Unmarshaled returns true if the OrderedMap has been unmarshaled from JSON.
+//
+// Example:
+//
+//	fmt.Println(m.Unmarshaled())
+func (m OrderedMap[K, V]) Unmarshaled() bool {
+	return m.unmarshaled
+}
+
+// SetUnmarshaled sets the unmarshaled flag.
+//
+// Example:
+//
+//	m.SetUnmarshaled(true)
+func (m *OrderedMap[K, V]) SetUnmarshaled(value bool) {
+	m.unmarshaled = value
+}
+
+// EqualValues reports whether both hold deeply equal values and the same
+// null state, ignoring the unmarshaled flag. Two null OrderedMaps are equal.
+//
+// Example:
+//
+//	a.EqualValues(b) // true when keys, order and values match
+func (m OrderedMap[K, V]) EqualValues(other OrderedMap[K, V]) bool {
+	if m.valid != other.valid {
+		return false
+	}
+	return !m.valid || slices.Equal(m.keys, other.keys) && deepEqualMaps(m.value, other.value)
+}
+
+// Clone returns a copy of the OrderedMap with its own keys and map, keeping
+// its null and unmarshaled state. Values are copied by assignment; use
+// CloneDeep when V holds references.
+//
+// Example:
+//
+//	c := m.Clone()
+//	c.SetItem("new", 1) // m is unchanged
+func (m OrderedMap[K, V]) Clone() OrderedMap[K, V] {
+	m.keys = slices.Clone(m.keys)
+	m.value = maps.Clone(m.value)
+	return m
+}
+
+// CloneDeep returns a copy of the OrderedMap whose keys and values share no
+// maps, slices or pointers with the original. Values must not contain cycles.
+//
+// Example:
+//
+//	c := m.CloneDeep()
+func (m OrderedMap[K, V]) CloneDeep() OrderedMap[K, V] {
+	m.keys = deepCopy(m.keys)
+	m.value = deepCopy(m.value)
+	return m
+}
+
+// MarshalJSON implements the json.Marshaler interface, emitting keys in
+// insertion order.
+//
+// Example:
+//
+//	json.Marshal(m)
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	if !m.valid {
+		return []byte("null"), nil
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range m.keys {
+		name, err := formatMapKey(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedKey, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(m.value[key])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(encodedKey)
+		buffer.WriteByte(':')
+		buffer.Write(encodedValue)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, keeping the key
+// order of the source document. When a key is repeated, the last value wins
+// and the key keeps its first position.
+//
+// Example:
+//
+//	json.Unmarshal(data, &m)
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.unmarshaled = true
+	return m.decode(data)
+}
+
+// decode parses a JSON object token by token, preserving key order.
+func (m *OrderedMap[K, V]) decode(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		m.SetNull()
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if defaultCodec.useJSONNumber() {
+		decoder.UseNumber()
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return wrapJSONError("OrderedMap", data, err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return newInvalidFormat("OrderedMap", string(data), "expected JSON object")
+	}
+
+	result := OrderedMap[K, V]{value: map[K]V{}, valid: true}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return wrapJSONError("OrderedMap", data, err)
+		}
+		name := token.(string)
+		key, err := parseMapKey[K](name)
+		if err != nil {
+			return mapKeyError[K]("OrderedMap", name, err)
+		}
+		var item V
+		if err := decoder.Decode(&item); err != nil {
+			return fmt.Errorf("map key %q: %w", name, err)
+		}
+		result.SetItem(key, item)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return wrapJSONError("OrderedMap", data, err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return newInvalidFormat("OrderedMap", string(data), "trailing data after object")
+	}
+
+	m.keys = result.keys
+	m.value = result.value
+	m.valid = true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface for database deserialization.
+// Key order is preserved as long as the column stores the raw text (json,
+// not jsonb, in Postgres).
+//
+// Example:
+//
+//	var m OrderedMap[string, any]
+//	db.QueryRow(...).Scan(&m)
+func (m *OrderedMap[K, V]) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		m.SetNull()
+		return nil
+	case string:
+		return m.decode([]byte(v))
+	case []byte:
+		return m.decode(v)
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements the driver.Valuer interface for database serialization.
+//
+// Example:
+//
+//	val, err := m.Value()
+func (m OrderedMap[K, V]) Value() (driver.Value, error) {
+	if !m.valid {
+		return nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return defaultCodec.mapDriverValue(data), nil
+}
+
+// ValueOrZero is like Value, but returns an empty JSON object instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullOrderedMap[string, int]().ValueOrZero() // `{}`
+func (m OrderedMap[K, V]) ValueOrZero() (driver.Value, error) {
+	if !m.valid {
+		return NewOrderedMap[K, V]().Value()
+	}
+	return m.Value()
+}
+
+// String returns the JSON string representation of the OrderedMap.
+// If the OrderedMap is invalid (null), it returns "{}".
+//
+// Example:
+//
+//	fmt.Println(m.String())
+func (m OrderedMap[K, V]) String() string {
+	if !m.valid {
+		return "{}"
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("%v", m.value)
+	}
+	return string(data)
+}
+
+// StringOr returns String for valid values and fallback for null, where
+// String writes "{}".
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullOrderedMap[string, int]().StringOr("null"))  // Output: null
+func (m OrderedMap[K, V]) StringOr(fallback string) string {
+	if !m.valid {
+		return fallback
+	}
+	return m.String()
+}
+
+// SchemaType reports OrderedMap as a nullable JSON object.
+//
+// Example:
+//
+//	ztype.OrderedMap[string, int]{}.SchemaType() // "object", "", true
+func (m OrderedMap[K, V]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "object", "", true
+}
+
+// schemaItem returns the value type, described as additionalProperties.
+func (m OrderedMap[K, V]) schemaItem() reflect.Type {
+	return reflect.TypeFor[V]()
+}
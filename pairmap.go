@@ -0,0 +1,277 @@
+package ztype
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// pairMapFields holds the key and value field names of PairMap pairs; nil
+// means "key" and "value".
+var pairMapFields atomic.Pointer[[2]string]
+
+// SetPairMapFields sets the field names PairMap reads and writes in each
+// pair object. Empty names restore the defaults, "key" and "value".
+//
+// Example:
+//
+//	ztype.SetPairMapFields("name", "val") // [{"name":"a","val":1}]
+func SetPairMapFields(key, value string) {
+	if key == "" && value == "" {
+		pairMapFields.Store(nil)
+		return
+	}
+	key, value = cmp.Or(key, "key"), cmp.Or(value, "value")
+	pairMapFields.Store(&[2]string{key, value})
+}
+
+// pairFieldNames returns the current key and value field names.
+func pairFieldNames() (string, string) {
+	if fields := pairMapFields.Load(); fields != nil {
+		return fields[0], fields[1]
+	}
+	return "key", "value"
+}
+
+// PairMap is a Map whose JSON form is an array of key/value pair objects,
+// such as [{"key":"a","value":1},{"key":"b","value":2}], for systems that
+// send maps that way. UnmarshalJSON and Scan accept both the pair array and
+// the JSON object form; MarshalJSON always writes pairs, ordered by key so
+// the output is stable. The field names are set with SetPairMapFields.
+//
+// A key that appears twice in the array is an error, as it is for Map. The
+// database Value and String still use the object form. It embeds Map, so
+// all Map methods are available.
+//
+// Example:
+//
+//	var m ztype.PairMap[string, int]
+//	err := json.Unmarshal([]byte(`[{"key":"a","value":1}]`), &m)
+//	fmt.Println(m.Get()) // Output: map[a:1]
+type PairMap[K comparable, V any] struct {
+	Map[K, V]
+}
+
+// NewPairMap creates a valid PairMap holding value.
+//
+// Example:
+//
+//	m := ztype.NewPairMap(map[string]int{"a": 1})
+func NewPairMap[K comparable, V any](value map[K]V) PairMap[K, V] {
+	return PairMap[K, V]{Map: NewMap(value)}
+}
+
+// NewNullPairMap creates a null PairMap.
+//
+// Example:
+//
+//	m := ztype.NewNullPairMap[string, int]()
+//	fmt.Println(m.IsNull()) // Output: true
+func NewNullPairMap[K comparable, V any]() PairMap[K, V] {
+	return PairMap[K, V]{Map: NewNullMap[K, V]()}
+}
+
+// MarshalJSON implements json.Marshaler, writing the pair array form.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ztype.NewPairMap(map[string]int{"a": 1}))
+//	// [{"key":"a","value":1}]
+func (m PairMap[K, V]) MarshalJSON() ([]byte, error) {
+	if !m.valid {
+		return []byte("null"), nil
+	}
+	type pair struct {
+		key   []byte
+		value V
+	}
+	pairs := make([]pair, 0, len(m.value))
+	for key, value := range m.value {
+		encoded, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair{key: encoded, value: value})
+	}
+	slices.SortFunc(pairs, func(a, b pair) int {
+		return bytes.Compare(a.key, b.key)
+	})
+
+	keyName, valueName := pairFieldNames()
+	dst := []byte{'['}
+	for i, pair := range pairs {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		value, err := json.Marshal(pair.value)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, '{')
+		dst = appendJSONString(dst, keyName)
+		dst = append(dst, ':')
+		dst = append(dst, pair.key...)
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, valueName)
+		dst = append(dst, ':')
+		dst = append(dst, value...)
+		dst = append(dst, '}')
+	}
+	return append(dst, ']'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the pair array form
+// and the object form. A pair without the value field holds the zero value
+// of V; one without the key field is an error.
+//
+// Example:
+//
+//	var m ztype.PairMap[int, string]
+//	err := json.Unmarshal([]byte(`[{"key":1,"value":"a"}]`), &m)
+func (m *PairMap[K, V]) UnmarshalJSON(data []byte) error {
+	if !isPairArray(data) {
+		return m.Map.UnmarshalJSON(data)
+	}
+	m.unmarshaled = true
+	return m.decodePairs(data)
+}
+
+// appendJSONCodec shadows the method promoted from Map, so a Codec writes
+// a PairMap in the pair array form of MarshalJSON.
+func (m PairMap[K, V]) appendJSONCodec(_ *Codec, dst []byte) ([]byte, error) {
+	data, err := m.MarshalJSON()
+	return append(dst, data...), err
+}
+
+// unmarshalJSONCodec shadows the method promoted from Map, so a Codec
+// accepts the pair array form like UnmarshalJSON.
+func (m *PairMap[K, V]) unmarshalJSONCodec(_ *Codec, data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler with the pair array form.
+//
+// Example:
+//
+//	text, _ := ztype.NewPairMap(map[string]int{"a": 1}).MarshalText()
+func (m PairMap[K, V]) MarshalText() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting both forms.
+//
+// Example:
+//
+//	err := m.UnmarshalText([]byte(`[{"key":"a","value":1}]`))
+func (m *PairMap[K, V]) UnmarshalText(data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+// Scan implements sql.Scanner, accepting the pair array form from string
+// and []byte columns besides everything Map.Scan accepts.
+//
+// Example:
+//
+//	err := db.QueryRow("SELECT labels FROM items").Scan(&m)
+func (m *PairMap[K, V]) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	}
+	if !isPairArray(data) {
+		return m.Map.Scan(value)
+	}
+	return m.decodePairs(data)
+}
+
+// SchemaType reports PairMap as a nullable JSON array.
+//
+// Example:
+//
+//	ztype.PairMap[string, int]{}.SchemaType() // "array", "", true
+func (m PairMap[K, V]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "array", "", true
+}
+
+// schemaItem describes a pair as an object with the current field names.
+func (m PairMap[K, V]) schemaItem() reflect.Type {
+	keyName, valueName := pairFieldNames()
+	return reflect.StructOf([]reflect.StructField{
+		{Name: "Key", Type: reflect.TypeFor[K](), Tag: reflect.StructTag(fmt.Sprintf("json:%q", keyName))},
+		{Name: "Value", Type: reflect.TypeFor[V](), Tag: reflect.StructTag(fmt.Sprintf("json:%q", valueName))},
+	})
+}
+
+// isPairArray reports whether data holds a JSON array.
+func isPairArray(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// decodePairs replaces the content of m with the pairs in data, leaving it
+// unchanged on error.
+func (m *PairMap[K, V]) decodePairs(data []byte) error {
+	if err := checkJSONLimits("PairMap", data); err != nil {
+		m.SetNull()
+		return err
+	}
+	var pairs []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return wrapJSONError("PairMap", data, err)
+	}
+
+	keyName, valueName := pairFieldNames()
+	result := make(map[K]V, len(pairs))
+	for i, pair := range pairs {
+		rawKey, ok := pair[keyName]
+		if !ok {
+			return newInvalidFormat("PairMap", string(data), "pair %d has no %q field", i, keyName)
+		}
+		key, err := decodePairKey[K](rawKey)
+		if err != nil {
+			return fmt.Errorf("pair %d: %w", i, err)
+		}
+		if _, ok := result[key]; ok {
+			return newInvalidFormat("PairMap", string(rawKey), "duplicate pair key %v", key)
+		}
+		var value V
+		if rawValue, ok := pair[valueName]; ok {
+			if err := defaultCodec.unmarshalJSONValue(rawValue, &value); err != nil {
+				return fmt.Errorf("pair %d: %w", i, err)
+			}
+		}
+		result[key] = value
+	}
+	m.value = result
+	m.valid = true
+	return nil
+}
+
+// decodePairKey decodes a pair key. JSON strings are converted like object
+// keys, so "1" is accepted for an int key; other values are decoded as K.
+func decodePairKey[K comparable](raw json.RawMessage) (K, error) {
+	var key K
+	if text := strings.TrimSpace(string(raw)); strings.HasPrefix(text, `"`) {
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return key, wrapJSONError("PairMap", raw, err)
+		}
+		key, err := parseMapKey[K](name)
+		if err != nil {
+			return key, mapKeyError[K]("PairMap", name, err)
+		}
+		return key, nil
+	}
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return key, wrapJSONError("PairMap", raw, err)
+	}
+	return key, nil
+}
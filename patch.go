@@ -0,0 +1,113 @@
+package ztype
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// nullableType is the reflect type of the Nullable interface.
+var nullableType = reflect.TypeFor[Nullable]()
+
+// ChangedFields walks a struct (or a pointer to one) and returns, keyed by
+// JSON field name, the ztype fields that were present in the decoded input.
+// Fields decoded from an explicit null map to nil; absent fields are left
+// out. Nested structs become nested maps holding only their changed fields,
+// embedded structs are flattened like encoding/json does, and fields that
+// are not ztype types are skipped.
+//
+// Example:
+//
+//	var req struct {
+//		Name  ztype.String `json:"name"`
+//		Email ztype.String `json:"email"`
+//		Age   ztype.Numeric[int] `json:"age"`
+//	}
+//	json.Unmarshal([]byte(`{"name":"Ana","email":null}`), &req)
+//	changed, _ := ztype.ChangedFields(&req)
+//	// changed: {"name": ztype.String("Ana"), "email": nil}
+func ChangedFields(v any) (JSON, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return NewNullMap[string, any](), fmt.Errorf("expected a struct, got nil %T", v)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return NewNullMap[string, any](), fmt.Errorf("expected a struct, got %T", v)
+	}
+	if !value.CanAddr() {
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		value = addressable
+	}
+
+	changed := map[string]any{}
+	collectChangedFields(value, changed)
+	return NewMap(changed), nil
+}
+
+// collectChangedFields adds the changed fields of the addressable struct
+// value to changed.
+func collectChangedFields(value reflect.Value, changed map[string]any) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if field.Type.Kind() != reflect.Pointer && reflect.PointerTo(field.Type).Implements(nullableType) {
+			nullable := fieldValue.Addr().Interface().(Nullable)
+			if !nullable.Unmarshaled() {
+				continue
+			}
+			if nullable.IsNull() {
+				changed[name] = nil
+			} else {
+				changed[name] = fieldValue.Interface()
+			}
+			continue
+		}
+
+		for fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() != reflect.Struct {
+			continue
+		}
+		if field.Anonymous && !hasJSONName(field) {
+			collectChangedFields(fieldValue, changed)
+			continue
+		}
+		nested := map[string]any{}
+		collectChangedFields(fieldValue, nested)
+		if len(nested) > 0 {
+			changed[name] = nested
+		}
+	}
+}
+
+// jsonFieldName returns the name encoding/json uses for the field, and
+// whether the field is excluded with a "-" tag.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, false
+	}
+	return field.Name, false
+}
+
+// hasJSONName reports whether the field sets an explicit JSON name.
+func hasJSONName(field reflect.StructField) bool {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name != "" && name != "-"
+}
@@ -0,0 +1,231 @@
+// Package pgxtype integrates ztype with the native pgx v5 interface, where
+// values are encoded through pgtype codecs instead of driver.Valuer.
+//
+// Register the types once per connection, e.g. in pgxpool.Config.AfterConnect:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxtype.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// Both the text and binary protocol formats are supported. Null ztype values
+// are sent as SQL NULL and SQL NULL is scanned as a null ztype value.
+package pgxtype
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/zhaori96/ztype"
+)
+
+// typeNames lists the PostgreSQL types whose codecs learn to scan into
+// ztype values. Scanning must be handled by the codec because pgx would
+// otherwise fall back to sql.Scanner, which only sees database/sql values.
+var typeNames = []string{
+	"bool",
+	"text", "varchar", "bpchar", "name", "uuid",
+	"int2", "int4", "int8", "float4", "float8", "numeric",
+	"timestamptz", "timestamp", "date",
+	"interval",
+	"json", "jsonb", "bytea",
+}
+
+// Register teaches m to encode and scan Bool, String, Numeric, Time,
+// Duration, Bytes, RawJSON and JSON values. Strings bind to uuid columns,
+// and Time binds to timestamp, timestamptz and date columns.
+//
+// Example:
+//
+//	conn, _ := pgx.Connect(ctx, url)
+//	pgxtype.Register(conn.TypeMap())
+//	conn.QueryRow(ctx, "SELECT now()").Scan(&createdAt) // createdAt is a ztype.Time
+func Register(m *pgtype.Map) {
+	for _, name := range typeNames {
+		t, ok := m.TypeForName(name)
+		if !ok {
+			continue
+		}
+		if _, wrapped := t.Codec.(*codec); wrapped {
+			continue
+		}
+		m.RegisterType(&pgtype.Type{Name: t.Name, OID: t.OID, Codec: &codec{Codec: t.Codec}})
+	}
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{tryWrapEncodePlan}, m.TryWrapEncodePlanFuncs...)
+}
+
+// binder converts between a ztype value and the Go type pgx natively handles.
+type binder interface {
+	planEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan
+	planScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan
+	wrapEncode(value any) (pgtype.WrappedEncodePlanNextSetter, any, bool)
+}
+
+// binders lists every supported ztype type.
+var binders = []binder{
+	binding[ztype.Bool, bool]{
+		get: (*ztype.Bool).Val,
+		set: func(v *ztype.Bool, value bool) { v.Set(value) },
+	},
+	binding[ztype.String, string]{
+		get: (*ztype.String).Val,
+		set: func(v *ztype.String, value string) { v.Set(value) },
+	},
+	binding[ztype.Time, time.Time]{
+		get: (*ztype.Time).Val,
+		set: func(v *ztype.Time, value time.Time) { v.Set(value) },
+	},
+	binding[ztype.Duration, time.Duration]{
+		get: (*ztype.Duration).Val,
+		set: func(v *ztype.Duration, value time.Duration) { v.Set(value) },
+	},
+	binding[ztype.Bytes, []byte]{
+		get: (*ztype.Bytes).Val,
+		set: func(v *ztype.Bytes, value []byte) { v.Set(append([]byte{}, value...)) },
+	},
+	binding[ztype.RawJSON, []byte]{
+		get: func(v *ztype.RawJSON) ([]byte, bool) { return v.Val() },
+		set: func(v *ztype.RawJSON, value []byte) { v.Set(append(json.RawMessage{}, value...)) },
+	},
+	binding[ztype.JSON, map[string]any]{
+		get: (*ztype.JSON).Val,
+		set: func(v *ztype.JSON, value map[string]any) { v.Set(value) },
+	},
+	numeric[int](),
+	numeric[int16](),
+	numeric[int32](),
+	numeric[int64](),
+	numeric[uint8](),
+	numeric[uint16](),
+	numeric[uint32](),
+	numeric[float32](),
+	numeric[float64](),
+}
+
+// numeric returns the binding for Numeric[T].
+func numeric[T ztype.NumberType]() binder {
+	return binding[ztype.Numeric[T], T]{
+		get: (*ztype.Numeric[T]).Val,
+		set: func(v *ztype.Numeric[T], value T) { v.Set(value) },
+	}
+}
+
+// binding converts between the ztype value Z and its native type N.
+type binding[Z any, N any] struct {
+	get func(*Z) (N, bool)
+	set func(*Z, N)
+}
+
+func (b binding[Z, N]) planEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case Z, *Z:
+	default:
+		return nil
+	}
+	var natural N
+	next := m.PlanEncode(oid, format, natural)
+	if next == nil {
+		return nil
+	}
+	return &encodePlan[Z, N]{binding: b, next: next}
+}
+
+func (b binding[Z, N]) planScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(*Z); !ok {
+		return nil
+	}
+	var natural *N
+	return &scanPlan[Z, N]{binding: b, next: m.PlanScan(oid, format, &natural)}
+}
+
+func (b binding[Z, N]) wrapEncode(value any) (pgtype.WrappedEncodePlanNextSetter, any, bool) {
+	if _, ok := value.(Z); !ok {
+		return nil, nil, false
+	}
+	var natural N
+	return &encodePlan[Z, N]{binding: b}, natural, true
+}
+
+// encodePlan encodes a ztype value through the plan of its native type.
+type encodePlan[Z any, N any] struct {
+	binding binding[Z, N]
+	next    pgtype.EncodePlan
+}
+
+func (p *encodePlan[Z, N]) SetNext(next pgtype.EncodePlan) { p.next = next }
+
+func (p *encodePlan[Z, N]) Encode(value any, buf []byte) ([]byte, error) {
+	var z Z
+	switch v := value.(type) {
+	case Z:
+		z = v
+	case *Z:
+		if v == nil {
+			return nil, nil
+		}
+		z = *v
+	}
+	natural, valid := p.binding.get(&z)
+	if !valid {
+		return nil, nil
+	}
+	return p.next.Encode(natural, buf)
+}
+
+// scanPlan scans into the native type and stores the result in the ztype
+// value, mapping SQL NULL to a null value.
+type scanPlan[Z any, N any] struct {
+	binding binding[Z, N]
+	next    pgtype.ScanPlan
+}
+
+func (p *scanPlan[Z, N]) Scan(src []byte, target any) error {
+	var natural *N
+	if err := p.next.Scan(src, &natural); err != nil {
+		return err
+	}
+	dst := target.(*Z)
+	if natural == nil {
+		any(dst).(ztype.Nullable).SetNull()
+		return nil
+	}
+	p.binding.set(dst, *natural)
+	return nil
+}
+
+// codec wraps a pgtype codec so that it plans ztype values before falling
+// back to the wrapped codec.
+type codec struct {
+	pgtype.Codec
+}
+
+func (c *codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	for _, b := range binders {
+		if plan := b.planEncode(m, oid, format, value); plan != nil {
+			return plan
+		}
+	}
+	return c.Codec.PlanEncode(m, oid, format, value)
+}
+
+func (c *codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	for _, b := range binders {
+		if plan := b.planScan(m, oid, format, target); plan != nil {
+			return plan
+		}
+	}
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+// tryWrapEncodePlan handles ztype values bound to parameters of unknown OID,
+// e.g. with the simple protocol.
+func tryWrapEncodePlan(value any) (pgtype.WrappedEncodePlanNextSetter, any, bool) {
+	for _, b := range binders {
+		if plan, next, ok := b.wrapEncode(value); ok {
+			return plan, next, true
+		}
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,141 @@
+package ztype
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Presence tells whether, and how, a field appeared in the decoded input.
+// See PresenceMap.
+type Presence int
+
+const (
+	// PresenceAbsent means the field was not in the input.
+	PresenceAbsent Presence = iota
+	// PresenceNull means the field was in the input as an explicit null.
+	PresenceNull
+	// PresenceValue means the field was in the input with a value.
+	PresenceValue
+)
+
+// String returns "absent", "null" or "value".
+//
+// Example:
+//
+//	ztype.PresenceNull.String() // "null"
+func (p Presence) String() string {
+	switch p {
+	case PresenceAbsent:
+		return "absent"
+	case PresenceNull:
+		return "null"
+	case PresenceValue:
+		return "value"
+	}
+	return fmt.Sprintf("Presence(%d)", int(p))
+}
+
+// MarshalText implements encoding.TextMarshaler, so a map returned by
+// PresenceMap logs as {"name":"value","email":"null"}.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ztype.PresenceAbsent) // "absent"
+func (p Presence) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// PresenceMap walks a decoded struct (or a pointer to one) and reports, for
+// every ztype field, whether it was absent from the JSON input, present as
+// null or present with a value, as Unmarshaled and IsNull tell. Keys are
+// JSON field names; fields of nested structs use dotted keys such as
+// "address.city", and embedded structs are flattened like encoding/json
+// does. Fields that are not ztype types are skipped. A nil pointer to a
+// ztype value or to a nested struct reports its fields as absent, since
+// encoding/json does not call UnmarshalJSON for them.
+//
+// Unlike ChangedFields, which keeps the present values, it lists every
+// field, for audit logs.
+//
+// Example:
+//
+//	var req struct {
+//		Name  ztype.String       `json:"name"`
+//		Email ztype.String       `json:"email"`
+//		Age   ztype.Numeric[int] `json:"age"`
+//	}
+//	json.Unmarshal([]byte(`{"name":"Ana","email":null}`), &req)
+//	presence, _ := ztype.PresenceMap(&req)
+//	// presence: {"name": PresenceValue, "email": PresenceNull, "age": PresenceAbsent}
+func PresenceMap(v any) (map[string]Presence, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, fmt.Errorf("expected a struct, got nil %T", v)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, got %T", v)
+	}
+	if !value.CanAddr() {
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		value = addressable
+	}
+
+	presence := map[string]Presence{}
+	collectPresence(value, "", presence)
+	return presence, nil
+}
+
+// collectPresence adds the Presence of the ztype fields of the addressable
+// struct value to presence, prefixing nested keys with prefix.
+func collectPresence(value reflect.Value, prefix string, presence map[string]Presence) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		key := prefix + name
+
+		fieldValue := value.Field(i)
+		for fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				fieldValue = reflect.New(fieldValue.Type().Elem()).Elem()
+				break
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if reflect.PointerTo(fieldValue.Type()).Implements(nullableType) {
+			if !field.IsExported() {
+				continue
+			}
+			nullable := fieldValue.Addr().Interface().(Nullable)
+			switch {
+			case !nullable.Unmarshaled():
+				presence[key] = PresenceAbsent
+			case nullable.IsNull():
+				presence[key] = PresenceNull
+			default:
+				presence[key] = PresenceValue
+			}
+			continue
+		}
+
+		if fieldValue.Kind() != reflect.Struct {
+			continue
+		}
+		if field.Anonymous && !hasJSONName(field) {
+			collectPresence(fieldValue, prefix, presence)
+			continue
+		}
+		if field.IsExported() {
+			collectPresence(fieldValue, key+".", presence)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+package ztype
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/netip"
+	"reflect"
+	"testing/quick"
+	"time"
+	"unicode/utf8"
+)
+
+// quickCurrencies are the currency codes Money.Generate picks from, covering
+// every minor unit exponent.
+var quickCurrencies = []string{"BRL", "USD", "EUR", "JPY", "KWD"}
+
+// quickNull reports whether a generated value should be null. Roughly one in
+// four generated values is null.
+func quickNull(rand *rand.Rand) bool {
+	return rand.Intn(4) == 0
+}
+
+// quickValue returns a random T. Interface types get a random JSON scalar, so
+// that JSON maps and slices stay encodable.
+func quickValue[T any](rand *rand.Rand, size int) T {
+	var value T
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Interface {
+		if scalar := quickJSONScalar(rand, size); scalar != nil {
+			reflect.ValueOf(&value).Elem().Set(reflect.ValueOf(scalar))
+		}
+		return value
+	}
+	if generated, ok := quick.Value(t, rand); ok {
+		reflect.ValueOf(&value).Elem().Set(generated)
+	}
+	return value
+}
+
+// quickJSONScalar returns a random string, float64, bool or nil.
+func quickJSONScalar(rand *rand.Rand, size int) any {
+	switch rand.Intn(4) {
+	case 0:
+		return quickValue[string](rand, size)
+	case 1:
+		return float64(rand.Int63n(1<<53)) / float64(int64(1)<<rand.Intn(16))
+	case 2:
+		return rand.Intn(2) == 0
+	}
+	return nil
+}
+
+// quickLen returns a random collection length bounded by size.
+func quickLen(rand *rand.Rand, size int) int {
+	return rand.Intn(size + 1)
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(b ztype.Bool) bool { return ztype.RoundTripJSON(b) == nil }, nil)
+func (b Bool) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullBool())
+	}
+	return reflect.ValueOf(NewBool(rand.Intn(2) == 0))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(b ztype.Byte) bool { return ztype.RoundTripJSON(b) == nil }, nil)
+func (b Byte) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullByte())
+	}
+	return reflect.ValueOf(NewByte(byte(rand.Intn(256))))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(c ztype.Char) bool { return ztype.RoundTripJSON(c) == nil }, nil)
+func (c Char) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullChar())
+	}
+	return reflect.ValueOf(NewChar(byte(rand.Intn(256))))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(b ztype.Bytes) bool { return ztype.RoundTripJSON(b) == nil }, nil)
+func (b Bytes) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullBytes())
+	}
+	value := make([]byte, quickLen(rand, size))
+	rand.Read(value)
+	return reflect.ValueOf(NewBytes(value))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(s ztype.String) bool { return ztype.RoundTripJSON(s) == nil }, nil)
+func (s String) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullString())
+	}
+	return reflect.ValueOf(NewString(quickValue[string](rand, size)))
+}
+
+// Generate implements quick.Generator.
+// Generated times are in UTC, between 1970 and 2100.
+//
+// Example:
+//
+//	err := quick.Check(func(t ztype.Time) bool { return ztype.RoundTripJSON(t) == nil }, nil)
+func (t Time) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullTime())
+	}
+	const maxSeconds = 4102444800 // 2100-01-01T00:00:00Z
+	return reflect.ValueOf(NewTime(time.Unix(rand.Int63n(maxSeconds), rand.Int63n(int64(time.Second))).UTC()))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(d ztype.Duration) bool { return ztype.RoundTripJSON(d) == nil }, nil)
+func (d Duration) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullDuration())
+	}
+	return reflect.ValueOf(NewDuration(time.Duration(rand.Int63() - rand.Int63())))
+}
+
+// Generate implements quick.Generator.
+// Generated runes are always valid Unicode code points.
+//
+// Example:
+//
+//	err := quick.Check(func(r ztype.Rune) bool { return ztype.RoundTripJSON(r) == nil }, nil)
+func (r Rune) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullRune())
+	}
+	value := rand.Int31n(utf8.MaxRune + 1)
+	for !utf8.ValidRune(value) {
+		value = rand.Int31n(utf8.MaxRune + 1)
+	}
+	return reflect.ValueOf(NewRune(value))
+}
+
+// Generate implements quick.Generator, mixing IPv4 and IPv6 addresses.
+//
+// Example:
+//
+//	err := quick.Check(func(ip ztype.IP) bool { return ztype.RoundTripJSON(ip) == nil }, nil)
+func (ip IP) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullIP())
+	}
+	return reflect.ValueOf(NewIP(quickAddr(rand)))
+}
+
+// Generate implements quick.Generator, producing masked prefixes.
+//
+// Example:
+//
+//	err := quick.Check(func(c ztype.CIDR) bool { return ztype.RoundTripJSON(c) == nil }, nil)
+func (c CIDR) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullCIDR())
+	}
+	addr := quickAddr(rand)
+	prefix := netip.PrefixFrom(addr, rand.Intn(addr.BitLen()+1)).Masked()
+	return reflect.ValueOf(NewCIDR(prefix))
+}
+
+// quickAddr returns a random IPv4 or IPv6 address.
+func quickAddr(rand *rand.Rand) netip.Addr {
+	if rand.Intn(2) == 0 {
+		var ip [4]byte
+		rand.Read(ip[:])
+		return netip.AddrFrom4(ip)
+	}
+	var ip [16]byte
+	rand.Read(ip[:])
+	return netip.AddrFrom16(ip)
+}
+
+// Generate implements quick.Generator, producing a JSON scalar or a small
+// object of scalars.
+//
+// Example:
+//
+//	err := quick.Check(func(r ztype.RawJSON) bool { return ztype.RoundTripJSON(r) == nil }, nil)
+func (r RawJSON) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullRawJSON())
+	}
+	var value any = quickJSONScalar(rand, size)
+	if value == nil || rand.Intn(2) == 0 {
+		object := map[string]any{}
+		for range quickLen(rand, size) {
+			object[quickValue[string](rand, size)] = quickJSONScalar(rand, size)
+		}
+		value = object
+	}
+	data, _ := json.Marshal(value)
+	return reflect.ValueOf(NewRawJSON(data))
+}
+
+// Generate implements quick.Generator, picking from common currencies.
+//
+// Example:
+//
+//	err := quick.Check(func(m ztype.Money) bool { return ztype.RoundTripJSON(m) == nil }, nil)
+func (m Money) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullMoney())
+	}
+	currency := quickCurrencies[rand.Intn(len(quickCurrencies))]
+	return reflect.ValueOf(NewMoney(rand.Int63()-rand.Int63(), currency))
+}
+
+// Generate implements quick.Generator, picking from the values registered
+// for T. When T has no registered values, the generated Enum is null.
+//
+// Example:
+//
+//	err := quick.Check(func(s ztype.Enum[Status]) bool { return ztype.RoundTripJSON(s) == nil }, nil)
+func (e Enum[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	enumType, err := lookupEnumType[T]()
+	if err != nil || len(enumType.values) == 0 || quickNull(rand) {
+		return reflect.ValueOf(Enum[T]{})
+	}
+	return reflect.ValueOf(Enum[T]{value: enumType.values[rand.Intn(len(enumType.values))], valid: true})
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(n ztype.Numeric[int]) bool { return ztype.RoundTripJSON(n) == nil }, nil)
+func (n Numeric[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullNumber[T]())
+	}
+	return reflect.ValueOf(NewNumber(quickValue[T](rand, size)))
+}
+
+// Generate implements quick.Generator. Interface values are generated as
+// JSON scalars.
+//
+// Example:
+//
+//	err := quick.Check(func(n ztype.Null[string]) bool { return ztype.RoundTripJSON(n) == nil }, nil)
+func (n Null[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNull[T]())
+	}
+	return reflect.ValueOf(New(quickValue[T](rand, size)))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(n ztype.NullComparable[int]) bool { return ztype.RoundTripJSON(n) == nil }, nil)
+func (n NullComparable[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(NullComparable[T]{Null: n.Null.Generate(rand, size).Interface().(Null[T])})
+}
+
+// Generate implements quick.Generator. Interface values are generated as
+// JSON scalars.
+//
+// Example:
+//
+//	err := quick.Check(func(s ztype.Slice[int]) bool { return ztype.RoundTripJSON(s) == nil }, nil)
+func (s Slice[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullSlice[T]())
+	}
+	value := make([]T, quickLen(rand, size))
+	for i := range value {
+		value[i] = quickValue[T](rand, size)
+	}
+	return reflect.ValueOf(NewSlice(value))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(s ztype.SliceComparable[int]) bool { return ztype.RoundTripJSON(s) == nil }, nil)
+func (s SliceComparable[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(SliceComparable[T]{Slice: s.Slice.Generate(rand, size).Interface().(Slice[T])})
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(s ztype.Set[string]) bool { return ztype.RoundTripJSON(s) == nil }, nil)
+func (s Set[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullSet[T]())
+	}
+	items := make([]T, quickLen(rand, size))
+	for i := range items {
+		items[i] = quickValue[T](rand, size)
+	}
+	return reflect.ValueOf(NewSet(items...))
+}
+
+// Generate implements quick.Generator. Interface values are generated as
+// JSON scalars.
+//
+// Example:
+//
+//	err := quick.Check(func(m ztype.JSON) bool { return ztype.RoundTripJSON(m) == nil }, nil)
+func (m Map[K, V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullMap[K, V]())
+	}
+	value := make(map[K]V)
+	for range quickLen(rand, size) {
+		value[quickValue[K](rand, size)] = quickValue[V](rand, size)
+	}
+	return reflect.ValueOf(NewMap(value))
+}
+
+// Generate implements quick.Generator.
+//
+// Example:
+//
+//	err := quick.Check(func(m ztype.MapComparable[string, int]) bool { return ztype.RoundTripJSON(m) == nil }, nil)
+func (m MapComparable[K, V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(MapComparable[K, V]{Map: m.Map.Generate(rand, size).Interface().(Map[K, V])})
+}
+
+// Generate implements quick.Generator. Interface values are generated as
+// JSON scalars.
+//
+// Example:
+//
+//	err := quick.Check(func(m ztype.OrderedMap[string, int]) bool { return ztype.RoundTripJSON(m) == nil }, nil)
+func (m OrderedMap[K, V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if quickNull(rand) {
+		return reflect.ValueOf(NewNullOrderedMap[K, V]())
+	}
+	generated := NewOrderedMap[K, V]()
+	for range quickLen(rand, size) {
+		generated.SetItem(quickValue[K](rand, size), quickValue[V](rand, size))
+	}
+	return reflect.ValueOf(generated)
+}
+
+// Generate implements quick.Generator. It may be called on a nil
+// *SyncMap, which is how testing/quick invokes it.
+//
+// Example:
+//
+//	err := quick.Check(func(m *ztype.SyncMap[string, int]) bool { return m.Len() >= 0 }, nil)
+func (m *SyncMap[K, V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	inner := Map[K, V]{}.Generate(rand, size).Interface().(Map[K, V])
+	return reflect.ValueOf(&SyncMap[K, V]{inner: inner})
+}
+
+// Generate implements quick.Generator. It may be called on a nil
+// *SyncMapComparable, which is how testing/quick invokes it.
+//
+// Example:
+//
+//	err := quick.Check(func(m *ztype.SyncMapComparable[string, int]) bool { return m.Len() >= 0 }, nil)
+func (m *SyncMapComparable[K, V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	inner := Map[K, V]{}.Generate(rand, size).Interface().(Map[K, V])
+	return reflect.ValueOf(&SyncMapComparable[K, V]{SyncMap: SyncMap[K, V]{inner: inner}})
+}
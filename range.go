@@ -0,0 +1,550 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// SetRangeValueLiteral selects what Range.Value returns: a JSON object (the
+// default), for json/jsonb columns, or a PostgreSQL range literal such as
+// "[1,10)", for int4range, int8range and numrange columns. Scan always
+// accepts both forms.
+//
+// Example:
+//
+//	ztype.SetRangeValueLiteral(true)
+//	v, _ := ztype.NewRange(ztype.NewNumber(1), ztype.NewNumber(10)).Value() // "[1,10]"
+func SetRangeValueLiteral(literal bool) {
+	defaultCodec.rangeValueLiteral.Store(literal)
+}
+
+// Range represents a nullable interval of numbers, such as a price or age
+// range. Each bound is a Numeric that is null when that side is unbounded,
+// and is either inclusive or exclusive.
+//
+// JSON uses {"min":1,"max":10}, where a null or missing bound is unbounded;
+// exclusive bounds add "minExclusive" or "maxExclusive". The database form is
+// set with SetRangeValueLiteral.
+//
+// Example Usage:
+//
+//	ages := ztype.NewRange(ztype.NewNumber(18), ztype.NewNumber(65))
+//	ages.Contains(ztype.NewNumber(30)) // true
+//	ages.String()                      // "[18,65]"
+type Range[T NumberType] struct {
+	lower          Numeric[T]
+	upper          Numeric[T]
+	lowerExclusive bool
+	upperExclusive bool
+	valid          bool
+	unmarshaled    bool
+}
+
+// NewRange creates a valid Range including both bounds. A null bound leaves
+// that side unbounded.
+//
+// Example:
+//
+//	atLeast18 := ztype.NewRange(ztype.NewNumber(18), ztype.NewNullNumber[int]())
+func NewRange[T NumberType](lower, upper Numeric[T]) Range[T] {
+	return Range[T]{lower: lower, upper: upper, valid: true}
+}
+
+// NewNullRange creates a new null Range instance.
+//
+// Example:
+//
+//	r := ztype.NewNullRange[int]()
+//	r.IsNull() // true
+func NewNullRange[T NumberType]() Range[T] {
+	return Range[T]{}
+}
+
+// ParseRange parses a PostgreSQL range literal such as "[1,10)", "(,5]" or
+// "empty". Bounds may be empty, meaning unbounded, or double-quoted. Returns
+// an *ErrInvalidFormat for malformed literals or bounds, and for a lower
+// bound greater than the upper bound, as PostgreSQL does.
+//
+// Example:
+//
+//	r, err := ztype.ParseRange[int]("[1,10)")
+//	r.Contains(ztype.NewNumber(10)) // false
+func ParseRange[T NumberType](s string) (Range[T], error) {
+	text := strings.TrimSpace(s)
+	if strings.EqualFold(text, "empty") {
+		return Range[T]{upperExclusive: true, lower: NewNumber[T](0), upper: NewNumber[T](0), valid: true}, nil
+	}
+	if len(text) < 3 || !strings.ContainsRune("[(", rune(text[0])) || !strings.ContainsRune("])", rune(text[len(text)-1])) {
+		return Range[T]{}, newInvalidFormat("Range", s, "expected a range literal such as [1,10)")
+	}
+	lowerText, upperText, ok := strings.Cut(text[1:len(text)-1], ",")
+	if !ok || strings.Contains(upperText, ",") {
+		return Range[T]{}, newInvalidFormat("Range", s, "expected two bounds")
+	}
+
+	lower, err := parseRangeBound[T](lowerText)
+	if err != nil {
+		return Range[T]{}, newInvalidFormat("Range", s, "lower bound: %w", err)
+	}
+	upper, err := parseRangeBound[T](upperText)
+	if err != nil {
+		return Range[T]{}, newInvalidFormat("Range", s, "upper bound: %w", err)
+	}
+	r := NewRange(lower, upper).WithBounds(text[0] == '[', text[len(text)-1] == ']')
+	if err := r.Validate(); err != nil {
+		return Range[T]{}, err
+	}
+	return r, nil
+}
+
+// parseRangeBound parses one bound of a range literal, where an empty bound
+// is unbounded.
+func parseRangeBound[T NumberType](text string) (Numeric[T], error) {
+	text = strings.TrimSpace(text)
+	if unquoted, ok := strings.CutPrefix(text, `"`); ok {
+		if text, ok = strings.CutSuffix(unquoted, `"`); !ok {
+			return Numeric[T]{}, errors.New("unterminated quote")
+		}
+		text = strings.TrimSpace(text)
+	}
+	if text == "" {
+		return NewNullNumber[T](), nil
+	}
+	value, err := parseNumberText[T]([]byte(text))
+	if err != nil {
+		return Numeric[T]{}, err
+	}
+	return NewNumber(value), nil
+}
+
+// WithBounds returns a copy of the Range with the given inclusivity of the
+// lower and upper bounds, as the "[]", "[)", "(]" and "()" of PostgreSQL.
+//
+// Example:
+//
+//	r := ztype.NewRange(ztype.NewNumber(1), ztype.NewNumber(10)).WithBounds(true, false) // [1,10)
+func (r Range[T]) WithBounds(lowerInclusive, upperInclusive bool) Range[T] {
+	r.lowerExclusive = !lowerInclusive
+	r.upperExclusive = !upperInclusive
+	return r
+}
+
+// Lower returns the lower bound, null when the Range has no lower bound.
+//
+// Example:
+//
+//	min := r.Lower()
+func (r Range[T]) Lower() Numeric[T] {
+	return r.lower
+}
+
+// Upper returns the upper bound, null when the Range has no upper bound.
+//
+// Example:
+//
+//	max := r.Upper()
+func (r Range[T]) Upper() Numeric[T] {
+	return r.upper
+}
+
+// LowerInclusive reports whether the lower bound belongs to the Range. It
+// is false when the Range has no lower bound.
+//
+// Example:
+//
+//	ztype.MustParseRange[int]("[1,10)").LowerInclusive() // true
+func (r Range[T]) LowerInclusive() bool {
+	return r.lower.value.Valid && !r.lowerExclusive
+}
+
+// UpperInclusive reports whether the upper bound belongs to the Range. It
+// is false when the Range has no upper bound.
+//
+// Example:
+//
+//	ztype.MustParseRange[int]("[1,10)").UpperInclusive() // false
+func (r Range[T]) UpperInclusive() bool {
+	return r.upper.value.Valid && !r.upperExclusive
+}
+
+// MustParseRange is like ParseRange but panics on error, for literals in
+// code and tests.
+//
+// Example:
+//
+//	adults := ztype.MustParseRange[int]("[18,)")
+func MustParseRange[T NumberType](s string) Range[T] {
+	r, err := ParseRange[T](s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// IsNull returns true if the Range is null.
+//
+// Example:
+//
+//	ztype.NewNullRange[int]().IsNull() // true
+func (r Range[T]) IsNull() bool {
+	return !r.valid
+}
+
+// SetNull sets the Range to null.
+//
+// Example:
+//
+//	r.SetNull()
+func (r *Range[T]) SetNull() {
+	*r = Range[T]{unmarshaled: r.unmarshaled}
+}
+
+// Unmarshaled reports whether the Range was set by UnmarshalJSON.
+//
+// Example:
+//
+//	if r.Unmarshaled() { /* field was present in the payload */ }
+func (r Range[T]) Unmarshaled() bool {
+	return r.unmarshaled
+}
+
+// SetUnmarshaled sets the unmarshaled flag.
+//
+// Example:
+//
+//	r.SetUnmarshaled(false)
+func (r *Range[T]) SetUnmarshaled(value bool) {
+	r.unmarshaled = value
+}
+
+// Validate returns an *ErrInvalidFormat when the lower bound is greater than
+// the upper bound, as PostgreSQL rejects. Equal bounds with an exclusive
+// side are a valid empty Range. Null and unbounded Ranges are valid.
+//
+// Example:
+//
+//	err := ztype.NewRange(ztype.NewNumber(10), ztype.NewNumber(1)).Validate() // error
+func (r Range[T]) Validate() error {
+	if r.valid && r.lower.value.Valid && r.upper.value.Valid && r.lower.value.V > r.upper.value.V {
+		literal, _ := r.appendBounds(nil)
+		return newInvalidFormat("Range", string(literal), "lower bound is greater than upper bound")
+	}
+	return nil
+}
+
+// Contains reports whether v lies within the Range. A null Range or a null v
+// contains nothing.
+//
+// Example:
+//
+//	r := ztype.MustParseRange[int]("[1,10)")
+//	r.Contains(ztype.NewNumber(1))  // true
+//	r.Contains(ztype.NewNumber(10)) // false
+func (r Range[T]) Contains(v Numeric[T]) bool {
+	if !r.valid || !v.value.Valid {
+		return false
+	}
+	value := v.value.V
+	if r.lower.value.Valid && (value < r.lower.value.V || (value == r.lower.value.V && r.lowerExclusive)) {
+		return false
+	}
+	if r.upper.value.Valid && (value > r.upper.value.V || (value == r.upper.value.V && r.upperExclusive)) {
+		return false
+	}
+	return true
+}
+
+// IsEmpty returns true if the Range contains no values: when it is null,
+// when its lower bound is greater than its upper bound, or when exclusive
+// bounds leave nothing between them, as in [5,5) or, for integers, (1,2).
+// See IsZero.
+//
+// Example:
+//
+//	ztype.MustParseRange[int]("(1,2)").IsEmpty()     // true
+//	ztype.MustParseRange[float64]("(1,2)").IsEmpty() // false
+func (r Range[T]) IsEmpty() bool {
+	if !r.valid {
+		return true
+	}
+	if !r.lower.value.Valid || !r.upper.value.Valid {
+		return false
+	}
+	lower, upper := r.lower.value.V, r.upper.value.V
+	if isIntegerNumber[T]() {
+		// Step exclusive integer bounds inwards, stopping at the limits of T.
+		if r.lowerExclusive {
+			if lower+1 < lower {
+				return true
+			}
+			lower++
+		}
+		if r.upperExclusive {
+			if upper-1 > upper {
+				return true
+			}
+			upper--
+		}
+		return lower > upper
+	}
+	if lower == upper {
+		return r.lowerExclusive || r.upperExclusive
+	}
+	return lower > upper
+}
+
+// IsZero is an alias for IsEmpty, so `json:",omitzero"` drops null and empty
+// Ranges.
+//
+// Example:
+//
+//	ztype.NewNullRange[int]().IsZero() // true
+func (r Range[T]) IsZero() bool {
+	return r.IsEmpty()
+}
+
+// isIntegerNumber reports whether T is an integer type.
+func isIntegerNumber[T NumberType]() bool {
+	kind := reflect.TypeFor[T]().Kind()
+	return kind != reflect.Float32 && kind != reflect.Float64
+}
+
+// Intersect returns the values in both Ranges, which is empty when they do
+// not overlap. The result is null when either Range is null.
+//
+// Example:
+//
+//	a := ztype.MustParseRange[int]("[1,10)")
+//	b := ztype.MustParseRange[int]("[5,)")
+//	a.Intersect(b).String() // "[5,10)"
+func (r Range[T]) Intersect(other Range[T]) Range[T] {
+	if !r.valid || !other.valid {
+		return NewNullRange[T]()
+	}
+	result := Range[T]{valid: true}
+	result.lower, result.lowerExclusive = r.lower, r.lowerExclusive
+	if other.lower.value.Valid {
+		switch {
+		case !r.lower.value.Valid || other.lower.value.V > r.lower.value.V:
+			result.lower, result.lowerExclusive = other.lower, other.lowerExclusive
+		case other.lower.value.V == r.lower.value.V:
+			result.lowerExclusive = r.lowerExclusive || other.lowerExclusive
+		}
+	}
+	result.upper, result.upperExclusive = r.upper, r.upperExclusive
+	if other.upper.value.Valid {
+		switch {
+		case !r.upper.value.Valid || other.upper.value.V < r.upper.value.V:
+			result.upper, result.upperExclusive = other.upper, other.upperExclusive
+		case other.upper.value.V == r.upper.value.V:
+			result.upperExclusive = r.upperExclusive || other.upperExclusive
+		}
+	}
+	return result
+}
+
+// Overlaps reports whether the Ranges have at least one value in common.
+//
+// Example:
+//
+//	ztype.MustParseRange[int]("[1,5)").Overlaps(ztype.MustParseRange[int]("[5,10)")) // false
+func (r Range[T]) Overlaps(other Range[T]) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// rangeJSON is the JSON object form of a Range.
+type rangeJSON[T NumberType] struct {
+	Min          Numeric[T] `json:"min"`
+	Max          Numeric[T] `json:"max"`
+	MinExclusive bool       `json:"minExclusive,omitempty"`
+	MaxExclusive bool       `json:"maxExclusive,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, writing {"min":1,"max":10} with null
+// for unbounded sides, or null for a null Range.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ztype.MustParseRange[int]("[1,10)")) // {"min":1,"max":10,"maxExclusive":true}
+func (r Range[T]) MarshalJSON() ([]byte, error) {
+	if !r.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(rangeJSON[T]{
+		Min:          r.lower,
+		Max:          r.upper,
+		MinExclusive: r.lower.value.Valid && r.lowerExclusive,
+		MaxExclusive: r.upper.value.Valid && r.upperExclusive,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the object form or
+// null. Missing bounds are unbounded.
+//
+// Example:
+//
+//	var r ztype.Range[float64]
+//	err := json.Unmarshal([]byte(`{"min":9.9,"max":null}`), &r)
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	r.unmarshaled = true
+	return r.decodeJSON(data)
+}
+
+// decodeJSON replaces the Range with the JSON object or null in data.
+func (r *Range[T]) decodeJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		r.SetNull()
+		return nil
+	}
+	var decoded rangeJSON[T]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return wrapJSONError("Range", data, err)
+	}
+	r.lower, r.upper = decoded.Min, decoded.Max
+	r.lowerExclusive, r.upperExclusive = decoded.MinExclusive, decoded.MaxExclusive
+	r.valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner, accepting the JSON object form and PostgreSQL
+// range literals such as "[1,10)" alike. NULL produces a null Range.
+//
+// Example:
+//
+//	var r ztype.Range[int64]
+//	err := db.QueryRow("SELECT age_range FROM plans WHERE id = 1").Scan(&r)
+func (r *Range[T]) Scan(value any) error {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		r.SetNull()
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return newUnsupportedScanType(value)
+	}
+
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") || trimmed == "null" {
+		return r.decodeJSON([]byte(trimmed))
+	}
+	parsed, err := ParseRange[T](trimmed)
+	if err != nil {
+		return err
+	}
+	parsed.unmarshaled = r.unmarshaled
+	*r = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, returning the JSON object as a string or,
+// with SetRangeValueLiteral enabled, the PostgreSQL range literal. Null
+// Ranges are written as NULL.
+//
+// Example:
+//
+//	v, _ := ztype.MustParseRange[int]("[1,10)").Value() // {"min":1,"max":10,"maxExclusive":true}
+func (r Range[T]) Value() (driver.Value, error) {
+	return r.valueCodec(&defaultCodec)
+}
+
+// valueCodec implements Value with the options of c.
+func (r Range[T]) valueCodec(c *Codec) (driver.Value, error) {
+	if !r.valid {
+		return nil, nil
+	}
+	if c.rangeValueLiteral.Load() {
+		literal, err := r.appendLiteral(nil)
+		if err != nil {
+			return nil, err
+		}
+		return string(literal), nil
+	}
+	data, err := r.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// appendLiteral appends the PostgreSQL range literal of a valid Range, or
+// "empty" when it contains no values.
+func (r Range[T]) appendLiteral(dst []byte) ([]byte, error) {
+	if r.IsEmpty() {
+		return append(dst, "empty"...), nil
+	}
+	return r.appendBounds(dst)
+}
+
+// appendBounds appends the bounds of the Range in range literal syntax.
+func (r Range[T]) appendBounds(dst []byte) ([]byte, error) {
+	var err error
+	if r.LowerInclusive() {
+		dst = append(dst, '[')
+	} else {
+		dst = append(dst, '(')
+	}
+	if r.lower.value.Valid {
+		if dst, err = appendRangeBound(dst, r.lower.value.V); err != nil {
+			return nil, err
+		}
+	}
+	dst = append(dst, ',')
+	if r.upper.value.Valid {
+		if dst, err = appendRangeBound(dst, r.upper.value.V); err != nil {
+			return nil, err
+		}
+	}
+	if r.UpperInclusive() {
+		return append(dst, ']'), nil
+	}
+	return append(dst, ')'), nil
+}
+
+// appendRangeBound appends a bound in decimal notation. Infinite and NaN
+// float bounds are written as PostgreSQL's "infinity" and "NaN".
+func appendRangeBound[T NumberType](dst []byte, value T) ([]byte, error) {
+	switch f := float64(value); {
+	case math.IsNaN(f):
+		return append(dst, "NaN"...), nil
+	case math.IsInf(f, 1):
+		return append(dst, "infinity"...), nil
+	case math.IsInf(f, -1):
+		return append(dst, "-infinity"...), nil
+	}
+	return appendJSONNumber(dst, value)
+}
+
+// SchemaType reports Range as a nullable object with min and max.
+//
+// Example:
+//
+//	ztype.Range[int]{}.SchemaType() // "object", "", true
+func (r Range[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "object", "", true
+}
+
+// String returns the PostgreSQL range literal, such as "[1,10)" or "empty",
+// or "<NULL>" for null.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewRange(ztype.NewNumber(1), ztype.NewNumber(10))) // Output: [1,10]
+func (r Range[T]) String() string {
+	if !r.valid {
+		return nullToken()
+	}
+	literal, err := r.appendLiteral(nil)
+	if err != nil {
+		return nullToken()
+	}
+	return string(literal)
+}
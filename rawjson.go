@@ -0,0 +1,407 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// RawJSON represents a nullable JSON fragment that is carried through
+// untouched, such as signed payloads or documents of unknown shape.
+// It wraps json.RawMessage and tracks null state and unmarshaling presence.
+//
+// Example Usage:
+//
+//	var payload struct{ Extra ztype.RawJSON }
+//	json.Unmarshal([]byte(`{"Extra":{"b":1, "a":2}}`), &payload)
+//	payload.Extra.IsObject() // true
+//	data, _ := json.Marshal(payload) // {"Extra":{"b":1,"a":2}}, key order kept
+type RawJSON struct {
+	value       json.RawMessage
+	valid       bool
+	unmarshaled bool
+}
+
+// NewRawJSON creates a new valid RawJSON instance. The bytes are not
+// validated; call Validate when the source is untrusted.
+//
+// Example:
+//
+//	raw := ztype.NewRawJSON(json.RawMessage(`{"a":1}`))
+func NewRawJSON(value json.RawMessage) RawJSON {
+	return RawJSON{value: value, valid: true}
+}
+
+// NewNullRawJSON creates a new null RawJSON instance.
+//
+// Example:
+//
+//	raw := ztype.NewNullRawJSON()
+//	raw.IsNull() // true
+func NewNullRawJSON() RawJSON {
+	return RawJSON{valid: false}
+}
+
+// Get returns the raw bytes. When null, returns nil.
+//
+// Example:
+//
+//	raw.Get() // {"a":1}
+func (r *RawJSON) Get() json.RawMessage {
+	return r.value
+}
+
+// Val returns the raw document and whether it is valid. A valid RawJSON may
+// hold the JSON literal null; see IsNull for the difference.
+//
+// Example:
+//
+//	if doc, ok := r.Val(); ok { fmt.Println(string(doc)) }
+func (r RawJSON) Val() (json.RawMessage, bool) {
+	return r.value, r.valid
+}
+
+// Set updates the value and marks it as valid. The bytes are not validated.
+//
+// Example:
+//
+//	var raw ztype.RawJSON
+//	raw.Set(json.RawMessage(`[1,2]`))
+//	err := raw.Validate()
+func (r *RawJSON) Set(value json.RawMessage) {
+	r.value = value
+	r.valid = true
+}
+
+// SetNull marks the value as null and clears the bytes.
+//
+// Example:
+//
+//	raw.SetNull()
+func (r *RawJSON) SetNull() {
+	r.value = nil
+	r.valid = false
+}
+
+// IsNull returns true if the value is null.
+//
+// Example:
+//
+//	ztype.NewNullRawJSON().IsNull() // true
+func (r *RawJSON) IsNull() bool {
+	return !r.valid
+}
+
+// IsEmpty returns true if the value is null or holds no bytes. A valid
+// fragment holding the JSON null literal is not empty.
+//
+// Example:
+//
+//	ztype.NewRawJSON(nil).IsEmpty() // true
+func (r RawJSON) IsEmpty() bool {
+	return !r.valid || len(r.value) == 0
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	ztype.NewNullRawJSON().IsZero() // true
+func (r RawJSON) IsZero() bool {
+	return r.IsEmpty()
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values.
+//
+// Example:
+//
+//	raw.Unmarshaled()
+func (r *RawJSON) Unmarshaled() bool {
+	return r.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	raw.SetUnmarshaled(true)
+func (r *RawJSON) SetUnmarshaled(value bool) {
+	r.unmarshaled = value
+}
+
+// Validate returns an error when a valid RawJSON does not hold a single
+// well-formed JSON value. Null values are always valid.
+//
+// Example:
+//
+//	raw := ztype.NewRawJSON(json.RawMessage(`{`))
+//	err := raw.Validate() // error
+func (r *RawJSON) Validate() error {
+	if !r.valid {
+		return nil
+	}
+	if !json.Valid(r.value) {
+		return &ErrInvalidFormat{Type: "RawJSON", Input: string(r.value)}
+	}
+	return nil
+}
+
+// Decode unmarshals the fragment into dest. Decoding a null RawJSON behaves
+// like decoding the JSON null literal: maps, slices and pointers become nil.
+//
+// Example:
+//
+//	var data map[string]int
+//	err := raw.Decode(&data)
+func (r *RawJSON) Decode(dest any) error {
+	if !r.valid {
+		return json.Unmarshal([]byte("null"), dest)
+	}
+	return json.Unmarshal(r.value, dest)
+}
+
+// kind returns the first significant byte of the fragment, or 0 when empty.
+func (r *RawJSON) kind() byte {
+	trimmed := bytes.TrimLeft(r.value, " \t\r\n")
+	if !r.valid || len(trimmed) == 0 {
+		return 0
+	}
+	return trimmed[0]
+}
+
+// IsObject returns true if the fragment is a JSON object.
+//
+// Example:
+//
+//	ztype.NewRawJSON(json.RawMessage(`{}`)).IsObject() // true
+func (r *RawJSON) IsObject() bool {
+	return r.kind() == '{'
+}
+
+// IsArray returns true if the fragment is a JSON array.
+//
+// Example:
+//
+//	ztype.NewRawJSON(json.RawMessage(`[]`)).IsArray() // true
+func (r *RawJSON) IsArray() bool {
+	return r.kind() == '['
+}
+
+// IsString returns true if the fragment is a JSON string.
+//
+// Example:
+//
+//	ztype.NewRawJSON(json.RawMessage(`"a"`)).IsString() // true
+func (r *RawJSON) IsString() bool {
+	return r.kind() == '"'
+}
+
+// Compact returns the fragment with insignificant whitespace removed.
+// Returns nil for null values.
+//
+// Example:
+//
+//	raw := ztype.NewRawJSON(json.RawMessage(`{ "a": 1 }`))
+//	compact, _ := raw.Compact() // {"a":1}
+func (r *RawJSON) Compact() (json.RawMessage, error) {
+	if !r.valid {
+		return nil, nil
+	}
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, r.value); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Indent returns the fragment indented with the given prefix and indent.
+// Returns nil for null values.
+//
+// Example:
+//
+//	pretty, _ := raw.Indent("", "  ")
+func (r *RawJSON) Indent(prefix, indent string) (json.RawMessage, error) {
+	if !r.valid {
+		return nil, nil
+	}
+	var buffer bytes.Buffer
+	if err := json.Indent(&buffer, r.value, prefix, indent); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Equal performs byte-wise equality check including null state.
+//
+// Example:
+//
+//	a.Equal(b)
+func (r *RawJSON) Equal(other RawJSON) bool {
+	return r.valid == other.valid && bytes.Equal(r.value, other.value)
+}
+
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	r := ztype.NewRawJSON(json.RawMessage(`{}`))
+//	fmt.Println(r.EqualValues(ztype.NewRawJSON(json.RawMessage(`{}`))))  // Output: true
+func (r RawJSON) EqualValues(other RawJSON) bool {
+	return r.Equal(other)
+}
+
+// Clone returns a copy of the RawJSON with its own backing array, keeping its
+// null and unmarshaled state.
+//
+// Example:
+//
+//	r := ztype.NewRawJSON(json.RawMessage(`{"a":1}`))
+//	c := r.Clone()
+func (r RawJSON) Clone() RawJSON {
+	r.value = bytes.Clone(r.value)
+	return r
+}
+
+// EqualRaw compares the bytes while ignoring null state.
+//
+// Example:
+//
+//	raw.EqualRaw(json.RawMessage(`{}`))
+func (r *RawJSON) EqualRaw(other json.RawMessage) bool {
+	return bytes.Equal(r.value, other)
+}
+
+// MarshalJSON implements json.Marshaler, emitting the stored bytes verbatim.
+// Null and empty values marshal as null.
+//
+// Example:
+//
+//	data, _ := json.Marshal(raw)
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	if !r.valid || len(r.value) == 0 {
+		return []byte("null"), nil
+	}
+	return r.value, nil
+}
+
+// AppendJSON appends the stored JSON to dst, or null when null or empty.
+//
+// Example:
+//
+//	buf, _ = ztype.NewRawJSON(json.RawMessage(`{"a":1}`)).AppendJSON(buf) // {"a":1}
+func (r RawJSON) AppendJSON(dst []byte) ([]byte, error) {
+	if !r.valid || len(r.value) == 0 {
+		return append(dst, "null"...), nil
+	}
+	return append(dst, r.value...), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, storing a copy of data
+// since encoding/json may reuse its buffer.
+//
+// Example:
+//
+//	err := json.Unmarshal([]byte(`{"a":1}`), &raw)
+func (r *RawJSON) UnmarshalJSON(data []byte) error {
+	r.unmarshaled = true
+	return r.store(data)
+}
+
+// store copies data, treating the null document as a null value. Documents
+// over the limits of SetMaxJSONSize and SetMaxJSONDepth leave r null.
+func (r *RawJSON) store(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		r.SetNull()
+		return nil
+	}
+	if err := checkJSONLimits("RawJSON", data); err != nil {
+		r.SetNull()
+		return err
+	}
+	r.value = bytes.Clone(data)
+	r.valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner for database integration.
+// SQL NULL and the JSON document null both produce a null value.
+//
+// Example:
+//
+//	var raw ztype.RawJSON
+//	err := db.QueryRow("SELECT payload FROM events WHERE id = 1").Scan(&raw)
+func (r *RawJSON) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		r.SetNull()
+		return nil
+	case []byte:
+		return r.store(v)
+	case string:
+		return r.store([]byte(v))
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements driver.Valuer for database integration,
+// returning the raw bytes.
+//
+// Example:
+//
+//	val, _ := raw.Value()
+func (r RawJSON) Value() (driver.Value, error) {
+	if !r.valid {
+		return nil, nil
+	}
+	return []byte(r.value), nil
+}
+
+// ValueOrZero is like Value, but returns the JSON literal null instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullRawJSON().ValueOrZero() // []byte("null")
+func (r RawJSON) ValueOrZero() (driver.Value, error) {
+	if !r.valid {
+		return []byte("null"), nil
+	}
+	return r.Value()
+}
+
+// String implements fmt.Stringer for human-readable output.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullRawJSON()) // "<NULL>"
+func (r RawJSON) String() string {
+	if !r.valid {
+		return nullToken()
+	}
+	return string(r.value)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullRawJSON().StringOr("null"))  // Output: null
+func (r RawJSON) StringOr(fallback string) string {
+	if !r.valid {
+		return fallback
+	}
+	return r.String()
+}
+
+// SchemaType reports no type for RawJSON, since it accepts any JSON value.
+//
+// Example:
+//
+//	ztype.RawJSON{}.SchemaType() // "", "", true
+func (r RawJSON) SchemaType() (jsonType string, format string, nullable bool) {
+	return "", "", true
+}
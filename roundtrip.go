@@ -0,0 +1,126 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// RoundTripJSON marshals value to JSON, decodes the result into a new T and
+// marshals it again. It returns an error when any step fails, when the two
+// encodings differ, or when the decoded value changed null state. Encodings
+// that drop detail, such as a Time's location name, pass as long as the
+// decoded value encodes the same way. It is meant for fuzz targets and
+// testing/quick properties.
+//
+// Example:
+//
+//	f.Fuzz(func(t *testing.T, data []byte) {
+//		var s ztype.String
+//		if json.Unmarshal(data, &s) != nil {
+//			return
+//		}
+//		if err := ztype.RoundTripJSON(s); err != nil {
+//			t.Fatal(err)
+//		}
+//	})
+func RoundTripJSON[T json.Marshaler](value T) error {
+	first, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal %T: %w", value, err)
+	}
+	var decoded T
+	if err := json.Unmarshal(first, &decoded); err != nil {
+		return fmt.Errorf("unmarshal %T from %s: %w", value, first, err)
+	}
+	second, err := json.Marshal(decoded)
+	if err != nil {
+		return fmt.Errorf("marshal decoded %T: %w", value, err)
+	}
+	if !bytes.Equal(first, second) {
+		return fmt.Errorf("%T json round trip mismatch: %s != %s", value, first, second)
+	}
+	return checkRoundTripNull(value, decoded)
+}
+
+// RoundTripText is like RoundTripJSON for encoding.TextMarshaler. T must
+// implement encoding.TextUnmarshaler through its pointer. Text has no null
+// representation, so null values are not checked and return nil.
+//
+// Example:
+//
+//	err := ztype.RoundTripText(ztype.NewDuration(time.Minute))
+func RoundTripText[T encoding.TextMarshaler](value T) error {
+	if nullable, ok := any(&value).(Nullable); ok && nullable.IsNull() {
+		return nil
+	}
+	first, err := value.MarshalText()
+	if err != nil {
+		return fmt.Errorf("marshal text %T: %w", value, err)
+	}
+	var decoded T
+	unmarshaler, ok := any(&decoded).(encoding.TextUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%T does not implement encoding.TextUnmarshaler", &decoded)
+	}
+	if err := unmarshaler.UnmarshalText(first); err != nil {
+		return fmt.Errorf("unmarshal text %T from %q: %w", value, first, err)
+	}
+	second, err := decoded.MarshalText()
+	if err != nil {
+		return fmt.Errorf("marshal text decoded %T: %w", value, err)
+	}
+	if !bytes.Equal(first, second) {
+		return fmt.Errorf("%T text round trip mismatch: %q != %q", value, first, second)
+	}
+	return checkRoundTripNull(value, decoded)
+}
+
+// RoundTripSQL converts value with driver.Valuer, scans the result into a new
+// T and converts it again. It returns an error when any step fails or the two
+// driver values differ. T must implement sql.Scanner through its pointer.
+//
+// Example:
+//
+//	err := ztype.RoundTripSQL(ztype.NewNumber(42))
+func RoundTripSQL[T driver.Valuer](value T) error {
+	first, err := value.Value()
+	if err != nil {
+		return fmt.Errorf("value %T: %w", value, err)
+	}
+	var decoded T
+	scanner, ok := any(&decoded).(sql.Scanner)
+	if !ok {
+		return fmt.Errorf("%T does not implement sql.Scanner", &decoded)
+	}
+	if err := scanner.Scan(first); err != nil {
+		return fmt.Errorf("scan %T from %v: %w", value, first, err)
+	}
+	second, err := decoded.Value()
+	if err != nil {
+		return fmt.Errorf("value decoded %T: %w", value, err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		return fmt.Errorf("%T sql round trip mismatch: %#v != %#v", value, first, second)
+	}
+	return checkRoundTripNull(value, decoded)
+}
+
+// checkRoundTripNull returns an error when original and decoded are Nullable
+// and disagree on null state.
+func checkRoundTripNull[T any](original, decoded T) error {
+	originalNullable, ok := any(&original).(Nullable)
+	if !ok {
+		return nil
+	}
+	decodedNullable := any(&decoded).(Nullable)
+	if originalNullable.IsNull() != decodedNullable.IsNull() {
+		return fmt.Errorf("%T round trip changed null state: %t != %t",
+			original, originalNullable.IsNull(), decodedNullable.IsNull())
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rune represents a nullable unicode character, suited for CHAR(1) columns
+// holding multibyte content. It marshals as a one-character string rather
+// than as its code point number.
+//
+// Example Usage:
+//
+//	r := ztype.NewRune('é')
+//	data, _ := json.Marshal(r) // "é"
+type Rune struct {
+	value       rune
+	valid       bool
+	unmarshaled bool
+}
+
+// NewRune creates a new valid Rune instance.
+//
+// Example:
+//
+//	r := ztype.NewRune('a')
+//	fmt.Println(r.String()) // Output: a
+func NewRune(value rune) Rune {
+	return Rune{value: value, valid: true}
+}
+
+// NewNullRune creates a new null Rune instance.
+//
+// Example:
+//
+//	r := ztype.NewNullRune()
+//	fmt.Println(r.IsNull()) // Output: true
+func NewNullRune() Rune {
+	return Rune{valid: false}
+}
+
+// NewNullRuneIfZero creates a null Rune if the given value is zero;
+// otherwise, it returns a non-null Rune with the specified value.
+//
+// Example:
+//
+//	r := ztype.NewNullRuneIfZero(0)
+//	r.IsNull() // true
+func NewNullRuneIfZero(value rune) Rune {
+	if value == 0 {
+		return NewNullRune()
+	}
+	return NewRune(value)
+}
+
+// parseRune returns the single rune held by text, or an error when text is
+// empty, invalid UTF-8 or holds more than one rune.
+func parseRune(text string) (rune, error) {
+	value, size := utf8.DecodeRuneInString(text)
+	switch {
+	case text == "":
+		return 0, newInvalidFormat("Rune", text, "empty string")
+	case value == utf8.RuneError && size <= 1:
+		return 0, newInvalidFormat("Rune", text, "not valid UTF-8")
+	case size != len(text):
+		return 0, newInvalidFormat("Rune", text, "expected a single character, got %d runes",
+			utf8.RuneCountInString(text))
+	}
+	return value, nil
+}
+
+// Get returns the rune value. When null, returns 0.
+//
+// Example:
+//
+//	r := ztype.NewRune('x')
+//	fmt.Println(r.Get()) // Output: 120
+func (r *Rune) Get() rune {
+	return r.value
+}
+
+// Val returns the rune and whether it is valid.
+//
+// Example:
+//
+//	if c, ok := r.Val(); ok { fmt.Printf("%c", c) }
+func (r Rune) Val() (rune, bool) {
+	return r.value, r.valid
+}
+
+// Set updates the value and marks it as valid.
+//
+// Example:
+//
+//	var r ztype.Rune
+//	r.Set('ß')
+func (r *Rune) Set(value rune) {
+	r.value = value
+	r.valid = true
+}
+
+// SetNull marks the value as null and resets the rune.
+//
+// Example:
+//
+//	r.SetNull()
+//	fmt.Println(r.IsNull()) // Output: true
+func (r *Rune) SetNull() {
+	r.value = 0
+	r.valid = false
+}
+
+// IsNull returns true if the value is null.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullRune().IsNull()) // Output: true
+func (r *Rune) IsNull() bool {
+	return !r.valid
+}
+
+// IsEmpty returns true if the value is null or the NUL character.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewRune(0).IsEmpty()) // Output: true
+func (r Rune) IsEmpty() bool {
+	return !r.valid || r.value == 0
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewRune('a').IsZero()) // Output: false
+func (r Rune) IsZero() bool {
+	return r.IsEmpty()
+}
+
+// Unmarshaled returns true if the value was present in the data source,
+// including explicit null values. Returns false if the field was absent.
+//
+// Example:
+//
+//	var r ztype.Rune
+//	json.Unmarshal([]byte(`null`), &r)
+//	fmt.Println(r.Unmarshaled()) // Output: true
+func (r *Rune) Unmarshaled() bool {
+	return r.unmarshaled
+}
+
+// SetUnmarshaled manually sets the unmarshaled state.
+//
+// Example:
+//
+//	r.SetUnmarshaled(true)
+func (r *Rune) SetUnmarshaled(value bool) {
+	r.unmarshaled = value
+}
+
+// IsLetter returns true if the value is a valid unicode letter.
+//
+// Example:
+//
+//	ztype.NewRune('é').IsLetter() // true
+func (r *Rune) IsLetter() bool {
+	return r.valid && unicode.IsLetter(r.value)
+}
+
+// IsDigit returns true if the value is a valid unicode decimal digit.
+//
+// Example:
+//
+//	ztype.NewRune('7').IsDigit() // true
+func (r *Rune) IsDigit() bool {
+	return r.valid && unicode.IsDigit(r.value)
+}
+
+// IsSpace returns true if the value is a valid unicode white space character.
+//
+// Example:
+//
+//	ztype.NewRune('\t').IsSpace() // true
+func (r *Rune) IsSpace() bool {
+	return r.valid && unicode.IsSpace(r.value)
+}
+
+// Equal performs equality check including null state.
+//
+// Example:
+//
+//	ztype.NewRune('a').Equal(ztype.NewRune('a')) // true
+func (r *Rune) Equal(other Rune) bool {
+	return r.valid == other.valid && r.value == other.value
+}
+
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	r := ztype.NewRune('a')
+//	fmt.Println(r.EqualValues(ztype.NewRune('a')))  // Output: true
+func (r Rune) EqualValues(other Rune) bool {
+	return r.Equal(other)
+}
+
+// Clone returns a copy of the Rune, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	r := ztype.NewRune('a')
+//	c := r.Clone()
+func (r Rune) Clone() Rune {
+	return r
+}
+
+// EqualRaw compares the rune value while ignoring null state.
+//
+// Example:
+//
+//	ztype.NewRune('a').EqualRaw('a') // true
+func (r *Rune) EqualRaw(other rune) bool {
+	return r.value == other
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Returns the UTF-8 character for valid values, nil for null.
+//
+// Example:
+//
+//	data, _ := ztype.NewRune('é').MarshalText()
+//	fmt.Println(string(data)) // Output: é
+func (r Rune) MarshalText() ([]byte, error) {
+	if r.valid {
+		return utf8.AppendRune(nil, r.value), nil
+	}
+	return nil, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Rejects input that is not exactly one character.
+//
+// Example:
+//
+//	var r ztype.Rune
+//	err := r.UnmarshalText([]byte("é"))
+func (r *Rune) UnmarshalText(data []byte) error {
+	r.unmarshaled = true
+	return r.parse(string(data))
+}
+
+// parse stores the single rune held by text, leaving the value untouched on error.
+func (r *Rune) parse(text string) error {
+	value, err := parseRune(text)
+	if err != nil {
+		return err
+	}
+	r.Set(value)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Returns a one-character JSON string for valid values, null for null.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ztype.NewRune('a'))
+//	fmt.Println(string(data)) // Output: "a"
+func (r Rune) MarshalJSON() ([]byte, error) {
+	return r.AppendJSON(make([]byte, 0, 8))
+}
+
+// AppendJSON appends the JSON encoding of the Rune to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewRune('é').AppendJSON(buf) // "é"
+func (r Rune) AppendJSON(dst []byte) ([]byte, error) {
+	if !r.valid {
+		return append(dst, "null"...), nil
+	}
+	var encoded [utf8.UTFMax]byte
+	return appendJSONString(dst, utf8.AppendRune(encoded[:0], r.value)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Accepts a one-character string or null.
+//
+// Example:
+//
+//	var r ztype.Rune
+//	err := json.Unmarshal([]byte(`"ab"`), &r) // error: expected a single character
+func (r *Rune) UnmarshalJSON(data []byte) error {
+	r.unmarshaled = true
+	if bytes.Equal(data, []byte("null")) {
+		r.SetNull()
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return wrapJSONError("Rune", data, err)
+	}
+	return r.parse(text)
+}
+
+// Scan implements sql.Scanner for database integration.
+// Accepts one-character strings and []byte, and int64 code points.
+//
+// Example:
+//
+//	var r ztype.Rune
+//	err := db.QueryRow("SELECT initial FROM users WHERE id = 1").Scan(&r)
+func (r *Rune) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		r.SetNull()
+		return nil
+	case string:
+		return r.parse(v)
+	case []byte:
+		return r.parse(string(v))
+	case int64:
+		if v < 0 || v > unicode.MaxRune || !utf8.ValidRune(rune(v)) {
+			return &ErrOverflow{Type: "Rune", Value: strconv.FormatInt(v, 10)}
+		}
+		r.Set(rune(v))
+		return nil
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements driver.Valuer for database integration.
+// Returns the character as a string, nil for null.
+//
+// Example:
+//
+//	val, _ := ztype.NewRune('é').Value() // "é"
+func (r Rune) Value() (driver.Value, error) {
+	if !r.valid {
+		return nil, nil
+	}
+	return string(r.value), nil
+}
+
+// ValueOrZero is like Value, but returns the empty string, since text columns reject NUL instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullRune().ValueOrZero() // ""
+func (r Rune) ValueOrZero() (driver.Value, error) {
+	if !r.valid {
+		return "", nil
+	}
+	return r.Value()
+}
+
+// String returns human-readable representation.
+// Returns "<NULL>" for null values, the character otherwise.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullRune()) // Output: <NULL>
+func (r Rune) String() string {
+	if !r.valid {
+		return nullToken()
+	}
+	return string(r.value)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullRune().StringOr("-"))  // Output: -
+func (r Rune) StringOr(fallback string) string {
+	if !r.valid {
+		return fallback
+	}
+	return r.String()
+}
+
+// SchemaType reports Rune as a nullable one-character string.
+//
+// Example:
+//
+//	ztype.Rune{}.SchemaType() // "string", "", true
+func (r Rune) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "", true
+}
@@ -0,0 +1,174 @@
+package ztype
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	sqlScannerType = reflect.TypeFor[sql.Scanner]()
+	timeType       = reflect.TypeFor[time.Time]()
+)
+
+// ScanStruct scans the current row of rows into the struct pointed to by
+// dest. Columns are matched to fields by their `db` tag, falling back to a
+// case-insensitive match on the field name; `db:"-"` skips a field.
+// Embedded structs are flattened, and the fields of a nested struct field
+// are matched as "<parent>.<column>", one level deep. Fields without a
+// column are left untouched and NULL columns scan as null ztype values. It
+// returns an error listing the columns that match no field.
+//
+// Example:
+//
+//	type User struct {
+//		ID    ztype.Numeric[int64] `db:"id"`
+//		Email ztype.String         `db:"email"`
+//	}
+//	rows, _ := db.Query("SELECT id, email FROM users")
+//	for rows.Next() {
+//		var u User
+//		if err := ztype.ScanStruct(rows, &u); err != nil { /* ... */ }
+//	}
+func ScanStruct(rows *sql.Rows, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", dest)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	plan, err := newScanPlan(columns, value.Elem().Type())
+	if err != nil {
+		return err
+	}
+	return plan.scan(rows, value.Elem())
+}
+
+// ScanAll scans every remaining row of rows into a new T, which must be a
+// struct, matching columns as ScanStruct does. It closes rows and returns
+// rows.Err.
+//
+// Example:
+//
+//	rows, _ := db.Query("SELECT id, email FROM users")
+//	users, err := ztype.ScanAll[User](rows)
+func ScanAll[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct type, got %v", t)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := newScanPlan(columns, t)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []T
+	for rows.Next() {
+		var item T
+		if err := plan.scan(rows, reflect.ValueOf(&item).Elem()); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// scanPlan holds the field index path for each column of a result set.
+type scanPlan [][]int
+
+// newScanPlan matches columns to the fields of the struct type t.
+func newScanPlan(columns []string, t reflect.Type) (scanPlan, error) {
+	exact := map[string][]int{}
+	folded := map[string][]int{}
+	collectScanFields(t, nil, "", 0, exact, folded)
+
+	plan := make(scanPlan, len(columns))
+	var unmatched []string
+	for i, column := range columns {
+		if index, ok := exact[column]; ok {
+			plan[i] = index
+		} else if index, ok := folded[strings.ToLower(column)]; ok {
+			plan[i] = index
+		} else {
+			unmatched = append(unmatched, column)
+		}
+	}
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("no field of %v matches columns: %s", t, strings.Join(unmatched, ", "))
+	}
+	return plan, nil
+}
+
+// collectScanFields adds the column names of the fields of t to exact and,
+// lower-cased, to folded. Earlier fields win over later ones and outer fields
+// over embedded ones, as with encoding/json.
+func collectScanFields(t reflect.Type, index []int, prefix string, depth int, exact, folded map[string][]int) {
+	var embedded []reflect.StructField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("db"), ",")
+		if name == "-" {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if isNestedScanStruct(field.Type) {
+			if field.Anonymous && name == "" {
+				field.Index = fieldIndex
+				embedded = append(embedded, field)
+			} else if depth == 0 && field.IsExported() {
+				if name == "" {
+					name = field.Name
+				}
+				collectScanFields(field.Type, fieldIndex, prefix+name+".", depth+1, exact, folded)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name != "" {
+			if _, ok := exact[prefix+name]; !ok {
+				exact[prefix+name] = fieldIndex
+			}
+		} else {
+			name = field.Name
+		}
+		if _, ok := folded[strings.ToLower(prefix+name)]; !ok {
+			folded[strings.ToLower(prefix+name)] = fieldIndex
+		}
+	}
+	for _, field := range embedded {
+		collectScanFields(field.Type, field.Index, prefix, depth, exact, folded)
+	}
+}
+
+// isNestedScanStruct reports whether t is a plain struct whose fields are
+// matched to columns, as opposed to a value scanned as a whole.
+func isNestedScanStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(sqlScannerType)
+}
+
+// scan scans the current row into the addressable struct value.
+func (p scanPlan) scan(rows *sql.Rows, value reflect.Value) error {
+	targets := make([]any, len(p))
+	for i, index := range p {
+		targets[i] = value.FieldByIndex(index).Addr().Interface()
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return fmt.Errorf("scan %v: %w", value.Type(), err)
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package ztype
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SchemaTyper is implemented by every type in the package so that JSON
+// Schema and OpenAPI generators can document ztype fields. jsonType is the
+// JSON Schema type ("string", "integer", "number", "boolean", "array" or
+// "object"), or empty when any JSON value is accepted; format is the OpenAPI
+// format, if any; nullable reports whether the field may encode as null.
+//
+// Example:
+//
+//	jsonType, format, nullable := ztype.Time{}.SchemaType()
+//	// "string", "date-time", true
+type SchemaTyper interface {
+	SchemaType() (jsonType string, format string, nullable bool)
+}
+
+// schemaItemer is implemented by the collection types to expose the Go type
+// of their items, or of their values for maps.
+type schemaItemer interface {
+	schemaItem() reflect.Type
+}
+
+// schemaWrapper is implemented by Null to expose the Go type it wraps, so
+// its fragment can describe the wrapped struct, slice or map in full.
+type schemaWrapper interface {
+	schemaValue() reflect.Type
+}
+
+// schemaEnumer is implemented by Enum to expose its registered values.
+type schemaEnumer interface {
+	schemaEnum() []string
+}
+
+var (
+	_ SchemaTyper = Bool{}
+	_ SchemaTyper = Byte{}
+	_ SchemaTyper = Char{}
+	_ SchemaTyper = Bytes{}
+	_ SchemaTyper = String{}
+	_ SchemaTyper = Numeric[int]{}
+	_ SchemaTyper = Time{}
+	_ SchemaTyper = Duration{}
+	_ SchemaTyper = Rune{}
+	_ SchemaTyper = Enum[string]{}
+	_ SchemaTyper = IP{}
+	_ SchemaTyper = CIDR{}
+	_ SchemaTyper = RawJSON{}
+	_ SchemaTyper = Money{}
+	_ SchemaTyper = Range[int]{}
+	_ SchemaTyper = Map[string, any]{}
+	_ SchemaTyper = OrderedMap[string, any]{}
+	_ SchemaTyper = (*SyncMap[string, any])(nil)
+	_ SchemaTyper = Slice[any]{}
+	_ SchemaTyper = Set[int]{}
+	_ SchemaTyper = Array[String]{}
+	_ SchemaTyper = Null[any]{}
+	_ SchemaTyper = NotNullColumn[String]{}
+)
+
+var (
+	schemaTyperType    = reflect.TypeFor[SchemaTyper]()
+	jsonRawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// JSONSchemaFor returns a JSON Schema fragment describing the struct v, or
+// the struct v points to. Properties are named after their `json` tags, with
+// `json:"-"` fields skipped and embedded structs flattened as encoding/json
+// does. ztype fields are described through SchemaType, with "items" for
+// slices and sets, "additionalProperties" for maps and "enum" for Enum types
+// with registered values; plain Go fields are mapped from their kind, and
+// pointers are marked nullable.
+//
+// Example:
+//
+//	type User struct {
+//		ID        ztype.Numeric[int64] `json:"id"`
+//		CreatedAt ztype.Time           `json:"created_at"`
+//	}
+//	schema, err := ztype.JSONSchemaFor(User{})
+//	// {"type":"object","properties":{
+//	//   "id":{"type":"integer","format":"int64","nullable":true},
+//	//   "created_at":{"type":"string","format":"date-time","nullable":true}}}
+func JSONSchemaFor(v any) (map[string]any, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || isSchemaTyper(t) || t == timeType {
+		return nil, fmt.Errorf("expected a struct or a pointer to a struct, got %T", v)
+	}
+	return schemaForType(t, map[reflect.Type]bool{})
+}
+
+// isSchemaTyper reports whether t or *t implements SchemaTyper.
+func isSchemaTyper(t reflect.Type) bool {
+	return t.Implements(schemaTyperType) || reflect.PointerTo(t).Implements(schemaTyperType)
+}
+
+// schemaForType returns the schema fragment of t. visiting holds the struct
+// types being described, to reject recursive types.
+func schemaForType(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	if isSchemaTyper(t) {
+		return schemaForTyper(t, visiting)
+	}
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case jsonRawMessageType:
+		return map[string]any{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		schema["nullable"] = true
+		return schema, nil
+	case reflect.Struct:
+		return schemaForStruct(t, visiting)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]any{"type": "string", "format": "byte"}, nil
+		}
+		items, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	}
+
+	jsonType, format := schemaKind(t)
+	if jsonType == "" {
+		return nil, fmt.Errorf("unsupported type %v", t)
+	}
+	schema := map[string]any{"type": jsonType}
+	if format != "" {
+		schema["format"] = format
+	}
+	return schema, nil
+}
+
+// schemaForTyper builds the fragment of a type implementing SchemaTyper.
+func schemaForTyper(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	value := reflect.New(t)
+	if t.Implements(schemaTyperType) {
+		value = value.Elem()
+	}
+	jsonType, format, nullable := value.Interface().(SchemaTyper).SchemaType()
+
+	if wrapper, ok := value.Interface().(schemaWrapper); ok {
+		schema, err := schemaForType(wrapper.schemaValue(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		if nullable {
+			schema["nullable"] = true
+		}
+		return schema, nil
+	}
+
+	schema := map[string]any{}
+	if jsonType != "" {
+		schema["type"] = jsonType
+	}
+	if format != "" {
+		schema["format"] = format
+	}
+	if nullable {
+		schema["nullable"] = true
+	}
+
+	if enumer, ok := value.Interface().(schemaEnumer); ok {
+		if values := enumer.schemaEnum(); len(values) > 0 {
+			schema["enum"] = values
+		}
+	}
+	if itemer, ok := value.Interface().(schemaItemer); ok {
+		items, err := schemaForType(itemer.schemaItem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		switch jsonType {
+		case "array":
+			schema["items"] = items
+		case "object":
+			schema["additionalProperties"] = items
+		}
+	}
+	return schema, nil
+}
+
+// schemaForStruct builds an object fragment with one property per JSON field.
+func schemaForStruct(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("recursive type %v", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	properties := map[string]any{}
+	if err := collectSchemaProperties(t, visiting, properties); err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": "object", "properties": properties}, nil
+}
+
+// collectSchemaProperties adds the properties of the fields of t, descending
+// into embedded structs without a json name. Outer fields win over embedded
+// ones.
+func collectSchemaProperties(t reflect.Type, visiting map[reflect.Type]bool, properties map[string]any) error {
+	var embedded []reflect.Type
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct && !isSchemaTyper(fieldType) && fieldType != timeType {
+				embedded = append(embedded, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema, err := schemaForType(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		properties[name] = schema
+	}
+
+	for _, embeddedType := range embedded {
+		inner := map[string]any{}
+		if err := collectSchemaProperties(embeddedType, visiting, inner); err != nil {
+			return err
+		}
+		for name, schema := range inner {
+			if _, ok := properties[name]; !ok {
+				properties[name] = schema
+			}
+		}
+	}
+	return nil
+}
+
+// schemaKind maps a basic kind to its JSON Schema type and OpenAPI format.
+// It returns empty strings for kinds JSON cannot represent.
+func schemaKind(t reflect.Type) (jsonType string, format string) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", ""
+	case reflect.String:
+		return "string", ""
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "integer", "int32"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer", "int64"
+	case reflect.Float32:
+		return "number", "float"
+	case reflect.Float64:
+		return "number", "double"
+	}
+	return "", ""
+}
+
+// schemaTypeOf returns the JSON Schema type and format of the Go type T, as
+// used by Null[T].
+func schemaTypeOf[T any]() (jsonType string, format string) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Interface {
+		return "", ""
+	}
+	if t == timeType {
+		return "string", "date-time"
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object", ""
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return "string", "byte"
+		}
+		return "array", ""
+	case reflect.Pointer:
+		return "", ""
+	}
+	return schemaKind(t)
+}
+
+// schemaNumberType returns the JSON Schema type and format of the number
+// type T.
+func schemaNumberType[T NumberType]() (jsonType string, format string) {
+	return schemaKind(reflect.TypeFor[T]())
+}
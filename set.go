@@ -0,0 +1,461 @@
+package ztype
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Set is a generic nullable set, stored in JSON and SQL as an array.
+// Marshaling emits items in a deterministic order: the order given to
+// SetLess, or the natural order for strings and numbers. Other item types
+// are ordered by their JSON encoding. Decoding drops duplicate items.
+//
+// Null propagation follows Map: Intersect and Difference keep the receiver's
+// null state, and Union is null only when every input is null.
+//
+// Example:
+//
+//	tags := NewSet("b", "a", "b")
+//	data, _ := json.Marshal(tags) // ["a","b"]
+type Set[T comparable] struct {
+	value       map[T]struct{}
+	less        func(a, b T) bool
+	valid       bool
+	unmarshaled bool
+}
+
+// NewSet creates a new valid Set holding the given items.
+//
+// Example:
+//
+//	s := NewSet(1, 2, 2) // {1, 2}
+func NewSet[T comparable](items ...T) Set[T] {
+	s := Set[T]{value: make(map[T]struct{}, len(items)), valid: true}
+	for _, item := range items {
+		s.value[item] = struct{}{}
+	}
+	return s
+}
+
+// NewNullSet creates a new Set that is marked as null (invalid).
+//
+// Example:
+//
+//	s := NewNullSet[string]()
+func NewNullSet[T comparable]() Set[T] {
+	return Set[T]{valid: false}
+}
+
+// NewNullSetIfZero creates a new Set that is null if no items are given,
+// otherwise returns a valid Set.
+//
+// Example:
+//
+//	s := NewNullSetIfZero[string]() // null Set
+func NewNullSetIfZero[T comparable](items ...T) Set[T] {
+	if len(items) == 0 {
+		return NewNullSet[T]()
+	}
+	return NewSet(items...)
+}
+
+// SetLess sets the order used when marshaling the Set.
+//
+// Example:
+//
+//	s.SetLess(func(a, b Point) bool { return a.X < b.X })
+func (s *Set[T]) SetLess(less func(a, b T) bool) {
+	s.less = less
+}
+
+// Add inserts items and marks the Set as valid. It is safe on the zero value.
+//
+// Example:
+//
+//	var s Set[string]
+//	s.Add("a", "b")
+func (s *Set[T]) Add(items ...T) {
+	if s.value == nil {
+		s.value = make(map[T]struct{}, len(items))
+	}
+	for _, item := range items {
+		s.value[item] = struct{}{}
+	}
+	s.valid = true
+}
+
+// Remove deletes items from the Set.
+//
+// Example:
+//
+//	s.Remove("a")
+func (s *Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s.value, item)
+	}
+}
+
+// Has returns true if item is in the Set.
+//
+// Example:
+//
+//	if s.Has("a") { /* ... */ }
+func (s Set[T]) Has(item T) bool {
+	_, ok := s.value[item]
+	return ok
+}
+
+// Len returns the number of items. Null Sets always report 0.
+//
+// Example:
+//
+//	fmt.Println(s.Len())
+func (s Set[T]) Len() int {
+	return len(s.value)
+}
+
+// SetNull marks the Set as null and clears its content.
+//
+// Example:
+//
+//	s.SetNull()
+func (s *Set[T]) SetNull() {
+	s.value = nil
+	s.valid = false
+}
+
+// IsNull returns true if the Set is null (invalid).
+//
+// Example:
+//
+//	if s.IsNull() { /* ... */ }
+func (s Set[T]) IsNull() bool {
+	return !s.valid
+}
+
+// IsZero returns true if the Set holds no items, which includes null Sets.
+//
+// Example:
+//
+//	fmt.Println(NewSet[int]().IsZero()) // true
+func (s Set[T]) IsZero() bool {
+	return len(s.value) == 0
+}
+
+// IsEmpty is an alias for IsZero.
+//
+// Example:
+//
+//	fmt.Println(NewNullSet[int]().IsEmpty()) // true
+func (s Set[T]) IsEmpty() bool {
+	return s.IsZero()
+}
+
+// Unmarshaled returns true if the Set has been unmarshaled from JSON.
+//
+// Example:
+//
+//	fmt.Println(s.Unmarshaled())
+func (s Set[T]) Unmarshaled() bool {
+	return s.unmarshaled
+}
+
+// SetUnmarshaled sets the unmarshaled flag.
+//
+// Example:
+//
+//	s.SetUnmarshaled(true)
+func (s *Set[T]) SetUnmarshaled(value bool) {
+	s.unmarshaled = value
+}
+
+// Values returns a sequence of all items, in no particular order.
+//
+// Example:
+//
+//	for item := range s.Values() { fmt.Println(item) }
+func (s Set[T]) Values() iter.Seq[T] {
+	return maps.Keys(s.value)
+}
+
+// Slice returns the items as a slice, in marshaling order.
+//
+// Example:
+//
+//	NewSet(3, 1, 2).Slice() // [1 2 3]
+func (s Set[T]) Slice() []T {
+	items := slices.AppendSeq(make([]T, 0, len(s.value)), maps.Keys(s.value))
+	if s.less != nil {
+		slices.SortStableFunc(items, func(a, b T) int {
+			switch {
+			case s.less(a, b):
+				return -1
+			case s.less(b, a):
+				return 1
+			}
+			return 0
+		})
+		return items
+	}
+	sortSetItems(items)
+	return items
+}
+
+// sortSetItems sorts items by their natural order when T is a string or
+// number kind, and by their JSON encoding otherwise.
+func sortSetItems[T comparable](items []T) {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.String:
+		slices.SortFunc(items, func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		})
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		slices.SortFunc(items, func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		})
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		slices.SortFunc(items, func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		})
+	case reflect.Float32, reflect.Float64:
+		slices.SortFunc(items, func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		})
+	default:
+		encoded := make(map[T][]byte, len(items))
+		for _, item := range items {
+			data, _ := json.Marshal(item)
+			encoded[item] = data
+		}
+		slices.SortFunc(items, func(a, b T) int {
+			return bytes.Compare(encoded[a], encoded[b])
+		})
+	}
+}
+
+// Clone returns a copy of the Set, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	c := s.Clone()
+func (s Set[T]) Clone() Set[T] {
+	s.value = maps.Clone(s.value)
+	return s
+}
+
+// Union returns a new Set with the items of the receiver and all others.
+// Null inputs are skipped; the result is null only when every input is null.
+//
+// Example:
+//
+//	NewSet(1).Union(NewSet(2)) // {1, 2}
+func (s Set[T]) Union(others ...Set[T]) Set[T] {
+	result := s.Clone()
+	for _, other := range others {
+		if !other.valid {
+			continue
+		}
+		result.Add(slices.Collect(other.Values())...)
+	}
+	return result
+}
+
+// Intersect returns a new Set with the items present in both Sets.
+// A null receiver produces a null Set; a null other acts as an empty Set.
+//
+// Example:
+//
+//	NewSet(1, 2).Intersect(NewSet(2, 3)) // {2}
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	return s.filter(func(item T) bool { return other.Has(item) })
+}
+
+// Difference returns a new Set with the items of the receiver that are not in other.
+// A null receiver produces a null Set; a null other acts as an empty Set.
+//
+// Example:
+//
+//	NewSet(1, 2).Difference(NewSet(2)) // {1}
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	return s.filter(func(item T) bool { return !other.Has(item) })
+}
+
+// filter returns a copy of the Set with only the items accepted by keep.
+func (s Set[T]) filter(keep func(T) bool) Set[T] {
+	if !s.valid {
+		return Set[T]{less: s.less}
+	}
+	result := make(map[T]struct{})
+	for item := range s.value {
+		if keep(item) {
+			result[item] = struct{}{}
+		}
+	}
+	s.value = result
+	return s
+}
+
+// Equal returns true if both Sets have the same null state and items.
+//
+// Example:
+//
+//	NewSet(1, 2).Equal(NewSet(2, 1)) // true
+func (s Set[T]) Equal(other Set[T]) bool {
+	if s.valid != other.valid || len(s.value) != len(other.value) {
+		return false
+	}
+	for item := range s.value {
+		if !other.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualValues is Equal under the name shared by every type: it compares
+// value and null state and ignores the unmarshaled flag.
+//
+// Example:
+//
+//	s := ztype.NewSet("a")
+//	fmt.Println(s.EqualValues(ztype.NewSet("a")))  // Output: true
+func (s Set[T]) EqualValues(other Set[T]) bool {
+	return s.Equal(other)
+}
+
+// MarshalJSON implements the json.Marshaler interface, emitting a sorted array.
+// Null Sets marshal as null and valid empty Sets as [].
+//
+// Example:
+//
+//	json.Marshal(s)
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	if !s.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, dropping duplicates.
+//
+// Example:
+//
+//	json.Unmarshal([]byte(`["a","a"]`), &s) // {"a"}
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	s.unmarshaled = true
+	return s.decode(data)
+}
+
+// decode parses a JSON array, treating the null document as a null Set.
+func (s *Set[T]) decode(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		s.SetNull()
+		return nil
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return wrapJSONError("Set", data, err)
+	}
+	s.value = nil
+	s.Add(items...)
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, reading a JSON array.
+//
+// Example:
+//
+//	var s Set[string]
+//	db.QueryRow(...).Scan(&s)
+func (s *Set[T]) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		s.SetNull()
+		return nil
+	case string:
+		return s.decode([]byte(v))
+	case []byte:
+		return s.decode(v)
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements the driver.Valuer interface, writing a sorted JSON array.
+//
+// Example:
+//
+//	val, err := s.Value()
+func (s Set[T]) Value() (driver.Value, error) {
+	if !s.valid {
+		return nil, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// ValueOrZero is like Value, but returns an empty JSON array instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullSet[string]().ValueOrZero() // "[]"
+func (s Set[T]) ValueOrZero() (driver.Value, error) {
+	if !s.valid {
+		return NewSet[T]().Value()
+	}
+	return s.Value()
+}
+
+// String returns the JSON representation of the Set, or "<NULL>" when null.
+//
+// Example:
+//
+//	fmt.Println(NewSet(2, 1)) // [1,2]
+func (s Set[T]) String() string {
+	if !s.valid {
+		return nullToken()
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("%v", s.Slice())
+	}
+	return string(data)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullSet[string]().StringOr("[]"))  // Output: []
+func (s Set[T]) StringOr(fallback string) string {
+	if !s.valid {
+		return fallback
+	}
+	return s.String()
+}
+
+// SchemaType reports Set as a nullable JSON array.
+//
+// Example:
+//
+//	ztype.Set[string]{}.SchemaType() // "array", "", true
+func (s Set[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "array", "", true
+}
+
+// schemaItem returns the item type, described as items.
+func (s Set[T]) schemaItem() reflect.Type {
+	return reflect.TypeFor[T]()
+}
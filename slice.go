@@ -0,0 +1,569 @@
+package ztype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// Slice is a generic nullable slice. It distinguishes a null slice from a valid
+// empty one in both JSON (null vs []) and SQL (NULL vs '[]'), and tracks whether
+// it has been unmarshaled from JSON. Scan and Value use a JSON document, which
+// suits json/jsonb array columns.
+//
+// Example:
+//
+//	tags := NewSlice([]string{"a", "b"})
+//	tags.Append("c")
+//	data, _ := json.Marshal(tags) // ["a","b","c"]
+type Slice[T any] struct {
+	value       []T
+	valid       bool
+	unmarshaled bool
+}
+
+// NewSlice creates a new Slice with the given items and marks it as valid.
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 2, 3})
+func NewSlice[T any](value []T) Slice[T] {
+	return Slice[T]{value: value, valid: true}
+}
+
+// NewNullSlice creates a new Slice that is marked as null (invalid).
+//
+// Example:
+//
+//	s := NewNullSlice[int]()
+func NewNullSlice[T any]() Slice[T] {
+	return Slice[T]{valid: false}
+}
+
+// NewNullSliceIfZero creates a new Slice that is null if the input slice is empty,
+// otherwise returns a valid Slice.
+//
+// Example:
+//
+//	s := NewNullSliceIfZero([]int{})  // null Slice
+//	s2 := NewNullSliceIfZero([]int{1}) // valid Slice
+func NewNullSliceIfZero[T any](value []T) Slice[T] {
+	if len(value) == 0 {
+		return NewNullSlice[T]()
+	}
+	return NewSlice(value)
+}
+
+// Get returns the underlying slice.
+//
+// Example:
+//
+//	s := NewSlice([]int{1})
+//	v := s.Get() // []int{1}
+func (s Slice[T]) Get() []T {
+	return s.value
+}
+
+// Val returns the elements and whether the Slice is valid.
+//
+// Example:
+//
+//	if items, ok := s.Val(); ok { fmt.Println(len(items)) }
+func (s Slice[T]) Val() ([]T, bool) {
+	return s.value, s.valid
+}
+
+// Set sets the underlying slice and marks the Slice as valid.
+//
+// Example:
+//
+//	var s Slice[int]
+//	s.Set([]int{1, 2})
+func (s *Slice[T]) Set(value []T) {
+	s.value = value
+	s.valid = true
+}
+
+// SetNull marks the Slice as null and clears its content.
+//
+// Example:
+//
+//	s := NewSlice([]int{1})
+//	s.SetNull()
+func (s *Slice[T]) SetNull() {
+	s.value = nil
+	s.valid = false
+}
+
+// IsNull returns true if the Slice is null (invalid).
+//
+// Example:
+//
+//	s := NewNullSlice[int]()
+//	if s.IsNull() { /* true */ }
+func (s Slice[T]) IsNull() bool {
+	return !s.valid
+}
+
+// IsZero returns true if the Slice holds no items, which includes null Slices.
+//
+// Example:
+//
+//	s := NewSlice([]int{})
+//	fmt.Println(s.IsZero()) // true
+func (s Slice[T]) IsZero() bool {
+	return len(s.value) == 0
+}
+
+// IsEmpty is an alias for IsZero.
+//
+// Example:
+//
+//	s := NewNullSlice[int]()
+//	fmt.Println(s.IsEmpty()) // true
+func (s Slice[T]) IsEmpty() bool {
+	return s.IsZero()
+}
+
+// Len returns the number of items. Null Slices always report 0.
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 2})
+//	fmt.Println(s.Len()) // 2
+func (s Slice[T]) Len() int {
+	return len(s.value)
+}
+
+// Unmarshaled returns true if the Slice has been unmarshaled from JSON.
+//
+// Example:
+//
+//	var s Slice[int]
+//	json.Unmarshal([]byte(`[1]`), &s)
+//	fmt.Println(s.Unmarshaled()) // true
+func (s Slice[T]) Unmarshaled() bool {
+	return s.unmarshaled
+}
+
+// SetUnmarshaled sets the unmarshaled flag.
+//
+// Example:
+//
+//	var s Slice[int]
+//	s.SetUnmarshaled(true)
+func (s *Slice[T]) SetUnmarshaled(value bool) {
+	s.unmarshaled = value
+}
+
+// EqualValues reports whether both hold deeply equal values and the same
+// null state, ignoring the unmarshaled flag. Two null Slices are equal, and
+// a nil slice equals an empty one.
+//
+// Example:
+//
+//	s := ztype.NewSlice([]int{1})
+//	fmt.Println(s.EqualValues(ztype.NewSlice([]int{1})))  // Output: true
+func (s Slice[T]) EqualValues(other Slice[T]) bool {
+	if s.valid != other.valid {
+		return false
+	}
+	if !s.valid {
+		return true
+	}
+	return slices.EqualFunc(s.value, other.value, func(a, b T) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
+
+// GetItem returns the item at the given index, and a boolean indicating
+// whether the index is in range.
+//
+// Example:
+//
+//	s := NewSlice([]int{1})
+//	v, ok := s.GetItem(0) // v=1, ok=true
+func (s Slice[T]) GetItem(index int) (T, bool) {
+	if index < 0 || index >= len(s.value) {
+		var zero T
+		return zero, false
+	}
+	return s.value[index], true
+}
+
+// Append adds items to the end of the Slice and marks it as valid.
+// It is safe on the zero value.
+//
+// Example:
+//
+//	var s Slice[int]
+//	s.Append(1, 2)
+func (s *Slice[T]) Append(items ...T) {
+	if s.value == nil {
+		s.value = make([]T, 0, len(items))
+	}
+	s.value = append(s.value, items...)
+	s.valid = true
+}
+
+// Insert inserts items at the given index, shifting later items, and marks
+// the Slice as valid. Returns an error when index is out of range [0:Len()].
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 3})
+//	err := s.Insert(1, 2) // [1, 2, 3]
+func (s *Slice[T]) Insert(index int, items ...T) error {
+	if index < 0 || index > len(s.value) {
+		return fmt.Errorf("index %d out of range [0:%d]", index, len(s.value))
+	}
+	if s.value == nil {
+		s.value = []T{}
+	}
+	s.value = slices.Insert(s.value, index, items...)
+	s.valid = true
+	return nil
+}
+
+// RemoveAt removes and returns the item at the given index.
+// Returns an error when index is out of range.
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 2, 3})
+//	v, err := s.RemoveAt(1) // v=2, s=[1, 3]
+func (s *Slice[T]) RemoveAt(index int) (T, error) {
+	if index < 0 || index >= len(s.value) {
+		var zero T
+		return zero, fmt.Errorf("index %d out of range [0:%d)", index, len(s.value))
+	}
+	item := s.value[index]
+	s.value = slices.Delete(s.value, index, index+1)
+	return item, nil
+}
+
+// All returns a sequence of all index-item pairs.
+//
+// Example:
+//
+//	for i, item := range s.All() { /* ... */ }
+func (s Slice[T]) All() iter.Seq2[int, T] {
+	return slices.All(s.value)
+}
+
+// Values returns a sequence of all items.
+//
+// Example:
+//
+//	for item := range s.Values() { /* ... */ }
+func (s Slice[T]) Values() iter.Seq[T] {
+	return slices.Values(s.value)
+}
+
+// Filter returns a new Slice containing only items where filter(index, item) is true.
+// A null Slice produces a null Slice.
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 2, 3})
+//	odd := s.Filter(func(i, v int) bool { return v%2 == 1 }) // [1, 3]
+func (s Slice[T]) Filter(filter func(int, T) bool) Slice[T] {
+	if !s.valid {
+		return NewNullSlice[T]()
+	}
+	result := []T{}
+	for index, item := range s.value {
+		if filter(index, item) {
+			result = append(result, item)
+		}
+	}
+	s.value = result
+	return s
+}
+
+// MapValues returns a new Slice with every item replaced by fn(index, item).
+// A null Slice produces a null Slice.
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 2})
+//	doubled := s.MapValues(func(i, v int) int { return v * 2 }) // [2, 4]
+func (s Slice[T]) MapValues(fn func(int, T) T) Slice[T] {
+	if !s.valid {
+		return NewNullSlice[T]()
+	}
+	result := make([]T, len(s.value))
+	for index, item := range s.value {
+		result[index] = fn(index, item)
+	}
+	s.value = result
+	return s
+}
+
+// Clone returns a copy of the Slice with its own backing array,
+// keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	s := NewSlice([]int{1})
+//	c := s.Clone()
+func (s Slice[T]) Clone() Slice[T] {
+	s.value = slices.Clone(s.value)
+	return s
+}
+
+// CloneDeep returns a copy of the Slice whose items share no maps, slices or
+// pointers with the original, keeping its null and unmarshaled state. Items
+// must not contain cycles.
+//
+// Example:
+//
+//	s := NewSlice([]map[string]int{{"a": 1}})
+//	c := s.CloneDeep()
+//	c.Get()[0]["a"] = 2 // s is unchanged
+func (s Slice[T]) CloneDeep() Slice[T] {
+	s.value = deepCopy(s.value)
+	return s
+}
+
+// TransformSlice returns a new Slice with every item of s converted by fn.
+// A null Slice produces a null Slice.
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 2})
+//	labels := TransformSlice(s, func(i, v int) string { return strconv.Itoa(v) })
+func TransformSlice[T any, U any](s Slice[T], fn func(int, T) U) Slice[U] {
+	if !s.valid {
+		return NewNullSlice[U]()
+	}
+	result := make([]U, len(s.value))
+	for index, item := range s.value {
+		result[index] = fn(index, item)
+	}
+	return Slice[U]{value: result, valid: true, unmarshaled: s.unmarshaled}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Null Slices marshal as null and valid empty Slices as [].
+//
+// Example:
+//
+//	json.Marshal(s)
+func (s Slice[T]) MarshalJSON() ([]byte, error) {
+	return s.appendJSONCodec(&defaultCodec, nil)
+}
+
+// appendJSONCodec implements MarshalJSON with the options of c.
+func (s Slice[T]) appendJSONCodec(c *Codec, dst []byte) ([]byte, error) {
+	if !s.valid {
+		return append(dst, "null"...), nil
+	}
+	if s.value == nil {
+		return append(dst, "[]"...), nil
+	}
+	return c.appendJSONValue(dst, reflect.ValueOf(s.value))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Example:
+//
+//	json.Unmarshal(data, &s)
+func (s *Slice[T]) UnmarshalJSON(data []byte) error {
+	return s.unmarshalJSONCodec(&defaultCodec, data)
+}
+
+// unmarshalJSONCodec implements UnmarshalJSON with the options of c.
+func (s *Slice[T]) unmarshalJSONCodec(c *Codec, data []byte) error {
+	s.unmarshaled = true
+	return s.decode(c, data)
+}
+
+// decode parses a JSON array with the options of c, treating the null
+// document as a null Slice.
+func (s *Slice[T]) decode(c *Codec, data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		s.SetNull()
+		return nil
+	}
+
+	result := []T{}
+	if err := c.unmarshalJSONValue(data, &result); err != nil {
+		return wrapJSONError("Slice", data, err)
+	}
+	s.value = result
+	s.valid = true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, reading a JSON array.
+// SQL NULL and the JSON document null both produce a null Slice.
+//
+// Example:
+//
+//	var s Slice[string]
+//	db.QueryRow(...).Scan(&s)
+func (s *Slice[T]) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		s.SetNull()
+		return nil
+	case string:
+		return s.decode(&defaultCodec, []byte(v))
+	case []byte:
+		return s.decode(&defaultCodec, v)
+	}
+	return newUnsupportedScanType(value)
+}
+
+// Value implements the driver.Valuer interface, writing a JSON array.
+// Null Slices are written as SQL NULL and valid empty Slices as '[]'.
+//
+// Example:
+//
+//	val, err := s.Value()
+func (s Slice[T]) Value() (driver.Value, error) {
+	return s.valueCodec(&defaultCodec)
+}
+
+// valueCodec implements Value with the options of c.
+func (s Slice[T]) valueCodec(c *Codec) (driver.Value, error) {
+	if !s.valid {
+		return nil, nil
+	}
+	data, err := s.appendJSONCodec(c, nil)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// ValueOrZero is like Value, but returns an empty JSON array instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullSlice[int]().ValueOrZero() // "[]"
+func (s Slice[T]) ValueOrZero() (driver.Value, error) {
+	if !s.valid {
+		return NewSlice([]T{}).Value()
+	}
+	return s.Value()
+}
+
+// String returns the JSON representation of the Slice, or "<NULL>" when null.
+//
+// Example:
+//
+//	s := NewSlice([]int{1, 2})
+//	fmt.Println(s.String()) // [1,2]
+func (s Slice[T]) String() string {
+	if !s.valid {
+		return nullToken()
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("%v", s.value)
+	}
+	return string(data)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullSlice[int]().StringOr("[]"))  // Output: []
+func (s Slice[T]) StringOr(fallback string) string {
+	if !s.valid {
+		return fallback
+	}
+	return s.String()
+}
+
+// SchemaType reports Slice as a nullable JSON array.
+//
+// Example:
+//
+//	ztype.Slice[int]{}.SchemaType() // "array", "", true
+func (s Slice[T]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "array", "", true
+}
+
+// schemaItem returns the item type, described as items.
+func (s Slice[T]) schemaItem() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// SliceComparable embeds Slice[T] and adds methods
+// useful when items are comparable.
+//
+// Example:
+//
+//	var s SliceComparable[string]
+//	s.Append("a")
+//	s.Contains("a") // true
+type SliceComparable[T comparable] struct {
+	Slice[T]
+}
+
+// Clone returns a copy of the SliceComparable with its own backing array.
+//
+// Example:
+//
+//	c := s.Clone()
+func (s SliceComparable[T]) Clone() SliceComparable[T] {
+	return SliceComparable[T]{Slice: s.Slice.Clone()}
+}
+
+// NewSliceComparable creates a new valid SliceComparable with the given items.
+//
+// Example:
+//
+//	s := NewSliceComparable([]string{"a"})
+func NewSliceComparable[T comparable](value []T) SliceComparable[T] {
+	return SliceComparable[T]{Slice: NewSlice(value)}
+}
+
+// Contains returns true if item is present in the Slice.
+//
+// Example:
+//
+//	s := NewSliceComparable([]int{1, 2})
+//	s.Contains(2) // true
+func (s SliceComparable[T]) Contains(item T) bool {
+	return slices.Contains(s.value, item)
+}
+
+// Index returns the index of the first occurrence of item, or -1 if not present.
+//
+// Example:
+//
+//	s := NewSliceComparable([]int{1, 2})
+//	s.Index(2) // 1
+func (s SliceComparable[T]) Index(item T) int {
+	return slices.Index(s.value, item)
+}
+
+// Equal returns true if both Slices have the same null state and items.
+//
+// Example:
+//
+//	equal := s1.Equal(s2)
+func (s SliceComparable[T]) Equal(other SliceComparable[T]) bool {
+	return s.valid == other.valid && slices.Equal(s.value, other.value)
+}
+
+// EqualRaw compares the items with a raw slice, ignoring null state.
+//
+// Example:
+//
+//	s := NewSliceComparable([]int{1})
+//	s.EqualRaw([]int{1}) // true
+func (s SliceComparable[T]) EqualRaw(other []T) bool {
+	return slices.Equal(s.value, other)
+}
@@ -5,8 +5,55 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"sync/atomic"
+	"unique"
 )
 
+// stringInterner holds the hook set with SetStringInterner; nil disables it.
+var stringInterner atomic.Pointer[func(string) string]
+
+// SetStringInterner installs fn to normalize every string String decodes in
+// UnmarshalJSON, UnmarshalText and Scan, so a column with few distinct values
+// can share one copy of each instead of holding millions of equal strings.
+// fn must return a string equal to its argument, and must be safe for
+// concurrent use since decoding may run on many goroutines. nil removes the
+// hook. InternString is a ready-made interner backed by the unique package.
+//
+// The decoded string is still allocated before fn sees it; what interning
+// saves is the memory retained by the decoded values.
+//
+// Example:
+//
+//	ztype.SetStringInterner(ztype.InternString)
+//	defer ztype.SetStringInterner(nil)
+func SetStringInterner(fn func(string) string) {
+	if fn == nil {
+		stringInterner.Store(nil)
+		return
+	}
+	stringInterner.Store(&fn)
+}
+
+// InternString returns the canonical copy of s, shared by every equal string
+// interned before it. Unused copies are reclaimed by the garbage collector.
+//
+// Example:
+//
+//	a := ztype.InternString(strings.Clone("active"))
+//	b := ztype.InternString(strings.Clone("active"))
+//	unsafe.StringData(a) == unsafe.StringData(b) // true
+func InternString(s string) string {
+	return unique.Make(s).Value()
+}
+
+// intern applies the hook set with SetStringInterner to s.
+func intern(s string) string {
+	if fn := stringInterner.Load(); fn != nil {
+		return (*fn)(s)
+	}
+	return s
+}
+
 // String represents a nullable string compatible with SQL NULL and JSON null.
 //
 // Example declarations:
@@ -67,6 +114,16 @@ func (s *String) Get() string {
 	return s.value.String
 }
 
+// Val returns the string and whether it is valid. Prefer it to Get where an
+// empty string and null mean different things.
+//
+// Example:
+//
+//	if name, ok := s.Val(); ok { greet(name) }
+func (s String) Val() (string, bool) {
+	return s.value.String, s.value.Valid
+}
+
 // Set updates the string value and marks it as valid.
 //
 // Example:
@@ -110,7 +167,7 @@ func (s *String) IsNull() bool {
 //	s2 := ztype.NewString("")
 //	s1.IsEmpty() // true
 //	s2.IsEmpty() // true
-func (s *String) IsEmpty() bool {
+func (s String) IsEmpty() bool {
 	return !s.value.Valid || s.value.String == ""
 }
 
@@ -120,7 +177,7 @@ func (s *String) IsEmpty() bool {
 //
 //	s := ztype.NewString("")
 //	s.IsZero() // true
-func (s *String) IsZero() bool {
+func (s String) IsZero() bool {
 	return s.IsEmpty()
 }
 
@@ -159,6 +216,28 @@ func (s *String) Equal(other String) bool {
 		s.value.Valid == other.value.Valid
 }
 
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	s := ztype.NewString("a")
+//	fmt.Println(s.EqualValues(ztype.NewString("a")))  // Output: true
+func (s String) EqualValues(other String) bool {
+	return s.Equal(other)
+}
+
+// Clone returns a copy of the String, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	s := ztype.NewString("a")
+//	c := s.Clone()
+func (s String) Clone() String {
+	return s
+}
+
 // EqualRaw compares value ignoring null state.
 //
 // Example:
@@ -177,7 +256,7 @@ func (s *String) EqualRaw(other string) bool {
 //	s := ztype.NewString("text")
 //	data, _ := s.MarshalText()
 //	string(data) // "text"
-func (s *String) MarshalText() ([]byte, error) {
+func (s String) MarshalText() ([]byte, error) {
 	if s.value.Valid {
 		return []byte(s.value.String), nil
 	}
@@ -194,7 +273,7 @@ func (s *String) MarshalText() ([]byte, error) {
 //	s.Unmarshaled() // true
 func (s *String) UnmarshalText(data []byte) error {
 	s.unmarshaled = true
-	s.value.String = string(data)
+	s.value.String = intern(string(data))
 	s.value.Valid = true
 	return nil
 }
@@ -206,11 +285,21 @@ func (s *String) UnmarshalText(data []byte) error {
 //	s := ztype.NewNullString()
 //	data, _ := json.Marshal(s)
 //	string(data) // "null"
-func (s *String) MarshalJSON() ([]byte, error) {
-	if s.value.Valid {
-		return json.Marshal(s.value.String)
+func (s String) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(make([]byte, 0, jsonStringCapacity(len(s.value.String))))
+}
+
+// AppendJSON appends the JSON encoding of the String to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewString(`a"b`).AppendJSON(buf) // "a\"b"
+func (s String) AppendJSON(dst []byte) ([]byte, error) {
+	if !s.value.Valid {
+		return append(dst, "null"...), nil
 	}
-	return []byte("null"), nil
+	return appendJSONString(dst, s.value.String), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -227,8 +316,13 @@ func (s *String) UnmarshalJSON(data []byte) error {
 		s.value.String = ""
 		return nil
 	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return wrapJSONError("String", data, err)
+	}
+	s.value.String = intern(value)
 	s.value.Valid = true
-	return json.Unmarshal(data, &s.value.String)
+	return nil
 }
 
 // Scan implements sql.Scanner for database integration.
@@ -239,7 +333,15 @@ func (s *String) UnmarshalJSON(data []byte) error {
 //	s.Scan("scanned-value")
 //	s.Get() // "scanned-value"
 func (s *String) Scan(value any) error {
-	return s.value.Scan(value)
+	scanned := s.value
+	if err := scanned.Scan(value); err != nil {
+		return wrapScanError("String", value, err)
+	}
+	if scanned.Valid {
+		scanned.String = intern(scanned.String)
+	}
+	s.value = scanned
+	return nil
 }
 
 // Value implements driver.Valuer for database integration.
@@ -253,15 +355,63 @@ func (s String) Value() (driver.Value, error) {
 	return s.value.Value()
 }
 
+// ValueOrZero is like Value, but returns the empty string instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullString().ValueOrZero() // ""
+func (s String) ValueOrZero() (driver.Value, error) {
+	if !s.value.Valid {
+		return "", nil
+	}
+	return s.Value()
+}
+
 // String implements fmt.Stringer for human-readable output.
 //
 // Example:
 //
 //	s := ztype.NewNullString()
 //	fmt.Println(s) // "<NULL>"
-func (s *String) String() string {
+func (s String) String() string {
 	if !s.value.Valid {
-		return "<NULL>"
+		return nullToken()
 	}
 	return s.value.String
 }
+
+// AppendString appends the String output to dst without allocating when
+// dst has enough capacity.
+//
+// Example:
+//
+//	buf = ztype.NewString("ana").AppendString(buf[:0]) // "ana"
+func (s String) AppendString(dst []byte) []byte {
+	if !s.value.Valid {
+		return append(dst, nullToken()...)
+	}
+	return append(dst, s.value.String...)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	cell := ztype.NewNullString().StringOr("") // ""
+func (s String) StringOr(fallback string) string {
+	if !s.value.Valid {
+		return fallback
+	}
+	return s.String()
+}
+
+// SchemaType reports String as a nullable JSON string.
+//
+// Example:
+//
+//	ztype.String{}.SchemaType() // "string", "", true
+func (s String) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "", true
+}
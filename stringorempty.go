@@ -0,0 +1,219 @@
+package ztype
+
+import (
+	"database/sql/driver"
+)
+
+// StringOrEmpty wraps a String for clients that cannot handle JSON null in
+// string fields: it marshals null as "" instead of null. Decoding still goes
+// to the wrapped String in V, so Unmarshaled and IsNull keep telling "" and
+// null apart, and Scan and Value keep SQL NULL as NULL. Use it per field
+// where plain String would write null.
+//
+// Example:
+//
+//	type Profile struct {
+//		Name     ztype.String        `json:"name"`
+//		Nickname ztype.StringOrEmpty `json:"nickname"`
+//	}
+//	data, _ := json.Marshal(Profile{}) // {"name":null,"nickname":""}
+type StringOrEmpty struct {
+	V String
+}
+
+// NewStringOrEmpty creates a non-null StringOrEmpty with initial value.
+//
+// Example:
+//
+//	s := ztype.NewStringOrEmpty("ana")
+//	s.Get() // "ana"
+func NewStringOrEmpty(value string) StringOrEmpty {
+	return StringOrEmpty{V: NewString(value)}
+}
+
+// WithEmptyOnNull wraps value so that it is marshaled as "" instead of null.
+//
+// Example:
+//
+//	resp.Nickname = ztype.WithEmptyOnNull(user.Nickname)
+func WithEmptyOnNull(value String) StringOrEmpty {
+	return StringOrEmpty{V: value}
+}
+
+// Get returns the wrapped string, empty if null.
+//
+// Example:
+//
+//	ztype.NewStringOrEmpty("ana").Get() // "ana"
+func (s StringOrEmpty) Get() string {
+	return s.V.value.String
+}
+
+// Set updates the wrapped string and marks it as valid.
+//
+// Example:
+//
+//	s.Set("ana")
+func (s *StringOrEmpty) Set(value string) {
+	s.V.Set(value)
+}
+
+// IsNull returns true if the wrapped String is null.
+//
+// Example:
+//
+//	var s ztype.StringOrEmpty
+//	s.IsNull() // true
+func (s *StringOrEmpty) IsNull() bool {
+	return s.V.IsNull()
+}
+
+// SetNull marks the wrapped String as null.
+//
+// Example:
+//
+//	s.SetNull()
+func (s *StringOrEmpty) SetNull() {
+	s.V.SetNull()
+}
+
+// IsEmpty returns true if the wrapped String is null or empty, the cases
+// MarshalJSON writes as "".
+//
+// Example:
+//
+//	ztype.NewStringOrEmpty("").IsEmpty() // true
+func (s StringOrEmpty) IsEmpty() bool {
+	return s.V.IsEmpty()
+}
+
+// IsZero is an alias for IsEmpty, so omitzero leaves out both null and "".
+//
+// Example:
+//
+//	ztype.StringOrEmpty{}.IsZero() // true
+func (s StringOrEmpty) IsZero() bool {
+	return s.IsEmpty()
+}
+
+// Unmarshaled returns true if the wrapped String was present in the decoded
+// input.
+//
+// Example:
+//
+//	s.Unmarshaled()
+func (s *StringOrEmpty) Unmarshaled() bool {
+	return s.V.Unmarshaled()
+}
+
+// SetUnmarshaled sets the unmarshaled state of the wrapped String.
+//
+// Example:
+//
+//	s.SetUnmarshaled(true)
+func (s *StringOrEmpty) SetUnmarshaled(value bool) {
+	s.V.SetUnmarshaled(value)
+}
+
+// MarshalText implements encoding.TextMarshaler. Null is the empty text, as
+// it is for String.
+//
+// Example:
+//
+//	data, _ := ztype.NewStringOrEmpty("ana").MarshalText() // "ana"
+func (s StringOrEmpty) MarshalText() ([]byte, error) {
+	return s.V.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by decoding into the
+// wrapped String.
+//
+// Example:
+//
+//	err := s.UnmarshalText([]byte("ana"))
+func (s *StringOrEmpty) UnmarshalText(data []byte) error {
+	return s.V.UnmarshalText(data)
+}
+
+// MarshalJSON implements json.Marshaler, writing "" when the wrapped String
+// is null.
+//
+// Example:
+//
+//	data, _ := json.Marshal(ztype.StringOrEmpty{}) // ""
+func (s StringOrEmpty) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(make([]byte, 0, jsonStringCapacity(len(s.V.value.String))))
+}
+
+// AppendJSON appends the JSON encoding of the StringOrEmpty to dst, the same
+// bytes MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.StringOrEmpty{}.AppendJSON(buf) // ""
+func (s StringOrEmpty) AppendJSON(dst []byte) ([]byte, error) {
+	return appendJSONString(dst, s.V.value.String), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding into the wrapped
+// String, so null still makes it null.
+//
+// Example:
+//
+//	err := json.Unmarshal([]byte(`null`), &s)
+//	s.IsNull() // true
+func (s *StringOrEmpty) UnmarshalJSON(data []byte) error {
+	return s.V.UnmarshalJSON(data)
+}
+
+// Scan implements sql.Scanner by scanning into the wrapped String.
+//
+// Example:
+//
+//	err := row.Scan(&p.Nickname)
+func (s *StringOrEmpty) Scan(value any) error {
+	return s.V.Scan(value)
+}
+
+// Value implements driver.Valuer. Null is still written as NULL; only JSON
+// writes "".
+//
+// Example:
+//
+//	v, _ := ztype.StringOrEmpty{}.Value() // nil
+func (s StringOrEmpty) Value() (driver.Value, error) {
+	return s.V.Value()
+}
+
+// ValueOrZero returns the string value, or "" when null. See ZeroValuer.
+//
+// Example:
+//
+//	v, _ := ztype.StringOrEmpty{}.ValueOrZero() // ""
+func (s StringOrEmpty) ValueOrZero() (driver.Value, error) {
+	return s.V.ValueOrZero()
+}
+
+// String returns the wrapped string, empty if null.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewStringOrEmpty("ana")) // ana
+func (s StringOrEmpty) String() string {
+	return s.V.value.String
+}
+
+// stringCodec keeps String for Codec.String, since null is "" whatever the
+// null token.
+func (s StringOrEmpty) stringCodec(c *Codec) string {
+	return s.String()
+}
+
+// SchemaType reports a non-nullable string, since null is marshaled as "".
+//
+// Example:
+//
+//	ztype.StringOrEmpty{}.SchemaType() // "string", "", false
+func (s StringOrEmpty) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "", false
+}
@@ -0,0 +1,416 @@
+package ztype
+
+import (
+	"database/sql/driver"
+	"iter"
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// SyncMap is a concurrency-safe variant of Map. Every operation is guarded by
+// an internal sync.RWMutex, and iteration works on a snapshot so the lock is
+// never held while user code runs.
+//
+// A SyncMap must not be copied after first use; pass it around by pointer.
+//
+// Example:
+//
+//	cache := NewSyncMap(map[string]any{"a": 1})
+//	go cache.SetItem("b", 2)
+//	v, ok := cache.GetItem("a") // v=1, ok=true
+type SyncMap[K comparable, V any] struct {
+	mu    sync.RWMutex
+	inner Map[K, V]
+}
+
+// NewSyncMap creates a new valid SyncMap holding the given map.
+//
+// Example:
+//
+//	m := NewSyncMap(map[string]int{"a": 1})
+func NewSyncMap[K comparable, V any](value map[K]V) *SyncMap[K, V] {
+	return &SyncMap[K, V]{inner: NewMap(value)}
+}
+
+// NewNullSyncMap creates a new SyncMap that is marked as null (invalid).
+//
+// Example:
+//
+//	m := NewNullSyncMap[string, int]()
+func NewNullSyncMap[K comparable, V any]() *SyncMap[K, V] {
+	return &SyncMap[K, V]{inner: NewNullMap[K, V]()}
+}
+
+// Get returns a copy of the underlying map.
+//
+// Example:
+//
+//	m := NewSyncMap(map[string]int{"a": 1})
+//	raw := m.Get() // map[string]int{"a": 1}
+func (m *SyncMap[K, V]) Get() map[K]V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.CloneRaw()
+}
+
+// Val returns a copy of the map and whether the SyncMap is valid, read
+// under a single lock.
+//
+// Example:
+//
+//	if snapshot, ok := m.Val(); ok { fmt.Println(len(snapshot)) }
+func (m *SyncMap[K, V]) Val() (map[K]V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.CloneRaw(), m.inner.valid
+}
+
+// Set replaces the underlying map and marks the SyncMap as valid.
+//
+// Example:
+//
+//	var m SyncMap[string, int]
+//	m.Set(map[string]int{"a": 1})
+func (m *SyncMap[K, V]) Set(value map[K]V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inner.Set(value)
+}
+
+// Snapshot returns a Map holding a copy of the current content.
+//
+// Example:
+//
+//	snapshot := m.Snapshot()
+//	fmt.Println(snapshot.Len())
+func (m *SyncMap[K, V]) Snapshot() Map[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.Clone()
+}
+
+// Clone returns a new SyncMap holding a copy of the current content, with
+// its own lock.
+//
+// Example:
+//
+//	c := m.Clone()
+//	c.SetItem("b", 2) // m is unchanged
+func (m *SyncMap[K, V]) Clone() *SyncMap[K, V] {
+	return &SyncMap[K, V]{inner: m.Snapshot()}
+}
+
+// CloneDeep is like Clone, but also deep-copies keys and values as
+// Map.CloneDeep does.
+//
+// Example:
+//
+//	c := m.CloneDeep()
+func (m *SyncMap[K, V]) CloneDeep() *SyncMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &SyncMap[K, V]{inner: m.inner.CloneDeep()}
+}
+
+// GetItem returns the value associated with the given key, and a boolean indicating existence.
+//
+// Example:
+//
+//	val, ok := m.GetItem("a")
+func (m *SyncMap[K, V]) GetItem(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.GetItem(key)
+}
+
+// SetItem sets the value for the given key and marks the SyncMap as valid.
+//
+// Example:
+//
+//	var m SyncMap[string, int]
+//	m.SetItem("a", 42)
+func (m *SyncMap[K, V]) SetItem(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inner.SetItem(key, value)
+}
+
+// DeleteItem removes the item with the given key and returns its value and true,
+// or zero value and false if key does not exist.
+//
+// Example:
+//
+//	val, ok := m.DeleteItem("a")
+func (m *SyncMap[K, V]) DeleteItem(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.DeleteItem(key)
+}
+
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores and returns the given value. The loaded result
+// is true if the value was loaded, false if stored.
+//
+// Example:
+//
+//	actual, loaded := m.LoadOrStore("a", 1)
+func (m *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if item, ok := m.inner.GetItem(key); ok {
+		return item, true
+	}
+	m.inner.SetItem(key, value)
+	return value, false
+}
+
+// Has returns true if the key exists in the SyncMap and the SyncMap is valid.
+//
+// Example:
+//
+//	if m.Has("a") { /* ... */ }
+func (m *SyncMap[K, V]) Has(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.Has(key)
+}
+
+// Len returns the number of items in the SyncMap.
+//
+// Example:
+//
+//	fmt.Println(m.Len())
+func (m *SyncMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.Len()
+}
+
+// All returns a sequence over a snapshot of all key-value pairs.
+// Mutations made during iteration are not observed.
+//
+// Example:
+//
+//	for key, value := range m.All() { /* ... */ }
+func (m *SyncMap[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m.Get())
+}
+
+// SetNull marks the SyncMap as null and clears its content.
+//
+// Example:
+//
+//	m.SetNull()
+func (m *SyncMap[K, V]) SetNull() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inner.SetNull()
+}
+
+// IsNull returns true if the SyncMap is null (invalid).
+//
+// Example:
+//
+//	if m.IsNull() { /* ... */ }
+func (m *SyncMap[K, V]) IsNull() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.IsNull()
+}
+
+// IsEmpty returns true if the SyncMap holds no items, which includes null
+// SyncMaps.
+//
+// Example:
+//
+//	if m.IsEmpty() { /* ... */ }
+func (m *SyncMap[K, V]) IsEmpty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.IsEmpty()
+}
+
+// IsZero is an alias for IsEmpty.
+//
+// Example:
+//
+//	if m.IsZero() { /* ... */ }
+func (m *SyncMap[K, V]) IsZero() bool {
+	return m.IsEmpty()
+}
+
+// Unmarshaled returns true if the SyncMap has been unmarshaled from JSON.
+//
+// Example:
+//
+//	fmt.Println(m.Unmarshaled())
+func (m *SyncMap[K, V]) Unmarshaled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.Unmarshaled()
+}
+
+// SetUnmarshaled sets the unmarshaled flag.
+//
+// Example:
+//
+//	m.SetUnmarshaled(true)
+func (m *SyncMap[K, V]) SetUnmarshaled(value bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inner.SetUnmarshaled(value)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The read lock is held while encoding, so concurrent writers wait.
+//
+// Example:
+//
+//	json.Marshal(m)
+func (m *SyncMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Example:
+//
+//	json.Unmarshal(data, m)
+func (m *SyncMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.UnmarshalJSON(data)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization.
+//
+// Example:
+//
+//	db.QueryRow(...).Scan(m)
+func (m *SyncMap[K, V]) Scan(value any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.Scan(value)
+}
+
+// Value implements the driver.Valuer interface for database serialization.
+// Unlike the other types it has a pointer receiver, since SyncMap holds a
+// sync.RWMutex and must not be copied.
+//
+// Example:
+//
+//	val, err := m.Value()
+func (m *SyncMap[K, V]) Value() (driver.Value, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.Value()
+}
+
+// ValueOrZero is like Value, but returns an empty JSON object instead of
+// NULL when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullSyncMap[string, int]().ValueOrZero() // `{}`
+func (m *SyncMap[K, V]) ValueOrZero() (driver.Value, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.ValueOrZero()
+}
+
+// String returns the JSON string representation of the SyncMap.
+//
+// Example:
+//
+//	fmt.Println(m.String())
+func (m *SyncMap[K, V]) String() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.String()
+}
+
+// StringOr returns String for valid values and fallback for null, where
+// String writes "{}".
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullSyncMap[string, int]().StringOr("null"))  // Output: null
+func (m *SyncMap[K, V]) StringOr(fallback string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inner.StringOr(fallback)
+}
+
+// SchemaType reports SyncMap as a nullable JSON object. It is safe to call
+// on a nil pointer.
+//
+// Example:
+//
+//	(*ztype.SyncMap[string, int])(nil).SchemaType() // "object", "", true
+func (m *SyncMap[K, V]) SchemaType() (jsonType string, format string, nullable bool) {
+	return "object", "", true
+}
+
+// schemaItem returns the value type, described as additionalProperties.
+func (m *SyncMap[K, V]) schemaItem() reflect.Type {
+	return reflect.TypeFor[V]()
+}
+
+// SyncMapComparable embeds SyncMap and adds atomic operations
+// that require comparable values.
+//
+// Example:
+//
+//	var m SyncMapComparable[string, int]
+//	m.SetItem("a", 1)
+//	swapped := m.CompareAndSwap("a", 1, 2) // true
+type SyncMapComparable[K comparable, V comparable] struct {
+	SyncMap[K, V]
+}
+
+// Clone returns a new SyncMapComparable holding a copy of the current
+// content, with its own lock.
+//
+// Example:
+//
+//	c := m.Clone()
+func (m *SyncMapComparable[K, V]) Clone() *SyncMapComparable[K, V] {
+	return &SyncMapComparable[K, V]{SyncMap: SyncMap[K, V]{inner: m.Snapshot()}}
+}
+
+// CompareAndSwap sets the value for key to replacement only if the current value is equal to old.
+// Returns true if the swap was performed.
+//
+// Example:
+//
+//	swapped := m.CompareAndSwap("a", 1, 3)
+func (m *SyncMapComparable[K, V]) CompareAndSwap(key K, old, replacement V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item, ok := m.inner.GetItem(key)
+	if !ok || item != old {
+		return false
+	}
+	m.inner.SetItem(key, replacement)
+	return true
+}
+
+// CompareAndDelete deletes the key only if its current value equals value.
+// Returns true if the key was deleted.
+//
+// Example:
+//
+//	deleted := m.CompareAndDelete("a", 3)
+func (m *SyncMapComparable[K, V]) CompareAndDelete(key K, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item, ok := m.inner.GetItem(key)
+	if !ok || item != value {
+		return false
+	}
+	m.inner.DeleteItem(key)
+	return true
+}
@@ -0,0 +1,100 @@
+package ztype
+
+import (
+	"fmt"
+	"reflect"
+	"text/template"
+	"time"
+)
+
+// TemplateFuncs returns helpers for rendering ztype values with text/template
+// and html/template, whose FuncMap types are the same:
+//
+//   - deref returns the underlying value of a ztype value, or nil when null;
+//     plain pointers are dereferenced too.
+//   - orElse returns the fallback when the value is null or a nil pointer,
+//     otherwise its underlying value. The fallback comes first so it can be
+//     used in a pipeline.
+//   - isNull reports whether the value is null or a nil pointer.
+//   - fmtTime formats a Time, Null[time.Time] or time.Time with the given
+//     layout, rendering null as the empty string.
+//
+// ztype values print through String, so {{ .Name }} renders the value, or
+// the null token when null. For conditions use {{ if .Active.Truth }} on
+// Bool and {{ if not (isNull .Name) }} on other types, since any struct is
+// truthy in a template.
+//
+// Example:
+//
+//	tmpl := template.Must(template.New("user").Funcs(ztype.TemplateFuncs()).Parse(
+//		`{{ .Name | orElse "anonymous" }} joined {{ .CreatedAt | fmtTime "2006-01-02" }}`))
+//	err := tmpl.Execute(w, user)
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"deref":   templateDeref,
+		"orElse":  templateOrElse,
+		"isNull":  templateIsNull,
+		"fmtTime": templateFormatTime,
+	}
+}
+
+// templateDeref returns the underlying value of value, or nil when it is
+// null or a nil pointer.
+func templateDeref(value any) any {
+	if value == nil {
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		if _, ok := value.(Nullable); !ok {
+			return templateDeref(v.Elem().Interface())
+		}
+	} else {
+		pointer := reflect.New(v.Type())
+		pointer.Elem().Set(v)
+		v = pointer
+	}
+
+	nullable, ok := v.Interface().(Nullable)
+	if !ok {
+		return value
+	}
+	if nullable.IsNull() {
+		return nil
+	}
+	get := v.MethodByName("Get")
+	if get.IsValid() && get.Type().NumIn() == 0 && get.Type().NumOut() == 1 {
+		return get.Call(nil)[0].Interface()
+	}
+	return value
+}
+
+// templateOrElse returns fallback when value is null, otherwise its
+// underlying value.
+func templateOrElse(fallback, value any) any {
+	if underlying := templateDeref(value); underlying != nil {
+		return underlying
+	}
+	return fallback
+}
+
+// templateIsNull reports whether value is null or a nil pointer.
+func templateIsNull(value any) bool {
+	return templateDeref(value) == nil
+}
+
+// templateFormatTime formats value with layout, returning the empty string
+// when it is null.
+func templateFormatTime(layout string, value any) (string, error) {
+	switch underlying := templateDeref(value).(type) {
+	case nil:
+		return "", nil
+	case time.Time:
+		return underlying.Format(layout), nil
+	default:
+		return "", fmt.Errorf("fmtTime: expected a time value, got %T", value)
+	}
+}
@@ -0,0 +1,340 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// appendCents is a number type with its own JSON encoding.
+type appendCents int64
+
+func (c appendCents) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(c), 10) + "c")
+}
+
+var appendStrings = []string{
+	"",
+	"plain ascii",
+	`quote " and backslash \`,
+	"<script>&amp;</script>",
+	"\b\f\n\r\t",
+	"\x00\x01\x1f\x7f",
+	"ação 日本 🎉",
+	"invalid \xff\xfe utf-8",
+	"truncated \xe6\x97",
+	"separators \u2028 \u2029",
+}
+
+// checkAppendJSON asserts that AppendJSON and MarshalJSON of value both
+// produce the bytes json.Marshal gives for want, the previous encoding.
+func checkAppendJSON(t *testing.T, value interface {
+	json.Marshaler
+	ztype.JSONAppender
+}, want any) {
+	t.Helper()
+	expected, err := json.Marshal(want)
+	require.NoError(t, err)
+
+	appended, err := value.AppendJSON([]byte("prefix:"))
+	require.NoError(t, err)
+	assert.Equal(t, "prefix:"+string(expected), string(appended))
+
+	marshaled, err := value.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(marshaled))
+}
+
+func TestAppendJSONMatchesEncodingJSON(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		for _, s := range appendStrings {
+			checkAppendJSON(t, ztype.NewString(s), s)
+		}
+		checkAppendJSON(t, ztype.NewNullString(), nil)
+	})
+	t.Run("Int", func(t *testing.T) {
+		for _, n := range []int64{0, 1, -1, math.MaxInt64, math.MinInt64} {
+			checkAppendJSON(t, ztype.NewNumber(n), n)
+		}
+		checkAppendJSON(t, ztype.NewNumber[int8](math.MinInt8), int8(math.MinInt8))
+		checkAppendJSON(t, ztype.NewNumber[uint64](math.MaxUint64), uint64(math.MaxUint64))
+		checkAppendJSON(t, ztype.NewNumber[uintptr](42), uintptr(42))
+		checkAppendJSON(t, ztype.NewNumber[appendCents](199), appendCents(199))
+		checkAppendJSON(t, ztype.NewNullNumber[int](), nil)
+	})
+	t.Run("Float", func(t *testing.T) {
+		for _, f := range []float64{
+			0, math.Copysign(0, -1), 1, -1.5, 123.456, 1e-6, 1e-7, 9.99e-7,
+			1e20, 1e21, 1.5e300, math.MaxFloat64, math.SmallestNonzeroFloat64, 0.1 + 0.2,
+		} {
+			checkAppendJSON(t, ztype.NewNumber(f), f)
+			if math.Abs(f) <= math.MaxFloat32 {
+				checkAppendJSON(t, ztype.NewNumber(float32(f)), float32(f))
+			}
+		}
+		checkAppendJSON(t, ztype.NewNumber[float32](1e-7), float32(1e-7))
+		checkAppendJSON(t, ztype.NewNumber[float32](3.4e38), float32(3.4e38))
+	})
+	t.Run("Bool", func(t *testing.T) {
+		checkAppendJSON(t, ztype.NewBool(true), true)
+		checkAppendJSON(t, ztype.NewBool(false), false)
+		checkAppendJSON(t, ztype.NewNullBool(), nil)
+	})
+	t.Run("Byte", func(t *testing.T) {
+		for _, b := range []byte{0, 10, 255} {
+			checkAppendJSON(t, ztype.NewByte(b), b)
+		}
+		ztype.SetByteJSONHex(true)
+		defer ztype.SetByteJSONHex(false)
+		checkAppendJSON(t, ztype.NewByte(0xaf), "0xaf")
+		checkAppendJSON(t, ztype.NewByte(7), "0x07")
+	})
+	t.Run("Char", func(t *testing.T) {
+		for _, c := range []byte{'A', ' ', '~', '"', '\\', '<', '&'} {
+			checkAppendJSON(t, ztype.NewChar(c), string(rune(c)))
+		}
+		checkAppendJSON(t, ztype.NewChar('\n'), 10)
+		checkAppendJSON(t, ztype.NewChar(200), 200)
+	})
+	t.Run("Bytes", func(t *testing.T) {
+		for _, b := range [][]byte{{}, []byte("a"), []byte("ab"), []byte("abc"), {0xff, 0x00, 0x10}} {
+			checkAppendJSON(t, ztype.NewBytes(b), b)
+		}
+		checkAppendJSON(t, ztype.NewNullBytes(), nil)
+	})
+	t.Run("Time", func(t *testing.T) {
+		for _, moment := range []time.Time{
+			time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC),
+			time.Date(1999, 12, 31, 23, 59, 59, 0, time.FixedZone("", -3*3600)),
+			time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		} {
+			checkAppendJSON(t, ztype.NewTime(moment), moment)
+		}
+	})
+	t.Run("Duration", func(t *testing.T) {
+		for _, d := range []time.Duration{0, time.Nanosecond, 90 * time.Minute, -time.Second} {
+			checkAppendJSON(t, ztype.NewDuration(d), d.String())
+		}
+	})
+	t.Run("Rune", func(t *testing.T) {
+		for _, r := range []rune{'a', '"', '<', 'é', '🎉', '\u2029', 0, utf8RuneInvalid} {
+			checkAppendJSON(t, ztype.NewRune(r), string(r))
+		}
+	})
+	t.Run("Enum", func(t *testing.T) {
+		checkAppendJSON(t, quickStatusType.MustNew("blocked"), "blocked")
+	})
+	t.Run("IP", func(t *testing.T) {
+		for _, addr := range []string{"10.0.0.1", "::1", "2001:db8::1", "fe80::1%eth0"} {
+			ip := netip.MustParseAddr(addr)
+			checkAppendJSON(t, ztype.NewIP(ip), ip.String())
+		}
+		prefix := netip.MustParsePrefix("2001:db8::/32")
+		checkAppendJSON(t, ztype.NewCIDR(prefix), prefix.String())
+	})
+	t.Run("Money", func(t *testing.T) {
+		checkAppendJSON(t, ztype.NewMoney(-5, "USD"), map[string]string{"amount": "-0.05", "currency": "USD"})
+		checkAppendJSON(t, ztype.NewMoney(1990, "<&>"), map[string]string{"amount": "19.90", "currency": "<&>"})
+		ztype.SetMoneyJSONCompact(true)
+		defer ztype.SetMoneyJSONCompact(false)
+		checkAppendJSON(t, ztype.NewMoney(1990, "BRL"), "19.90 BRL")
+	})
+	t.Run("RawJSON", func(t *testing.T) {
+		checkAppendJSON(t, ztype.NewRawJSON(json.RawMessage(`{"a":[1,2]}`)), json.RawMessage(`{"a":[1,2]}`))
+		checkAppendJSON(t, ztype.NewNullRawJSON(), nil)
+	})
+}
+
+// utf8RuneInvalid is a surrogate half, which encodes as U+FFFD.
+const utf8RuneInvalid = 0xD800
+
+func TestAppendJSONUnsupportedFloat(t *testing.T) {
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := ztype.NewNumber(f).AppendJSON(nil)
+		assert.Error(t, err)
+		_, err = json.Marshal(ztype.NewNumber(f))
+		assert.Error(t, err)
+	}
+}
+
+func TestMarshalAppend(t *testing.T) {
+	buf, err := ztype.MarshalAppend(nil, ztype.NewString("a"))
+	require.NoError(t, err)
+	buf = append(buf, ',')
+	buf, err = ztype.MarshalAppend(buf, ztype.NewSlice([]int{1, 2}))
+	require.NoError(t, err)
+	buf = append(buf, ',')
+	buf, err = ztype.MarshalAppend(buf, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `"a",[1,2],null`, string(buf))
+
+	_, err = ztype.MarshalAppend(buf, func() {})
+	assert.Error(t, err)
+}
+
+func TestMarshalJSONAllocations(t *testing.T) {
+	values := map[string]interface {
+		json.Marshaler
+		ztype.JSONAppender
+	}{
+		"String":  ztype.NewString("some <escaped> \"text\""),
+		"Int":     ztype.NewNumber[int64](1234567),
+		"Float":   ztype.NewNumber(1234.5678),
+		"Bool":    ztype.NewBool(true),
+		"Time":    ztype.NewTime(time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC)),
+		"IP":      ztype.NewIP(netip.MustParseAddr("10.0.0.1")),
+		"Bytes":   ztype.NewBytes([]byte("payload")),
+		"Null":    ztype.NewNullString(),
+		"Enum":    quickStatusType.MustNew("active"),
+		"Char":    ztype.NewChar('A'),
+		"Byte":    ztype.NewByte(200),
+		"RawJSON": ztype.NewRawJSON(json.RawMessage(`[1]`)),
+	}
+	buf := make([]byte, 0, 256)
+	for name, value := range values {
+		t.Run(name, func(t *testing.T) {
+			assert.LessOrEqual(t, testing.AllocsPerRun(100, func() { _, _ = value.MarshalJSON() }), 1.0)
+			assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = value.AppendJSON(buf[:0]) }))
+		})
+	}
+}
+
+type appendRow struct {
+	ID      ztype.Numeric[int64]   `json:"id"`
+	Name    ztype.String           `json:"name"`
+	Score   ztype.Numeric[float64] `json:"score"`
+	Active  ztype.Bool             `json:"active"`
+	Created ztype.Time             `json:"created"`
+}
+
+func appendRows() []appendRow {
+	rows := make([]appendRow, 1000)
+	for i := range rows {
+		rows[i] = appendRow{
+			ID:      ztype.NewNumber(int64(i)),
+			Name:    ztype.NewString("user <" + strconv.Itoa(i) + ">"),
+			Score:   ztype.NewNumber(float64(i) / 3),
+			Active:  ztype.NewBool(i%2 == 0),
+			Created: ztype.NewTime(time.Date(2024, 3, 1, 12, 0, i%60, 0, time.UTC)),
+		}
+	}
+	return rows
+}
+
+// BenchmarkMarshalJSON compares the previous encoding, json.Marshal of the
+// underlying value, with MarshalJSON and AppendJSON into a reused buffer.
+func BenchmarkMarshalJSON(b *testing.B) {
+	moment := time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC)
+	cases := []struct {
+		name       string
+		underlying any
+		value      interface {
+			json.Marshaler
+			ztype.JSONAppender
+		}
+	}{
+		{"String", "some <escaped> \"text\"", ztype.NewString("some <escaped> \"text\"")},
+		{"Int", int64(1234567), ztype.NewNumber[int64](1234567)},
+		{"Float", 1234.5678, ztype.NewNumber(1234.5678)},
+		{"Time", moment, ztype.NewTime(moment)},
+	}
+	for _, tc := range cases {
+		b.Run(tc.name+"/JSONMarshal", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = json.Marshal(tc.underlying)
+			}
+		})
+		b.Run(tc.name+"/MarshalJSON", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = tc.value.MarshalJSON()
+			}
+		})
+		b.Run(tc.name+"/AppendJSON", func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 64)
+			for b.Loop() {
+				buf, _ = tc.value.AppendJSON(buf[:0])
+			}
+		})
+	}
+}
+
+// BenchmarkMarshalRows measures json.Marshal of a large slice of structs of
+// ztype fields.
+func BenchmarkMarshalRows(b *testing.B) {
+	rows := appendRows()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = json.Marshal(rows)
+	}
+}
+
+func TestAppendStringMatchesString(t *testing.T) {
+	durations := []time.Duration{
+		0, 1, 999, time.Microsecond, 1500 * time.Microsecond, time.Millisecond + 1,
+		time.Second, 90 * time.Minute, -90*time.Minute - 5, 100*time.Hour + time.Nanosecond,
+		math.MaxInt64, math.MinInt64,
+	}
+	values := []interface {
+		fmt.Stringer
+		ztype.StringAppender
+	}{
+		ztype.NewBool(false),
+		ztype.NewNullBool(),
+		ztype.NewByte(255),
+		ztype.NewNullByte(),
+		ztype.NewString("some text"),
+		ztype.NewNullString(),
+		ztype.NewNumber[int8](-128),
+		ztype.NewNumber[uint64](math.MaxUint64),
+		ztype.NewNumber[float32](0.1),
+		ztype.NewNumber(1e21),
+		ztype.NewNullNumber[int](),
+		ztype.NewTime(time.Date(2024, 3, 1, 12, 30, 45, 500, time.FixedZone("", -3*3600))),
+		ztype.NewDateOnlyTime(2024, 3, 1),
+		ztype.NewNullTime(),
+		ztype.NewNullDuration(),
+	}
+	for _, d := range durations {
+		values = append(values, ztype.NewDuration(d))
+	}
+	for _, value := range values {
+		assert.Equal(t, value.String(), string(value.AppendString([]byte("x")))[1:], "%#v", value)
+	}
+
+	t.Run("formatter", func(t *testing.T) {
+		ztype.SetNumberFormatter(func(v any) string { return fmt.Sprintf("<%v>", v) })
+		t.Cleanup(func() { ztype.SetNumberFormatter(nil) })
+		assert.Equal(t, "<5>", string(ztype.NewNumber(5).AppendString(nil)))
+	})
+}
+
+func TestAppendStringAllocations(t *testing.T) {
+	values := map[string]ztype.StringAppender{
+		"Bool":     ztype.NewBool(true),
+		"Byte":     ztype.NewByte(200),
+		"String":   ztype.NewString("text"),
+		"Int":      ztype.NewNumber[int64](1234567),
+		"Float":    ztype.NewNumber(1234.5678),
+		"Time":     ztype.NewTime(time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC)),
+		"Duration": ztype.NewDuration(90*time.Minute + 1500*time.Microsecond),
+		"Null":     ztype.NewNullTime(),
+	}
+	buf := make([]byte, 0, 256)
+	for name, value := range values {
+		t.Run(name, func(t *testing.T) {
+			assert.Zero(t, testing.AllocsPerRun(100, func() { _ = value.AppendString(buf[:0]) }))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func stringArrayValues(a ztype.StringArray) []any {
+	var values []any
+	for _, s := range a.Get() {
+		if s.IsNull() {
+			values = append(values, nil)
+		} else {
+			values = append(values, s.Get())
+		}
+	}
+	return values
+}
+
+func TestArrayValueEscaping(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Plain", "abc", `"abc"`},
+		{"Empty", "", `""`},
+		{"Space", "a b", `"a b"`},
+		{"LeadingTrailingSpace", "  a  ", `"  a  "`},
+		{"Quote", `a"b`, `"a\"b"`},
+		{"OnlyQuote", `"`, `"\""`},
+		{"Backslash", `a\b`, `"a\\b"`},
+		{"TrailingBackslash", `a\`, `"a\\"`},
+		{"EscapedQuoteSequence", `\"`, `"\\\""`},
+		{"Comma", "a,b", `"a,b"`},
+		{"OpenBrace", "{a", `"{a"`},
+		{"CloseBrace", "a}", `"a}"`},
+		{"Braces", "{}", `"{}"`},
+		{"NullWord", "NULL", `"NULL"`},
+		{"NullWordLower", "null", `"null"`},
+		{"Newline", "a\nb", "\"a\nb\""},
+		{"Tab", "a\tb", "\"a\tb\""},
+		{"Unicode", "ação 日本", `"ação 日本"`},
+		{"Everything", `{"a\b",}`, `"{\"a\\b\",}"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			array := ztype.NewArray(ztype.NewString(tc.input))
+			value, err := array.Value()
+			require.NoError(t, err)
+			assert.Equal(t, "{"+tc.want+"}", value)
+
+			var decoded ztype.StringArray
+			require.NoError(t, decoded.Scan(value))
+			assert.Equal(t, []any{tc.input}, stringArrayValues(decoded))
+		})
+	}
+}
+
+func TestArrayValue(t *testing.T) {
+	t.Run("NullArray", func(t *testing.T) {
+		value, err := ztype.NewNullArray[ztype.String]().Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		value, err := ztype.NewArray[ztype.String]().Value()
+		require.NoError(t, err)
+		assert.Equal(t, "{}", value)
+	})
+	t.Run("NullElements", func(t *testing.T) {
+		value, err := ztype.NewArray(ztype.NewString("a"), ztype.NewNullString(), ztype.NewString("b")).Value()
+		require.NoError(t, err)
+		assert.Equal(t, `{"a",NULL,"b"}`, value)
+	})
+	t.Run("Int", func(t *testing.T) {
+		value, err := ztype.NewArray(ztype.NewNumber[int64](1), ztype.NewNumber[int64](-20), ztype.NewNullNumber[int64]()).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "{1,-20,NULL}", value)
+	})
+	t.Run("Float", func(t *testing.T) {
+		value, err := ztype.NewArray(ztype.NewNumber(1.5), ztype.NewNumber(-0.25)).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "{1.5,-0.25}", value)
+	})
+	t.Run("Bool", func(t *testing.T) {
+		value, err := ztype.NewArray(ztype.NewBool(true), ztype.NewBool(false), ztype.NewNullBool()).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "{true,false,NULL}", value)
+	})
+	t.Run("Bytes", func(t *testing.T) {
+		value, err := ztype.NewArray(ztype.NewBytes([]byte{0xde, 0xad})).Value()
+		require.NoError(t, err)
+		assert.Equal(t, `{"\\xdead"}`, value)
+	})
+	t.Run("Time", func(t *testing.T) {
+		moment := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+		value, err := ztype.NewArray(ztype.NewTime(moment)).Value()
+		require.NoError(t, err)
+		assert.Equal(t, `{"2024-03-01T12:30:00Z"}`, value)
+	})
+}
+
+func TestArrayScan(t *testing.T) {
+	cases := []struct {
+		name    string
+		literal string
+		want    []any
+	}{
+		{"Empty", "{}", nil},
+		{"EmptyWithSpaces", "{ }", nil},
+		{"Unquoted", "{a,b,c}", []any{"a", "b", "c"}},
+		{"Quoted", `{"a","b"}`, []any{"a", "b"}},
+		{"Mixed", `{a,"b c",NULL}`, []any{"a", "b c", nil}},
+		{"NullCaseInsensitive", "{null,Null}", []any{nil, nil}},
+		{"QuotedNull", `{"NULL"}`, []any{"NULL"}},
+		{"QuotedEmpty", `{""}`, []any{""}},
+		{"SpacesAroundElements", `{ a , "b" ,c }`, []any{"a", "b", "c"}},
+		{"UnquotedInnerSpace", "{a b}", []any{"a b"}},
+		{"UnquotedEscape", `{a\,b}`, []any{"a,b"}},
+		{"EscapedQuoteAndBackslash", `{"a\"b\\c"}`, []any{`a"b\c`}},
+		{"RedundantEscape", `{"\a"}`, []any{"a"}},
+		{"QuotedComma", `{"a,b",c}`, []any{"a,b", "c"}},
+		{"QuotedBraces", `{"{x}","}"}`, []any{"{x}", "}"}},
+		{"Unicode", `{ação,"日本"}`, []any{"ação", "日本"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var array ztype.StringArray
+			require.NoError(t, array.Scan(tc.literal))
+			assert.False(t, array.IsNull())
+			assert.Equal(t, tc.want, stringArrayValues(array))
+
+			var fromBytes ztype.StringArray
+			require.NoError(t, fromBytes.Scan([]byte(tc.literal)))
+			assert.Equal(t, tc.want, stringArrayValues(fromBytes))
+		})
+	}
+
+	t.Run("Nil", func(t *testing.T) {
+		array := ztype.NewArray(ztype.NewString("a"))
+		require.NoError(t, array.Scan(nil))
+		assert.True(t, array.IsNull())
+		assert.Nil(t, array.Get())
+	})
+	t.Run("Int", func(t *testing.T) {
+		var array ztype.NumberArray[int64]
+		require.NoError(t, array.Scan("{1,-2,NULL}"))
+		require.Equal(t, 3, array.Len())
+		assert.Equal(t, int64(1), array.Get()[0].Get())
+		assert.Equal(t, int64(-2), array.Get()[1].Get())
+		assert.True(t, array.Get()[2].IsNull())
+	})
+	t.Run("Bool", func(t *testing.T) {
+		var array ztype.Array[ztype.Bool]
+		require.NoError(t, array.Scan("{t,f,NULL}"))
+		require.Equal(t, 3, array.Len())
+		assert.True(t, array.Get()[0].Get())
+		assert.False(t, array.Get()[1].Get())
+		assert.True(t, array.Get()[2].IsNull())
+	})
+	t.Run("Bytes", func(t *testing.T) {
+		var array ztype.Array[ztype.Bytes]
+		require.NoError(t, array.Scan(`{"\\xdead",NULL}`))
+		require.Equal(t, 2, array.Len())
+		assert.Equal(t, []byte{0xde, 0xad}, array.Get()[0].Get())
+		assert.True(t, array.Get()[1].IsNull())
+	})
+	t.Run("PostgresTimestamp", func(t *testing.T) {
+		var array ztype.Array[ztype.Time]
+		require.NoError(t, array.Scan(`{"2024-03-01 12:30:00+00","2024-03-01 09:30:00.5-03",NULL}`))
+		require.Equal(t, 3, array.Len())
+		assert.True(t, time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC).Equal(array.Get()[0].Get()))
+		assert.True(t, time.Date(2024, 3, 1, 12, 30, 0, 5e8, time.UTC).Equal(array.Get()[1].Get()))
+		assert.True(t, array.Get()[2].IsNull())
+	})
+}
+
+func TestArrayScanErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		value any
+	}{
+		{"UnsupportedType", 42},
+		{"NoBraces", "a,b"},
+		{"MissingClose", "{a,b"},
+		{"MissingOpen", "a,b}"},
+		{"Nested", "{{1,2},{3,4}}"},
+		{"UnterminatedQuote", `{"a}`},
+		{"GarbageAfterQuote", `{"a"b}`},
+		{"EmptyElement", "{a,,b}"},
+		{"TrailingComma", "{a,}"},
+		{"StrayQuote", `{a"b}`},
+		{"BadElement", "{1,x}"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var array ztype.NumberArray[int64]
+			if s, ok := tc.value.(string); ok && tc.name != "BadElement" {
+				var texts ztype.StringArray
+				assert.Error(t, texts.Scan(s))
+			}
+			assert.Error(t, array.Scan(tc.value))
+			assert.True(t, array.IsNull())
+		})
+	}
+}
+
+func TestArrayRoundTrip(t *testing.T) {
+	t.Run("Int", func(t *testing.T) {
+		for _, array := range []ztype.NumberArray[int64]{
+			ztype.NewArray[ztype.Numeric[int64]](),
+			ztype.NewArray(ztype.NewNumber[int64](0), ztype.NewNumber[int64](-9223372036854775808), ztype.NewNullNumber[int64]()),
+			ztype.NewNullArray[ztype.Numeric[int64]](),
+		} {
+			assert.NoError(t, ztype.RoundTripSQL(array))
+			assert.NoError(t, ztype.RoundTripJSON(array))
+		}
+	})
+	t.Run("String", func(t *testing.T) {
+		for _, array := range []ztype.StringArray{
+			ztype.NewArray[ztype.String](),
+			ztype.NewArray(ztype.NewString(`{"a\b",}`), ztype.NewNullString(), ztype.NewString("NULL"), ztype.NewString("")),
+			ztype.NewNullArray[ztype.String](),
+		} {
+			assert.NoError(t, ztype.RoundTripSQL(array))
+			assert.NoError(t, ztype.RoundTripJSON(array))
+		}
+	})
+}
+
+func TestArrayJSON(t *testing.T) {
+	data, err := json.Marshal(ztype.NewArray(ztype.NewString("a"), ztype.NewNullString()))
+	require.NoError(t, err)
+	assert.JSONEq(t, `["a",null]`, string(data))
+
+	var array ztype.StringArray
+	require.NoError(t, json.Unmarshal([]byte(`["x",null]`), &array))
+	assert.True(t, array.Unmarshaled())
+	assert.Equal(t, []any{"x", nil}, stringArrayValues(array))
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &array))
+	assert.True(t, array.IsNull())
+}
+
+func TestArrayString(t *testing.T) {
+	assert.Equal(t, `{"a",NULL}`, ztype.NewArray(ztype.NewString("a"), ztype.NewNullString()).String())
+	assert.Equal(t, "<NULL>", ztype.NewNullArray[ztype.String]().String())
+	assert.Equal(t, "{}", ztype.NewNullArray[ztype.String]().StringOr("{}"))
+
+	zero, err := ztype.NewNullArray[ztype.String]().ValueOrZero()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", zero)
+}
@@ -0,0 +1,221 @@
+package ztype_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type binaryValue interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	ztype.Nullable
+}
+
+func binaryPtr[T any](value T) *T {
+	return &value
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  binaryValue
+		target func() binaryValue
+	}{
+		{"Bool", binaryPtr(ztype.NewBool(false)), func() binaryValue { return new(ztype.Bool) }},
+		{"Bool null", binaryPtr(ztype.NewNullBool()), func() binaryValue { return binaryPtr(ztype.NewBool(true)) }},
+		{"Byte", binaryPtr(ztype.NewByte(255)), func() binaryValue { return new(ztype.Byte) }},
+		{"String", binaryPtr(ztype.NewString("日本語, \x00")), func() binaryValue { return new(ztype.String) }},
+		{"String empty", binaryPtr(ztype.NewString("")), func() binaryValue { return new(ztype.String) }},
+		{"String null", binaryPtr(ztype.NewNullString()), func() binaryValue { return binaryPtr(ztype.NewString("x")) }},
+		{"Duration", binaryPtr(ztype.NewDuration(-time.Nanosecond)), func() binaryValue { return new(ztype.Duration) }},
+		{"Numeric int8", binaryPtr(ztype.NewNumber[int8](-128)), func() binaryValue { return new(ztype.Numeric[int8]) }},
+		{"Numeric uint", binaryPtr(ztype.NewNumber[uint](42)), func() binaryValue { return new(ztype.Numeric[uint]) }},
+		{"Numeric float32", binaryPtr(ztype.NewNumber[float32](0.1)), func() binaryValue { return new(ztype.Numeric[float32]) }},
+		{"Numeric null", binaryPtr(ztype.NewNullNumber[int64]()), func() binaryValue { return binaryPtr(ztype.NewNumber[int64](1)) }},
+		{"Map", binaryPtr(ztype.NewMap(map[int]string{1: "a"})), func() binaryValue { return new(ztype.Map[int, string]) }},
+		{"Map null", binaryPtr(ztype.NewNullMap[int, string]()), func() binaryValue { return new(ztype.Map[int, string]) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.value.MarshalBinary()
+			require.NoError(t, err)
+			decoded := tt.target()
+			require.NoError(t, decoded.UnmarshalBinary(data))
+			assert.True(t, decoded.Unmarshaled())
+			if tt.value.IsNull() {
+				assert.True(t, decoded.IsNull())
+				return
+			}
+			decoded.SetUnmarshaled(false)
+			assert.Equal(t, tt.value, decoded)
+		})
+	}
+}
+
+func TestBinaryGob(t *testing.T) {
+	type cached struct {
+		Count ztype.Numeric[int64]
+		Name  ztype.String
+		Tags  ztype.Map[string, int]
+		TTL   ztype.Duration
+	}
+	in := cached{Count: ztype.NewNumber[int64](7), Tags: ztype.NewMap(map[string]int{"a": 1}), TTL: ztype.NewDuration(time.Hour)}
+	var buffer bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buffer).Encode(in))
+	var out cached
+	require.NoError(t, gob.NewDecoder(&buffer).Decode(&out))
+	assert.Equal(t, int64(7), out.Count.Get())
+	assert.True(t, out.Name.IsNull())
+	assert.Equal(t, map[string]int{"a": 1}, out.Tags.Get())
+	assert.Equal(t, time.Hour, out.TTL.Get())
+}
+
+// TestBinaryVersion1Payloads decodes payloads written by the first version of
+// the format, which must keep decoding as the format evolves.
+func TestBinaryVersion1Payloads(t *testing.T) {
+	tests := []struct {
+		file   string
+		target binaryValue
+		want   any
+	}{
+		{"bool_true", new(ztype.Bool), true},
+		{"bool_null", new(ztype.Bool), nil},
+		{"byte", new(ztype.Byte), byte(200)},
+		{"string", new(ztype.String), "olá, mundo"},
+		{"string_empty", new(ztype.String), ""},
+		{"string_null", new(ztype.String), nil},
+		{"duration", new(ztype.Duration), -90 * time.Second},
+		{"numeric_int64", new(ztype.Numeric[int64]), int64(-1 << 40)},
+		{"numeric_uint64", new(ztype.Numeric[uint64]), uint64(1<<64 - 1)},
+		{"numeric_float64", new(ztype.Numeric[float64]), 3.25},
+		{"numeric_null", new(ztype.Numeric[int]), nil},
+		{"map", new(ztype.Map[string, int]), map[string]int{"a": 1, "b": 2}},
+		{"map_null", new(ztype.Map[string, int]), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "binary_v1", tt.file+".bin"))
+			require.NoError(t, err)
+			require.NoError(t, tt.target.UnmarshalBinary(data))
+			if tt.want == nil {
+				assert.True(t, tt.target.IsNull())
+				return
+			}
+			assert.Equal(t, tt.want, reflect.ValueOf(tt.target).MethodByName("Get").Call(nil)[0].Interface())
+		})
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	n := ztype.NewNumber[uint8](9)
+	wide, _ := ztype.NewNumber[uint64](300).MarshalBinary()
+	assert.ErrorIs(t, n.UnmarshalBinary(wide), &ztype.ErrOverflow{})
+	assert.Equal(t, uint8(9), n.Get())
+
+	s := ztype.NewString("kept")
+	assert.ErrorIs(t, s.UnmarshalBinary([]byte{9, 1, 'a'}), &ztype.ErrInvalidFormat{})
+	assert.ErrorContains(t, s.UnmarshalBinary([]byte{9, 1}), "unsupported binary version 9")
+	assert.ErrorIs(t, s.UnmarshalBinary([]byte{1}), &ztype.ErrInvalidFormat{})
+	assert.ErrorIs(t, s.UnmarshalBinary([]byte{1, 0, 'a'}), &ztype.ErrInvalidFormat{})
+	assert.Equal(t, "kept", s.Get())
+
+	var b ztype.Bool
+	assert.Error(t, b.UnmarshalBinary([]byte{1, 1, 2}))
+	var d ztype.Duration
+	assert.Error(t, d.UnmarshalBinary([]byte{1, 1, 0x80}))
+}
+
+func TestBinaryNumericAcrossTypes(t *testing.T) {
+	unsigned, _ := ztype.NewNumber[uint32](5).MarshalBinary()
+	var signed ztype.Numeric[int64]
+	require.NoError(t, signed.UnmarshalBinary(unsigned))
+	assert.Equal(t, int64(5), signed.Get())
+
+	positive, _ := ztype.NewNumber[int32](5).MarshalBinary()
+	var wide ztype.Numeric[uint64]
+	require.NoError(t, wide.UnmarshalBinary(positive))
+	assert.Equal(t, uint64(5), wide.Get())
+
+	var float ztype.Numeric[float64]
+	require.NoError(t, float.UnmarshalBinary(positive))
+	assert.Equal(t, 5.0, float.Get())
+
+	whole, _ := ztype.NewNumber(3.0).MarshalBinary()
+	var small ztype.Numeric[int8]
+	require.NoError(t, small.UnmarshalBinary(whole))
+	assert.Equal(t, int8(3), small.Get())
+
+	negative, _ := ztype.NewNumber[int32](-5).MarshalBinary()
+	wide = ztype.NewNumber[uint64](9)
+	assert.ErrorIs(t, wide.UnmarshalBinary(negative), &ztype.ErrOverflow{})
+	assert.Equal(t, uint64(9), wide.Get())
+
+	huge, _ := ztype.NewNumber[uint64](1<<64 - 1).MarshalBinary()
+	assert.ErrorIs(t, signed.UnmarshalBinary(huge), &ztype.ErrOverflow{})
+
+	fraction, _ := ztype.NewNumber(1.5).MarshalBinary()
+	assert.ErrorIs(t, small.UnmarshalBinary(fraction), &ztype.ErrInvalidFormat{})
+	assert.ErrorContains(t, small.UnmarshalBinary([]byte{2, 1, 7, 0}), "unknown number kind 7")
+}
+
+func TestBinaryTime(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, 5, time.FixedZone("", 9*3600))
+
+	data, err := ztype.NewTime(at).MarshalBinary()
+	require.NoError(t, err)
+	var decoded ztype.Time
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.True(t, decoded.Unmarshaled())
+	assert.False(t, decoded.IsNull())
+	assert.True(t, decoded.Get().Equal(at))
+	_, offset := decoded.Get().Zone()
+	assert.Equal(t, 9*3600, offset)
+
+	data, err = ztype.NewNullTime().MarshalBinary()
+	require.NoError(t, err)
+	decoded = ztype.NewTime(at)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.True(t, decoded.IsNull())
+
+	t.Run("bare time.Time payload", func(t *testing.T) {
+		raw, err := at.MarshalBinary()
+		require.NoError(t, err)
+		var legacy ztype.Time
+		require.NoError(t, legacy.UnmarshalBinary(raw))
+		assert.True(t, legacy.Get().Equal(at))
+
+		raw, err = time.Time{}.MarshalBinary()
+		require.NoError(t, err)
+		legacy = ztype.NewTime(at)
+		require.NoError(t, legacy.UnmarshalBinary(raw))
+		assert.True(t, legacy.IsNull())
+	})
+
+	assert.ErrorIs(t, decoded.UnmarshalBinary([]byte{1, 1, 9}), &ztype.ErrInvalidFormat{})
+}
+
+func TestBinaryMarshalerValueReceivers(t *testing.T) {
+	values := []any{
+		ztype.NewBool(true),
+		ztype.NewByte(1),
+		ztype.NewString("a"),
+		ztype.NewDuration(time.Second),
+		ztype.NewNumber[int](1),
+		ztype.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	for _, value := range values {
+		_, ok := value.(encoding.BinaryMarshaler)
+		assert.True(t, ok, "%T", value)
+	}
+}
@@ -3,12 +3,15 @@ package ztype_test
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
 )
 
 func TestBool(t *testing.T) {
@@ -41,22 +44,108 @@ func TestBool(t *testing.T) {
 		})
 	})
 
+	t.Run("Ergonomics", func(t *testing.T) {
+		t.Run("Toggle", func(t *testing.T) {
+			b := ztype.NewBool(true)
+			b.Toggle()
+			require.True(t, b.IsFalse())
+			b.Toggle()
+			require.True(t, b.IsTrue())
+
+			null := ztype.NewNullBool()
+			null.Toggle()
+			require.True(t, null.IsNull())
+			require.False(t, null.Get())
+		})
+
+		t.Run("GetOr", func(t *testing.T) {
+			b := ztype.NewBool(false)
+			require.False(t, b.GetOr(true))
+			null := ztype.NewNullBool()
+			require.True(t, null.GetOr(true))
+		})
+
+		t.Run("Ptr", func(t *testing.T) {
+			b := ztype.NewBool(true)
+			p := b.Ptr()
+			require.NotNil(t, p)
+			require.True(t, *p)
+			*p = false
+			require.True(t, b.Get(), "Ptr must not alias the Bool")
+
+			null := ztype.NewNullBool()
+			require.Nil(t, null.Ptr())
+		})
+
+		t.Run("NewBoolFromPtr", func(t *testing.T) {
+			value := false
+			b := ztype.NewBoolFromPtr(&value)
+			require.True(t, b.IsFalse())
+			null := ztype.NewBoolFromPtr(nil)
+			require.True(t, null.IsNull())
+		})
+
+		t.Run("ToInt", func(t *testing.T) {
+			tests := []struct {
+				instance ztype.Bool
+				expected ztype.Numeric[int]
+			}{
+				{ztype.NewBool(true), ztype.NewNumber(1)},
+				{ztype.NewBool(false), ztype.NewNumber(0)},
+				{ztype.NewNullBool(), ztype.NewNullNumber[int]()},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.instance.String(), func(t *testing.T) {
+					require.True(t, tt.instance.ToInt().Equal(tt.expected))
+				})
+			}
+		})
+	})
+
 	t.Run("StateChecks", func(t *testing.T) {
 		tests := []struct {
-			name     string
-			instance ztype.Bool
-			isNull   bool
-			isZero   bool
+			name          string
+			instance      ztype.Bool
+			isNull        bool
+			isZero        bool
+			isNullOrFalse bool
 		}{
-			{"Valid true", ztype.NewBool(true), false, false},
-			{"Valid false", ztype.NewBool(false), false, true},
-			{"Null", ztype.NewNullBool(), true, true},
+			{"Valid true", ztype.NewBool(true), false, false, false},
+			{"Valid false", ztype.NewBool(false), false, false, true},
+			{"Null", ztype.NewNullBool(), true, true, true},
 		}
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
 				require.Equal(t, tt.isNull, tt.instance.IsNull())
 				require.Equal(t, tt.isZero, tt.instance.IsZero())
+				require.Equal(t, tt.isNullOrFalse, tt.instance.IsNullOrFalse())
+			})
+		}
+	})
+
+	t.Run("OmitZero", func(t *testing.T) {
+		type payload struct {
+			DryRun ztype.Bool `json:"dry_run,omitzero"`
+		}
+
+		tests := []struct {
+			name     string
+			instance payload
+			expected string
+		}{
+			{"Valid true", payload{ztype.NewBool(true)}, `{"dry_run":true}`},
+			{"Valid false", payload{ztype.NewBool(false)}, `{"dry_run":false}`},
+			{"Null", payload{ztype.NewNullBool()}, `{}`},
+			{"Unset", payload{}, `{}`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				data, err := json.Marshal(&tt.instance)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.expected, string(data))
 			})
 		}
 	})
@@ -249,6 +338,258 @@ func TestBool(t *testing.T) {
 		})
 	})
 
+	t.Run("LenientParsing", func(t *testing.T) {
+		tokens := []struct {
+			input    string
+			expected bool
+		}{
+			{"1", true}, {"t", true}, {"T", true}, {"true", true}, {"TRUE", true}, {"True", true},
+			{"y", true}, {"Y", true}, {"yes", true}, {"YES", true}, {"on", true}, {"On", true},
+			{"0", false}, {"f", false}, {"F", false}, {"false", false}, {"FALSE", false}, {"False", false},
+			{"n", false}, {"N", false}, {"no", false}, {"No", false}, {"off", false}, {"OFF", false},
+		}
+		invalid := []string{"", "2", "-1", "yess", "tru", "nope", "o", "1.0"}
+
+		t.Run("UnmarshalText", func(t *testing.T) {
+			for _, tt := range tokens {
+				t.Run(tt.input, func(t *testing.T) {
+					var b ztype.Bool
+					require.NoError(t, b.UnmarshalText([]byte(tt.input)))
+					require.False(t, b.IsNull())
+					require.Equal(t, tt.expected, b.Get())
+				})
+			}
+			for _, input := range invalid {
+				t.Run("invalid "+input, func(t *testing.T) {
+					var b ztype.Bool
+					require.Error(t, b.UnmarshalText([]byte(input)))
+				})
+			}
+		})
+
+		t.Run("Scan", func(t *testing.T) {
+			for _, tt := range tokens {
+				t.Run(tt.input, func(t *testing.T) {
+					var fromString, fromBytes ztype.Bool
+					require.NoError(t, fromString.Scan(tt.input))
+					require.NoError(t, fromBytes.Scan([]byte(tt.input)))
+					require.True(t, fromString.Equal(ztype.NewBool(tt.expected)))
+					require.True(t, fromBytes.Equal(ztype.NewBool(tt.expected)))
+				})
+			}
+			for _, input := range invalid {
+				t.Run("invalid "+input, func(t *testing.T) {
+					var b ztype.Bool
+					require.Error(t, b.Scan(input))
+				})
+			}
+
+			integers := []struct {
+				input    int64
+				expected bool
+				wantErr  bool
+			}{
+				{1, true, false},
+				{0, false, false},
+				{2, true, false},
+				{-1, true, false},
+			}
+			for _, tt := range integers {
+				t.Run(strconv.FormatInt(tt.input, 10), func(t *testing.T) {
+					var b ztype.Bool
+					err := b.Scan(tt.input)
+					if tt.wantErr {
+						require.Error(t, err)
+						return
+					}
+					require.NoError(t, err)
+					require.True(t, b.Equal(ztype.NewBool(tt.expected)))
+				})
+			}
+
+			var b ztype.Bool
+			require.NoError(t, b.Scan(1.0))
+			require.True(t, b.Get())
+			require.NoError(t, b.Scan(0.0))
+			require.False(t, b.Get())
+			require.NoError(t, b.Scan(0.5))
+			require.True(t, b.Get())
+			require.ErrorIs(t, b.Scan(math.NaN()), &ztype.ErrInvalidFormat{Type: "Bool"})
+			require.Error(t, b.Scan(float32(1)))
+		})
+
+		t.Run("ScanBit", func(t *testing.T) {
+			var b ztype.Bool
+			require.NoError(t, b.Scan([]byte{0x01}))
+			require.True(t, b.Equal(ztype.NewBool(true)))
+			require.NoError(t, b.Scan([]byte{0x00}))
+			require.True(t, b.Equal(ztype.NewBool(false)))
+
+			err := b.Scan([]byte{0x02})
+			require.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "Bool"})
+			require.ErrorContains(t, err, `"\x02"`)
+		})
+
+		t.Run("ScanChar", func(t *testing.T) {
+			for input, expected := range map[string]bool{"Y": true, "n ": false, "T": true, "f": false} {
+				var b ztype.Bool
+				require.NoError(t, b.Scan(input))
+				require.True(t, b.Equal(ztype.NewBool(expected)), input)
+			}
+		})
+
+		t.Run("ScanTokens", func(t *testing.T) {
+			ztype.SetBoolScanTokens([]string{"S", "Y", "1"}, []string{"N", "0"})
+			defer ztype.SetBoolScanTokens(nil, nil)
+
+			var b ztype.Bool
+			require.NoError(t, b.Scan("s"))
+			require.True(t, b.Equal(ztype.NewBool(true)))
+			require.NoError(t, b.Scan([]byte("N")))
+			require.True(t, b.Equal(ztype.NewBool(false)))
+			require.NoError(t, b.Scan([]byte{0x01}))
+			require.True(t, b.Equal(ztype.NewBool(true)))
+
+			err := b.Scan("true")
+			require.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "Bool"})
+			require.ErrorContains(t, err, `"true"`)
+			require.True(t, b.Equal(ztype.NewBool(true)))
+
+			require.NoError(t, b.Scan(nil))
+			require.True(t, b.IsNull())
+
+			require.NoError(t, b.UnmarshalText([]byte("true")))
+
+			ztype.SetBoolScanTokens(nil, nil)
+			require.NoError(t, b.Scan("true"))
+			require.Error(t, b.Scan("S"))
+		})
+
+		t.Run("JSON", func(t *testing.T) {
+			lenient := []struct {
+				input    string
+				expected bool
+			}{
+				{`true`, true}, {`false`, false}, {`1`, true}, {`0`, false},
+				{`"true"`, true}, {`"false"`, false}, {`"yes"`, true}, {`"off"`, false}, {`"1"`, true},
+			}
+			rejected := []string{`2`, `0.5`, `"string"`, `""`, `[]`, `{}`}
+
+			t.Run("Strict", func(t *testing.T) {
+				for _, input := range []string{`1`, `0`, `"true"`, `"yes"`} {
+					var b ztype.Bool
+					require.Error(t, json.Unmarshal([]byte(input), &b), input)
+				}
+			})
+
+			ztype.SetBoolJSONLenient(true)
+			defer ztype.SetBoolJSONLenient(false)
+
+			for _, tt := range lenient {
+				t.Run(tt.input, func(t *testing.T) {
+					var b ztype.Bool
+					require.NoError(t, json.Unmarshal([]byte(tt.input), &b))
+					require.True(t, b.Equal(ztype.NewBool(tt.expected)))
+					require.True(t, b.Unmarshaled())
+				})
+			}
+			for _, input := range rejected {
+				t.Run("invalid "+input, func(t *testing.T) {
+					var b ztype.Bool
+					require.Error(t, json.Unmarshal([]byte(input), &b))
+				})
+			}
+
+			var b ztype.Bool
+			require.NoError(t, json.Unmarshal([]byte(`null`), &b))
+			require.True(t, b.IsNull())
+		})
+	})
+
+	t.Run("ThreeValuedLogic", func(t *testing.T) {
+		T, F, N := ztype.NewBool(true), ztype.NewBool(false), ztype.NewNullBool()
+
+		t.Run("Binary", func(t *testing.T) {
+			tests := []struct {
+				a, b         ztype.Bool
+				and, or, xor ztype.Bool
+			}{
+				{T, T, T, T, F},
+				{T, F, F, T, T},
+				{T, N, N, T, N},
+				{F, T, F, T, T},
+				{F, F, F, F, F},
+				{F, N, F, N, N},
+				{N, T, N, T, N},
+				{N, F, F, N, N},
+				{N, N, N, N, N},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.a.String()+"_"+tt.b.String(), func(t *testing.T) {
+					and, or, xor := tt.a.And(tt.b), tt.a.Or(tt.b), tt.a.Xor(tt.b)
+					require.True(t, and.Equal(tt.and), "AND: got %s", and.String())
+					require.True(t, or.Equal(tt.or), "OR: got %s", or.String())
+					require.True(t, xor.Equal(tt.xor), "XOR: got %s", xor.String())
+				})
+			}
+		})
+
+		t.Run("Not", func(t *testing.T) {
+			tests := []struct {
+				input    ztype.Bool
+				expected ztype.Bool
+			}{
+				{T, F},
+				{F, T},
+				{N, N},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.input.String(), func(t *testing.T) {
+					not := tt.input.Not()
+					require.True(t, not.Equal(tt.expected))
+				})
+			}
+		})
+
+		t.Run("Predicates", func(t *testing.T) {
+			require.True(t, T.IsTrue())
+			require.False(t, T.IsFalse())
+			require.False(t, F.IsTrue())
+			require.True(t, F.IsFalse())
+			require.False(t, N.IsTrue())
+			require.False(t, N.IsFalse())
+		})
+
+		t.Run("Aggregation", func(t *testing.T) {
+			tests := []struct {
+				name     string
+				values   []ztype.Bool
+				any, all ztype.Bool
+				count    int
+			}{
+				{"Empty", nil, F, T, 0},
+				{"AllNull", []ztype.Bool{N, N}, N, N, 0},
+				{"AllTrue", []ztype.Bool{T, T}, T, T, 2},
+				{"AllFalse", []ztype.Bool{F, F}, F, F, 0},
+				{"TrueAndFalse", []ztype.Bool{T, F}, T, F, 1},
+				{"TrueAndNull", []ztype.Bool{N, T}, T, N, 1},
+				{"FalseAndNull", []ztype.Bool{F, N}, N, F, 0},
+				{"Mixed", []ztype.Bool{T, N, F, T}, T, F, 2},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					anyTrue, allTrue := ztype.AnyTrue(tt.values), ztype.AllTrue(tt.values)
+					require.True(t, anyTrue.Equal(tt.any), "AnyTrue = %s", anyTrue.String())
+					require.True(t, allTrue.Equal(tt.all), "AllTrue = %s", allTrue.String())
+					require.Equal(t, tt.count, ztype.CountTrue(tt.values))
+				})
+			}
+		})
+	})
+
 	t.Run("StringRepresentation", func(t *testing.T) {
 		tests := []struct {
 			instance ztype.Bool
@@ -285,3 +626,16 @@ func TestBool(t *testing.T) {
 		})
 	})
 }
+
+func TestBoolValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Bool) }, []ztypetest.Case{
+		{Name: "bool", Scan: true, Want: true},
+		{Name: "false", Scan: false, Want: false},
+		{Name: "int64", Scan: int64(1), Want: true},
+		{Name: "text", Scan: "false", Want: false},
+		{Name: "bytes", Scan: []byte("t"), Want: true},
+		{Name: "float", Scan: 1.5, Want: true},
+		{Name: "NaN", Scan: math.NaN(), Err: true},
+		{Name: "time", Scan: time.Now(), Err: true},
+	})
+}
@@ -0,0 +1,96 @@
+package ztype_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestTimeAddBusinessDays(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 9, 30, 0, 0, time.UTC)
+	}
+	holidays := []time.Time{
+		time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		n        int
+		holidays []time.Time
+		expected time.Time
+	}{
+		{"zero", date(3, 2), 0, nil, date(3, 2)},
+		{"within week", date(3, 4), 3, nil, date(3, 7)},
+		{"over weekend", date(3, 1), 1, nil, date(3, 4)},
+		{"from saturday", date(3, 2), 1, nil, date(3, 4)},
+		{"two weeks", date(3, 1), 10, nil, date(3, 15)},
+		{"negative over weekend", date(3, 4), -1, nil, date(3, 1)},
+		{"negative from sunday", date(3, 3), -2, nil, date(2, 29)},
+		{"cross month", date(2, 28), 3, nil, date(3, 4)},
+		{"holidays", date(3, 28), 1, holidays, date(4, 2)},
+		{"negative holidays", date(4, 2), -1, holidays, date(3, 28)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := ztype.NewTime(tt.from)
+			result := start.AddBusinessDays(tt.n, tt.holidays...)
+			assert.Equal(t, tt.expected, result.Get())
+
+			if weekday := tt.from.Weekday(); weekday != time.Saturday && weekday != time.Sunday {
+				n, err := start.BusinessDaysUntil(result, tt.holidays...)
+				require.NoError(t, err)
+				assert.Equal(t, tt.n, n)
+			}
+		})
+	}
+
+	null := ztype.NewNullTime().AddBusinessDays(3)
+	assert.True(t, null.IsNull())
+}
+
+func TestTimeBusinessDaysInLocation(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*3600)
+	// 2024-03-01 22:00 in São Paulo is already Saturday in UTC.
+	friday := ztype.NewTime(time.Date(2024, 3, 1, 22, 0, 0, 0, saoPaulo))
+	result := friday.AddBusinessDays(1, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 3, 5, 22, 0, 0, 0, saoPaulo), result.Get())
+
+	monday := ztype.NewTime(time.Date(2024, 3, 5, 0, 30, 0, 0, time.UTC))
+	n, err := friday.BusinessDaysUntil(monday)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestTimeBusinessDaysUntil(t *testing.T) {
+	friday := ztype.NewTime(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	saturday := ztype.NewTime(time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC))
+	nextFriday := ztype.NewTime(time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC))
+
+	n, err := friday.BusinessDaysUntil(friday)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = friday.BusinessDaysUntil(saturday)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = friday.BusinessDaysUntil(nextFriday, time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	n, err = nextFriday.BusinessDaysUntil(friday)
+	require.NoError(t, err)
+	assert.Equal(t, -5, n)
+
+	_, err = friday.BusinessDaysUntil(ztype.NewNullTime())
+	assert.ErrorIs(t, err, ztype.ErrNullValue)
+	_, err = ztype.NewNullTime().BusinessDaysUntil(friday)
+	assert.ErrorIs(t, err, ztype.ErrNullValue)
+}
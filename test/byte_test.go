@@ -4,10 +4,12 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
 )
 
 func TestByte(t *testing.T) {
@@ -62,6 +64,51 @@ func TestByte(t *testing.T) {
 		})
 	})
 
+	t.Run("StateChecks", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			instance ztype.Byte
+			isNull   bool
+			isZero   bool
+		}{
+			{"Valid 7", ztype.NewByte(7), false, false},
+			{"Valid 0", ztype.NewByte(0), false, true},
+			{"Null", ztype.NewNullByte(), true, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				require.Equal(t, tt.isNull, tt.instance.IsNull())
+				require.Equal(t, tt.isZero, tt.instance.IsZero())
+				require.Equal(t, tt.isZero, tt.instance.IsEmpty())
+			})
+		}
+	})
+
+	t.Run("OmitZero", func(t *testing.T) {
+		type payload struct {
+			Opcode ztype.Byte `json:"opcode,omitzero"`
+		}
+
+		tests := []struct {
+			name     string
+			instance payload
+			expected string
+		}{
+			{"Valid 7", payload{ztype.NewByte(7)}, `{"opcode":7}`},
+			{"Valid 0", payload{ztype.NewByte(0)}, `{}`},
+			{"Null", payload{ztype.NewNullByte()}, `{}`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				data, err := json.Marshal(&tt.instance)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.expected, string(data))
+			})
+		}
+	})
+
 	t.Run("JSON", func(t *testing.T) {
 		tests := []struct {
 			name        string
@@ -158,6 +205,30 @@ func TestByte(t *testing.T) {
 					expected:    ztype.NewNullByte(),
 					expectError: true,
 				},
+				{
+					name:        "Decimal text bytes",
+					input:       []byte("200"),
+					expected:    ztype.NewByte(200),
+					expectError: false,
+				},
+				{
+					name:        "Single raw octet",
+					input:       []byte{0xff},
+					expected:    ztype.NewByte(255),
+					expectError: false,
+				},
+				{
+					name:        "Single zero octet",
+					input:       []byte{0x00},
+					expected:    ztype.NewByte(0),
+					expectError: false,
+				},
+				{
+					name:        "Multiple raw octets",
+					input:       []byte{0x01, 0x02},
+					expected:    ztype.NewNullByte(),
+					expectError: true,
+				},
 			}
 
 			for _, tt := range tests {
@@ -219,6 +290,195 @@ func TestByte(t *testing.T) {
 		})
 	})
 
+	t.Run("Representations", func(t *testing.T) {
+		t.Run("ToHexAndToBinary", func(t *testing.T) {
+			tests := []struct {
+				instance ztype.Byte
+				hex      ztype.String
+				binary   ztype.String
+			}{
+				{ztype.NewByte(47), ztype.NewString("0x2f"), ztype.NewString("00101111")},
+				{ztype.NewByte(0), ztype.NewString("0x00"), ztype.NewString("00000000")},
+				{ztype.NewByte(255), ztype.NewString("0xff"), ztype.NewString("11111111")},
+				{ztype.NewNullByte(), ztype.NewNullString(), ztype.NewNullString()},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.instance.String(), func(t *testing.T) {
+					hex, binary := tt.instance.ToHex(), tt.instance.ToBinary()
+					require.True(t, hex.Equal(tt.hex))
+					require.True(t, binary.Equal(tt.binary))
+				})
+			}
+		})
+
+		t.Run("UnmarshalText", func(t *testing.T) {
+			tests := []struct {
+				input       string
+				expected    byte
+				expectError bool
+			}{
+				{"0xFF", 255, false},
+				{"0x2f", 47, false},
+				{"0b1010", 10, false},
+				{"0o17", 15, false},
+				{"010", 10, false},
+				{"0x1FF", 0, true},
+				{"0b100000000", 0, true},
+				{"256", 0, true},
+				{"0x", 0, true},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.input, func(t *testing.T) {
+					var b ztype.Byte
+					err := b.UnmarshalText([]byte(tt.input))
+					if tt.expectError {
+						require.Error(t, err)
+						return
+					}
+					require.NoError(t, err)
+					require.Equal(t, tt.expected, b.Get())
+				})
+			}
+		})
+
+		t.Run("HexJSONMode", func(t *testing.T) {
+			ztype.SetByteJSONHex(true)
+			defer ztype.SetByteJSONHex(false)
+
+			b := ztype.NewByte(47)
+			data, err := json.Marshal(&b)
+			require.NoError(t, err)
+			require.Equal(t, `"0x2f"`, string(data))
+
+			null := ztype.NewNullByte()
+			data, err = json.Marshal(&null)
+			require.NoError(t, err)
+			require.Equal(t, `null`, string(data))
+
+			var decoded ztype.Byte
+			require.NoError(t, json.Unmarshal([]byte(`"0x2f"`), &decoded))
+			require.True(t, decoded.Equal(b))
+		})
+
+		t.Run("RoundTrip", func(t *testing.T) {
+			for _, value := range []byte{0, 1, 47, 128, 255} {
+				b := ztype.NewByte(value)
+				hex, binary := b.ToHex(), b.ToBinary()
+
+				var fromHex, fromBinary ztype.Byte
+				require.NoError(t, fromHex.UnmarshalText([]byte(hex.Get())))
+				require.NoError(t, fromBinary.UnmarshalText([]byte("0b"+binary.Get())))
+				require.True(t, fromHex.Equal(b))
+				require.True(t, fromBinary.Equal(b))
+			}
+		})
+	})
+
+	t.Run("Arithmetic", func(t *testing.T) {
+		N := ztype.NewNullByte()
+
+		tests := []struct {
+			name             string
+			x, y             ztype.Byte
+			add, sub         ztype.Byte
+			addErr, subErr   bool
+			addWrap, subWrap byte
+		}{
+			{"Zero", ztype.NewByte(0), ztype.NewByte(0), ztype.NewByte(0), ztype.NewByte(0), false, false, 0, 0},
+			{"MidRange", ztype.NewByte(100), ztype.NewByte(27), ztype.NewByte(127), ztype.NewByte(73), false, false, 127, 73},
+			{"UpperBound", ztype.NewByte(255), ztype.NewByte(0), ztype.NewByte(255), ztype.NewByte(255), false, false, 255, 255},
+			{"Overflow", ztype.NewByte(255), ztype.NewByte(1), N, ztype.NewByte(254), true, false, 0, 254},
+			{"Underflow", ztype.NewByte(0), ztype.NewByte(1), ztype.NewByte(1), N, false, true, 1, 255},
+			{"NullOperand", ztype.NewByte(1), N, N, N, false, false, 0, 0},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				sum, err := tt.x.AddChecked(tt.y)
+				require.Equal(t, tt.addErr, err != nil)
+				require.True(t, sum.Equal(tt.add))
+
+				diff, err := tt.x.SubChecked(tt.y)
+				require.Equal(t, tt.subErr, err != nil)
+				require.True(t, diff.Equal(tt.sub))
+
+				wrappedSum, wrappedDiff := tt.x.Add(tt.y), tt.x.Sub(tt.y)
+				require.Equal(t, tt.addWrap, wrappedSum.Get())
+				require.Equal(t, tt.subWrap, wrappedDiff.Get())
+				require.Equal(t, tt.y.IsNull(), wrappedSum.IsNull())
+				require.Equal(t, tt.y.IsNull(), wrappedDiff.IsNull())
+			})
+		}
+	})
+
+	t.Run("Bitwise", func(t *testing.T) {
+		a, b, N := ztype.NewByte(0b1100), ztype.NewByte(0b1010), ztype.NewNullByte()
+		requireByte := func(t *testing.T, expected, actual ztype.Byte, msgAndArgs ...any) {
+			t.Helper()
+			require.True(t, actual.Equal(expected), msgAndArgs...)
+		}
+
+		t.Run("Operators", func(t *testing.T) {
+			tests := []struct {
+				name               string
+				x, y               ztype.Byte
+				and, or, xor, nand ztype.Byte
+			}{
+				{"Values", a, b, ztype.NewByte(0b1000), ztype.NewByte(0b1110), ztype.NewByte(0b0110), ztype.NewByte(0b0100)},
+				{"NullRight", a, N, N, N, N, N},
+				{"NullLeft", N, b, N, N, N, N},
+				{"BothNull", N, N, N, N, N, N},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					requireByte(t, tt.and, tt.x.And(tt.y))
+					requireByte(t, tt.or, tt.x.Or(tt.y))
+					requireByte(t, tt.xor, tt.x.Xor(tt.y))
+					requireByte(t, tt.nand, tt.x.AndNot(tt.y))
+				})
+			}
+		})
+
+		t.Run("Shifts", func(t *testing.T) {
+			tests := []struct {
+				n           uint
+				left, right ztype.Byte
+			}{
+				{0, ztype.NewByte(0b1100), ztype.NewByte(0b1100)},
+				{2, ztype.NewByte(0b110000), ztype.NewByte(0b11)},
+				{5, ztype.NewByte(0b10000000), ztype.NewByte(0)},
+				{8, ztype.NewByte(0), ztype.NewByte(0)},
+				{64, ztype.NewByte(0), ztype.NewByte(0)},
+			}
+
+			for _, tt := range tests {
+				requireByte(t, tt.left, a.ShiftLeft(tt.n), "ShiftLeft(%d)", tt.n)
+				requireByte(t, tt.right, a.ShiftRight(tt.n), "ShiftRight(%d)", tt.n)
+			}
+			requireByte(t, N, N.ShiftLeft(1))
+			requireByte(t, N, N.ShiftRight(1))
+		})
+
+		t.Run("Bits", func(t *testing.T) {
+			expected := []bool{false, false, true, true, false, false, false, false}
+			for pos, set := range expected {
+				require.Equal(t, set, a.HasBit(uint(pos)), "HasBit(%d)", pos)
+			}
+			require.False(t, a.HasBit(8))
+			require.False(t, N.HasBit(0))
+
+			requireByte(t, ztype.NewByte(0b1101), a.SetBit(0, true))
+			requireByte(t, ztype.NewByte(0b0100), a.SetBit(3, false))
+			requireByte(t, a, a.SetBit(2, true))
+			requireByte(t, ztype.NewByte(0b10001100), a.SetBit(7, true))
+			requireByte(t, a, a.SetBit(8, true))
+			requireByte(t, N, N.SetBit(0, true))
+		})
+	})
+
 	t.Run("StringRepresentation", func(t *testing.T) {
 		tests := []struct {
 			name     string
@@ -244,3 +504,14 @@ func TestByte(t *testing.T) {
 		}
 	})
 }
+
+func TestByteValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Byte) }, []ztypetest.Case{
+		{Name: "int64", Scan: int64(200), Want: int64(200)},
+		{Name: "text", Scan: "7", Want: int64(7)},
+		{Name: "bytes", Scan: []byte("7"), Want: int64(7)},
+		{Name: "overflow", Scan: int64(256), Err: true},
+		{Name: "float", Scan: 1.5, Err: true},
+		{Name: "time", Scan: time.Now(), Err: true},
+	})
+}
@@ -0,0 +1,165 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
+)
+
+func TestBytesConstructors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ztype.Bytes
+		isNull bool
+		isZero bool
+	}{
+		{"NewBytes", ztype.NewBytes([]byte("a")), false, false},
+		{"NewBytes empty", ztype.NewBytes([]byte{}), false, true},
+		{"NewNullBytes", ztype.NewNullBytes(), true, true},
+		{"NewNullBytesIfZero empty", ztype.NewNullBytesIfZero(nil), true, true},
+		{"NewNullBytesIfZero populated", ztype.NewNullBytesIfZero([]byte("a")), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.isNull, tt.input.IsNull())
+			assert.Equal(t, tt.isZero, tt.input.IsZero())
+		})
+	}
+}
+
+func TestBytesJSON(t *testing.T) {
+	t.Run("Marshal", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			input    ztype.Bytes
+			expected string
+		}{
+			{"valid", ztype.NewBytes([]byte{0xde, 0xad}), `"3q0="`},
+			{"empty", ztype.NewBytes(nil), `""`},
+			{"null", ztype.NewNullBytes(), `null`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				data, err := json.Marshal(&tt.input)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, string(data))
+			})
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			input    string
+			expected []byte
+			isNull   bool
+			wantErr  bool
+		}{
+			{"base64", `"3q0="`, []byte{0xde, 0xad}, false, false},
+			{"number array", `[222,173]`, []byte{0xde, 0xad}, false, false},
+			{"empty string", `""`, []byte{}, false, false},
+			{"null", `null`, nil, true, false},
+			{"out of range", `[256]`, nil, false, true},
+			{"invalid base64", `"***"`, nil, false, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var b ztype.Bytes
+				err := json.Unmarshal([]byte(tt.input), &b)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.True(t, b.Unmarshaled())
+				assert.Equal(t, tt.isNull, b.IsNull())
+				assert.Equal(t, tt.expected, b.Get())
+			})
+		}
+	})
+}
+
+func TestBytesDatabase(t *testing.T) {
+	t.Run("Scan copies driver buffer", func(t *testing.T) {
+		buffer := []byte("first")
+		var b ztype.Bytes
+		require.NoError(t, b.Scan(buffer))
+
+		copy(buffer, "xxxxx")
+		assert.Equal(t, []byte("first"), b.Get())
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			input    any
+			expected []byte
+			isNull   bool
+		}{
+			{"bytes", []byte("a"), []byte("a"), false},
+			{"empty bytes", []byte{}, []byte{}, false},
+			{"string", "a", []byte("a"), false},
+			{"nil", nil, nil, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var b ztype.Bytes
+				require.NoError(t, b.Scan(tt.input))
+				assert.Equal(t, tt.isNull, b.IsNull())
+				assert.Equal(t, tt.expected, b.Get())
+			})
+		}
+
+		var b ztype.Bytes
+		assert.Error(t, b.Scan(42))
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		value, err := ztype.NewBytes([]byte("a")).Value()
+		require.NoError(t, err)
+		assert.Equal(t, driver.Value([]byte("a")), value)
+
+		value, err = ztype.NewBytes(nil).Value()
+		require.NoError(t, err)
+		assert.Equal(t, driver.Value([]byte{}), value)
+
+		value, err = ztype.NewNullBytes().Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestBytesHelpers(t *testing.T) {
+	b := ztype.NewBytes([]byte{0xde, 0xad})
+	assert.Equal(t, "dead", b.EncodeHex())
+	assert.Equal(t, "3q0=", b.String())
+
+	var decoded ztype.Bytes
+	require.NoError(t, decoded.DecodeHex("dead"))
+	assert.True(t, decoded.Equal(b))
+	assert.True(t, decoded.EqualRaw([]byte{0xde, 0xad}))
+	assert.Error(t, decoded.DecodeHex("zz"))
+	assert.True(t, decoded.Equal(b))
+
+	null := ztype.NewNullBytes()
+	assert.False(t, null.Equal(ztype.NewBytes(nil)))
+	assert.Equal(t, "<NULL>", null.String())
+}
+
+func TestBytesValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Bytes) }, []ztypetest.Case{
+		{Name: "bytes", Scan: []byte{0, 1}, Want: []byte{0, 1}},
+		{Name: "text", Scan: "ab", Want: []byte("ab")},
+		{Name: "int64", Scan: int64(1), Err: true},
+	})
+}
@@ -0,0 +1,94 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
+)
+
+func TestCharMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance ztype.Char
+		expected string
+	}{
+		{"letter", ztype.NewChar('A'), `"A"`},
+		{"space", ztype.NewChar(' '), `" "`},
+		{"tilde", ztype.NewChar('~'), `"~"`},
+		{"quote", ztype.NewChar('"'), `"\""`},
+		{"newline", ztype.NewChar('\n'), `10`},
+		{"nul", ztype.NewChar(0), `0`},
+		{"del", ztype.NewChar(0x7f), `127`},
+		{"high byte", ztype.NewChar(0xe9), `233`},
+		{"null", ztype.NewNullChar(), `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(&tt.instance)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+
+			var decoded ztype.Char
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.True(t, decoded.Equal(tt.instance), "round trip of %s", data)
+		})
+	}
+}
+
+func TestCharUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ztype.Char
+		wantErr  bool
+	}{
+		{`"A"`, ztype.NewChar('A'), false},
+		{`"\t"`, ztype.NewChar('\t'), false},
+		{`65`, ztype.NewChar('A'), false},
+		{`null`, ztype.NewNullChar(), false},
+		{`"AB"`, ztype.Char{}, true},
+		{`""`, ztype.Char{}, true},
+		{`"é"`, ztype.Char{}, true},
+		{`256`, ztype.Char{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var c ztype.Char
+			err := json.Unmarshal([]byte(tt.input), &c)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, c.Unmarshaled())
+			assert.True(t, c.Equal(tt.expected))
+		})
+	}
+}
+
+func TestCharString(t *testing.T) {
+	letter, control, null := ztype.NewChar('z'), ztype.NewChar(7), ztype.NewNullChar()
+	assert.Equal(t, "z", letter.String())
+	assert.Equal(t, "7", control.String())
+	assert.Equal(t, "<NULL>", null.String())
+
+	value, err := letter.Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64('z'), value)
+}
+
+func TestCharValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Char) }, []ztypetest.Case{
+		{Name: "int64", Scan: int64(65), Want: int64(65)},
+		{Name: "text", Scan: "65", Want: int64(65)},
+		{Name: "float", Scan: 1.5, Err: true},
+		{Name: "time", Scan: time.Now(), Err: true},
+	})
+}
@@ -0,0 +1,160 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestCloneKeepsState(t *testing.T) {
+	var decoded struct {
+		Name ztype.String `json:"name"`
+		Age  ztype.Numeric[int]
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"name":null}`), &decoded))
+
+	name := decoded.Name.Clone()
+	assert.True(t, name.IsNull())
+	assert.True(t, name.Unmarshaled())
+
+	age := decoded.Age.Clone()
+	assert.True(t, age.IsNull())
+	assert.False(t, age.Unmarshaled())
+
+	money := ztype.NewMoney(1990, "BRL").Clone()
+	assert.Equal(t, "19.90 BRL", money.String())
+}
+
+func TestCloneBytesAndRawJSON(t *testing.T) {
+	original := ztype.NewBytes([]byte("abc"))
+	clone := original.Clone()
+	clone.Get()[0] = 'x'
+	assert.Equal(t, []byte("abc"), original.Get())
+
+	raw := ztype.NewRawJSON(json.RawMessage(`{"a":1}`))
+	rawClone := raw.Clone()
+	rawClone.Get()[2] = 'b'
+	assert.JSONEq(t, `{"a":1}`, string(raw.Get()))
+}
+
+func TestCloneMapIsShallowAndCloneDeepIsNot(t *testing.T) {
+	original := ztype.NewMap(map[string]any{
+		"tags":   []any{"a", "b"},
+		"nested": map[string]any{"count": 1},
+	})
+
+	shallow := original.Clone()
+	shallow.SetItem("extra", true)
+	assert.False(t, original.Has("extra"))
+	shallow.Get()["tags"].([]any)[0] = "shared"
+	assert.Equal(t, "shared", original.Get()["tags"].([]any)[0])
+
+	original.Get()["tags"].([]any)[0] = "a"
+	deep := original.CloneDeep()
+	deep.Get()["tags"].([]any)[0] = "changed"
+	deep.Get()["nested"].(map[string]any)["count"] = 2
+	assert.Equal(t, "a", original.Get()["tags"].([]any)[0])
+	assert.Equal(t, 1, original.Get()["nested"].(map[string]any)["count"])
+
+	assert.True(t, ztype.NewNullMap[string, any]().CloneDeep().IsNull())
+}
+
+func TestCloneMapFunc(t *testing.T) {
+	original := ztype.NewMap(map[string][]int{"a": {1, 2}})
+	clone := original.CloneFunc(slices.Clone)
+	clone.Get()["a"][0] = 9
+	assert.Equal(t, []int{1, 2}, original.Get()["a"])
+}
+
+func TestCloneSliceNullAndOrderedMap(t *testing.T) {
+	slice := ztype.NewSlice([]map[string]int{{"a": 1}})
+	sliceClone := slice.CloneDeep()
+	sliceClone.Get()[0]["a"] = 2
+	assert.Equal(t, 1, slice.Get()[0]["a"])
+
+	null := ztype.New(map[string][]string{"k": {"v"}})
+	nullClone := null.CloneDeep()
+	nullClone.Get()["k"][0] = "changed"
+	assert.Equal(t, "v", null.Get()["k"][0])
+
+	ordered := ztype.NewOrderedMap[string, []int]()
+	ordered.SetItem("b", []int{1})
+	ordered.SetItem("a", []int{2})
+	orderedClone := ordered.CloneDeep()
+	orderedClone.SetItem("c", nil)
+	value, _ := orderedClone.GetItem("b")
+	value[0] = 9
+	assert.Equal(t, 2, ordered.Len())
+	value, _ = ordered.GetItem("b")
+	assert.Equal(t, []int{1}, value)
+	assert.Equal(t, []string{"b", "a", "c"}, slices.Collect(orderedClone.Keys()))
+}
+
+func TestCloneSyncMap(t *testing.T) {
+	original := ztype.NewSyncMap(map[string][]int{"a": {1}})
+	clone := original.CloneDeep()
+	clone.SetItem("b", nil)
+	value, _ := clone.GetItem("a")
+	value[0] = 9
+
+	assert.Equal(t, 1, original.Len())
+	value, _ = original.GetItem("a")
+	assert.Equal(t, []int{1}, value)
+}
+
+type cloneInner struct {
+	Meta ztype.JSON
+}
+
+type cloneOuter struct {
+	Name   ztype.String
+	Tags   ztype.Slice[string]
+	Raw    ztype.Bytes
+	Inner  cloneInner
+	Ptr    *cloneInner
+	Plain  map[string][]string
+	Items  []cloneInner
+	hidden []int
+}
+
+func TestCloneStruct(t *testing.T) {
+	original := &cloneOuter{
+		Name:   ztype.NewString("a"),
+		Tags:   ztype.NewSlice([]string{"x"}),
+		Raw:    ztype.NewBytes([]byte{1}),
+		Inner:  cloneInner{Meta: ztype.NewMap(map[string]any{"list": []any{1.0}})},
+		Ptr:    &cloneInner{Meta: ztype.NewMap(map[string]any{"k": "v"})},
+		Plain:  map[string][]string{"p": {"q"}},
+		Items:  []cloneInner{{Meta: ztype.NewMap(map[string]any{"n": map[string]any{"deep": true}})}},
+		hidden: []int{1},
+	}
+
+	clone, ok := ztype.CloneStruct(original).(*cloneOuter)
+	require.True(t, ok)
+	require.NotSame(t, original, clone)
+	assert.True(t, clone.Name.EqualValues(original.Name))
+
+	clone.Tags.Get()[0] = "changed"
+	clone.Raw.Get()[0] = 9
+	clone.Inner.Meta.Get()["list"].([]any)[0] = 2.0
+	clone.Ptr.Meta.SetItem("k", "changed")
+	clone.Plain["p"][0] = "changed"
+	clone.Items[0].Meta.Get()["n"].(map[string]any)["deep"] = false
+
+	assert.Equal(t, []string{"x"}, original.Tags.Get())
+	assert.Equal(t, []byte{1}, original.Raw.Get())
+	assert.Equal(t, 1.0, original.Inner.Meta.Get()["list"].([]any)[0])
+	assert.Equal(t, "v", original.Ptr.Meta.Get()["k"])
+	assert.Equal(t, "q", original.Plain["p"][0])
+	assert.Equal(t, true, original.Items[0].Meta.Get()["n"].(map[string]any)["deep"])
+
+	byValue, ok := ztype.CloneStruct(*original).(cloneOuter)
+	require.True(t, ok)
+	assert.Equal(t, []int{1}, byValue.hidden)
+	assert.Nil(t, ztype.CloneStruct(nil))
+}
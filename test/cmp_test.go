@@ -0,0 +1,126 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypecmp"
+)
+
+type cmpAddress struct {
+	City ztype.String `json:"city"`
+	IP   ztype.IP     `json:"ip"`
+}
+
+type cmpOrder struct {
+	ID        ztype.Numeric[int64] `json:"id"`
+	Paid      ztype.Bool           `json:"paid"`
+	Note      ztype.String         `json:"note"`
+	CreatedAt ztype.Time           `json:"created_at"`
+	Total     ztype.Money          `json:"total"`
+	Tags      ztype.Set[string]    `json:"tags"`
+	Items     ztype.Slice[int]     `json:"items"`
+	Meta      ztype.JSON           `json:"meta"`
+	Address   cmpAddress           `json:"address"`
+}
+
+const cmpOrderJSON = `{
+	"id": 7,
+	"paid": false,
+	"note": null,
+	"created_at": "2024-03-01T12:00:00Z",
+	"total": {"amount": "19.90", "currency": "BRL"},
+	"tags": ["b", "a"],
+	"items": [1, 2],
+	"meta": {"k": "v"},
+	"address": {"city": "Recife", "ip": "10.0.0.1"}
+}`
+
+func constructedCmpOrder() cmpOrder {
+	return cmpOrder{
+		ID:        ztype.NewNumber[int64](7),
+		Paid:      ztype.NewBool(false),
+		Note:      ztype.NewNullString(),
+		CreatedAt: ztype.NewTime(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)),
+		Total:     ztype.NewMoney(1990, "BRL"),
+		Tags:      ztype.NewSet("a", "b"),
+		Items:     ztype.NewSlice([]int{1, 2}),
+		Meta:      ztype.NewMap(map[string]any{"k": "v"}),
+		Address: cmpAddress{
+			City: ztype.NewString("Recife"),
+			IP:   ztype.NewIP(netip.MustParseAddr("10.0.0.1")),
+		},
+	}
+}
+
+func TestCmpOptionsDecodedEqualsConstructed(t *testing.T) {
+	var decoded cmpOrder
+	require.NoError(t, json.Unmarshal([]byte(cmpOrderJSON), &decoded))
+	want := constructedCmpOrder()
+
+	assert.False(t, reflect.DeepEqual(want, decoded), "DeepEqual sees the unmarshaled flag")
+	assert.Empty(t, cmp.Diff(want, decoded, ztypecmp.Options()...))
+}
+
+func TestCmpOptionsReportsDifferences(t *testing.T) {
+	var decoded cmpOrder
+	require.NoError(t, json.Unmarshal([]byte(cmpOrderJSON), &decoded))
+
+	want := constructedCmpOrder()
+	want.Note = ztype.NewString("")
+	want.Address.City = ztype.NewString("Olinda")
+
+	diff := cmp.Diff(want, decoded, ztypecmp.Options()...)
+	assert.Contains(t, diff, "Note")
+	assert.Contains(t, diff, "Olinda")
+}
+
+func TestEqualValues(t *testing.T) {
+	var decoded cmpOrder
+	require.NoError(t, json.Unmarshal([]byte(cmpOrderJSON), &decoded))
+	want := constructedCmpOrder()
+
+	assert.True(t, decoded.ID.EqualValues(want.ID))
+	assert.True(t, decoded.Paid.EqualValues(want.Paid))
+	assert.True(t, decoded.Note.EqualValues(want.Note))
+	assert.True(t, decoded.CreatedAt.EqualValues(want.CreatedAt))
+	assert.True(t, decoded.Total.EqualValues(want.Total))
+	assert.True(t, decoded.Tags.EqualValues(want.Tags))
+	assert.True(t, decoded.Items.EqualValues(want.Items))
+	assert.True(t, decoded.Meta.EqualValues(want.Meta))
+	assert.True(t, decoded.Address.IP.EqualValues(want.Address.IP))
+
+	assert.False(t, decoded.Note.EqualValues(ztype.NewString("")))
+	assert.False(t, decoded.Items.EqualValues(ztype.NewNullSlice[int]()))
+
+	values := []any{ztype.NewBool(true), ztype.NewDuration(time.Second), ztype.New("x")}
+	assert.True(t, values[0].(ztype.Bool).EqualValues(ztype.NewBool(true)))
+	assert.True(t, values[1].(ztype.Duration).EqualValues(ztype.NewDuration(time.Second)))
+	assert.True(t, values[2].(ztype.Null[string]).EqualValues(ztype.New("x")))
+}
+
+func TestEqualValuesNilAndEmptyCollections(t *testing.T) {
+	var fromNull ztype.Map[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`null`), &fromNull))
+	assert.True(t, ztype.NewNullMap[string, int]().EqualValues(fromNull))
+	assert.True(t, ztype.NewMap[string, int](nil).EqualValues(ztype.NewMap(map[string]int{})))
+	assert.False(t, ztype.NewMap(map[string]int{"a": 1}).EqualValues(ztype.NewMap(map[string]int{"b": 1})))
+	assert.Empty(t, cmp.Diff(ztype.NewNullMap[string, int](), fromNull, ztypecmp.Options()...))
+
+	var nullSlice ztype.Slice[int]
+	require.NoError(t, json.Unmarshal([]byte(`null`), &nullSlice))
+	assert.True(t, ztype.NewNullSlice[int]().EqualValues(nullSlice))
+	assert.True(t, ztype.NewSlice[int](nil).EqualValues(ztype.NewSlice([]int{})))
+
+	var nullOrdered ztype.OrderedMap[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`null`), &nullOrdered))
+	assert.True(t, ztype.NewNullOrderedMap[string, int]().EqualValues(nullOrdered))
+}
@@ -0,0 +1,134 @@
+package ztype_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/zhaori96/ztype"
+)
+
+type codecRecord struct {
+	Active   ztype.Bool             `msgpack:"active" cbor:"active"`
+	Flags    ztype.Byte             `msgpack:"flags" cbor:"flags"`
+	Payload  ztype.Bytes            `msgpack:"payload" cbor:"payload"`
+	Name     ztype.String           `msgpack:"name" cbor:"name"`
+	Small    ztype.Numeric[int64]   `msgpack:"small" cbor:"small"`
+	Big      ztype.Numeric[uint64]  `msgpack:"big" cbor:"big"`
+	Ratio    ztype.Numeric[float64] `msgpack:"ratio" cbor:"ratio"`
+	At       ztype.Time             `msgpack:"at" cbor:"at"`
+	Duration ztype.Duration         `msgpack:"duration" cbor:"duration"`
+}
+
+func codecRecords() map[string]codecRecord {
+	at := time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC)
+	return map[string]codecRecord{
+		"valid": {
+			Active:   ztype.NewBool(false),
+			Flags:    ztype.NewByte(255),
+			Payload:  ztype.NewBytes([]byte{0, 1, 2}),
+			Name:     ztype.NewString("ação"),
+			Small:    ztype.NewNumber[int64](math.MinInt64),
+			Big:      ztype.NewNumber[uint64](math.MaxUint64),
+			Ratio:    ztype.NewNumber(0.1),
+			At:       ztype.NewTime(at),
+			Duration: ztype.NewDuration(1500 * time.Millisecond),
+		},
+		"null": {
+			Active:   ztype.NewNullBool(),
+			Flags:    ztype.NewNullByte(),
+			Payload:  ztype.NewNullBytes(),
+			Name:     ztype.NewNullString(),
+			Small:    ztype.NewNullNumber[int64](),
+			Big:      ztype.NewNullNumber[uint64](),
+			Ratio:    ztype.NewNullNumber[float64](),
+			At:       ztype.NewNullTime(),
+			Duration: ztype.NewNullDuration(),
+		},
+	}
+}
+
+func assertCodecRecord(t *testing.T, expected, actual codecRecord) {
+	t.Helper()
+	assert.True(t, actual.Active.Equal(expected.Active), "Active")
+	assert.True(t, actual.Flags.Equal(expected.Flags), "Flags")
+	assert.True(t, actual.Payload.Equal(expected.Payload), "Payload")
+	assert.True(t, actual.Name.Equal(expected.Name), "Name")
+	assert.True(t, actual.Small.Equal(expected.Small), "Small")
+	assert.True(t, actual.Big.Equal(expected.Big), "Big")
+	assert.True(t, actual.Ratio.Equal(expected.Ratio), "Ratio")
+	assert.Equal(t, expected.At.IsNull(), actual.At.IsNull(), "At null state")
+	assert.True(t, actual.At.Get().Equal(expected.At.Get()), "At %v != %v", actual.At.Get(), expected.At.Get())
+	assert.True(t, actual.Duration.Equal(expected.Duration), "Duration")
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	for name, record := range codecRecords() {
+		t.Run(name, func(t *testing.T) {
+			data, err := msgpack.Marshal(record)
+			require.NoError(t, err)
+
+			var decoded codecRecord
+			require.NoError(t, msgpack.Unmarshal(data, &decoded))
+			assertCodecRecord(t, record, decoded)
+			// msgpack resolves nil without calling DecodeMsgpack.
+			assert.Equal(t, !record.Name.IsNull(), decoded.Name.Unmarshaled())
+		})
+	}
+
+	t.Run("wire types", func(t *testing.T) {
+		data, err := msgpack.Marshal(ztype.NewNullString())
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xc0}, data)
+
+		data, err = msgpack.Marshal(ztype.NewNumber[int64](1))
+		require.NoError(t, err)
+		var plain int64
+		require.NoError(t, msgpack.Unmarshal(data, &plain))
+		assert.Equal(t, int64(1), plain)
+
+		data, err = msgpack.Marshal(ztype.NewTime(time.Unix(0, 1)))
+		require.NoError(t, err)
+		var plainTime time.Time
+		require.NoError(t, msgpack.Unmarshal(data, &plainTime))
+		assert.Equal(t, int64(1), plainTime.UnixNano())
+	})
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	for name, record := range codecRecords() {
+		t.Run(name, func(t *testing.T) {
+			data, err := cbor.Marshal(record)
+			require.NoError(t, err)
+
+			var decoded codecRecord
+			require.NoError(t, cbor.Unmarshal(data, &decoded))
+			assertCodecRecord(t, record, decoded)
+			assert.True(t, decoded.Name.Unmarshaled())
+			assert.True(t, decoded.At.Unmarshaled())
+		})
+	}
+
+	t.Run("wire types", func(t *testing.T) {
+		data, err := cbor.Marshal(ztype.NewNullBool())
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xf6}, data)
+
+		data, err = cbor.Marshal(ztype.NewString("a"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x61, 'a'}, data)
+
+		data, err = cbor.Marshal(ztype.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC)))
+		require.NoError(t, err)
+		assert.Equal(t, byte(0xc0), data[0], "times carry CBOR tag 0")
+
+		var epoch ztype.Time
+		require.NoError(t, cbor.Unmarshal([]byte{0xc1, 0x1a, 0x65, 0x92, 0x00, 0x80}, &epoch))
+		assert.Equal(t, int64(1704067200), epoch.Get().Unix())
+	})
+}
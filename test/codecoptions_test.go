@@ -0,0 +1,442 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type codecEvent struct {
+	At       ztype.Time                     `json:"at"`
+	Ends     *ztype.Time                    `json:"ends,omitempty"`
+	Stops    []ztype.Time                   `json:"stops"`
+	ByName   map[string]ztype.Time          `json:"byName"`
+	Extra    any                            `json:"extra"`
+	Versions ztype.Map[string, ztype.Time]  `json:"versions"`
+	History  ztype.Slice[ztype.Time]        `json:"history"`
+	Count    int                            `json:"count,string"`
+	Ignored  string                         `json:"-"`
+	Named    ztype.Map[string, json.Number] `json:"named,omitzero"`
+}
+
+func newCodecEvent(at time.Time) codecEvent {
+	value := ztype.NewTime(at)
+	return codecEvent{
+		At:       value,
+		Ends:     &value,
+		Stops:    []ztype.Time{value},
+		ByName:   map[string]ztype.Time{"b": value, "a": value},
+		Extra:    value,
+		Versions: ztype.NewMap(map[string]ztype.Time{"v1": value}),
+		History:  ztype.NewSlice([]ztype.Time{value}),
+		Count:    3,
+		Ignored:  "x",
+	}
+}
+
+func TestCodecConflictingTimeLayouts(t *testing.T) {
+	dateTime := ztype.NewCodec(ztype.WithTimeLayout(time.DateTime))
+	rfc1123 := ztype.NewCodec(ztype.WithTimeLayout(time.RFC1123))
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	event := newCodecEvent(at)
+
+	format := func(text string) string {
+		return fmt.Sprintf(`{"at":%[1]q,"ends":%[1]q,"stops":[%[1]q],"byName":{"a":%[1]q,"b":%[1]q},`+
+			`"extra":%[1]q,"versions":{"v1":%[1]q},"history":[%[1]q],"count":"3"}`, text)
+	}
+	expected := map[*ztype.Codec]string{
+		dateTime: format("2024-03-01 12:30:00"),
+		rfc1123:  format("Fri, 01 Mar 2024 12:30:00 UTC"),
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for range 50 {
+		for codec, want := range expected {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				data, err := codec.Marshal(event)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if string(data) != want {
+					errs <- fmt.Errorf("marshal: got %s, want %s", data, want)
+					return
+				}
+				var decoded codecEvent
+				if err := codec.Unmarshal(data, &decoded); err != nil {
+					errs <- err
+					return
+				}
+				version, byName := decoded.Versions.Get()["v1"], decoded.ByName["a"]
+				if !decoded.At.Get().Equal(at) || !decoded.Ends.Get().Equal(at) ||
+					!decoded.Stops[0].Get().Equal(at) || !byName.Get().Equal(at) ||
+					!version.Get().Equal(at) || !decoded.History.Get()[0].Get().Equal(at) ||
+					decoded.Count != 3 {
+					errs <- fmt.Errorf("unmarshal: got %+v", decoded)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	t.Run("default codec is unchanged", func(t *testing.T) {
+		data, err := json.Marshal(event.At)
+		require.NoError(t, err)
+		assert.JSONEq(t, `"2024-03-01T12:30:00Z"`, string(data))
+
+		fromDefault, err := ztype.DefaultCodec().Marshal(event)
+		require.NoError(t, err)
+		fromJSON, err := json.Marshal(event)
+		require.NoError(t, err)
+		assert.Equal(t, string(fromJSON), string(fromDefault))
+	})
+
+	t.Run("layouts are strict", func(t *testing.T) {
+		var decoded codecEvent
+		err := rfc1123.Unmarshal([]byte(`{"stops":["2024-03-01 12:30:00"]}`), &decoded)
+		assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+		assert.ErrorContains(t, err, "stops[0]: ")
+	})
+
+	t.Run("unmarshaled flags", func(t *testing.T) {
+		var decoded codecEvent
+		require.NoError(t, dateTime.Unmarshal([]byte(`{"at":null}`), &decoded))
+		assert.True(t, decoded.At.Unmarshaled())
+		assert.True(t, decoded.At.IsNull())
+		assert.False(t, decoded.Versions.Unmarshaled())
+	})
+}
+
+func TestCodecTimeEntryPoints(t *testing.T) {
+	kitchen := ztype.NewCodec(ztype.WithTimeLayout(time.Kitchen))
+	at := time.Date(2024, 3, 1, 15, 4, 0, 0, time.UTC)
+	assert.Equal(t, "3:04PM", kitchen.FormatTime(ztype.NewTime(at)))
+	assert.Equal(t, "", kitchen.FormatTime(ztype.NewNullTime()))
+	assert.Equal(t, "2024-03-01", kitchen.FormatTime(ztype.NewDateOnlyTime(2024, 3, 1)))
+
+	parsed, err := kitchen.ParseTime("3:04PM")
+	require.NoError(t, err)
+	assert.Equal(t, 15, parsed.Get().Hour())
+
+	blank, err := kitchen.ParseTime(" ")
+	require.NoError(t, err)
+	assert.True(t, blank.IsNull())
+
+	_, err = kitchen.ParseTime("2024-03-01T15:04:00Z")
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	utc := ztype.NewCodec(ztype.WithTimeMarshalUTC(true))
+	assert.Equal(t, "2024-03-01T15:04:00Z", utc.FormatTime(ztype.NewTime(at.In(tokyo))))
+
+	preserving := ztype.NewCodec(ztype.WithTimeMarshalUTC(true), ztype.WithTimePreserveOffset(true))
+	kept, err := preserving.ParseTime("2024-03-01T10:00:00+09:00")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-01T10:00:00+09:00", preserving.FormatTime(kept))
+}
+
+func TestCodecOptions(t *testing.T) {
+	t.Run("bool, byte and money", func(t *testing.T) {
+		c := ztype.NewCodec(ztype.WithBoolJSONLenient(true), ztype.WithByteJSONHex(true), ztype.WithMoneyJSONCompact(true))
+		var flags struct{ On ztype.Bool }
+		require.NoError(t, c.Unmarshal([]byte(`{"On":"yes"}`), &flags))
+		assert.True(t, flags.On.Get())
+		assert.Error(t, json.Unmarshal([]byte(`{"On":"yes"}`), &flags))
+
+		data, err := c.Marshal([]any{ztype.NewByte(47), ztype.NewMoney(1990, "BRL")})
+		require.NoError(t, err)
+		assert.Equal(t, `["0x2f","19.90 BRL"]`, string(data))
+	})
+
+	t.Run("json number mode", func(t *testing.T) {
+		exact := ztype.NewCodec(ztype.WithJSONNumberMode(ztype.JSONNumberExact))
+		var doc struct {
+			ID    any
+			Attrs ztype.JSON
+		}
+		input := []byte(`{"ID":1152921504606846977,"Attrs":{"n":1152921504606846977}}`)
+		require.NoError(t, exact.Unmarshal(input, &doc))
+		assert.Equal(t, json.Number("1152921504606846977"), doc.ID)
+		assert.Equal(t, json.Number("1152921504606846977"), doc.Attrs.Get()["n"])
+
+		require.NoError(t, json.Unmarshal(input, &doc))
+		assert.IsType(t, float64(0), doc.ID)
+		assert.IsType(t, float64(0), doc.Attrs.Get()["n"])
+	})
+
+	t.Run("driver values", func(t *testing.T) {
+		c := ztype.NewCodec(
+			ztype.WithDurationValueMode(ztype.DurationValueString),
+			ztype.WithMapValueAsBytes(true),
+			ztype.WithRangeValueLiteral(true),
+			ztype.WithTimeValueUTC(true),
+		)
+		tests := []struct {
+			name     string
+			value    any
+			expected any
+		}{
+			{"duration", ztype.NewDuration(90 * time.Minute), "1h30m0s"},
+			{"map", ztype.NewMap(map[string]int{"a": 1}), []byte(`{"a":1}`)},
+			{"range", ztype.MustParseRange[int]("[1,10)"), "[1,10)"},
+			{"time", ztype.NewTime(time.Date(2024, 3, 1, 9, 0, 0, 0, time.FixedZone("", 3600))), time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)},
+			{"null", ztype.NewNullDuration(), nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				value, err := c.Value(tt.value)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, value)
+
+				standard, err := ztype.ValueOf(tt.value)
+				require.NoError(t, err)
+				if tt.expected != nil {
+					assert.NotEqual(t, tt.expected, standard)
+				}
+			})
+		}
+	})
+
+	t.Run("new codecs ignore the package settings", func(t *testing.T) {
+		ztype.SetByteJSONHex(true)
+		t.Cleanup(func() { ztype.SetByteJSONHex(false) })
+
+		data, err := ztype.NewCodec().Marshal(ztype.NewByte(47))
+		require.NoError(t, err)
+		assert.Equal(t, `47`, string(data))
+
+		data, err = ztype.DefaultCodec().Marshal(ztype.NewByte(47))
+		require.NoError(t, err)
+		assert.Equal(t, `"0x2f"`, string(data))
+	})
+}
+
+func TestCodecStructFields(t *testing.T) {
+	c := ztype.NewCodec(ztype.WithTimeLayout(time.DateOnly))
+	type Base struct {
+		Created ztype.Time `json:"created"`
+		Name    string     `json:"name"`
+	}
+	type Record struct {
+		Base
+		Name    string         `json:"name"`
+		Tags    map[int]string `json:"tags,omitempty"`
+		Updated ztype.Time     `json:"updated,omitzero"`
+	}
+
+	record := Record{
+		Base: Base{Created: ztype.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), Name: "hidden"},
+		Name: "shown",
+		Tags: map[int]string{2: "b", 10: "a"},
+	}
+	data, err := c.Marshal(&record)
+	require.NoError(t, err)
+	assert.Equal(t, `{"created":"2024-03-01","name":"shown","tags":{"10":"a","2":"b"}}`, string(data))
+
+	var decoded Record
+	require.NoError(t, c.Unmarshal(data, &decoded))
+	assert.Equal(t, "shown", decoded.Name)
+	assert.Equal(t, "", decoded.Base.Name)
+	assert.Equal(t, map[int]string{2: "b", 10: "a"}, decoded.Tags)
+	assert.True(t, decoded.Created.Get().Equal(record.Created.Get()))
+
+	assert.Error(t, c.Unmarshal([]byte(`{"created":`), &decoded))
+	assert.Error(t, c.Unmarshal([]byte(`{}`), decoded))
+}
+
+type codecTaggedC struct {
+	Name  string
+	Color string
+}
+
+type codecTaggedD struct {
+	Other string `json:"Name"`
+	Color string
+	Skip  string `json:"-"`
+}
+
+type codecTaggedE struct {
+	Size string
+	At   ztype.Time `json:"at"`
+}
+
+type codecTaggedF struct {
+	Size  string
+	Depth string
+}
+
+type codecTaggedInner struct {
+	codecTaggedF
+}
+
+type codecTaggedRecord struct {
+	codecTaggedC
+	codecTaggedD
+	codecTaggedE
+	codecTaggedInner
+	Depth  string `json:"Depth"`
+	Hidden string `json:"-"`
+}
+
+func TestCodecFieldsMatchEncodingJSON(t *testing.T) {
+	c := ztype.NewCodec()
+	record := codecTaggedRecord{
+		codecTaggedC:     codecTaggedC{Name: "c", Color: "c"},
+		codecTaggedD:     codecTaggedD{Other: "d", Color: "d", Skip: "d"},
+		codecTaggedE:     codecTaggedE{Size: "e", At: ztype.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))},
+		codecTaggedInner: codecTaggedInner{codecTaggedF{Size: "f", Depth: "f"}},
+		Depth:            "top",
+		Hidden:           "h",
+	}
+
+	want, err := json.Marshal(record)
+	require.NoError(t, err)
+	got, err := c.Marshal(record)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+	assert.Contains(t, string(got), `"Name":"d"`)
+
+	input := []byte(`{"Name":"n","Color":"x","Size":"s","Depth":"p","Skip":"k","Hidden":"h","at":"2024-03-01T00:00:00Z"}`)
+	var fromJSON, fromCodec codecTaggedRecord
+	require.NoError(t, json.Unmarshal(input, &fromJSON))
+	require.NoError(t, c.Unmarshal(input, &fromCodec))
+	assert.Equal(t, fromJSON.codecTaggedC, fromCodec.codecTaggedC)
+	assert.Equal(t, fromJSON.codecTaggedD, fromCodec.codecTaggedD)
+	assert.Equal(t, fromJSON.codecTaggedE.Size, fromCodec.codecTaggedE.Size)
+	assert.Equal(t, fromJSON.codecTaggedInner, fromCodec.codecTaggedInner)
+	assert.Equal(t, fromJSON.Depth, fromCodec.Depth)
+	assert.Equal(t, fromJSON.Hidden, fromCodec.Hidden)
+	assert.Equal(t, "n", fromCodec.Other)
+}
+
+func TestCodecTextAndScanOptions(t *testing.T) {
+	brt := time.FixedZone("BRT", -3*3600)
+	c := ztype.NewCodec(
+		ztype.WithNullToken("-"),
+		ztype.WithNumberFormatter(func(v any) string { return fmt.Sprintf("%.2f", v) }),
+		ztype.WithNumberTextLenient(true, ztype.WithSeparators('.', ',')),
+		ztype.WithBoolScanTokens([]string{"S"}, []string{"N"}),
+		ztype.WithScanLocation(brt),
+		ztype.WithScanConvertTo(time.UTC),
+	)
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, "-", c.String(ztype.NewNullString()))
+		assert.Equal(t, "-", c.String(ztype.NewNullNumber[int]()))
+		assert.Equal(t, "1.50", c.String(ztype.NewNumber(1.5)))
+		assert.Equal(t, "ana", c.String(ztype.NewString("ana")))
+		assert.Equal(t, "", c.String(ztype.StringOrEmpty{}))
+		assert.Equal(t, "<NULL>", ztype.NewNullString().String())
+		assert.Equal(t, "1.5", ztype.NewNumber(1.5).String())
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var price ztype.Numeric[float64]
+		require.NoError(t, c.UnmarshalText([]byte("1.234,56"), &price))
+		assert.Equal(t, 1234.56, price.Get())
+		assert.Error(t, price.UnmarshalText([]byte("1.234,56")))
+
+		var name ztype.String
+		require.NoError(t, c.UnmarshalText([]byte("ana"), &name))
+		assert.Equal(t, "ana", name.Get())
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		var flag ztype.Bool
+		require.NoError(t, c.Scan(&flag, "S"))
+		assert.True(t, flag.Get())
+		assert.Error(t, flag.Scan("S"))
+
+		wall := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		var at ztype.Time
+		require.NoError(t, c.Scan(&at, wall))
+		assert.True(t, at.Get().Equal(time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)))
+		assert.Equal(t, time.UTC, at.Get().Location())
+		require.NoError(t, at.Scan(wall))
+		assert.True(t, at.Get().Equal(wall))
+
+		var count ztype.Numeric[int64]
+		require.NoError(t, c.Scan(&count, int64(3)))
+		assert.Equal(t, int64(3), count.Get())
+	})
+}
+
+// codecRoundTrip checks that a non-default Codec encodes value like
+// encoding/json, or like its own Time layout when same is false, and that
+// decoding the result gives the same value back.
+func codecRoundTrip[T any](t *testing.T, c *ztype.Codec, value T, same bool) {
+	t.Helper()
+	record := struct{ V T }{V: value}
+	data, err := c.Marshal(record)
+	require.NoError(t, err)
+	expected, err := json.Marshal(record)
+	require.NoError(t, err)
+	if same {
+		assert.JSONEq(t, string(expected), string(data))
+	}
+
+	var decoded struct{ V T }
+	require.NoError(t, c.Unmarshal(data, &decoded))
+	actual, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestCodecRoundTripsEveryType(t *testing.T) {
+	c := ztype.NewCodec(ztype.WithTimeLayout(time.DateOnly))
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	ordered := ztype.NewOrderedMap[string, int]()
+	ordered.SetItem("b", 2)
+	ordered.SetItem("a", 1)
+	status, err := enumTestStatusType.New("active")
+	require.NoError(t, err)
+
+	t.Run("Bool", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewBool(true), true) })
+	t.Run("Bool false", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewBool(false), true) })
+	t.Run("Byte", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewByte(7), true) })
+	t.Run("Char", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewChar('x'), true) })
+	t.Run("String", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewString("a"), true) })
+	t.Run("StringOrEmpty", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewStringOrEmpty("a"), true) })
+	t.Run("Numeric", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewNumber(1.5), true) })
+	t.Run("Time", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewTime(day), false) })
+	t.Run("Duration", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewDuration(time.Minute), true) })
+	t.Run("Money", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewMoney(100, "USD"), true) })
+	t.Run("Bytes", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewBytes([]byte("ab")), true) })
+	t.Run("Rune", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewRune('é'), true) })
+	t.Run("IP", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewIP(netip.MustParseAddr("10.0.0.1")), true) })
+	t.Run("CIDR", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8")), true) })
+	t.Run("RawJSON", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewRawJSON(json.RawMessage(`{"a":1}`)), true) })
+	t.Run("Enum", func(t *testing.T) { codecRoundTrip(t, c, status, true) })
+	t.Run("Null", func(t *testing.T) { codecRoundTrip(t, c, ztype.New(3), true) })
+	t.Run("Range", func(t *testing.T) {
+		codecRoundTrip(t, c, ztype.NewRange(ztype.NewNumber(1), ztype.NewNumber(5)), true)
+	})
+	t.Run("Map", func(t *testing.T) {
+		codecRoundTrip(t, c, ztype.NewMap(map[string]ztype.Time{"a": ztype.NewTime(day)}), false)
+	})
+	t.Run("PairMap", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewPairMap(map[string]int{"a": 1}), true) })
+	t.Run("OrderedMap", func(t *testing.T) { codecRoundTrip(t, c, ordered, true) })
+	t.Run("SyncMap", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewSyncMap(map[string]int{"a": 1}), true) })
+	t.Run("Slice", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewSlice([]ztype.Time{ztype.NewTime(day)}), false) })
+	t.Run("Set", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewSet(1, 2), true) })
+	t.Run("Array", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewArray(ztype.NewString("a")), true) })
+	t.Run("NotNullColumn", func(t *testing.T) {
+		codecRoundTrip(t, c, ztype.NotNullColumn[ztype.String]{V: ztype.NewString("a")}, true)
+	})
+	t.Run("null", func(t *testing.T) { codecRoundTrip(t, c, ztype.NewNullBool(), true) })
+}
@@ -0,0 +1,97 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestAsMapOf(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		payload := decodeJSONPayload(t, `{"a":"x","b":"y"}`)
+		labels, err := ztype.AsMapOf[string](payload)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "x", "b": "y"}, labels.Get())
+	})
+
+	t.Run("structs", func(t *testing.T) {
+		type point struct {
+			X int    `json:"x"`
+			Y string `json:"y"`
+		}
+		payload := decodeJSONPayload(t, `{"p":{"x":1,"y":"up"}}`)
+		points, err := ztype.AsMapOf[point](payload)
+		require.NoError(t, err)
+		assert.Equal(t, point{X: 1, Y: "up"}, points.Get()["p"])
+	})
+
+	t.Run("ztype values", func(t *testing.T) {
+		payload := decodeJSONPayload(t, `{"a":3,"b":null}`)
+		numbers, err := ztype.AsMapOf[ztype.Numeric[int64]](payload)
+		require.NoError(t, err)
+		a, b := numbers.Get()["a"], numbers.Get()["b"]
+		assert.Equal(t, int64(3), a.Get())
+		assert.True(t, b.IsNull())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		converted, err := ztype.AsMapOf[int64](ztype.NewNullMap[string, any]())
+		require.NoError(t, err)
+		assert.True(t, converted.IsNull())
+
+		empty, err := ztype.AsMapOf[int64](ztype.NewMap(map[string]any{}))
+		require.NoError(t, err)
+		assert.False(t, empty.IsNull())
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		payload := decodeJSONPayload(t, `{"a":1,"b":2.5,"c":"3","d":4,"e":1e20}`)
+		counts, err := ztype.AsMapOf[int64](payload)
+		require.Error(t, err)
+		assert.Equal(t, map[string]int64{"a": 1, "d": 4}, counts.Get())
+
+		lines := strings.Split(err.Error(), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, `b: invalid int64 "2.5": not an integer`, lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "c: "), lines[1])
+		assert.Equal(t, "e: value 100000000000000000000 overflows int64", lines[2])
+		assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "int64"})
+		assert.ErrorIs(t, err, &ztype.ErrOverflow{Type: "int64"})
+	})
+}
+
+func TestAsMapOfNumbers(t *testing.T) {
+	payload := decodeJSONPayload(t, `{"big":1e15,"max":9007199254740992,"neg":-7}`)
+	values, err := ztype.AsMapOf[int64](payload)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"big": 1_000_000_000_000_000, "max": 1 << 53, "neg": -7}, values.Get())
+
+	_, err = ztype.AsMapOf[uint8](payload)
+	assert.ErrorIs(t, err, &ztype.ErrOverflow{Type: "uint8"})
+	assert.ErrorContains(t, err, "neg: value -7 overflows uint8")
+
+	floats, err := ztype.AsMapOf[float32](ztype.NewMap(map[string]any{"a": 1.5, "b": 7}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float32{"a": 1.5, "b": 7}, floats.Get())
+
+	_, err = ztype.AsMapOf[float32](ztype.NewMap(map[string]any{"a": 1e300}))
+	assert.ErrorIs(t, err, &ztype.ErrOverflow{Type: "float32"})
+
+	numbers := ztype.NewMap(map[string]any{
+		"exact": json.Number("9007199254740993"),
+		"exp":   json.Number("1.5e1"),
+		"sci":   json.Number("2E3"),
+	})
+	exact, err := ztype.AsMapOf[int64](numbers)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"exact": 9007199254740993, "exp": 15, "sci": 2000}, exact.Get())
+
+	numbers.SetItem("frac", json.Number("0.5"))
+	_, err = ztype.AsMapOf[int](numbers)
+	assert.ErrorContains(t, err, `frac: invalid int "0.5": not an integer`)
+}
@@ -0,0 +1,161 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type csvAudit struct {
+	CreatedAt ztype.Time `csv:"created_at"`
+}
+
+type csvRow struct {
+	csvAudit
+	Name     ztype.String                  `csv:"name"`
+	Active   ztype.Bool                    `csv:"active"`
+	Level    ztype.Byte                    `csv:"level"`
+	Grade    ztype.Char                    `csv:"grade"`
+	Symbol   ztype.Rune                    `csv:"symbol"`
+	Blob     ztype.Bytes                   `csv:"blob"`
+	Count    ztype.Numeric[int64]          `csv:"count"`
+	Ratio    ztype.Numeric[float64]        `csv:"ratio"`
+	Timeout  ztype.Duration                `csv:"timeout"`
+	Status   ztype.Enum[quickStatus]       `csv:"status"`
+	Addr     ztype.IP                      `csv:"addr"`
+	Network  ztype.CIDR                    `csv:"network"`
+	Price    ztype.Money                   `csv:"price"`
+	Extra    ztype.RawJSON                 `csv:"extra"`
+	Labels   ztype.Map[string, string]     `csv:"labels"`
+	Order    ztype.OrderedMap[string, int] `csv:"order"`
+	Scores   ztype.Slice[int]              `csv:"scores"`
+	Tags     ztype.Set[string]             `csv:"tags"`
+	Aliases  ztype.Array[ztype.String]     `csv:"aliases"`
+	Owner    ztype.Null[string]            `csv:"owner"`
+	Priority ztype.NullComparable[int]     `csv:"priority"`
+	Note     string
+	Skipped  ztype.String `csv:"-"`
+}
+
+func fullCSVRow() csvRow {
+	order := ztype.NewOrderedMap[string, int]()
+	order.SetItem("b", 2)
+	order.SetItem("a", 1)
+	return csvRow{
+		csvAudit: csvAudit{CreatedAt: ztype.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC))},
+		Name:     ztype.NewString(`São Paulo, "SP"`),
+		Active:   ztype.NewBool(true),
+		Level:    ztype.NewByte(7),
+		Grade:    ztype.NewChar('A'),
+		Symbol:   ztype.NewRune('€'),
+		Blob:     ztype.NewBytes([]byte{0, 1, 2}),
+		Count:    ztype.NewNumber[int64](1 << 40),
+		Ratio:    ztype.NewNumber(0.25),
+		Timeout:  ztype.NewDuration(90 * time.Second),
+		Status:   quickStatusType.MustNew("active"),
+		Addr:     ztype.NewIP(netip.MustParseAddr("10.0.0.1")),
+		Network:  ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8")),
+		Price:    ztype.NewMoney(1990, "BRL"),
+		Extra:    ztype.NewRawJSON(json.RawMessage(`{"a":[1,2]}`)),
+		Labels:   ztype.NewMap(map[string]string{"env": "prod"}),
+		Order:    order,
+		Scores:   ztype.NewSlice([]int{3, 1}),
+		Tags:     ztype.NewSet("x"),
+		Aliases:  ztype.NewArray(ztype.NewString("a,b")),
+		Owner:    ztype.New("ana"),
+		Priority: ztype.NewComparable(2),
+		Note:     "line\nbreak",
+		Skipped:  ztype.NewString("ignored"),
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	rows := []csvRow{fullCSVRow(), {Note: "all null"}}
+	data, err := ztype.MarshalCSV(rows)
+	require.NoError(t, err)
+
+	header, _, _ := strings.Cut(string(data), "\n")
+	assert.True(t, strings.HasPrefix(header, "created_at,name,active,"))
+	assert.NotContains(t, header, "Skipped")
+	assert.Contains(t, string(data), `"São Paulo, ""SP"""`)
+
+	var decoded []*csvRow
+	require.NoError(t, ztype.UnmarshalCSV(data, &decoded))
+	require.Len(t, decoded, 2)
+
+	want, got := fullCSVRow(), decoded[0]
+	assert.True(t, want.CreatedAt.Get().Equal(got.CreatedAt.Get()))
+	assert.Equal(t, want.Name.Get(), got.Name.Get())
+	assert.Equal(t, want.Active.Get(), got.Active.Get())
+	assert.Equal(t, want.Level.Get(), got.Level.Get())
+	assert.Equal(t, want.Grade.Get(), got.Grade.Get())
+	assert.Equal(t, want.Symbol.Get(), got.Symbol.Get())
+	assert.Equal(t, want.Blob.Get(), got.Blob.Get())
+	assert.Equal(t, want.Count.Get(), got.Count.Get())
+	assert.Equal(t, want.Ratio.Get(), got.Ratio.Get())
+	assert.Equal(t, want.Timeout.Get(), got.Timeout.Get())
+	assert.Equal(t, want.Status.Get(), got.Status.Get())
+	assert.Equal(t, want.Addr.Get(), got.Addr.Get())
+	assert.Equal(t, want.Network.Get(), got.Network.Get())
+	assert.Equal(t, want.Price.String(), got.Price.String())
+	assert.JSONEq(t, string(want.Extra.Get()), string(got.Extra.Get()))
+	assert.Equal(t, want.Labels.Get(), got.Labels.Get())
+	assert.Equal(t, slices.Collect(want.Order.Keys()), slices.Collect(got.Order.Keys()))
+	assert.Equal(t, want.Scores.Get(), got.Scores.Get())
+	assert.True(t, got.Tags.Has("x"))
+	assert.Equal(t, "a,b", got.Aliases.Get()[0].Get())
+	assert.Equal(t, want.Owner.Get(), got.Owner.Get())
+	assert.Equal(t, want.Priority.Get(), got.Priority.Get())
+	assert.Equal(t, want.Note, got.Note)
+	assert.True(t, got.Skipped.IsNull())
+
+	empty := decoded[1]
+	for name, field := range map[string]ztype.Nullable{
+		"created_at": &empty.CreatedAt, "name": &empty.Name, "count": &empty.Count,
+		"timeout": &empty.Timeout, "price": &empty.Price, "labels": &empty.Labels,
+		"scores": &empty.Scores, "owner": &empty.Owner, "extra": &empty.Extra,
+	} {
+		assert.True(t, field.IsNull(), name)
+		assert.True(t, field.Unmarshaled(), name)
+	}
+	assert.Equal(t, "all null", empty.Note)
+}
+
+func TestUnmarshalCSVHeaders(t *testing.T) {
+	type row struct {
+		Name    ztype.String       `csv:"name"`
+		Qty     ztype.Numeric[int] `csv:"qty"`
+		Started ztype.Time
+	}
+	data := "\ufeffNAME,qty,unknown,started\n\"pão, doce\",3,x,01/02/2024\nbolo,,y,\n"
+	var rows []row
+	require.NoError(t, ztype.UnmarshalCSV([]byte(data), &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "pão, doce", rows[0].Name.Get())
+	assert.Equal(t, 3, rows[0].Qty.Get())
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), rows[0].Started.Get())
+	assert.True(t, rows[1].Qty.IsNull())
+	assert.True(t, rows[1].Started.IsNull())
+}
+
+func TestUnmarshalCSVErrors(t *testing.T) {
+	type row struct {
+		Qty ztype.Numeric[int8] `csv:"qty"`
+	}
+	var rows []row
+	err := ztype.UnmarshalCSV([]byte("qty\n1\n300\n"), &rows)
+	assert.ErrorIs(t, err, &ztype.ErrOverflow{})
+	assert.ErrorContains(t, err, `line 3, column "qty"`)
+
+	assert.Error(t, ztype.UnmarshalCSV([]byte("qty\n1\n"), rows))
+	_, err = ztype.MarshalCSV(42)
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type decodeStep struct {
+	Name    ztype.String   `json:"name"`
+	Timeout ztype.Duration `json:"timeout"`
+}
+
+type decodeAudit struct {
+	CreatedAt ztype.Time `json:"created_at"`
+	DeletedAt ztype.Time `json:"deleted_at"`
+}
+
+type decodeJob struct {
+	decodeAudit
+	ID       ztype.Numeric[int64]   `json:"id"`
+	Retries  ztype.Numeric[int]     `json:"retries"`
+	Ratio    ztype.Numeric[float64] `json:"ratio"`
+	Owner    ztype.String           `json:"owner"`
+	Enabled  ztype.Bool             `json:"enabled"`
+	Interval time.Duration          `json:"interval"`
+	RunAt    time.Time              `json:"run_at"`
+	Priority int                    `json:"priority"`
+	Tags     []string               `json:"tags"`
+	Steps    []decodeStep           `json:"steps"`
+	Labels   map[string]ztype.String
+	Parent   *decodeStep `json:"parent"`
+	Extra    any         `json:"extra"`
+	Skipped  string      `json:"-"`
+}
+
+const decodeDocument = `{
+	"id": "9007199254740993",
+	"retries": 3,
+	"ratio": "0.25",
+	"owner": null,
+	"enabled": true,
+	"interval": "1m30s",
+	"run_at": "2024-03-01 10:00:00",
+	"priority": "7",
+	"created_at": "2023-01-01T00:00:00Z",
+	"deleted_at": null,
+	"tags": ["a", "b"],
+	"steps": [{"name": "build", "timeout": "30s"}, {"name": "test", "timeout": null}],
+	"LABELS": {"env": "prod"},
+	"parent": {"name": "root", "timeout": ""},
+	"extra": {"nested": [1, "x"]},
+	"Skipped": "ignored",
+	"unknown": 1
+}`
+
+func TestMapDecodeInto(t *testing.T) {
+	var doc ztype.JSON
+	require.NoError(t, json.Unmarshal([]byte(decodeDocument), &doc))
+
+	var job decodeJob
+	require.NoError(t, doc.DecodeInto(&job))
+
+	assert.Equal(t, int64(9007199254740993), job.ID.Get())
+	assert.Equal(t, 3, job.Retries.Get())
+	assert.Equal(t, 0.25, job.Ratio.Get())
+	assert.True(t, job.Owner.IsNull())
+	assert.True(t, job.Owner.Unmarshaled())
+	assert.True(t, job.Enabled.Get())
+	assert.Equal(t, 90*time.Second, job.Interval)
+	assert.True(t, job.RunAt.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 7, job.Priority)
+
+	assert.True(t, job.CreatedAt.Get().Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, job.CreatedAt.Unmarshaled())
+	assert.True(t, job.DeletedAt.IsNull())
+	assert.True(t, job.DeletedAt.Unmarshaled())
+
+	assert.Equal(t, []string{"a", "b"}, job.Tags)
+	require.Len(t, job.Steps, 2)
+	assert.Equal(t, "build", job.Steps[0].Name.Get())
+	assert.Equal(t, 30*time.Second, job.Steps[0].Timeout.Get())
+	assert.True(t, job.Steps[1].Timeout.IsNull())
+	env := job.Labels["env"]
+	assert.Equal(t, "prod", env.Get())
+	require.NotNil(t, job.Parent)
+	assert.Equal(t, "root", job.Parent.Name.Get())
+	assert.True(t, job.Parent.Timeout.IsNull())
+	assert.Equal(t, map[string]any{"nested": []any{float64(1), "x"}}, job.Extra)
+	assert.Empty(t, job.Skipped)
+}
+
+func TestMapDecodeIntoErrors(t *testing.T) {
+	decode := func(document string) error {
+		var doc ztype.JSON
+		require.NoError(t, json.Unmarshal([]byte(document), &doc))
+		var job decodeJob
+		return doc.DecodeInto(&job)
+	}
+
+	err := decode(`{"steps": [{"timeout": "soon"}]}`)
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "Duration"})
+	assert.True(t, strings.HasPrefix(err.Error(), "steps[0].timeout: "), err.Error())
+
+	err = decode(`{"retries": "many"}`)
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+	assert.ErrorContains(t, err, "retries: ")
+
+	err = decode(`{"priority": 1e30}`)
+	assert.ErrorIs(t, err, &ztype.ErrOverflow{Type: "int"})
+
+	err = decode(`{"tags": "a"}`)
+	assert.ErrorContains(t, err, "tags: cannot decode string into []string")
+
+	var job decodeJob
+	assert.Error(t, ztype.NewMap(map[string]any{}).DecodeInto(job))
+	assert.NoError(t, ztype.NewNullMap[string, any]().DecodeInto(&job))
+}
+
+func TestMapDecodeIntoHooks(t *testing.T) {
+	doc := ztype.NewMap(map[string]any{"owner": "ana", "retries": "3", "created_at": "2023-01-01"})
+	upper := func(from, to reflect.Type, data any) (any, error) {
+		if s, ok := data.(string); ok && to == reflect.TypeFor[ztype.String]() {
+			return ztype.NewString(strings.ToUpper(s)), nil
+		}
+		return data, nil
+	}
+
+	var job decodeJob
+	require.NoError(t, doc.DecodeInto(&job, append(ztype.DefaultDecodeHooks(), upper)...))
+	assert.Equal(t, "ANA", job.Owner.Get())
+	assert.True(t, job.Owner.Unmarshaled())
+	assert.Equal(t, 3, job.Retries.Get())
+	assert.Equal(t, 2023, job.CreatedAt.Get().Year())
+
+	failing := func(from, to reflect.Type, data any) (any, error) {
+		if to == reflect.TypeFor[ztype.Time]() {
+			return nil, errors.New("no times")
+		}
+		return data, nil
+	}
+	err := doc.DecodeInto(&job, failing)
+	assert.EqualError(t, err, "created_at: no times")
+}
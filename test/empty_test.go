@@ -0,0 +1,90 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhaori96/ztype"
+)
+
+type emptyRecord struct {
+	Name string
+}
+
+type emptier interface {
+	IsEmpty() bool
+	IsZero() bool
+}
+
+func TestIsEmptyAcrossTypes(t *testing.T) {
+	orderedMap := ztype.NewOrderedMap[string, int]()
+	orderedMap.SetItem("a", 1)
+
+	tests := []struct {
+		name              string
+		null, zero, valid emptier
+	}{
+		{"Byte", ztype.NewNullByte(), ztype.NewByte(0), ztype.NewByte(1)},
+		{"Char", ztype.NewNullChar(), ztype.NewChar(0), ztype.NewChar('A')},
+		{"Bytes", ztype.NewNullBytes(), ztype.NewBytes([]byte{}), ztype.NewBytes([]byte("a"))},
+		{"String", ztype.NewNullString(), ztype.NewString(""), ztype.NewString("a")},
+		{"Numeric", ztype.NewNullNumber[float64](), ztype.NewNumber(0.0), ztype.NewNumber(0.5)},
+		{"Time", ztype.NewNullTime(), ztype.NewTime(time.Time{}), ztype.NewTime(time.Unix(0, 0))},
+		{"Duration", ztype.NewNullDuration(), ztype.NewDuration(0), ztype.NewDuration(time.Second)},
+		{"Rune", ztype.NewNullRune(), ztype.NewRune(0), ztype.NewRune('a')},
+		{"Enum", quickStatusType.NewNull(), ztype.Enum[quickStatus]{}, quickStatusType.MustNew("active")},
+		{"IP", ztype.NewNullIP(), ztype.NewIP(netip.Addr{}), ztype.NewIP(netip.IPv4Unspecified())},
+		{"CIDR", ztype.NewNullCIDR(), ztype.NewCIDR(netip.Prefix{}), ztype.NewCIDR(netip.MustParsePrefix("0.0.0.0/0"))},
+		{"RawJSON", ztype.NewNullRawJSON(), ztype.NewRawJSON(nil), ztype.NewRawJSON(json.RawMessage(`null`))},
+		{"Money", ztype.NewNullMoney(), ztype.NewMoney(0, "USD"), ztype.NewMoney(-1, "USD")},
+		{"Map", ztype.NewNullMap[string, int](), ztype.NewMap(map[string]int{}), ztype.NewMap(map[string]int{"a": 0})},
+		{"OrderedMap", ztype.NewNullOrderedMap[string, int](), ztype.NewOrderedMap[string, int](), orderedMap},
+		{"SyncMap", ztype.NewNullSyncMap[string, int](), ztype.NewSyncMap(map[string]int{}), ztype.NewSyncMap(map[string]int{"a": 0})},
+		{"Slice", ztype.NewNullSlice[int](), ztype.NewSlice([]int{}), ztype.NewSlice([]int{0})},
+		{"SliceComparable", ztype.SliceComparable[int]{}, ztype.NewSliceComparable([]int{}), ztype.NewSliceComparable([]int{0})},
+		{"Set", ztype.NewNullSet[int](), ztype.NewSet[int](), ztype.NewSet(0)},
+		{"Null", ztype.NewNull[emptyRecord](), ztype.New(emptyRecord{}), ztype.New(emptyRecord{Name: "a"})},
+		{"NullComparable", ztype.NewNullComparable[int](), ztype.NewComparable(0), ztype.NewComparable(1)},
+		{"Array", ztype.NewNullArray[ztype.String](), ztype.NewArray[ztype.String](), ztype.NewArray(ztype.NewNullString())},
+		{"NotNullColumn", ztype.WithZeroOnNull(ztype.NewNullString()), ztype.WithZeroOnNull(ztype.NewString("")), ztype.WithZeroOnNull(ztype.NewString("a"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for state, value := range map[string]emptier{"null": tt.null, "zero": tt.zero} {
+				assert.True(t, value.IsEmpty(), state)
+				assert.True(t, value.IsZero(), state)
+			}
+			assert.False(t, tt.valid.IsEmpty())
+			assert.False(t, tt.valid.IsZero())
+		})
+	}
+}
+
+func TestBoolIsZeroIsNullOnly(t *testing.T) {
+	assert.True(t, ztype.NewNullBool().IsEmpty())
+	assert.True(t, ztype.NewNullBool().IsZero())
+	assert.True(t, ztype.NewBool(false).IsEmpty())
+	assert.False(t, ztype.NewBool(false).IsZero())
+	assert.False(t, ztype.NewBool(true).IsEmpty())
+	assert.False(t, ztype.NewBool(true).IsZero())
+}
+
+func TestIsZeroOmitZero(t *testing.T) {
+	type payload struct {
+		Count ztype.Numeric[int] `json:"count,omitzero"`
+		Wait  ztype.Duration     `json:"wait,omitzero"`
+		Tags  ztype.Slice[int]   `json:"tags,omitzero"`
+		Name  ztype.String       `json:"name,omitzero"`
+	}
+	data, err := json.Marshal(payload{Count: ztype.NewNumber(0), Wait: ztype.NewDuration(0), Tags: ztype.NewSlice([]int{})})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data))
+
+	data, err = json.Marshal(payload{Count: ztype.NewNumber(1), Name: ztype.NewString("a")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"count":1,"name":"a"}`, string(data))
+}
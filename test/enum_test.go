@@ -0,0 +1,137 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type enumTestStatus string
+
+type enumTestColor string
+
+type enumTestUnregistered string
+
+var (
+	enumTestStatusType = ztype.NewEnumType[enumTestStatus]("active", "blocked", "deleted")
+	enumTestColorType  = ztype.NewEnumType[enumTestColor]("Red", "Green").CaseInsensitive()
+)
+
+func TestEnumFactory(t *testing.T) {
+	s, err := enumTestStatusType.New("active")
+	require.NoError(t, err)
+	assert.True(t, s.Is("active"))
+	assert.False(t, s.Is("blocked"))
+	assert.Equal(t, []enumTestStatus{"active", "blocked", "deleted"}, s.Values())
+
+	_, err = enumTestStatusType.New("gone")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, &ztype.ErrInvalidFormat{}))
+	assert.Contains(t, err.Error(), "active, blocked, deleted")
+
+	assert.Panics(t, func() { enumTestStatusType.MustNew("gone") })
+	null := enumTestStatusType.NewNull()
+	assert.True(t, null.IsNull())
+	assert.False(t, null.Is(""))
+}
+
+func TestEnumSet(t *testing.T) {
+	var s ztype.Enum[enumTestStatus]
+	require.NoError(t, s.Set("blocked"))
+	assert.Equal(t, enumTestStatus("blocked"), s.Get())
+
+	assert.Error(t, s.Set("Blocked"))
+	assert.Equal(t, enumTestStatus("blocked"), s.Get())
+
+	var u ztype.Enum[enumTestUnregistered]
+	assert.Error(t, u.Set("x"))
+	assert.Nil(t, u.Values())
+}
+
+func TestEnumJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected enumTestStatus
+		isNull   bool
+		wantErr  bool
+	}{
+		{"valid", `{"status":"deleted"}`, "deleted", false, false},
+		{"null", `{"status":null}`, "", true, false},
+		{"invalid", `{"status":"gone"}`, "", false, true},
+		{"wrong type", `{"status":1}`, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload struct {
+				Status ztype.Enum[enumTestStatus] `json:"status"`
+			}
+			err := json.Unmarshal([]byte(tt.input), &payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, payload.Status.Unmarshaled())
+			assert.Equal(t, tt.isNull, payload.Status.IsNull())
+			assert.Equal(t, tt.expected, payload.Status.Get())
+
+			data, err := json.Marshal(&payload.Status)
+			require.NoError(t, err)
+			if tt.isNull {
+				assert.Equal(t, `null`, string(data))
+			} else {
+				assert.Equal(t, `"`+string(tt.expected)+`"`, string(data))
+			}
+		})
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var payload map[string]ztype.Enum[enumTestStatus]
+		assert.Error(t, json.Unmarshal([]byte(`{"a":1}`), &payload))
+
+		var s ztype.Enum[enumTestStatus]
+		assert.Error(t, s.UnmarshalText([]byte("gone")))
+		require.NoError(t, s.UnmarshalText([]byte("active")))
+		data, err := s.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "active", string(data))
+	})
+}
+
+func TestEnumDatabase(t *testing.T) {
+	var s ztype.Enum[enumTestStatus]
+	require.NoError(t, s.Scan([]byte("active")))
+	assert.True(t, s.Is("active"))
+
+	value, err := s.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "active", value)
+
+	assert.Error(t, s.Scan("gone"))
+	assert.Error(t, s.Scan(42))
+	assert.True(t, s.Is("active"))
+
+	require.NoError(t, s.Scan(nil))
+	assert.True(t, s.IsNull())
+	value, err = s.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, "<NULL>", s.String())
+}
+
+func TestEnumCaseInsensitive(t *testing.T) {
+	var c ztype.Enum[enumTestColor]
+	require.NoError(t, json.Unmarshal([]byte(`"GREEN"`), &c))
+	assert.Equal(t, enumTestColor("Green"), c.Get())
+
+	require.NoError(t, c.Scan("red"))
+	assert.True(t, c.Is("Red"))
+	assert.Error(t, c.Scan("blue"))
+}
@@ -0,0 +1,104 @@
+package ztype_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type envDatabase struct {
+	URL      ztype.String
+	MaxConns ztype.Numeric[int]
+}
+
+type envCommon struct {
+	Region ztype.String
+}
+
+type envConfig struct {
+	envCommon
+	Port      ztype.Numeric[int]
+	Debug     ztype.Bool
+	Timeout   ztype.Duration `env:"TIMEOUT"`
+	StartedAt ztype.Time
+	Name      ztype.String
+	Ignored   ztype.String `env:"-"`
+	DB        envDatabase
+	Replica   envDatabase `env:"RO"`
+}
+
+func TestFromEnvPresentAndAbsent(t *testing.T) {
+	t.Setenv("APP_REGION", "sa-east-1")
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_TIMEOUT", "1m30s")
+	t.Setenv("APP_STARTED_AT", "2024-03-01")
+	t.Setenv("APP_IGNORED", "value")
+	t.Setenv("APP_DB_URL", "postgres://localhost/app")
+	t.Setenv("APP_DB_MAX_CONNS", "10")
+	t.Setenv("APP_RO_URL", "postgres://replica/app")
+
+	var cfg envConfig
+	require.NoError(t, ztype.FromEnv("APP", &cfg))
+
+	assert.Equal(t, "sa-east-1", cfg.Region.Get())
+	assert.Equal(t, 8080, cfg.Port.Get())
+	assert.True(t, cfg.Port.Unmarshaled())
+	assert.Equal(t, 90*time.Second, cfg.Timeout.Get())
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), cfg.StartedAt.Get())
+	assert.Equal(t, "postgres://localhost/app", cfg.DB.URL.Get())
+	assert.Equal(t, 10, cfg.DB.MaxConns.Get())
+	assert.Equal(t, "postgres://replica/app", cfg.Replica.URL.Get())
+
+	assert.True(t, cfg.Debug.IsNull())
+	assert.False(t, cfg.Debug.Unmarshaled())
+	assert.True(t, cfg.Debug.GetOr(true))
+	assert.True(t, cfg.Ignored.IsNull())
+	assert.True(t, cfg.Replica.MaxConns.IsNull())
+	assert.False(t, cfg.Replica.MaxConns.Unmarshaled())
+}
+
+func TestFromEnvEmptyVariable(t *testing.T) {
+	t.Setenv("APP_NAME", "")
+	t.Setenv("APP_DEBUG", "")
+
+	cfg := envConfig{Name: ztype.NewString("default")}
+	require.NoError(t, ztype.FromEnv("APP_", &cfg))
+
+	assert.True(t, cfg.Name.IsNull())
+	assert.True(t, cfg.Name.Unmarshaled())
+	assert.True(t, cfg.Debug.IsNull())
+	assert.True(t, cfg.Debug.Unmarshaled())
+}
+
+func TestFromEnvMalformedVariables(t *testing.T) {
+	t.Setenv("APP_PORT", "eighty")
+	t.Setenv("APP_TIMEOUT", "soon")
+	t.Setenv("APP_DEBUG", "true")
+
+	var cfg envConfig
+	err := ztype.FromEnv("APP", &cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid APP_PORT")
+	assert.Contains(t, err.Error(), "invalid APP_TIMEOUT")
+	assert.True(t, cfg.Debug.Get())
+}
+
+func TestFromEnvWithoutPrefix(t *testing.T) {
+	t.Setenv("DEBUG", "1")
+
+	var cfg envConfig
+	require.NoError(t, ztype.FromEnv("", &cfg))
+	assert.True(t, cfg.Debug.Get())
+}
+
+func TestFromEnvInvalidDestination(t *testing.T) {
+	var cfg envConfig
+	assert.Error(t, ztype.FromEnv("APP", cfg))
+	assert.Error(t, ztype.FromEnv("APP", (*envConfig)(nil)))
+	port := 0
+	assert.Error(t, ztype.FromEnv("APP", &port))
+}
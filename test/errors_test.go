@@ -0,0 +1,146 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestErrInvalidFormat(t *testing.T) {
+	cases := []struct {
+		name     string
+		typeName string
+		err      func() error
+	}{
+		{"Bool JSON", "Bool", func() error { var b ztype.Bool; return json.Unmarshal([]byte(`"maybe"`), &b) }},
+		{"Bool text", "Bool", func() error { var b ztype.Bool; return b.UnmarshalText([]byte("maybe")) }},
+		{"Byte text", "Byte", func() error { var b ztype.Byte; return b.UnmarshalText([]byte("x")) }},
+		{"Char JSON", "Char", func() error { var c ztype.Char; return json.Unmarshal([]byte(`"AB"`), &c) }},
+		{"Bytes text", "Bytes", func() error { var b ztype.Bytes; return b.UnmarshalText([]byte("%%")) }},
+		{"String JSON", "String", func() error { var s ztype.String; return json.Unmarshal([]byte(`1`), &s) }},
+		{"Numeric text", "Numeric[int]", func() error { var n ztype.Numeric[int]; return n.UnmarshalText([]byte("abc")) }},
+		{"Numeric scan", "Numeric[int]", func() error { var n ztype.Numeric[int]; return n.Scan("abc") }},
+		{"Rune JSON", "Rune", func() error { var r ztype.Rune; return json.Unmarshal([]byte(`"ab"`), &r) }},
+		{"Time JSON", "Time", func() error { var tm ztype.Time; return json.Unmarshal([]byte(`"yesterday"`), &tm) }},
+		{"Time scan", "Time", func() error { var tm ztype.Time; return tm.Scan("yesterday") }},
+		{"Duration text", "Duration", func() error { var d ztype.Duration; return d.UnmarshalText([]byte("soon")) }},
+		{"IP text", "IP", func() error { var ip ztype.IP; return ip.UnmarshalText([]byte("10.0.0")) }},
+		{"CIDR text", "CIDR", func() error { var c ztype.CIDR; return c.UnmarshalText([]byte("10.0.0.0/40")) }},
+		{"Money JSON", "Money", func() error { var m ztype.Money; return json.Unmarshal([]byte(`"19.90"`), &m) }},
+		{"Money currency", "Money", func() error { _, err := ztype.ParseMoney("1", "REAL"); return err }},
+		{"Enum", "", func() error { _, err := quickStatusType.New("gone"); return err }},
+		{"RawJSON", "RawJSON", func() error { raw := ztype.NewRawJSON(json.RawMessage(`{`)); return raw.Validate() }},
+		{"Array scan", "Array", func() error { var a ztype.StringArray; return a.Scan(`{a,"b}`) }},
+		{"Slice JSON", "Slice", func() error { var s ztype.Slice[int]; return json.Unmarshal([]byte(`{}`), &s) }},
+		{"Map key", "Map", func() error { var m ztype.Map[int, string]; return m.Scan(`{"one":"a"}`) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err()
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, &ztype.ErrInvalidFormat{}), "%v", err)
+			assert.False(t, errors.Is(err, &ztype.ErrOverflow{}), "%v", err)
+
+			var invalid *ztype.ErrInvalidFormat
+			require.True(t, errors.As(err, &invalid))
+			if tc.typeName != "" {
+				assert.Equal(t, tc.typeName, invalid.Type)
+				assert.True(t, errors.Is(err, &ztype.ErrInvalidFormat{Type: tc.typeName}))
+			}
+			assert.False(t, errors.Is(err, &ztype.ErrInvalidFormat{Type: "Other"}))
+		})
+	}
+}
+
+func TestErrOverflow(t *testing.T) {
+	cases := map[string]func() error{
+		"Byte text":    func() error { var b ztype.Byte; return b.UnmarshalText([]byte("256")) },
+		"Byte JSON":    func() error { var b ztype.Byte; return json.Unmarshal([]byte(`300`), &b) },
+		"Byte add":     func() error { b := ztype.NewByte(200); _, err := b.AddChecked(ztype.NewByte(100)); return err },
+		"Numeric text": func() error { var n ztype.Numeric[int8]; return n.UnmarshalText([]byte("300")) },
+		"Numeric JSON": func() error { var n ztype.Numeric[uint8]; return json.Unmarshal([]byte(`-1`), &n) },
+		"Numeric scan": func() error { var n ztype.Numeric[int8]; return n.Scan(int64(300)) },
+		"Rune scan":    func() error { var r ztype.Rune; return r.Scan(int64(0x110000)) },
+		"Money amount": func() error { _, err := ztype.ParseMoney("99999999999999999999", "USD"); return err },
+		"Money add": func() error {
+			_, err := ztype.NewMoney(1<<62, "USD").Add(ztype.NewMoney(1<<62, "USD"))
+			return err
+		},
+		"Slice element": func() error { var s ztype.Slice[ztype.Numeric[int8]]; return json.Unmarshal([]byte(`[1,300]`), &s) },
+		"Map key":       func() error { var m ztype.Map[int8, string]; return m.Scan(`{"300":"a"}`) },
+	}
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := fn()
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, &ztype.ErrOverflow{}), "%v", err)
+			var overflow *ztype.ErrOverflow
+			require.True(t, errors.As(err, &overflow))
+			assert.NotEmpty(t, overflow.Type)
+		})
+	}
+
+	var n ztype.Numeric[int8]
+	err := n.UnmarshalText([]byte("300"))
+	assert.True(t, errors.Is(err, &ztype.ErrOverflow{Type: "Numeric[int8]"}))
+	assert.False(t, errors.Is(err, &ztype.ErrOverflow{Type: "Numeric[int16]"}))
+}
+
+func TestErrUnsupportedScanType(t *testing.T) {
+	scanners := map[string]interface{ Scan(any) error }{
+		"Bool":     new(ztype.Bool),
+		"Byte":     new(ztype.Byte),
+		"Bytes":    new(ztype.Bytes),
+		"String":   new(ztype.String),
+		"Numeric":  new(ztype.Numeric[int]),
+		"Time":     new(ztype.Time),
+		"Duration": new(ztype.Duration),
+		"IP":       new(ztype.IP),
+		"RawJSON":  new(ztype.RawJSON),
+		"Slice":    new(ztype.Slice[int]),
+		"Map":      new(ztype.Map[string, int]),
+		"Array":    new(ztype.StringArray),
+	}
+	got := reflect.TypeFor[struct{}]()
+	for name, scanner := range scanners {
+		t.Run(name, func(t *testing.T) {
+			err := scanner.Scan(struct{}{})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, &ztype.ErrUnsupportedScanType{}), "%v", err)
+			assert.True(t, errors.Is(err, &ztype.ErrUnsupportedScanType{Got: got}))
+			assert.False(t, errors.Is(err, &ztype.ErrUnsupportedScanType{Got: reflect.TypeFor[int]()}))
+
+			var unsupported *ztype.ErrUnsupportedScanType
+			require.True(t, errors.As(err, &unsupported))
+			assert.Equal(t, got, unsupported.Got)
+		})
+	}
+}
+
+func TestErrNullValueAndDivisionByZero(t *testing.T) {
+	_, err := ztype.NewNumber(1).Compare(ztype.NewNullNumber[int]())
+	assert.ErrorIs(t, err, ztype.ErrNullValue)
+
+	_, err = ztype.NewMoney(1, "USD").Compare(ztype.NewNullMoney())
+	assert.ErrorIs(t, err, ztype.ErrNullValue)
+
+	_, err = ztype.NewNumber(1).SafeDiv(ztype.NewNumber(0))
+	assert.ErrorIs(t, err, ztype.ErrDivisionByZero)
+	assert.NotErrorIs(t, err, ztype.ErrNullValue)
+}
+
+func TestErrInvalidFormatUnwrap(t *testing.T) {
+	var d ztype.Duration
+	err := d.UnmarshalText([]byte("soon"))
+	var invalid *ztype.ErrInvalidFormat
+	require.True(t, errors.As(err, &invalid))
+	assert.Equal(t, "soon", invalid.Input)
+	assert.Error(t, errors.Unwrap(err))
+	assert.Equal(t, `invalid Duration "soon": `+errors.Unwrap(err).Error(), err.Error())
+}
@@ -0,0 +1,89 @@
+package ztype_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type flagConfig struct {
+	DryRun  ztype.Bool
+	Name    ztype.String
+	Workers ztype.Numeric[int]
+	Timeout ztype.Duration
+	Since   ztype.Time
+}
+
+func newFlagSet(cfg *flagConfig) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(ztype.BoolFlag(&cfg.DryRun), "dry-run", "")
+	fs.Var(ztype.StringFlag(&cfg.Name), "name", "")
+	fs.Var(ztype.NumberFlag(&cfg.Workers), "workers", "")
+	fs.Var(ztype.DurationFlag(&cfg.Timeout), "timeout", "")
+	fs.Var(ztype.TimeFlag(&cfg.Since), "since", "")
+	return fs
+}
+
+func TestFlagDefaultsStayNull(t *testing.T) {
+	var cfg flagConfig
+	require.NoError(t, newFlagSet(&cfg).Parse(nil))
+
+	assert.True(t, cfg.DryRun.IsNull())
+	assert.True(t, cfg.Name.IsNull())
+	assert.True(t, cfg.Workers.IsNull())
+	assert.True(t, cfg.Timeout.IsNull())
+	assert.True(t, cfg.Since.IsNull())
+	assert.False(t, cfg.DryRun.Unmarshaled())
+}
+
+func TestFlagExplicitlySet(t *testing.T) {
+	var cfg flagConfig
+	err := newFlagSet(&cfg).Parse([]string{
+		"-dry-run", "-name=", "-workers", "4", "-timeout", "1m30s", "-since", "2024-03-01",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, cfg.DryRun.IsTrue())
+	assert.True(t, cfg.DryRun.Unmarshaled())
+	assert.False(t, cfg.Name.IsNull(), "an explicit empty string is still set")
+	assert.Equal(t, "", cfg.Name.Get())
+	assert.Equal(t, 4, cfg.Workers.Get())
+	assert.Equal(t, 90*time.Second, cfg.Timeout.Get())
+	assert.Equal(t, "2024-03-01", cfg.Since.Get().Format(time.DateOnly))
+}
+
+func TestFlagExplicitFalse(t *testing.T) {
+	var cfg flagConfig
+	require.NoError(t, newFlagSet(&cfg).Parse([]string{"-dry-run=false"}))
+	assert.True(t, cfg.DryRun.IsFalse())
+}
+
+func TestFlagInvalidValue(t *testing.T) {
+	var cfg flagConfig
+	assert.Error(t, newFlagSet(&cfg).Parse([]string{"-workers", "many"}))
+	assert.Error(t, newFlagSet(&cfg).Parse([]string{"-timeout", "soon"}))
+}
+
+func TestFlagValueMethods(t *testing.T) {
+	b := ztype.NewNullBool()
+	value := ztype.BoolFlag(&b)
+	assert.Equal(t, "", value.String())
+	assert.Equal(t, "bool", value.Type())
+	assert.True(t, value.IsBoolFlag())
+
+	require.NoError(t, value.Set("yes"))
+	assert.Equal(t, "true", value.String())
+
+	n := ztype.NewNumber[int64](7)
+	numberValue := ztype.NumberFlag(&n)
+	assert.Equal(t, "int64", numberValue.Type())
+	assert.False(t, numberValue.IsBoolFlag())
+	assert.Equal(t, "7", numberValue.String())
+}
@@ -0,0 +1,150 @@
+package ztype_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhaori96/ztype"
+)
+
+type formatCase struct {
+	format string
+	value  any
+	want   string
+}
+
+// runFormatCases checks fmt.Sprintf output for each case.
+func runFormatCases(t *testing.T, cases []formatCase) {
+	t.Helper()
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, fmt.Sprintf(tc.format, tc.value), "format %q on %#v", tc.format, tc.value)
+	}
+}
+
+func TestFormatNumeric(t *testing.T) {
+	runFormatCases(t, []formatCase{
+		{"%v", ztype.NewNumber(5), "5"},
+		{"%s", ztype.NewNumber(5), "5"},
+		{"%d", ztype.NewNumber(5), "5"},
+		{"%05d", ztype.NewNumber(42), "00042"},
+		{"%-4d|", ztype.NewNumber(7), "7   |"},
+		{"%+d", ztype.NewNumber(7), "+7"},
+		{"%x", ztype.NewNumber(255), "ff"},
+		{"%#X", ztype.NewNumber(255), "0XFF"},
+		{"%q", ztype.NewNumber(65), "'A'"},
+		{"%v", ztype.NewNumber(1.5), "1.5"},
+		{"%f", ztype.NewNumber(1.5), "1.500000"},
+		{"%.2f", ztype.NewNumber(3.14159), "3.14"},
+		{"%8.3f", ztype.NewNumber(3.14159), "   3.142"},
+		{"%e", ztype.NewNumber(1500.0), "1.500000e+03"},
+
+		{"%v", ztype.NewNullNumber[int](), "<NULL>"},
+		{"%s", ztype.NewNullNumber[int](), "<NULL>"},
+		{"%d", ztype.NewNullNumber[int](), "<NULL>"},
+		{"%x", ztype.NewNullNumber[int](), "<NULL>"},
+		{"%q", ztype.NewNullNumber[int](), "<NULL>"},
+		{"%.2f", ztype.NewNullNumber[float64](), "<NULL>"},
+		{"%8d", ztype.NewNullNumber[int](), "  <NULL>"},
+		{"%-8d|", ztype.NewNullNumber[int](), "<NULL>  |"},
+	})
+}
+
+func TestFormatByte(t *testing.T) {
+	runFormatCases(t, []formatCase{
+		{"%v", ztype.NewByte(10), "10"},
+		{"%d", ztype.NewByte(10), "10"},
+		{"%x", ztype.NewByte(10), "a"},
+		{"%#02x", ztype.NewByte(10), "0x0a"},
+		{"%08b", ztype.NewByte(5), "00000101"},
+		{"%c", ztype.NewByte('A'), "A"},
+		{"%q", ztype.NewByte('A'), "'A'"},
+		{"%3d", ztype.NewByte(7), "  7"},
+
+		{"%v", ztype.NewNullByte(), "<NULL>"},
+		{"%d", ztype.NewNullByte(), "<NULL>"},
+		{"%x", ztype.NewNullByte(), "<NULL>"},
+		{"%08b", ztype.NewNullByte(), "  <NULL>"},
+		{"%q", ztype.NewNullByte(), "<NULL>"},
+	})
+}
+
+func TestFormatChar(t *testing.T) {
+	runFormatCases(t, []formatCase{
+		{"%v", ztype.NewChar('A'), "A"},
+		{"%s", ztype.NewChar('A'), "A"},
+		{"%d", ztype.NewChar('A'), "65"},
+		{"%q", ztype.NewChar('A'), "'A'"},
+		{"%v", ztype.NewChar('\n'), "10"},
+		{"%d", ztype.NewNullChar(), "<NULL>"},
+	})
+}
+
+func TestFormatBool(t *testing.T) {
+	runFormatCases(t, []formatCase{
+		{"%v", ztype.NewBool(true), "true"},
+		{"%s", ztype.NewBool(false), "false"},
+		{"%t", ztype.NewBool(true), "true"},
+		{"%-6t|", ztype.NewBool(true), "true  |"},
+		{"%6t", ztype.NewBool(false), " false"},
+
+		{"%v", ztype.NewNullBool(), "<NULL>"},
+		{"%t", ztype.NewNullBool(), "<NULL>"},
+		{"%q", ztype.NewNullBool(), "<NULL>"},
+		{"%7t", ztype.NewNullBool(), " <NULL>"},
+	})
+}
+
+func TestFormatDuration(t *testing.T) {
+	runFormatCases(t, []formatCase{
+		{"%v", ztype.NewDuration(90 * time.Second), "1m30s"},
+		{"%s", ztype.NewDuration(90 * time.Second), "1m30s"},
+		{"%d", ztype.NewDuration(time.Microsecond), "1000"},
+		{"%q", ztype.NewDuration(time.Second), `"1s"`},
+		{"%8v", ztype.NewDuration(time.Second), "      1s"},
+
+		{"%v", ztype.NewNullDuration(), "<NULL>"},
+		{"%d", ztype.NewNullDuration(), "<NULL>"},
+		{"%q", ztype.NewNullDuration(), "<NULL>"},
+	})
+}
+
+func TestFormatTime(t *testing.T) {
+	value := ztype.NewTime(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	runFormatCases(t, []formatCase{
+		{"%v", value, "2024-03-01T12:00:00Z"},
+		{"%s", value, "2024-03-01T12:00:00Z"},
+		{"%q", value, `"2024-03-01T12:00:00Z"`},
+		{"%v", ztype.NewNullTime(), "<NULL>"},
+		{"%s", ztype.NewNullTime(), "<NULL>"},
+	})
+}
+
+type formatRow struct {
+	ID      ztype.Numeric[int64]
+	Name    ztype.String
+	Active  ztype.Bool
+	Level   ztype.Byte
+	Created ztype.Time
+	Timeout ztype.Duration
+	Email   ztype.String
+}
+
+func TestFormatStructOfValues(t *testing.T) {
+	row := formatRow{
+		ID:      ztype.NewNumber[int64](7),
+		Name:    ztype.NewString("ana"),
+		Active:  ztype.NewBool(true),
+		Level:   ztype.NewByte(3),
+		Created: ztype.NewTime(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)),
+		Timeout: ztype.NewDuration(90 * time.Second),
+		Email:   ztype.NewNullString(),
+	}
+	want := "{7 ana true 3 2024-03-01T12:00:00Z 1m30s <NULL>}"
+	assert.Equal(t, want, fmt.Sprintf("%v", row))
+	assert.Equal(t, want, fmt.Sprintf("%s", row))
+	assert.Equal(t, "{ID:7 Name:ana Active:true Level:3 Created:2024-03-01T12:00:00Z Timeout:1m30s Email:<NULL>}",
+		fmt.Sprintf("%+v", row))
+}
@@ -0,0 +1,223 @@
+package ztype_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/zhaori96/ztype"
+)
+
+// fuzzJSON decodes data into a T and checks that accepted input round-trips.
+func fuzzJSON[T json.Marshaler](t *testing.T, data []byte) {
+	var value T
+	if json.Unmarshal(data, &value) != nil {
+		return
+	}
+	if err := ztype.RoundTripJSON(value); err != nil {
+		t.Fatalf("input %q: %v", data, err)
+	}
+}
+
+// fuzzText decodes data with UnmarshalText and checks that accepted input
+// round-trips.
+func fuzzText[T encoding.TextMarshaler](t *testing.T, data []byte) {
+	var value T
+	if any(&value).(encoding.TextUnmarshaler).UnmarshalText(data) != nil {
+		return
+	}
+	if err := ztype.RoundTripText(value); err != nil {
+		t.Fatalf("input %q: %v", data, err)
+	}
+}
+
+// fuzzScan scans src into a T and checks that accepted input round-trips
+// through driver.Valuer.
+func fuzzScan[T driver.Valuer](t *testing.T, src any) {
+	var value T
+	if any(&value).(sql.Scanner).Scan(src) != nil {
+		return
+	}
+	if err := ztype.RoundTripSQL(value); err != nil {
+		t.Fatalf("input %#v: %v", src, err)
+	}
+}
+
+// addSeeds adds each seed to the fuzz corpus.
+func addSeeds(f *testing.F, seeds ...string) {
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+}
+
+func FuzzBool(f *testing.F) {
+	addSeeds(f, `true`, `false`, `null`, `"true"`, `"1"`, `"yes"`, `"off"`, `1`, `0`, `T`, ``)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Bool](t, data)
+		fuzzText[ztype.Bool](t, data)
+		fuzzScan[ztype.Bool](t, string(data))
+	})
+}
+
+func FuzzByte(f *testing.F) {
+	addSeeds(f, `0`, `255`, `256`, `null`, `"0x1f"`, `"0b101"`, `"007"`, `-1`, `1.5`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Byte](t, data)
+		fuzzText[ztype.Byte](t, data)
+		fuzzScan[ztype.Byte](t, data)
+	})
+}
+
+func FuzzChar(f *testing.F) {
+	addSeeds(f, `"A"`, `"AB"`, `""`, `65`, `10`, `null`, `"é"`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Char](t, data)
+	})
+}
+
+func FuzzBytes(f *testing.F) {
+	addSeeds(f, `"aGVsbG8="`, `""`, `[1,2,255]`, `[256]`, `null`, `"not base64"`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Bytes](t, data)
+		fuzzText[ztype.Bytes](t, data)
+		fuzzScan[ztype.Bytes](t, data)
+	})
+}
+
+func FuzzString(f *testing.F) {
+	addSeeds(f, `"hello"`, `""`, `null`, `"é😀"`, `123`, `"\ud800"`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.String](t, data)
+		fuzzText[ztype.String](t, data)
+		fuzzScan[ztype.String](t, string(data))
+	})
+}
+
+func FuzzTime(f *testing.F) {
+	addSeeds(f, `"2023-01-01T00:00:00Z"`, `"2023-06-15T10:30:00-03:00"`, `"2023-01-01"`,
+		`"2023-01-01 12:00:00"`, `null`, `""`, `"not a time"`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Time](t, data)
+		fuzzText[ztype.Time](t, data)
+	})
+}
+
+func FuzzDuration(f *testing.F) {
+	addSeeds(f, `"1h30m"`, `"0s"`, `"-5ms"`, `null`, `"abc"`, `1000000000`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Duration](t, data)
+		fuzzText[ztype.Duration](t, data)
+		fuzzScan[ztype.Duration](t, string(data))
+	})
+}
+
+func FuzzRune(f *testing.F) {
+	addSeeds(f, `"a"`, `"é"`, `"😀"`, `"ab"`, `""`, `null`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Rune](t, data)
+		fuzzText[ztype.Rune](t, data)
+		fuzzScan[ztype.Rune](t, string(data))
+	})
+}
+
+func FuzzIP(f *testing.F) {
+	addSeeds(f, `"192.168.0.1"`, `"::1"`, `"fe80::1%eth0"`, `"::ffff:10.0.0.1"`, `"300.1.1.1"`, `null`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.IP](t, data)
+		fuzzText[ztype.IP](t, data)
+		fuzzScan[ztype.IP](t, string(data))
+	})
+}
+
+func FuzzCIDR(f *testing.F) {
+	addSeeds(f, `"10.0.0.0/8"`, `"10.1.2.3/8"`, `"2001:db8::/32"`, `"10.0.0.1"`, `"10.0.0.0/33"`, `null`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.CIDR](t, data)
+		fuzzText[ztype.CIDR](t, data)
+		fuzzScan[ztype.CIDR](t, string(data))
+	})
+}
+
+func FuzzRawJSON(f *testing.F) {
+	addSeeds(f, `{"a":1}`, `[1, 2, 3]`, `"text"`, `null`, ` { "nested" : { "b" : true } } `)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.RawJSON](t, data)
+		fuzzScan[ztype.RawJSON](t, data)
+	})
+}
+
+func FuzzMoney(f *testing.F) {
+	addSeeds(f, `{"amount":"19.90","currency":"BRL"}`, `{"amount":"100","currency":"JPY"}`,
+		`"19.90 BRL"`, `{"amount":"1.234","currency":"USD"}`, `null`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Money](t, data)
+		fuzzScan[ztype.Money](t, string(data))
+	})
+}
+
+func FuzzEnum(f *testing.F) {
+	addSeeds(f, `"active"`, `"blocked"`, `"gone"`, `""`, `null`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Enum[quickStatus]](t, data)
+		fuzzText[ztype.Enum[quickStatus]](t, data)
+		fuzzScan[ztype.Enum[quickStatus]](t, string(data))
+	})
+}
+
+func FuzzNumeric(f *testing.F) {
+	addSeeds(f, `42`, `-1`, `3.14`, `"42"`, `1e3`, `9223372036854775807`, `null`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Numeric[int64]](t, data)
+		fuzzJSON[ztype.Numeric[uint8]](t, data)
+		fuzzJSON[ztype.Numeric[float64]](t, data)
+		fuzzText[ztype.Numeric[int64]](t, data)
+		fuzzScan[ztype.Numeric[int64]](t, string(data))
+		fuzzScan[ztype.Numeric[float64]](t, string(data))
+	})
+}
+
+func FuzzNull(f *testing.F) {
+	addSeeds(f, `"value"`, `{"X":1,"Y":2}`, `null`, `1`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Null[string]](t, data)
+		fuzzJSON[ztype.Null[cmpAddress]](t, data)
+	})
+}
+
+func FuzzSlice(f *testing.F) {
+	addSeeds(f, `[1,2,3]`, `[]`, `null`, `["a",1,true,null]`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Slice[int]](t, data)
+		fuzzJSON[ztype.Slice[any]](t, data)
+		fuzzScan[ztype.Slice[int]](t, data)
+	})
+}
+
+func FuzzSet(f *testing.F) {
+	addSeeds(f, `["a","b","a"]`, `[]`, `null`, `[3,1,2]`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.Set[string]](t, data)
+		fuzzJSON[ztype.Set[int]](t, data)
+		fuzzScan[ztype.Set[string]](t, data)
+	})
+}
+
+func FuzzMap(f *testing.F) {
+	addSeeds(f, `{"name":"Alice","age":30}`, `{}`, `null`, `{"1":2}`, `{"a":{"b":[1,2]}}`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.JSON](t, data)
+		fuzzJSON[ztype.Map[int, string]](t, data)
+		fuzzText[ztype.JSON](t, data)
+		fuzzScan[ztype.JSON](t, data)
+	})
+}
+
+func FuzzOrderedMap(f *testing.F) {
+	addSeeds(f, `{"b":1,"a":2}`, `{}`, `null`, `{"a":1,"a":2}`)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[ztype.OrderedMap[string, int]](t, data)
+		fuzzJSON[ztype.OrderedMap[string, any]](t, data)
+	})
+}
@@ -0,0 +1,192 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
+)
+
+func TestIPParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  string
+		is4       bool
+		is6       bool
+		isPrivate bool
+		wantErr   bool
+	}{
+		{"v4", "192.168.0.1", "192.168.0.1", true, false, true, false},
+		{"v4 public", "8.8.8.8", "8.8.8.8", true, false, false, false},
+		{"v4 with host suffix", "10.0.0.1/32", "10.0.0.1", true, false, true, false},
+		{"v4 with network suffix", "10.0.0.1/8", "10.0.0.1", true, false, true, false},
+		{"v6", "2001:db8::1", "2001:db8::1", false, true, false, false},
+		{"v6 private", "fd00::1/128", "fd00::1", false, true, true, false},
+		{"v6 with zone", "fe80::1%eth0", "fe80::1%eth0", false, true, false, false},
+		{"v4-mapped v6", "::ffff:10.0.0.1", "::ffff:10.0.0.1", true, false, true, false},
+		{"invalid", "999.0.0.1", "", false, false, false, true},
+		{"invalid suffix", "10.0.0.1/33", "", false, false, false, true},
+		{"garbage suffix", "10.0.0.1/x", "", false, false, false, true},
+		{"empty", "", "", false, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip, err := ztype.ParseIP(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ip.String())
+			assert.Equal(t, tt.is4, ip.Is4())
+			assert.Equal(t, tt.is6, ip.Is6())
+			assert.Equal(t, tt.isPrivate, ip.IsPrivate())
+		})
+	}
+}
+
+func TestIPSerialization(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		var payload struct {
+			Client ztype.IP `json:"client"`
+			Proxy  ztype.IP `json:"proxy"`
+			Origin ztype.IP `json:"origin"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"client":"::1","proxy":null}`), &payload))
+		assert.True(t, payload.Client.Unmarshaled())
+		assert.True(t, payload.Client.EqualRaw(netip.MustParseAddr("::1")))
+		assert.True(t, payload.Proxy.Unmarshaled())
+		assert.True(t, payload.Proxy.IsNull())
+		assert.False(t, payload.Origin.Unmarshaled())
+
+		data, err := json.Marshal(&payload.Client)
+		require.NoError(t, err)
+		assert.Equal(t, `"::1"`, string(data))
+		data, err = json.Marshal(&payload.Proxy)
+		require.NoError(t, err)
+		assert.Equal(t, `null`, string(data))
+
+		var ip ztype.IP
+		assert.Error(t, json.Unmarshal([]byte(`"nope"`), &ip))
+		assert.Error(t, json.Unmarshal([]byte(`12`), &ip))
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		var ip ztype.IP
+		require.NoError(t, ip.Scan([]byte("192.168.1.5/32")))
+		value, err := ip.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "192.168.1.5", value)
+
+		assert.Error(t, ip.Scan("bad"))
+		assert.Error(t, ip.Scan(42))
+		assert.Equal(t, "192.168.1.5", ip.String())
+
+		require.NoError(t, ip.Scan(nil))
+		assert.True(t, ip.IsNull())
+		value, err = ip.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestCIDR(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+			wantErr  bool
+		}{
+			{"10.0.0.0/8", "10.0.0.0/8", false},
+			{"10.0.0.1", "10.0.0.1/32", false},
+			{"2001:db8::/32", "2001:db8::/32", false},
+			{"2001:db8::1", "2001:db8::1/128", false},
+			{"10.0.0.0/40", "", true},
+			{"bogus/8", "", true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				network, err := ztype.ParseCIDR(tt.input)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, network.String())
+
+				var scanned ztype.CIDR
+				require.NoError(t, scanned.Scan([]byte(tt.input)))
+				value, err := scanned.Value()
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, value)
+			})
+		}
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		v4, _ := ztype.ParseCIDR("10.0.0.0/8")
+		v6, _ := ztype.ParseCIDR("2001:db8::/32")
+		nullNetwork := ztype.NewNullCIDR()
+
+		inside, _ := ztype.ParseIP("10.1.2.3")
+		mapped, _ := ztype.ParseIP("::ffff:10.1.2.3")
+		outside, _ := ztype.ParseIP("11.0.0.1")
+		inside6, _ := ztype.ParseIP("2001:db8::5")
+		nullIP := ztype.NewNullIP()
+
+		tests := []struct {
+			name     string
+			network  ztype.CIDR
+			ip       ztype.IP
+			expected bool
+		}{
+			{"v4 inside", v4, inside, true},
+			{"v4 mapped", v4, mapped, true},
+			{"v4 outside", v4, outside, false},
+			{"v4 vs v6", v4, inside6, false},
+			{"v6 inside", v6, inside6, true},
+			{"v6 vs v4", v6, inside, false},
+			{"null ip", v4, nullIP, false},
+			{"null network", nullNetwork, inside, false},
+			{"both null", nullNetwork, nullIP, false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, tt.network.Contains(tt.ip))
+			})
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var network ztype.CIDR
+		require.NoError(t, json.Unmarshal([]byte(`"192.168.0.0/16"`), &network))
+		assert.True(t, network.Unmarshaled())
+		assert.True(t, network.EqualRaw(netip.MustParsePrefix("192.168.0.0/16")))
+
+		data, err := json.Marshal(&network)
+		require.NoError(t, err)
+		assert.Equal(t, `"192.168.0.0/16"`, string(data))
+
+		require.NoError(t, json.Unmarshal([]byte(`null`), &network))
+		assert.True(t, network.IsNull())
+		assert.Error(t, json.Unmarshal([]byte(`"x/1"`), &network))
+	})
+}
+
+func TestIPValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.IP) }, []ztypetest.Case{
+		{Name: "text", Scan: "10.0.0.1", Want: "10.0.0.1"},
+		{Name: "bytes", Scan: []byte("::1"), Want: "::1"},
+		{Name: "invalid", Scan: "10.0.0", Err: true},
+		{Name: "int64", Scan: int64(1), Err: true},
+	})
+}
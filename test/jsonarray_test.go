@@ -0,0 +1,108 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func decodeJSONPayload(t *testing.T, data string) ztype.JSON {
+	t.Helper()
+	var payload ztype.JSON
+	require.NoError(t, json.Unmarshal([]byte(data), &payload))
+	return payload
+}
+
+func TestJSONGetStringSlice(t *testing.T) {
+	payload := decodeJSONPayload(t, `{"tags":["a",null,"b"],"empty":[],"none":null,"mixed":["a",1],"nested":[{"a":1}],"name":"x"}`)
+
+	tags, err := payload.GetStringSlice("tags")
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+	assert.Equal(t, "a", tags[0].Get())
+	assert.True(t, tags[1].IsNull())
+	assert.Equal(t, "b", tags[2].Get())
+
+	empty, err := payload.GetStringSlice("empty")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	for _, key := range []string{"none", "missing"} {
+		values, err := payload.GetStringSlice(key)
+		require.NoError(t, err, key)
+		assert.Empty(t, values, key)
+	}
+
+	_, err = payload.GetStringSlice("mixed")
+	assert.EqualError(t, err, `key "mixed": element 1: unsupported type: float64`)
+	assert.ErrorIs(t, err, &ztype.ErrUnsupportedScanType{})
+
+	_, err = payload.GetStringSlice("nested")
+	assert.ErrorContains(t, err, "element 0")
+
+	_, err = payload.GetStringSlice("name")
+	assert.EqualError(t, err, `key "name": expected an array, got string`)
+
+	values, err := ztype.NewNullMap[string, any]().GetStringSlice("tags")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestJSONGetNumberSlice(t *testing.T) {
+	payload := decodeJSONPayload(t, `{"scores":[1.5,2,null,-3e2],"mixed":[1,"2"]}`)
+
+	scores, err := payload.GetNumberSlice("scores")
+	require.NoError(t, err)
+	require.Len(t, scores, 4)
+	assert.Equal(t, 1.5, scores[0].Get())
+	assert.Equal(t, 2.0, scores[1].Get())
+	assert.True(t, scores[2].IsNull())
+	assert.Equal(t, -300.0, scores[3].Get())
+
+	_, err = payload.GetNumberSlice("mixed")
+	assert.EqualError(t, err, `key "mixed": element 1: unsupported type: string`)
+
+	payload.SetItem("counts", []int{4, 5})
+	payload.SetItem("numbers", []any{json.Number("6.5")})
+	counts, err := payload.GetNumberSlice("counts")
+	require.NoError(t, err)
+	assert.Equal(t, []ztype.Numeric[float64]{ztype.NewNumber(4.0), ztype.NewNumber(5.0)}, counts)
+	numbers, err := payload.GetNumberSlice("numbers")
+	require.NoError(t, err)
+	assert.Equal(t, 6.5, numbers[0].Get())
+
+	missing, err := payload.GetNumberSlice("missing")
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestJSONArrayAs(t *testing.T) {
+	payload := decodeJSONPayload(t, `{"items":[{"sku":"A1"},{"sku":"B2"}],"broken":[{"sku":"A1"},{"id":2}]}`)
+	sku := func(item any) (string, error) {
+		object, ok := item.(map[string]any)
+		if !ok {
+			return "", errors.New("expected an object")
+		}
+		value, ok := object["sku"].(string)
+		if !ok {
+			return "", errors.New("missing sku")
+		}
+		return value, nil
+	}
+
+	skus, err := ztype.JSONArrayAs(payload, "items", sku)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B2"}, skus)
+
+	_, err = ztype.JSONArrayAs(payload, "broken", sku)
+	assert.EqualError(t, err, `key "broken": element 1: missing sku`)
+
+	missing, err := ztype.JSONArrayAs(payload, "missing", sku)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
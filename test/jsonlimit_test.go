@@ -0,0 +1,104 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// nestedJSON returns an object nested depth levels deep.
+func nestedJSON(depth int) string {
+	return strings.Repeat(`{"a":`, depth-1) + `{}` + strings.Repeat(`}`, depth-1)
+}
+
+func TestMaxJSONDepth(t *testing.T) {
+	ztype.SetMaxJSONDepth(20)
+	defer ztype.SetMaxJSONDepth(0)
+
+	t.Run("Map at the limit", func(t *testing.T) {
+		var m ztype.JSON
+		require.NoError(t, json.Unmarshal([]byte(nestedJSON(20)), &m))
+		assert.False(t, m.IsNull())
+		require.NoError(t, m.Scan(nestedJSON(20)))
+	})
+
+	t.Run("Map over the limit", func(t *testing.T) {
+		m := ztype.NewMap(map[string]any{"kept": true})
+		err := json.Unmarshal([]byte(nestedJSON(21)), &m)
+		assert.ErrorIs(t, err, ztype.ErrJSONTooDeep)
+		assert.ErrorContains(t, err, "more than 20 levels")
+		assert.True(t, m.IsNull())
+
+		m = ztype.NewMap(map[string]any{"kept": true})
+		assert.ErrorIs(t, m.Scan([]byte(nestedJSON(21))), ztype.ErrJSONTooDeep)
+		assert.True(t, m.IsNull())
+	})
+
+	t.Run("arrays count", func(t *testing.T) {
+		var m ztype.JSON
+		doc := `{"a":` + strings.Repeat(`[`, 20) + strings.Repeat(`]`, 20) + `}`
+		assert.ErrorIs(t, m.Scan(doc), ztype.ErrJSONTooDeep)
+	})
+
+	t.Run("brackets in strings are ignored", func(t *testing.T) {
+		var m ztype.JSON
+		doc := `{"a":"` + strings.Repeat(`{[`, 50) + `\"{"}`
+		require.NoError(t, m.Scan(doc))
+		assert.Equal(t, strings.Repeat(`{[`, 50)+`"{`, m.Get()["a"])
+	})
+
+	t.Run("RawJSON", func(t *testing.T) {
+		var raw ztype.RawJSON
+		require.NoError(t, raw.Scan(nestedJSON(20)))
+		err := json.Unmarshal([]byte(nestedJSON(21)), &raw)
+		assert.ErrorIs(t, err, ztype.ErrJSONTooDeep)
+		assert.True(t, raw.IsNull())
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		ztype.SetMaxJSONDepth(0)
+		defer ztype.SetMaxJSONDepth(20)
+		var m ztype.JSON
+		require.NoError(t, m.Scan(nestedJSON(200)))
+	})
+}
+
+func TestMaxJSONSize(t *testing.T) {
+	doc := `{"data":"` + strings.Repeat("x", 1000) + `"}`
+	ztype.SetMaxJSONSize(len(doc))
+	defer ztype.SetMaxJSONSize(0)
+
+	var m ztype.JSON
+	require.NoError(t, m.Scan(doc))
+
+	over := `{"data":"` + strings.Repeat("x", 1001) + `"}`
+	err := json.Unmarshal([]byte(over), &m)
+	assert.ErrorIs(t, err, ztype.ErrJSONTooLarge)
+	assert.ErrorContains(t, err, "limit is 1011")
+	assert.True(t, m.IsNull())
+
+	var raw ztype.RawJSON
+	assert.ErrorIs(t, raw.Scan([]byte(over)), ztype.ErrJSONTooLarge)
+	assert.True(t, raw.IsNull())
+	require.NoError(t, raw.Scan("null"))
+
+	ztype.SetMaxJSONSize(-1)
+	require.NoError(t, m.Scan(over))
+	assert.False(t, m.IsNull())
+}
+
+func BenchmarkMaxJSONDepthCheck(b *testing.B) {
+	ztype.SetMaxJSONDepth(64)
+	defer ztype.SetMaxJSONDepth(0)
+	doc := []byte(`[` + strings.Repeat(`{"k":"v","n":[1,2,3]},`, 50000) + `{}]`)
+	b.ReportAllocs()
+	for b.Loop() {
+		var raw ztype.RawJSON
+		_ = raw.Scan(doc)
+	}
+}
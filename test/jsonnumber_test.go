@@ -0,0 +1,112 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// bigID is 2^60 + 1, which float64 cannot hold.
+const bigID = int64(1)<<60 + 1
+
+const bigIDDocument = `{"id":1152921504606846977,"owner":{"id":1152921504606846977},"refs":[1152921504606846977]}`
+
+type bigIDOwner struct {
+	ID ztype.Numeric[int64] `json:"id"`
+}
+
+type bigIDRecord struct {
+	ID    ztype.Numeric[int64]   `json:"id"`
+	Owner bigIDOwner             `json:"owner"`
+	Refs  []int64                `json:"refs"`
+	Extra ztype.Map[string, any] `json:"extra"`
+}
+
+func useExactJSONNumbers(t *testing.T) {
+	t.Helper()
+	ztype.SetJSONNumberMode(ztype.JSONNumberExact)
+	t.Cleanup(func() { ztype.SetJSONNumberMode(ztype.JSONNumberFloat64) })
+}
+
+func TestJSONNumberModeDefault(t *testing.T) {
+	var doc ztype.JSON
+	require.NoError(t, json.Unmarshal([]byte(bigIDDocument), &doc))
+
+	id, ok := doc.Get()["id"].(float64)
+	require.True(t, ok, "numbers decode to float64 by default")
+	assert.NotEqual(t, bigID, int64(id))
+}
+
+func TestJSONNumberModeExact(t *testing.T) {
+	useExactJSONNumbers(t)
+
+	t.Run("Map.UnmarshalJSON", func(t *testing.T) {
+		var doc ztype.JSON
+		require.NoError(t, json.Unmarshal([]byte(bigIDDocument), &doc))
+		assert.Equal(t, json.Number("1152921504606846977"), doc.Get()["id"])
+		assert.Equal(t, json.Number("1152921504606846977"), doc.Get()["owner"].(map[string]any)["id"])
+
+		var record bigIDRecord
+		require.NoError(t, doc.DecodeInto(&record))
+		assert.Equal(t, bigID, record.ID.Get())
+		assert.Equal(t, bigID, record.Owner.ID.Get())
+		assert.Equal(t, []int64{bigID}, record.Refs)
+	})
+
+	t.Run("Map.Scan", func(t *testing.T) {
+		var doc ztype.JSON
+		require.NoError(t, doc.Scan([]byte(bigIDDocument)))
+
+		var record bigIDRecord
+		require.NoError(t, doc.DecodeInto(&record))
+		assert.Equal(t, bigID, record.ID.Get())
+
+		encoded, err := json.Marshal(doc)
+		require.NoError(t, err)
+		assert.JSONEq(t, bigIDDocument, string(encoded))
+	})
+
+	t.Run("nested Map field", func(t *testing.T) {
+		var record bigIDRecord
+		require.NoError(t, json.Unmarshal([]byte(`{"extra":{"n":1152921504606846977}}`), &record))
+
+		ids, err := ztype.AsMapOf[int64](record.Extra)
+		require.NoError(t, err)
+		assert.Equal(t, bigID, ids.Get()["n"])
+	})
+
+	t.Run("OrderedMap and Slice", func(t *testing.T) {
+		var ordered ztype.OrderedMap[string, any]
+		require.NoError(t, json.Unmarshal([]byte(bigIDDocument), &ordered))
+		id, _ := ordered.GetItem("id")
+		assert.Equal(t, json.Number("1152921504606846977"), id)
+
+		var refs ztype.Slice[any]
+		require.NoError(t, json.Unmarshal([]byte(`[1152921504606846977,1.5]`), &refs))
+		assert.Equal(t, []any{json.Number("1152921504606846977"), json.Number("1.5")}, refs.Get())
+	})
+
+	t.Run("exponent into integer field", func(t *testing.T) {
+		var doc ztype.JSON
+		require.NoError(t, json.Unmarshal([]byte(`{"refs":[1e3]}`), &doc))
+
+		var record bigIDRecord
+		require.NoError(t, doc.DecodeInto(&record))
+		assert.Equal(t, []int64{1000}, record.Refs)
+
+		require.NoError(t, json.Unmarshal([]byte(`{"refs":[1.5]}`), &doc))
+		assert.ErrorIs(t, doc.DecodeInto(&record), &ztype.ErrInvalidFormat{})
+	})
+
+	t.Run("invalid documents still fail", func(t *testing.T) {
+		var doc ztype.JSON
+		assert.Error(t, json.Unmarshal([]byte(`{"a":1 2}`), &doc))
+
+		var refs ztype.Slice[any]
+		assert.Error(t, refs.Scan(`[1] [2]`))
+	})
+}
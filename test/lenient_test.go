@@ -0,0 +1,135 @@
+package ztype_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestParseNumberLenient(t *testing.T) {
+	dotDecimal := []ztype.ParseOption{}
+	commaDecimal := []ztype.ParseOption{ztype.WithSeparators('.', ',')}
+	spaceGroups := []ztype.ParseOption{ztype.WithSeparators(' ', ',')}
+
+	valid := []struct {
+		input    string
+		opts     []ztype.ParseOption
+		expected float64
+	}{
+		{"1,234.56", dotDecimal, 1234.56},
+		{"1234.56", dotDecimal, 1234.56},
+		{"  42  ", dotDecimal, 42},
+		{"+7", dotDecimal, 7},
+		{"-1,000,000", dotDecimal, -1_000_000},
+		{".5", dotDecimal, 0.5},
+		{"0.1", dotDecimal, 0.1},
+		{"15%", dotDecimal, 0.15},
+		{"15 %", dotDecimal, 0.15},
+		{"5%", dotDecimal, 0.05},
+		{"0.5%", dotDecimal, 0.005},
+		{"1,500%", dotDecimal, 15},
+		{"-2.5%", dotDecimal, -0.025},
+		{"(123)", dotDecimal, -123},
+		{"( 1,234.50 )", dotDecimal, -1234.5},
+		{"(15%)", dotDecimal, -0.15},
+		{"1.234,56", commaDecimal, 1234.56},
+		{"1234,56", commaDecimal, 1234.56},
+		{"-1.234.567", commaDecimal, -1234567},
+		{"12,5%", commaDecimal, 0.125},
+		{"(1.000,01)", commaDecimal, -1000.01},
+		{"1 234 567,8", spaceGroups, 1234567.8},
+	}
+	for _, tt := range valid {
+		t.Run(tt.input, func(t *testing.T) {
+			n, err := ztype.ParseNumberLenient[float64](tt.input, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, n.Get())
+			assert.False(t, n.IsNull())
+		})
+	}
+
+	invalid := []struct {
+		input string
+		opts  []ztype.ParseOption
+	}{
+		{"1,23.4", dotDecimal},
+		{"1,2345", dotDecimal},
+		{",123", dotDecimal},
+		{"1,,234", dotDecimal},
+		{"1234,", dotDecimal},
+		{"1.234,56", dotDecimal},
+		{"1,234.56", commaDecimal},
+		{"1.2.3", dotDecimal},
+		{"12.", dotDecimal},
+		{"(-5)", dotDecimal},
+		{"-(5)", dotDecimal},
+		{"(5", dotDecimal},
+		{"5)", dotDecimal},
+		{"- 5", dotDecimal},
+		{"%", dotDecimal},
+		{"()", dotDecimal},
+		{"5%%", dotDecimal},
+		{"1e3", dotDecimal},
+		{"abc", dotDecimal},
+		{"$5", dotDecimal},
+		{"1,234", []ztype.ParseOption{ztype.WithSeparators(',', ',')}},
+	}
+	for _, tt := range invalid {
+		t.Run("invalid "+tt.input, func(t *testing.T) {
+			_, err := ztype.ParseNumberLenient[float64](tt.input, tt.opts...)
+			assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "Numeric[float64]"})
+		})
+	}
+}
+
+func TestParseNumberLenientIntegers(t *testing.T) {
+	n, err := ztype.ParseNumberLenient[int64]("(1,234)")
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1234), n.Get())
+
+	n, err = ztype.ParseNumberLenient[int64]("1,234.00")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), n.Get())
+
+	_, err = ztype.ParseNumberLenient[int64]("1,234.5")
+	assert.ErrorContains(t, err, "not an integer")
+	_, err = ztype.ParseNumberLenient[int64]("15%")
+	assert.ErrorContains(t, err, "percent needs a float type")
+
+	_, err = ztype.ParseNumberLenient[int8]("1,000")
+	assert.ErrorIs(t, err, &ztype.ErrOverflow{Type: "Numeric[int8]"})
+	_, err = ztype.ParseNumberLenient[uint]("(1)")
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+
+	null, err := ztype.ParseNumberLenient[int]("   ")
+	require.NoError(t, err)
+	assert.True(t, null.IsNull())
+}
+
+func TestNumberTextLenient(t *testing.T) {
+	var strict ztype.Numeric[float64]
+	assert.Error(t, strict.UnmarshalText([]byte("1,234.5")))
+
+	ztype.SetNumberTextLenient(true, ztype.WithSeparators('.', ','))
+	defer ztype.SetNumberTextLenient(false)
+
+	var n ztype.Numeric[float64]
+	require.NoError(t, n.UnmarshalText([]byte("1.234,5")))
+	assert.Equal(t, 1234.5, n.Get())
+	assert.True(t, n.Unmarshaled())
+
+	require.NoError(t, n.UnmarshalText([]byte("(10%)")))
+	assert.Equal(t, -0.1, n.Get())
+
+	assert.ErrorIs(t, n.UnmarshalText([]byte("1,234.5")), &ztype.ErrInvalidFormat{})
+	assert.Equal(t, -0.1, n.Get())
+
+	require.NoError(t, n.UnmarshalText([]byte(" ")))
+	assert.True(t, n.IsNull())
+
+	ztype.SetNumberTextLenient(false)
+	assert.Error(t, n.UnmarshalText([]byte("1.234,5")))
+}
@@ -0,0 +1,918 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
+)
+
+func newTestPayload(t *testing.T) ztype.JSON {
+	var payload ztype.JSON
+	data := `{
+		"data": {"user": {"id": 7, "name": "Alice"}},
+		"items": [{"sku": "A1"}, {"sku": "B2"}],
+		"title": "order"
+	}`
+	require.NoError(t, json.Unmarshal([]byte(data), &payload))
+	return payload
+}
+
+func TestMapGetPath(t *testing.T) {
+	payload := newTestPayload(t)
+
+	tests := []struct {
+		name     string
+		path     []string
+		expected any
+		ok       bool
+	}{
+		{"top level", []string{"title"}, "order", true},
+		{"deep path", []string{"data", "user", "id"}, float64(7), true},
+		{"array index", []string{"items", "1", "sku"}, "B2", true},
+		{"missing intermediate", []string{"data", "account", "id"}, nil, false},
+		{"missing leaf", []string{"data", "user", "email"}, nil, false},
+		{"index out of range", []string{"items", "5", "sku"}, nil, false},
+		{"invalid index", []string{"items", "first"}, nil, false},
+		{"string where map expected", []string{"title", "length"}, nil, false},
+		{"empty path", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := payload.GetPath(tt.path...)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	t.Run("null map", func(t *testing.T) {
+		m := ztype.NewNullMap[string, any]()
+		_, ok := m.GetPath("a")
+		assert.False(t, ok)
+	})
+}
+
+func TestMapGetDotted(t *testing.T) {
+	payload := newTestPayload(t)
+
+	value, ok := payload.GetDotted("items.0.sku")
+	assert.True(t, ok)
+	assert.Equal(t, "A1", value)
+
+	value, ok = payload.GetDotted("data.user.name")
+	assert.True(t, ok)
+	assert.Equal(t, "Alice", value)
+
+	_, ok = payload.GetDotted("data.user.name.first")
+	assert.False(t, ok)
+
+	numeric := ztype.NewMap(map[int]any{1: "a"})
+	_, ok = numeric.GetDotted("1")
+	assert.False(t, ok)
+}
+
+func TestMapSetPath(t *testing.T) {
+	t.Run("creates structure", func(t *testing.T) {
+		var m ztype.JSON
+		require.NoError(t, m.SetPath(42, "data", "user", "id"))
+		assert.False(t, m.IsNull())
+		assert.Equal(t, `{"data":{"user":{"id":42}}}`, m.String())
+	})
+
+	t.Run("keeps siblings", func(t *testing.T) {
+		payload := newTestPayload(t)
+		require.NoError(t, payload.SetPath("alice@example.com", "data", "user", "email"))
+
+		value, ok := payload.GetDotted("data.user.name")
+		assert.True(t, ok)
+		assert.Equal(t, "Alice", value)
+
+		value, ok = payload.GetDotted("data.user.email")
+		assert.True(t, ok)
+		assert.Equal(t, "alice@example.com", value)
+	})
+
+	t.Run("array element", func(t *testing.T) {
+		payload := newTestPayload(t)
+		require.NoError(t, payload.SetPath("C3", "items", "1", "sku"))
+
+		value, _ := payload.GetDotted("items.1.sku")
+		assert.Equal(t, "C3", value)
+	})
+
+	t.Run("array out of range", func(t *testing.T) {
+		payload := newTestPayload(t)
+		assert.Error(t, payload.SetPath("C3", "items", "2", "sku"))
+	})
+
+	t.Run("type conflict", func(t *testing.T) {
+		payload := newTestPayload(t)
+		assert.Error(t, payload.SetPath(1, "title", "length"))
+
+		value, _ := payload.GetPath("title")
+		assert.Equal(t, "order", value)
+	})
+
+	t.Run("typed values", func(t *testing.T) {
+		m := ztype.NewMap(map[string]int{})
+		assert.NoError(t, m.SetPath(1, "a"))
+		assert.Error(t, m.SetPath("x", "b"))
+		assert.Error(t, m.SetPath(1, "c", "d"))
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("empty path", func(t *testing.T) {
+		var m ztype.JSON
+		assert.Error(t, m.SetPath(1))
+	})
+}
+
+func TestMapDeletePath(t *testing.T) {
+	payload := newTestPayload(t)
+
+	assert.True(t, payload.DeletePath("data", "user", "id"))
+	_, ok := payload.GetDotted("data.user.id")
+	assert.False(t, ok)
+
+	assert.False(t, payload.DeletePath("data", "user", "id"))
+	assert.False(t, payload.DeletePath("items", "0"))
+	assert.False(t, payload.DeletePath("missing", "key"))
+
+	assert.True(t, payload.DeletePath("title"))
+	assert.False(t, payload.Has("title"))
+}
+
+func TestMapZeroValueMutation(t *testing.T) {
+	t.Run("zero value SetItem", func(t *testing.T) {
+		var m ztype.Map[string, int]
+		m.SetItem("a", 1)
+		assert.False(t, m.IsNull())
+		assert.Equal(t, map[string]int{"a": 1}, m.Get())
+	})
+
+	t.Run("zero value SetItemIf", func(t *testing.T) {
+		var m ztype.Map[string, int]
+		m.SetItemIf("a", 1, false)
+		assert.True(t, m.IsNull())
+		m.SetItemIf("a", 1, true)
+		assert.False(t, m.IsNull())
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("zero value Insert", func(t *testing.T) {
+		var m ztype.Map[string, int]
+		m.Insert(ztype.NewMap(map[string]int{"a": 1, "b": 2}).All())
+		assert.False(t, m.IsNull())
+		assert.Equal(t, 2, m.Len())
+	})
+
+	t.Run("zero value Collect", func(t *testing.T) {
+		var m ztype.Map[string, int]
+		m.Collect(ztype.NewMap(map[string]int{"a": 1}).All())
+		assert.False(t, m.IsNull())
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("NewNullMap SetItem", func(t *testing.T) {
+		m := ztype.NewNullMap[string, int]()
+		m.SetItem("a", 1)
+		assert.False(t, m.IsNull())
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("after SetNull", func(t *testing.T) {
+		m := ztype.NewMap(map[string]int{"a": 1})
+		m.SetNull()
+		assert.True(t, m.IsNull())
+		m.SetItem("b", 2)
+		assert.False(t, m.IsNull())
+		assert.Equal(t, map[string]int{"b": 2}, m.Get())
+	})
+
+	t.Run("after UnmarshalJSON null", func(t *testing.T) {
+		var m ztype.Map[string, int]
+		require.NoError(t, json.Unmarshal([]byte(`null`), &m))
+		assert.True(t, m.IsNull())
+		m.SetItem("a", 1)
+		assert.False(t, m.IsNull())
+		assert.Equal(t, 1, m.Len())
+	})
+}
+
+func TestMapAccessorsWithFallback(t *testing.T) {
+	valid := ztype.NewMap(map[string]int{"zero": 0, "one": 1})
+	null := ztype.NewNullMap[string, int]()
+
+	t.Run("GetOr", func(t *testing.T) {
+		fallback := map[string]int{"fallback": 1}
+		assert.Equal(t, valid.Get(), valid.GetOr(fallback))
+		assert.Equal(t, fallback, null.GetOr(fallback))
+	})
+
+	t.Run("GetItemOr", func(t *testing.T) {
+		assert.Equal(t, 1, valid.GetItemOr("one", 9))
+		assert.Equal(t, 0, valid.GetItemOr("zero", 9))
+		assert.Equal(t, 9, valid.GetItemOr("missing", 9))
+		assert.Equal(t, 9, null.GetItemOr("one", 9))
+	})
+
+	t.Run("MustGetItem", func(t *testing.T) {
+		assert.Equal(t, 0, valid.MustGetItem("zero"))
+		assert.PanicsWithError(t, `key "missing" not found`, func() {
+			valid.MustGetItem("missing")
+		})
+		assert.Panics(t, func() { null.MustGetItem("one") })
+	})
+
+	t.Run("Pop", func(t *testing.T) {
+		m := valid.Clone()
+		assert.Equal(t, 0, m.Pop("zero", 9))
+		assert.False(t, m.Has("zero"))
+		assert.Equal(t, 9, m.Pop("zero", 9))
+		assert.Equal(t, 9, null.Pop("one", 9))
+	})
+}
+
+func TestMapDiff(t *testing.T) {
+	equal := func(a, b int) bool { return a == b }
+
+	t.Run("disjoint", func(t *testing.T) {
+		before := ztype.NewMap(map[string]int{"a": 1})
+		after := ztype.NewMap(map[string]int{"b": 2})
+		diff := before.DiffFunc(after, equal)
+		assert.Equal(t, map[string]int{"b": 2}, diff.Added)
+		assert.Equal(t, map[string]int{"a": 1}, diff.Removed)
+		assert.Empty(t, diff.Changed)
+	})
+
+	t.Run("identical", func(t *testing.T) {
+		m := ztype.NewMap(map[string]int{"a": 1, "b": 2})
+		assert.True(t, m.DiffFunc(m.Clone(), equal).IsEmpty())
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		before := ztype.MapComparable[string, int]{}
+		before.Set(map[string]int{"a": 1, "b": 2})
+		after := ztype.MapComparable[string, int]{}
+		after.Set(map[string]int{"a": 1, "b": 3})
+
+		diff := before.Diff(after)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Equal(t, map[string]ztype.MapChange[int]{"b": {Old: 2, New: 3}}, diff.Changed)
+	})
+
+	t.Run("null inputs", func(t *testing.T) {
+		null := ztype.NewNullMap[string, int]()
+		valid := ztype.NewMap(map[string]int{"a": 1})
+
+		assert.Equal(t, map[string]int{"a": 1}, null.DiffFunc(valid, equal).Added)
+		assert.Equal(t, map[string]int{"a": 1}, valid.DiffFunc(null, equal).Removed)
+		assert.True(t, null.DiffFunc(null, equal).IsEmpty())
+	})
+}
+
+func TestMapDeepDiff(t *testing.T) {
+	var before, after ztype.JSON
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"status": "active",
+		"user": {"id": 1, "name": "Alice", "address": {"city": "Recife"}},
+		"tags": ["a", "b"]
+	}`), &before))
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"status": "active",
+		"user": {"id": 1, "email": "alice@example.com", "address": {"city": "Olinda"}},
+		"tags": ["a", "c"]
+	}`), &after))
+
+	diff := ztype.DeepDiff(before, after)
+	assert.Equal(t, map[string]any{"user.email": "alice@example.com"}, diff.Added)
+	assert.Equal(t, map[string]any{"user.name": "Alice"}, diff.Removed)
+	assert.Equal(t, map[string]ztype.MapChange[any]{
+		"user.address.city": {Old: "Recife", New: "Olinda"},
+		"tags":              {Old: []any{"a", "b"}, New: []any{"a", "c"}},
+	}, diff.Changed)
+
+	t.Run("object replaced by scalar", func(t *testing.T) {
+		replaced := ztype.NewMap(map[string]any{"user": "deleted", "status": "active", "tags": []any{"a", "b"}})
+		diff := ztype.DeepDiff(before, replaced)
+		assert.Contains(t, diff.Changed, "user")
+	})
+
+	t.Run("null input", func(t *testing.T) {
+		diff := ztype.DeepDiff(ztype.NewNullMap[string, any](), before)
+		assert.Len(t, diff.Added, 3)
+		assert.True(t, ztype.DeepDiff(before, before).IsEmpty())
+	})
+}
+
+func TestMapTransformHelpers(t *testing.T) {
+	valid := ztype.NewMap(map[string]int{"a": 1, "b": 2})
+	empty := ztype.NewMap(map[string]int{})
+	null := ztype.NewNullMap[string, int]()
+
+	t.Run("MapValues", func(t *testing.T) {
+		double := func(_ string, v int) int { return v * 2 }
+		assert.Equal(t, map[string]int{"a": 2, "b": 4}, valid.MapValues(double).Get())
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, valid.Get())
+
+		result := empty.MapValues(double)
+		assert.False(t, result.IsNull())
+		assert.Equal(t, 0, result.Len())
+		assert.True(t, null.MapValues(double).IsNull())
+	})
+
+	t.Run("MapKeys", func(t *testing.T) {
+		prefixed, err := valid.MapKeys(func(k string, _ int) string { return "x" + k })
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"xa": 1, "xb": 2}, prefixed.Get())
+
+		_, err = valid.MapKeys(func(string, int) string { return "same" })
+		assert.ErrorContains(t, err, `"same"`)
+
+		result, err := null.MapKeys(func(k string, _ int) string { return k })
+		require.NoError(t, err)
+		assert.True(t, result.IsNull())
+	})
+
+	t.Run("TransformMap", func(t *testing.T) {
+		labels := ztype.TransformMap(valid, func(k string, v int) string {
+			return k + "=" + string(rune('0'+v))
+		})
+		assert.Equal(t, map[string]string{"a": "a=1", "b": "b=2"}, labels.Get())
+		assert.True(t, ztype.TransformMap(null, func(string, int) bool { return true }).IsNull())
+		assert.False(t, ztype.TransformMap(empty, func(string, int) bool { return true }).IsNull())
+	})
+
+	t.Run("Reduce", func(t *testing.T) {
+		sum := func(acc int, _ string, v int) int { return acc + v }
+		assert.Equal(t, 3, ztype.Reduce(valid, 0, sum))
+		assert.Equal(t, 10, ztype.Reduce(empty, 10, sum))
+		assert.Equal(t, 10, ztype.Reduce(null, 10, sum))
+	})
+}
+
+func TestMapJsonOutput(t *testing.T) {
+	m := ztype.NewMap(map[string]int{"c": 3, "a": 1, "b": 2})
+
+	t.Run("stable across runs", func(t *testing.T) {
+		first, err := m.JsonStringE()
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":1,"b":2,"c":3}`, first)
+		for range 50 {
+			again, err := m.JsonStringE()
+			require.NoError(t, err)
+			assert.Equal(t, first, again)
+		}
+	})
+
+	t.Run("indent", func(t *testing.T) {
+		value, err := m.JsonStringIndent("", "  ")
+		require.NoError(t, err)
+		assert.Equal(t, "{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}", value)
+	})
+
+	t.Run("sorted int keys", func(t *testing.T) {
+		numeric := ztype.NewMap(map[int]string{10: "j", 2: "b", 1: "a"})
+
+		data, err := json.Marshal(numeric)
+		require.NoError(t, err)
+		assert.Equal(t, `{"1":"a","10":"j","2":"b"}`, string(data))
+
+		value, err := numeric.JsonStringSorted(func(a, b int) int { return a - b })
+		require.NoError(t, err)
+		assert.Equal(t, `{"1":"a","2":"b","10":"j"}`, value)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		null := ztype.NewNullMap[string, int]()
+		value, err := null.JsonStringE()
+		require.NoError(t, err)
+		assert.Equal(t, "{}", value)
+		assert.Equal(t, "{}", null.String())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, `{"a":1,"b":2,"c":3}`, m.String())
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		broken := ztype.NewMap(map[string]any{"ch": make(chan int)})
+
+		_, err := broken.JsonStringE()
+		assert.Error(t, err)
+		_, err = broken.JsonStringIndent("", "  ")
+		assert.Error(t, err)
+		_, err = broken.JsonStringSorted(func(a, b string) int { return 0 })
+		assert.Error(t, err)
+
+		assert.Empty(t, broken.JsonString())
+		assert.Contains(t, broken.String(), "map[ch:0x")
+	})
+
+	t.Run("marshal error in other collections", func(t *testing.T) {
+		slice := ztype.NewSlice([]any{make(chan int)})
+		_, err := slice.MarshalJSON()
+		assert.ErrorContains(t, err, "unsupported type: chan int")
+
+		ordered := ztype.NewOrderedMap[string, any]()
+		ordered.SetItem("ch", make(chan int))
+		_, err = ordered.MarshalJSON()
+		assert.ErrorContains(t, err, "unsupported type: chan int")
+
+		_, err = json.Marshal(struct{ M ztype.JSON }{ztype.NewMap(map[string]any{"ch": make(chan int)})})
+		assert.ErrorContains(t, err, "unsupported type: chan int")
+	})
+}
+
+func TestMapScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected map[string]int
+		isNull   bool
+		wantErr  bool
+	}{
+		{"bytes JSON", []byte(`{"a":1}`), map[string]int{"a": 1}, false, false},
+		{"string JSON", `{"a":1}`, map[string]int{"a": 1}, false, false},
+		{"typed map", map[string]int{"a": 1}, map[string]int{"a": 1}, false, false},
+		{"decoded map", map[string]any{"a": float64(1)}, map[string]int{"a": 1}, false, false},
+		{"decoded map mismatch", map[string]any{"a": "x"}, nil, false, true},
+		{"jsonb null", []byte("null"), map[string]int{}, true, false},
+		{"jsonb null string", "null", map[string]int{}, true, false},
+		{"SQL NULL", nil, map[string]int{}, true, false},
+		{"invalid JSON", []byte(`{`), nil, false, true},
+		{"unsupported type", 42, nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ztype.NewMap(map[string]int{"previous": 1})
+			err := m.Scan(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.isNull, m.IsNull())
+			assert.Equal(t, tt.expected, m.Get())
+		})
+	}
+
+	t.Run("typed map is copied", func(t *testing.T) {
+		source := map[string]any{"a": 1}
+		var m ztype.JSON
+		require.NoError(t, m.Scan(source))
+		source["a"] = 2
+		assert.Equal(t, 1, m.GetItemOr("a", nil))
+	})
+}
+
+func TestMapValue(t *testing.T) {
+	valid := ztype.NewMap(map[string]int{"a": 1})
+	null := ztype.NewNullMap[string, int]()
+
+	tests := []struct {
+		name     string
+		asBytes  bool
+		value    func() (driver.Value, error)
+		expected driver.Value
+	}{
+		{"valid as string", false, valid.Value, `{"a":1}`},
+		{"valid as bytes", true, valid.Value, []byte(`{"a":1}`)},
+		{"null", false, null.Value, nil},
+		{"null as bytes", true, null.Value, nil},
+		{"null empty object", false, null.ValueOrEmptyObject, "{}"},
+		{"null empty object as bytes", true, null.ValueOrEmptyObject, []byte("{}")},
+		{"null document", false, null.ValueOrNullDocument, "null"},
+		{"null document as bytes", true, null.ValueOrNullDocument, []byte("null")},
+		{"valid empty object", false, valid.ValueOrEmptyObject, `{"a":1}`},
+		{"valid null document as bytes", true, valid.ValueOrNullDocument, []byte(`{"a":1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ztype.SetMapValueAsBytes(tt.asBytes)
+			defer ztype.SetMapValueAsBytes(false)
+
+			value, err := tt.value()
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, value)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+type mapTestCode string
+
+func TestMapKeyCoercion(t *testing.T) {
+	t.Run("int keys", func(t *testing.T) {
+		m := ztype.NewMap(map[int]string{1: "a", -2: "b"})
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{"-2":"b","1":"a"}`, string(data))
+
+		var decoded ztype.Map[int, string]
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, m.Get(), decoded.Get())
+	})
+
+	t.Run("int64 keys through Scan and Value", func(t *testing.T) {
+		m := ztype.NewMap(map[int64]bool{1 << 40: true})
+		value, err := m.Value()
+		require.NoError(t, err)
+
+		var scanned ztype.Map[int64, bool]
+		require.NoError(t, scanned.Scan(value))
+		assert.Equal(t, m.Get(), scanned.Get())
+	})
+
+	t.Run("custom string key", func(t *testing.T) {
+		var decoded ztype.Map[mapTestCode, int]
+		require.NoError(t, json.Unmarshal([]byte(`{"BRL":1,"USD":2}`), &decoded))
+		assert.Equal(t, map[mapTestCode]int{"BRL": 1, "USD": 2}, decoded.Get())
+
+		data, err := json.Marshal(decoded)
+		require.NoError(t, err)
+		assert.Equal(t, `{"BRL":1,"USD":2}`, string(data))
+	})
+
+	t.Run("unparsable key", func(t *testing.T) {
+		var decoded ztype.Map[int, string]
+		err := json.Unmarshal([]byte(`{"one":"a"}`), &decoded)
+		assert.True(t, errors.Is(err, &ztype.ErrInvalidFormat{Type: "Map"}))
+		assert.ErrorContains(t, err, `"one"`)
+		assert.True(t, decoded.IsNull())
+
+		err = decoded.Scan(`{"300":"a"}`)
+		assert.NoError(t, err)
+
+		var small ztype.Map[int8, string]
+		err = small.Scan(`{"300":"a"}`)
+		assert.True(t, errors.Is(err, &ztype.ErrOverflow{Type: "int8"}))
+		assert.ErrorContains(t, err, "300")
+	})
+
+	t.Run("duplicate after coercion", func(t *testing.T) {
+		var decoded ztype.Map[int, string]
+		err := json.Unmarshal([]byte(`{"1":"a","01":"b"}`), &decoded)
+		assert.True(t, errors.Is(err, &ztype.ErrInvalidFormat{Type: "Map"}))
+		assert.ErrorContains(t, err, "duplicate map key")
+	})
+
+	t.Run("invalid value names key", func(t *testing.T) {
+		var decoded ztype.Map[string, int]
+		err := json.Unmarshal([]byte(`{"a":"x"}`), &decoded)
+		assert.ErrorContains(t, err, `"a"`)
+	})
+}
+
+func TestMapSliceAccessors(t *testing.T) {
+	less := func(a, b string) bool { return a < b }
+	m := ztype.NewMap(map[string]int{"c": 3, "a": 1, "b": 2})
+	null := ztype.NewNullMap[string, int]()
+
+	t.Run("KeysSlice and ValuesSlice", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"a", "b", "c"}, m.KeysSlice())
+		assert.ElementsMatch(t, []int{1, 2, 3}, m.ValuesSlice())
+		assert.NotNil(t, null.KeysSlice())
+		assert.Empty(t, null.KeysSlice())
+		assert.NotNil(t, null.ValuesSlice())
+	})
+
+	t.Run("Items", func(t *testing.T) {
+		assert.ElementsMatch(t, []ztype.MapItem[string, int]{
+			{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3},
+		}, m.Items())
+		assert.NotNil(t, null.Items())
+	})
+
+	t.Run("sorted ordering is stable", func(t *testing.T) {
+		for range 20 {
+			assert.Equal(t, []string{"a", "b", "c"}, m.SortedKeys(less))
+
+			var keys []string
+			var values []int
+			for key, value := range m.AllSorted(less) {
+				keys = append(keys, key)
+				values = append(values, value)
+			}
+			assert.Equal(t, []string{"a", "b", "c"}, keys)
+			assert.Equal(t, []int{1, 2, 3}, values)
+		}
+	})
+
+	t.Run("AllSorted early stop", func(t *testing.T) {
+		for key := range m.AllSorted(less) {
+			assert.Equal(t, "a", key)
+			break
+		}
+	})
+
+	t.Run("returned slices are detached", func(t *testing.T) {
+		keys := m.SortedKeys(less)
+		keys[0] = "z"
+		values := m.ValuesSlice()
+		values[0] = 100
+		items := m.Items()
+		items[0].Value = 100
+
+		assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, m.Get())
+	})
+}
+
+func TestMapMergeHelpers(t *testing.T) {
+	t.Run("MergeFunc resolver", func(t *testing.T) {
+		calls := 0
+		sum := func(_ string, existing, incoming int) int {
+			calls++
+			return existing + incoming
+		}
+		base := ztype.NewMap(map[string]int{"a": 1, "b": 1})
+		merged := base.MergeFunc(sum,
+			ztype.NewMap(map[string]int{"a": 2, "c": 3}),
+			ztype.NewMap(map[string]int{"a": 4}),
+		)
+		assert.Equal(t, map[string]int{"a": 7, "b": 1, "c": 3}, merged.Get())
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, map[string]int{"a": 1, "b": 1}, base.Get())
+	})
+
+	t.Run("MergeFunc null inputs", func(t *testing.T) {
+		keep := func(_ string, existing, _ int) int { return existing }
+		null := ztype.NewNullMap[string, int]()
+		merged := null.MergeFunc(keep, ztype.NewMap(map[string]int{"a": 1}), null)
+		assert.Equal(t, map[string]int{"a": 1}, merged.Get())
+	})
+
+	t.Run("Merge null receiver", func(t *testing.T) {
+		null := ztype.NewNullMap[string, int]()
+		merged := null.Merge(ztype.NewMap(map[string]int{"a": 1}))
+		assert.Equal(t, map[string]int{"a": 1}, merged.Get())
+	})
+
+	t.Run("MergeInto mutates in place", func(t *testing.T) {
+		raw := map[string]int{"a": 1}
+		dst := ztype.NewMap(raw)
+		ztype.NewMap(map[string]int{"a": 2, "b": 3}).MergeInto(&dst)
+		assert.Equal(t, map[string]int{"a": 2, "b": 3}, raw)
+	})
+
+	t.Run("MergeInto null", func(t *testing.T) {
+		var dst ztype.Map[string, int]
+		ztype.NewNullMap[string, int]().MergeInto(&dst)
+		assert.True(t, dst.IsNull())
+
+		ztype.NewMap(map[string]int{"a": 1}).MergeInto(&dst)
+		assert.False(t, dst.IsNull())
+		assert.Equal(t, 1, dst.Len())
+	})
+
+	t.Run("unmarshaled flag", func(t *testing.T) {
+		var decoded ztype.Map[string, int]
+		require.NoError(t, json.Unmarshal([]byte(`{"a":1}`), &decoded))
+		keep := func(_ string, existing, _ int) int { return existing }
+		assert.True(t, decoded.MergeFunc(keep).Unmarshaled())
+
+		var dst ztype.Map[string, int]
+		decoded.MergeInto(&dst)
+		assert.False(t, dst.Unmarshaled())
+	})
+}
+
+func TestMapClearCopyEmpty(t *testing.T) {
+	t.Run("Clear keeps valid", func(t *testing.T) {
+		m := ztype.NewMap(map[string]int{"a": 1})
+		m.Clear()
+		assert.False(t, m.IsNull())
+		assert.True(t, m.IsEmpty())
+		assert.Equal(t, 0, m.Len())
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{}`, string(data))
+	})
+
+	t.Run("SetNull invalidates", func(t *testing.T) {
+		m := ztype.NewMap(map[string]int{"a": 1})
+		m.SetNull()
+		assert.True(t, m.IsNull())
+		assert.True(t, m.IsEmpty())
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `null`, string(data))
+	})
+
+	t.Run("Clear on null", func(t *testing.T) {
+		var m ztype.Map[string, int]
+		m.Clear()
+		assert.True(t, m.IsNull())
+	})
+
+	t.Run("IsEmpty", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			m       ztype.Map[string, int]
+			isNull  bool
+			isEmpty bool
+			length  int
+		}{
+			{"null", ztype.NewNullMap[string, int](), true, true, 0},
+			{"zero value", ztype.Map[string, int]{}, true, true, 0},
+			{"valid empty", ztype.NewMap(map[string]int{}), false, true, 0},
+			{"valid populated", ztype.NewMap(map[string]int{"a": 1}), false, false, 1},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.isNull, tt.m.IsNull())
+				assert.Equal(t, tt.isEmpty, tt.m.IsEmpty())
+				assert.Equal(t, tt.isEmpty, tt.m.IsZero())
+				assert.Equal(t, tt.length, tt.m.Len())
+			})
+		}
+	})
+
+	t.Run("CopyInto", func(t *testing.T) {
+		dst := map[string]int{"a": 0, "b": 2}
+		ztype.NewMap(map[string]int{"a": 1}).CopyInto(dst)
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, dst)
+
+		ztype.NewNullMap[string, int]().CopyInto(dst)
+		assert.Len(t, dst, 2)
+	})
+}
+
+func TestMapKeySetOperations(t *testing.T) {
+	payload := ztype.NewMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	other := ztype.NewMap(map[string]int{"b": 20, "c": 30, "d": 40})
+	disjoint := ztype.NewMap(map[string]int{"x": 1})
+	null := ztype.NewNullMap[string, int]()
+
+	t.Run("HasAll", func(t *testing.T) {
+		assert.True(t, payload.HasAll("a", "b"))
+		assert.False(t, payload.HasAll("a", "d"))
+		assert.True(t, payload.HasAll())
+		assert.False(t, null.HasAll("a"))
+	})
+
+	t.Run("HasAny", func(t *testing.T) {
+		assert.True(t, payload.HasAny("d", "a"))
+		assert.False(t, payload.HasAny("x", "y"))
+		assert.False(t, payload.HasAny())
+		assert.False(t, null.HasAny("a"))
+	})
+
+	t.Run("MissingKeys", func(t *testing.T) {
+		assert.Equal(t, []string{"e", "d"}, payload.MissingKeys("a", "e", "d"))
+		assert.Equal(t, []string{}, payload.MissingKeys("a"))
+		assert.Equal(t, []string{"a"}, null.MissingKeys("a"))
+	})
+
+	t.Run("IntersectKeys", func(t *testing.T) {
+		assert.Equal(t, map[string]int{"b": 2, "c": 3}, payload.IntersectKeys(other).Get())
+		assert.Empty(t, payload.IntersectKeys(disjoint).Get())
+		assert.Empty(t, payload.IntersectKeys(null).Get())
+		assert.Empty(t, null.IntersectKeys(payload).Get())
+	})
+
+	t.Run("SubtractKeys", func(t *testing.T) {
+		assert.Equal(t, map[string]int{"a": 1}, payload.SubtractKeys(other).Get())
+		assert.Equal(t, payload.Get(), payload.SubtractKeys(disjoint).Get())
+		assert.Equal(t, payload.Get(), payload.SubtractKeys(null).Get())
+		assert.Empty(t, null.SubtractKeys(payload).Get())
+	})
+}
+
+func TestMapInvertAndGroupBy(t *testing.T) {
+	t.Run("Invert", func(t *testing.T) {
+		codes := ztype.MapComparable[string, int]{}
+		codes.Set(map[string]int{"active": 1, "blocked": 2})
+
+		names, err := codes.Invert()
+		require.NoError(t, err)
+		assert.Equal(t, map[int]string{1: "active", 2: "blocked"}, names.Get())
+	})
+
+	t.Run("Invert collision", func(t *testing.T) {
+		codes := ztype.MapComparable[string, int]{}
+		codes.Set(map[string]int{"active": 1, "enabled": 1})
+
+		names, err := codes.Invert()
+		assert.ErrorContains(t, err, "value 1")
+		assert.True(t, names.IsNull())
+	})
+
+	t.Run("Invert null", func(t *testing.T) {
+		names, err := ztype.MapComparable[string, int]{}.Invert()
+		require.NoError(t, err)
+		assert.True(t, names.IsNull())
+	})
+
+	t.Run("GroupBy", func(t *testing.T) {
+		scores := ztype.NewMap(map[string]int{"d": 4, "a": 1, "c": 3, "b": 2, "e": 5})
+		parity := func(_ string, v int) string {
+			if v%2 == 0 {
+				return "even"
+			}
+			return "odd"
+		}
+		for range 20 {
+			groups := ztype.GroupBy(scores, parity)
+			assert.Equal(t, map[string][]int{"even": {2, 4}, "odd": {1, 3, 5}}, groups.Get())
+		}
+	})
+
+	t.Run("GroupBy numeric keys", func(t *testing.T) {
+		names := ztype.NewMap(map[int]string{10: "ten", 2: "two", 1: "one"})
+		groups := ztype.GroupBy(names, func(int, string) string { return "all" })
+		assert.Equal(t, map[string][]string{"all": {"one", "two", "ten"}}, groups.Get())
+	})
+
+	t.Run("GroupBy null", func(t *testing.T) {
+		groups := ztype.GroupBy(ztype.NewNullMap[string, int](), func(k string, _ int) string { return k })
+		assert.True(t, groups.IsNull())
+	})
+}
+
+func TestMapDerivedValidity(t *testing.T) {
+	receivers := []struct {
+		name  string
+		build func() ztype.Map[string, int]
+		valid bool
+	}{
+		{"null", func() ztype.Map[string, int] { return ztype.NewNullMap[string, int]() }, false},
+		{"valid empty", func() ztype.Map[string, int] { return ztype.NewMap(map[string]int{}) }, true},
+		{"valid populated", func() ztype.Map[string, int] { return ztype.NewMap(map[string]int{"a": 1}) }, true},
+	}
+	identity := func(k string, _ int) string { return k }
+	keep := func(string, int) bool { return true }
+
+	derived := map[string]func(ztype.Map[string, int]) ztype.Map[string, int]{
+		"Filter": func(m ztype.Map[string, int]) ztype.Map[string, int] { return m.Filter(keep) },
+		"Clone":  func(m ztype.Map[string, int]) ztype.Map[string, int] { return m.Clone() },
+		"MapValues": func(m ztype.Map[string, int]) ztype.Map[string, int] {
+			return m.MapValues(func(_ string, v int) int { return v })
+		},
+		"IntersectKeys": func(m ztype.Map[string, int]) ztype.Map[string, int] { return m.IntersectKeys(m) },
+		"SubtractKeys":  func(m ztype.Map[string, int]) ztype.Map[string, int] { return m.SubtractKeys(ztype.Map[string, int]{}) },
+		"MapKeys": func(m ztype.Map[string, int]) ztype.Map[string, int] {
+			result, _ := m.MapKeys(identity)
+			return result
+		},
+		"TransformMap": func(m ztype.Map[string, int]) ztype.Map[string, int] {
+			return ztype.TransformMap(m, func(_ string, v int) int { return v })
+		},
+		"Merge": func(m ztype.Map[string, int]) ztype.Map[string, int] {
+			return m.Merge(ztype.NewNullMap[string, int]())
+		},
+		"MergeFunc": func(m ztype.Map[string, int]) ztype.Map[string, int] {
+			return m.MergeFunc(func(_ string, e, _ int) int { return e }, ztype.NewNullMap[string, int]())
+		},
+	}
+
+	for _, receiver := range receivers {
+		for name, derive := range derived {
+			t.Run(receiver.name+"/"+name, func(t *testing.T) {
+				source := receiver.build()
+				result := derive(source)
+				assert.Equal(t, !receiver.valid, result.IsNull())
+				assert.Equal(t, source.Len(), result.Len())
+			})
+		}
+	}
+
+	t.Run("Merge with a valid input", func(t *testing.T) {
+		null := ztype.NewNullMap[string, int]()
+		assert.False(t, null.Merge(ztype.NewMap(map[string]int{})).IsNull())
+		assert.True(t, null.Merge(null, null).IsNull())
+		assert.True(t, null.Merge().IsNull())
+	})
+
+	t.Run("DeleteFunc keeps state", func(t *testing.T) {
+		null := ztype.NewNullMap[string, int]()
+		null.DeleteFunc(keep)
+		assert.True(t, null.IsNull())
+
+		valid := ztype.NewMap(map[string]int{"a": 1})
+		valid.DeleteFunc(keep)
+		assert.False(t, valid.IsNull())
+		assert.Equal(t, 0, valid.Len())
+	})
+}
+
+func TestMapValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Map[string, int]) }, []ztypetest.Case{
+		{Name: "text", Scan: `{"a":1}`, Want: `{"a":1}`},
+		{Name: "bytes", Scan: []byte(`{"a":1}`), Want: `{"a":1}`},
+		{Name: "array", Scan: `[1]`, Err: true},
+		{Name: "int64", Scan: int64(1), Err: true},
+	})
+}
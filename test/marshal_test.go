@@ -0,0 +1,89 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// marshalCases holds non-pointer values, which encoding/json cannot take the
+// address of when they sit inside maps or interfaces.
+func marshalCases() []struct {
+	name     string
+	value    any
+	json     string
+	stringer string
+} {
+	moment := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	return []struct {
+		name     string
+		value    any
+		json     string
+		stringer string
+	}{
+		{"Bool", ztype.NewBool(false), `false`, "false"},
+		{"Byte", ztype.NewByte(7), `7`, "7"},
+		{"Char", ztype.NewChar('A'), `"A"`, "A"},
+		{"Bytes", ztype.NewBytes([]byte("ab")), `"YWI="`, "YWI="},
+		{"String", ztype.NewString("a"), `"a"`, "a"},
+		{"Numeric", ztype.NewNumber(42), `42`, "42"},
+		{"Time", ztype.NewTime(moment), `"2024-03-01T12:00:00Z"`, "2024-03-01T12:00:00Z"},
+		{"Duration", ztype.NewDuration(90 * time.Second), `"1m30s"`, "1m30s"},
+		{"Rune", ztype.NewRune('é'), `"é"`, "é"},
+		{"Enum", enumTestStatusType.MustNew("active"), `"active"`, "active"},
+		{"IP", ztype.NewIP(netip.MustParseAddr("10.0.0.1")), `"10.0.0.1"`, "10.0.0.1"},
+		{"CIDR", ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8")), `"10.0.0.0/8"`, "10.0.0.0/8"},
+		{"RawJSON", ztype.NewRawJSON([]byte(`{"a":1}`)), `{"a":1}`, `{"a":1}`},
+		{"Map", ztype.NewMap(map[string]int{"a": 1}), `{"a":1}`, ""},
+		{"Slice", ztype.NewSlice([]int{1, 2}), `[1,2]`, "[1,2]"},
+		{"Set", ztype.NewSet("a"), `["a"]`, `["a"]`},
+		{"Null", ztype.New(3), `3`, "3"},
+		{"Money", ztype.NewMoney(1990, "BRL"), `{"amount":"19.90","currency":"BRL"}`, "19.90 BRL"},
+		{"NullBool", ztype.NewNullBool(), `null`, "<NULL>"},
+		{"NullString", ztype.NewNullString(), `null`, "<NULL>"},
+		{"NullTime", ztype.NewNullTime(), `null`, "<NULL>"},
+	}
+}
+
+func TestMarshalNonAddressableValues(t *testing.T) {
+	for _, tt := range marshalCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(map[string]any{"v": tt.value})
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"v":`+tt.json+`}`, string(data))
+
+			data, err = json.Marshal([]any{tt.value})
+			require.NoError(t, err)
+			assert.JSONEq(t, `[`+tt.json+`]`, string(data))
+
+			if tt.stringer != "" {
+				assert.Equal(t, tt.stringer, fmt.Sprint(tt.value))
+			}
+		})
+	}
+}
+
+func TestMarshalTextNonAddressableValues(t *testing.T) {
+	data, err := json.Marshal(map[ztype.String]int{ztype.NewString("key"): 1})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":1}`, string(data))
+
+	type payload struct {
+		Flag ztype.Bool `json:"flag,omitzero"`
+	}
+	data, err = json.Marshal(payload{ztype.NewBool(true)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"flag":true}`, string(data))
+
+	data, err = json.Marshal(payload{ztype.NewBool(false)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"flag":false}`, string(data))
+}
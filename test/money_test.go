@@ -0,0 +1,239 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestMoneyParse(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		minor    int64
+		decimal  string
+		wantErr  bool
+	}{
+		{"19.90", "BRL", 1990, "19.90", false},
+		{"19.9", "brl", 1990, "19.90", false},
+		{"19", "USD", 1900, "19.00", false},
+		{"-0.05", "USD", -5, "-0.05", false},
+		{".5", "EUR", 50, "0.50", false},
+		{"1500", "JPY", 1500, "1500", false},
+		{"1.234", "KWD", 1234, "1.234", false},
+		{"19.999", "BRL", 0, "", true},
+		{"1.5", "JPY", 0, "", true},
+		{"19.", "BRL", 0, "", true},
+		{"1,00", "BRL", 0, "", true},
+		{"--1", "BRL", 0, "", true},
+		{"", "BRL", 0, "", true},
+		{"1", "BR", 0, "", true},
+		{"99999999999999999999", "BRL", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			m, err := ztype.ParseMoney(tt.amount, tt.currency)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.minor, m.Amount())
+			assert.Equal(t, tt.decimal, m.Decimal())
+		})
+	}
+
+	assert.Equal(t, "-92233720368547758.08", ztype.NewMoney(math.MinInt64, "USD").Decimal())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	brl := ztype.NewMoney(1000, "BRL")
+	usd := ztype.NewMoney(1000, "USD")
+	null := ztype.NewNullMoney()
+
+	sum, err := brl.Add(ztype.NewMoney(90, "BRL"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.90 BRL", sum.String())
+
+	diff, err := brl.Sub(ztype.NewMoney(1090, "BRL"))
+	require.NoError(t, err)
+	assert.Equal(t, "-0.90 BRL", diff.String())
+
+	product, err := brl.Mult(3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), product.Amount())
+
+	_, err = brl.Add(usd)
+	assert.ErrorContains(t, err, "currency mismatch")
+	_, err = brl.Sub(usd)
+	assert.ErrorContains(t, err, "currency mismatch")
+	_, err = brl.Compare(usd)
+	assert.ErrorContains(t, err, "currency mismatch")
+
+	_, err = ztype.NewMoney(math.MaxInt64, "BRL").Add(ztype.NewMoney(1, "BRL"))
+	assert.Error(t, err)
+	_, err = ztype.NewMoney(math.MaxInt64, "BRL").Mult(2)
+	assert.Error(t, err)
+
+	result, err := brl.Add(null)
+	require.NoError(t, err)
+	assert.True(t, result.IsNull())
+
+	cmp, err := brl.Compare(ztype.NewMoney(1, "BRL"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		ratios   []int
+		expected []int64
+	}{
+		{"even", 900, []int{1, 1, 1}, []int64{300, 300, 300}},
+		{"remainder to first", 100, []int{1, 1, 1}, []int64{34, 33, 33}},
+		{"two leftovers", 1001, []int{1, 1, 1}, []int64{334, 334, 333}},
+		{"weighted", 100, []int{70, 30}, []int64{70, 30}},
+		{"weighted remainder", 5, []int{3, 7}, []int64{2, 3}},
+		{"zero ratio skipped", 5, []int{0, 1, 1}, []int64{0, 3, 2}},
+		{"negative amount", -100, []int{1, 1, 1}, []int64{-34, -33, -33}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := ztype.NewMoney(tt.amount, "BRL").Allocate(tt.ratios...)
+			require.NoError(t, err)
+			total := int64(0)
+			amounts := make([]int64, len(parts))
+			for i, part := range parts {
+				amounts[i] = part.Amount()
+				total += part.Amount()
+				assert.Equal(t, "BRL", part.Currency())
+			}
+			assert.Equal(t, tt.expected, amounts)
+			assert.Equal(t, tt.amount, total)
+		})
+	}
+
+	parts, err := ztype.NewMoney(1000, "BRL").Split(3)
+	require.NoError(t, err)
+	assert.Equal(t, "3.34 BRL", parts[0].String())
+	assert.Equal(t, "3.33 BRL", parts[2].String())
+
+	_, err = ztype.NewMoney(1, "BRL").Split(0)
+	assert.Error(t, err)
+	_, err = ztype.NewMoney(1, "BRL").Allocate(0, 0)
+	assert.Error(t, err)
+	_, err = ztype.NewMoney(1, "BRL").Allocate(-1, 2)
+	assert.Error(t, err)
+	_, err = ztype.NewNullMoney().Split(2)
+	assert.Error(t, err)
+}
+
+func TestMoneyJSON(t *testing.T) {
+	t.Run("object round trip", func(t *testing.T) {
+		data, err := json.Marshal(ztype.NewMoney(1990, "BRL"))
+		require.NoError(t, err)
+		assert.Equal(t, `{"amount":"19.90","currency":"BRL"}`, string(data))
+
+		var m ztype.Money
+		require.NoError(t, json.Unmarshal(data, &m))
+		assert.True(t, m.Unmarshaled())
+		assert.True(t, m.Equal(ztype.NewMoney(1990, "BRL")))
+	})
+
+	t.Run("compact mode", func(t *testing.T) {
+		ztype.SetMoneyJSONCompact(true)
+		defer ztype.SetMoneyJSONCompact(false)
+
+		data, err := json.Marshal(ztype.NewMoney(1500, "JPY"))
+		require.NoError(t, err)
+		assert.Equal(t, `"1500 JPY"`, string(data))
+
+		var m ztype.Money
+		require.NoError(t, json.Unmarshal(data, &m))
+		assert.Equal(t, int64(1500), m.Amount())
+	})
+
+	t.Run("accepted input", func(t *testing.T) {
+		tests := []struct {
+			input   string
+			minor   int64
+			isNull  bool
+			wantErr bool
+		}{
+			{`{"amount":19.9,"currency":"BRL"}`, 1990, false, false},
+			{`"19.90 brl"`, 1990, false, false},
+			{`null`, 0, true, false},
+			{`{"amount":"19.999","currency":"BRL"}`, 0, false, true},
+			{`{"currency":"BRL"}`, 0, false, true},
+			{`"19.90"`, 0, false, true},
+			{`[]`, 0, false, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				var m ztype.Money
+				err := json.Unmarshal([]byte(tt.input), &m)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.isNull, m.IsNull())
+				assert.Equal(t, tt.minor, m.Amount())
+			})
+		}
+	})
+}
+
+func TestMoneyDatabase(t *testing.T) {
+	t.Run("single column", func(t *testing.T) {
+		value, err := ztype.NewMoney(1990, "BRL").Value()
+		require.NoError(t, err)
+		assert.Equal(t, `{"amount":"19.90","currency":"BRL"}`, value)
+
+		var m ztype.Money
+		require.NoError(t, m.Scan([]byte(value.(string))))
+		assert.Equal(t, "19.90 BRL", m.String())
+
+		require.NoError(t, m.Scan(nil))
+		assert.True(t, m.IsNull())
+		value, err = m.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("column pair", func(t *testing.T) {
+		var m ztype.Money
+		require.NoError(t, m.AmountColumn().Scan(int64(1990)))
+		require.NoError(t, m.CurrencyColumn().Scan([]byte("brl")))
+		assert.Equal(t, "19.90 BRL", m.String())
+
+		var amount, currency driver.Valuer = m.AmountColumn(), m.CurrencyColumn()
+		value, err := amount.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1990), value)
+		value, err = currency.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "BRL", value)
+
+		require.NoError(t, m.AmountColumn().Scan(nil))
+		require.NoError(t, m.CurrencyColumn().Scan(nil))
+		assert.True(t, m.IsNull())
+		value, err = m.AmountColumn().Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		assert.Error(t, m.CurrencyColumn().Scan("EURO"))
+		assert.Error(t, m.AmountColumn().Scan(1.5))
+	})
+}
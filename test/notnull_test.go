@@ -0,0 +1,111 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestValueOrZero(t *testing.T) {
+	cases := []struct {
+		name  string
+		null  ztype.ZeroValuer
+		zero  driver.Value
+		valid ztype.ZeroValuer
+	}{
+		{"Bool", ztype.NewNullBool(), false, ztype.NewBool(true)},
+		{"Byte", ztype.NewNullByte(), int64(0), ztype.NewByte(7)},
+		{"Char", ztype.NewNullChar(), int64(0), ztype.NewChar('A')},
+		{"Bytes", ztype.NewNullBytes(), []byte{}, ztype.NewBytes([]byte("x"))},
+		{"String", ztype.NewNullString(), "", ztype.NewString("a")},
+		{"Time", ztype.NewNullTime(), time.Unix(0, 0).UTC(), ztype.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))},
+		{"Duration", ztype.NewNullDuration(), int64(0), ztype.NewDuration(time.Second)},
+		{"Rune", ztype.NewNullRune(), "", ztype.NewRune('é')},
+		{"Enum", quickStatusType.NewNull(), "", quickStatusType.MustNew("active")},
+		{"IP", ztype.NewNullIP(), "0.0.0.0", ztype.NewIP(netip.MustParseAddr("10.0.0.1"))},
+		{"CIDR", ztype.NewNullCIDR(), "0.0.0.0/0", ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8"))},
+		{"RawJSON", ztype.NewNullRawJSON(), []byte("null"), ztype.NewRawJSON(json.RawMessage(`{"a":1}`))},
+		{"Money", ztype.NewNullMoney(), `{"amount":"0.00","currency":""}`, ztype.NewMoney(1990, "BRL")},
+		{"NumericInt", ztype.NewNullNumber[int64](), int64(0), ztype.NewNumber[int64](5)},
+		{"NumericFloat", ztype.NewNullNumber[float64](), float64(0), ztype.NewNumber(1.5)},
+		{"Map", ztype.NewNullMap[string, any](), "{}", ztype.NewMap(map[string]any{"a": 1})},
+		{"OrderedMap", ztype.NewNullOrderedMap[string, int](), "{}", ztype.NewOrderedMap[string, int]()},
+		{"SyncMap", ztype.NewNullSyncMap[string, int](), "{}", ztype.NewSyncMap(map[string]int{"a": 1})},
+		{"Slice", ztype.NewNullSlice[int](), "[]", ztype.NewSlice([]int{1})},
+		{"Set", ztype.NewNullSet[string](), "[]", ztype.NewSet("a")},
+		{"Null", ztype.NewNull[int64](), int64(0), ztype.New[int64](3)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := tc.null.Value()
+			require.NoError(t, err)
+			assert.Nil(t, value)
+
+			zero, err := tc.null.ValueOrZero()
+			require.NoError(t, err)
+			assert.Equal(t, tc.zero, zero)
+
+			column, err := ztype.WithZeroOnNull(tc.null).Value()
+			require.NoError(t, err)
+			assert.Equal(t, tc.zero, column)
+
+			want, err := tc.valid.Value()
+			require.NoError(t, err)
+			got, err := tc.valid.ValueOrZero()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+			got, err = ztype.WithZeroOnNull(tc.valid).Value()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+type notNullUser struct {
+	Name     ztype.String                              `json:"name" db:"name"`
+	Nickname ztype.NotNullColumn[ztype.String]         `json:"nickname" db:"nickname"`
+	Score    ztype.NotNullColumn[ztype.Numeric[int64]] `json:"score" db:"score"`
+}
+
+func TestNotNullColumnJSONAndPresence(t *testing.T) {
+	var user notNullUser
+	require.NoError(t, json.Unmarshal([]byte(`{"name":null,"nickname":null,"score":3}`), &user))
+
+	assert.True(t, user.Nickname.IsNull())
+	assert.True(t, user.Nickname.Unmarshaled())
+	assert.Equal(t, int64(3), user.Score.V.Get())
+
+	data, err := json.Marshal(user)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":null,"nickname":null,"score":3}`, string(data))
+
+	query, args, err := ztype.BuildUpdate("users", &user)
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = NULL, nickname = $1, score = $2", query)
+	require.Len(t, args, 2)
+	nickname, err := args[0].(driver.Valuer).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "", nickname)
+}
+
+func TestNotNullColumnScan(t *testing.T) {
+	var column ztype.NotNullColumn[ztype.String]
+	require.NoError(t, column.Scan("ana"))
+	assert.Equal(t, "ana", column.V.Get())
+
+	require.NoError(t, column.Scan(nil))
+	assert.True(t, column.IsNull())
+	value, err := column.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	column.SetUnmarshaled(true)
+	assert.True(t, column.V.Unmarshaled())
+}
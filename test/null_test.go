@@ -0,0 +1,229 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
+)
+
+type nullTestAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type nullTestProfile struct {
+	Nickname *string          `json:"nickname"`
+	Address  *nullTestAddress `json:"address"`
+	Tags     []string         `json:"tags"`
+}
+
+// nullTestPoint stores itself as "x,y" in the database.
+type nullTestPoint struct {
+	X, Y int
+}
+
+func (p nullTestPoint) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d,%d", p.X, p.Y), nil
+}
+
+func (p *nullTestPoint) Scan(value any) error {
+	text, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	_, err := fmt.Sscanf(strings.TrimSpace(text), "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+func TestNullStruct(t *testing.T) {
+	t.Run("state", func(t *testing.T) {
+		n := ztype.New(nullTestAddress{City: "Lisbon"})
+		assert.False(t, n.IsNull())
+		assert.Equal(t, "Lisbon", n.Get().City)
+
+		n.SetNull()
+		assert.True(t, n.IsNull())
+		assert.Equal(t, nullTestAddress{}, n.Get())
+		assert.Equal(t, "Porto", n.GetOr(nullTestAddress{City: "Porto"}).City)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var payload struct {
+			Home ztype.Null[nullTestAddress] `json:"home"`
+			Work ztype.Null[nullTestAddress] `json:"work"`
+			Old  ztype.Null[nullTestAddress] `json:"old"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"home":{"city":"Lisbon","zip":"1000"},"work":null}`), &payload))
+
+		assert.True(t, payload.Home.Unmarshaled())
+		assert.Equal(t, nullTestAddress{City: "Lisbon", Zip: "1000"}, payload.Home.Get())
+		assert.True(t, payload.Work.Unmarshaled())
+		assert.True(t, payload.Work.IsNull())
+		assert.False(t, payload.Old.Unmarshaled())
+		assert.True(t, payload.Old.IsNull())
+
+		data, err := json.Marshal(payload)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"home":{"city":"Lisbon","zip":"1000"},"work":null,"old":null}`, string(data))
+	})
+
+	t.Run("SQL falls back to JSON", func(t *testing.T) {
+		n := ztype.New(nullTestAddress{City: "Lisbon"})
+		value, err := n.Value()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"city":"Lisbon","zip":""}`, value.(string))
+
+		var scanned ztype.Null[nullTestAddress]
+		require.NoError(t, scanned.Scan([]byte(value.(string))))
+		assert.Equal(t, n.Get(), scanned.Get())
+
+		require.NoError(t, scanned.Scan(nil))
+		assert.True(t, scanned.IsNull())
+		value, err = scanned.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		assert.Error(t, scanned.Scan(42))
+	})
+
+	t.Run("EqualFunc", func(t *testing.T) {
+		sameCity := func(a, b nullTestAddress) bool { return a.City == b.City }
+		a := ztype.New(nullTestAddress{City: "Lisbon", Zip: "1"})
+		b := ztype.New(nullTestAddress{City: "Lisbon", Zip: "2"})
+		assert.True(t, a.EqualFunc(b, sameCity))
+		assert.False(t, a.EqualFunc(ztype.NewNull[nullTestAddress](), sameCity))
+		assert.True(t, ztype.NewNull[nullTestAddress]().EqualFunc(ztype.NewNull[nullTestAddress](), sameCity))
+	})
+}
+
+func TestNullPointers(t *testing.T) {
+	nickname := "zed"
+	n := ztype.New(nullTestProfile{
+		Nickname: &nickname,
+		Address:  &nullTestAddress{City: "Lisbon"},
+	})
+
+	data, err := json.Marshal(n)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"nickname":"zed","address":{"city":"Lisbon","zip":""},"tags":null}`, string(data))
+
+	var decoded ztype.Null[nullTestProfile]
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NotNil(t, decoded.Get().Nickname)
+	assert.Equal(t, "zed", *decoded.Get().Nickname)
+	assert.Equal(t, "Lisbon", decoded.Get().Address.City)
+
+	var pointer ztype.Null[*nullTestAddress]
+	require.NoError(t, json.Unmarshal([]byte(`{"city":"Porto"}`), &pointer))
+	require.NotNil(t, pointer.Get())
+	assert.Equal(t, "Porto", pointer.Get().City)
+}
+
+func TestNullValuer(t *testing.T) {
+	n := ztype.New(nullTestPoint{X: 1, Y: 2})
+	value, err := n.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "1,2", value)
+
+	var scanned ztype.Null[nullTestPoint]
+	require.NoError(t, scanned.Scan("3,4"))
+	assert.Equal(t, nullTestPoint{X: 3, Y: 4}, scanned.Get())
+	assert.Error(t, scanned.Scan([]byte("3,4")))
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.True(t, scanned.IsNull())
+}
+
+func TestNullComparable(t *testing.T) {
+	a := ztype.NewComparable(nullTestPoint{X: 1, Y: 2})
+	b := ztype.NewComparable(nullTestPoint{X: 1, Y: 2})
+	assert.True(t, a.Equal(b))
+	assert.True(t, a.EqualRaw(nullTestPoint{X: 1, Y: 2}))
+	assert.False(t, a.Equal(ztype.NewNullComparable[nullTestPoint]()))
+
+	var scalar ztype.NullComparable[int64]
+	require.NoError(t, scalar.Scan(int64(7)))
+	assert.True(t, scalar.EqualRaw(7))
+	value, err := scalar.Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestNullScanDriverValues(t *testing.T) {
+	t.Run("text into string", func(t *testing.T) {
+		var n ztype.Null[string]
+		require.NoError(t, n.Scan([]byte("hello")))
+		assert.Equal(t, "hello", n.Get())
+		require.NoError(t, n.Scan("world"))
+		assert.Equal(t, "world", n.Get())
+		require.NoError(t, n.Scan(int64(5)))
+		assert.Equal(t, "5", n.Get())
+	})
+
+	t.Run("int64 into integer kinds", func(t *testing.T) {
+		var n ztype.Null[int]
+		require.NoError(t, n.Scan(int64(5)))
+		assert.Equal(t, 5, n.Get())
+		require.NoError(t, n.Scan([]byte("-7")))
+		assert.Equal(t, -7, n.Get())
+
+		var small ztype.Null[int8]
+		assert.ErrorIs(t, small.Scan(int64(300)), &ztype.ErrOverflow{})
+		var unsigned ztype.Null[uint16]
+		assert.ErrorIs(t, unsigned.Scan(int64(-1)), &ztype.ErrOverflow{})
+		assert.Error(t, n.Scan(1.5))
+		assert.Error(t, n.Scan(true))
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		var n ztype.Null[float32]
+		require.NoError(t, n.Scan(1.5))
+		assert.Equal(t, float32(1.5), n.Get())
+		require.NoError(t, n.Scan("2.25"))
+		assert.Equal(t, float32(2.25), n.Get())
+		require.NoError(t, n.Scan(int64(3)))
+		assert.Equal(t, float32(3), n.Get())
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		var n ztype.Null[bool]
+		require.NoError(t, n.Scan("true"))
+		assert.True(t, n.Get())
+		require.NoError(t, n.Scan(int64(0)))
+		assert.False(t, n.Get())
+		assert.Error(t, n.Scan("maybe"))
+	})
+
+	t.Run("bytes are copied", func(t *testing.T) {
+		buffer := []byte("abc")
+		var n ztype.Null[[]byte]
+		require.NoError(t, n.Scan(buffer))
+		buffer[0] = 'x'
+		assert.Equal(t, []byte("abc"), n.Get())
+
+		require.NoError(t, n.Scan("text"))
+		assert.Equal(t, []byte("text"), n.Get())
+
+		var raw ztype.Null[json.RawMessage]
+		require.NoError(t, raw.Scan(buffer))
+		buffer[0] = 'y'
+		assert.Equal(t, json.RawMessage("xbc"), raw.Get())
+	})
+}
+
+func TestNullValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Null[int64]) }, []ztypetest.Case{
+		{Name: "int64", Scan: int64(7), Want: int64(7)},
+		{Name: "text", Scan: "7", Want: int64(7)},
+		{Name: "bytes", Scan: []byte("7"), Want: int64(7)},
+		{Name: "float", Scan: 1.5, Err: true},
+	})
+}
@@ -0,0 +1,49 @@
+package ztype_test
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestNullableHelpers(t *testing.T) {
+	name := ztype.NewString("ana")
+	age := ztype.NewNumber(30)
+	active := ztype.NewBool(true)
+	tags := ztype.NewSet("a")
+	address := ztype.NewIP(netip.MustParseAddr("::1"))
+	created := ztype.NewTime(time.Now())
+	extra := ztype.NewNullMap[string, any]()
+	score := ztype.NewNull[float64]()
+
+	values := []ztype.Nullable{&name, &age, &active, &tags, &address, &created}
+	nulls := []ztype.Nullable{&extra, &score}
+
+	assert.False(t, ztype.AnyNull(values...))
+	assert.False(t, ztype.AllNull(values...))
+	assert.True(t, ztype.AnyNull(append(values, &extra)...))
+	assert.True(t, ztype.AllNull(nulls...))
+
+	assert.False(t, ztype.AnyNull())
+	assert.True(t, ztype.AllNull())
+
+	ztype.SetAllUnmarshaled(true, append(values, nulls...)...)
+	for _, value := range append(values, nulls...) {
+		assert.True(t, value.Unmarshaled())
+	}
+	assert.True(t, name.Unmarshaled(), "the helper must update the original value")
+
+	ztype.SetAllUnmarshaled(false, values...)
+	assert.False(t, age.Unmarshaled())
+	assert.True(t, score.Unmarshaled())
+
+	for _, value := range values {
+		value.SetNull()
+	}
+	assert.True(t, ztype.AllNull(values...))
+	assert.True(t, active.IsNull())
+}
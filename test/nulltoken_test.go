@@ -0,0 +1,129 @@
+package ztype_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhaori96/ztype"
+)
+
+// useNullToken sets the null token for the duration of the test.
+func useNullToken(t *testing.T, token string) {
+	ztype.SetNullToken(token)
+	t.Cleanup(func() { ztype.SetNullToken("<NULL>") })
+}
+
+// nullStringers returns a null value of every type.
+func nullStringers() map[string]interface {
+	fmt.Stringer
+	StringOr(string) string
+} {
+	return map[string]interface {
+		fmt.Stringer
+		StringOr(string) string
+	}{
+		"Bool":     ztype.NewNullBool(),
+		"Byte":     ztype.NewNullByte(),
+		"Char":     ztype.NewNullChar(),
+		"Bytes":    ztype.NewNullBytes(),
+		"String":   ztype.NewNullString(),
+		"Time":     ztype.NewNullTime(),
+		"Duration": ztype.NewNullDuration(),
+		"Rune":     ztype.NewNullRune(),
+		"Enum":     quickStatusType.NewNull(),
+		"IP":       ztype.NewNullIP(),
+		"CIDR":     ztype.NewNullCIDR(),
+		"RawJSON":  ztype.NewNullRawJSON(),
+		"Money":    ztype.NewNullMoney(),
+		"Numeric":  ztype.NewNullNumber[int](),
+		"Null":     ztype.NewNull[string](),
+		"Slice":    ztype.NewNullSlice[int](),
+		"Set":      ztype.NewNullSet[int](),
+	}
+}
+
+func TestNullTokenDefault(t *testing.T) {
+	for name, value := range nullStringers() {
+		assert.Equal(t, "<NULL>", value.String(), name)
+	}
+	assert.Equal(t, "{}", ztype.NewNullMap[string, any]().String())
+}
+
+func TestSetNullToken(t *testing.T) {
+	useNullToken(t, "")
+	for name, value := range nullStringers() {
+		assert.Equal(t, "", value.String(), name)
+		assert.Equal(t, "", fmt.Sprint(value), name)
+	}
+	assert.Equal(t, "", fmt.Sprintf("%d", ztype.NewNullNumber[int]()))
+	assert.Equal(t, "x", ztype.NewString("x").String())
+
+	assert.Equal(t, "{}", ztype.NewNullMap[string, int]().String())
+	assert.Equal(t, "{}", ztype.NewNullOrderedMap[string, int]().String())
+	assert.Equal(t, "{}", ztype.NewNullSyncMap[string, int]().String())
+}
+
+func TestStringOrPrecedence(t *testing.T) {
+	useNullToken(t, "NULL")
+	for name, value := range nullStringers() {
+		assert.Equal(t, "n/a", value.StringOr("n/a"), name)
+	}
+	assert.Equal(t, "n/a", ztype.NewNullMap[string, any]().StringOr("n/a"))
+	assert.Equal(t, "n/a", ztype.NewNullOrderedMap[string, int]().StringOr("n/a"))
+	assert.Equal(t, "n/a", ztype.NewNullSyncMap[string, int]().StringOr("n/a"))
+
+	assert.Equal(t, "true", ztype.NewBool(true).StringOr("n/a"))
+	assert.Equal(t, "A", ztype.NewChar('A').StringOr("n/a"))
+	assert.Equal(t, "1s", ztype.NewDuration(time.Second).StringOr("n/a"))
+	assert.Equal(t, `{"a":1}`, ztype.NewMap(map[string]int{"a": 1}).StringOr("n/a"))
+}
+
+func TestSetNumberFormatter(t *testing.T) {
+	ztype.SetNumberFormatter(func(value any) string {
+		return strings.ReplaceAll(fmt.Sprintf("%.2f", value), ".", ",")
+	})
+	t.Cleanup(func() { ztype.SetNumberFormatter(nil) })
+
+	assert.Equal(t, "1,50", ztype.NewNumber(1.5).String())
+	assert.Equal(t, "1,50", fmt.Sprint(ztype.NewNumber(1.5)))
+	assert.Equal(t, "1.5", fmt.Sprintf("%.1f", ztype.NewNumber(1.5)))
+	assert.Equal(t, "<NULL>", ztype.NewNullNumber[float64]().String())
+
+	data, err := ztype.NewNumber(1.5).MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", string(data))
+
+	ztype.SetNumberFormatter(nil)
+	assert.Equal(t, "1.5", ztype.NewNumber(1.5).String())
+}
+
+func TestNullTokenConcurrentReads(t *testing.T) {
+	t.Cleanup(func() {
+		ztype.SetNullToken("<NULL>")
+		ztype.SetNumberFormatter(nil)
+	})
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				ztype.SetNullToken(fmt.Sprintf("null-%d", i))
+				ztype.SetNumberFormatter(func(value any) string { return fmt.Sprint(value) })
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				assert.NotEmpty(t, ztype.NewNullString().String())
+				assert.NotEmpty(t, ztype.NewNumber(i).String())
+			}
+		}()
+	}
+	wg.Wait()
+}
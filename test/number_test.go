@@ -1,15 +1,19 @@
 package ztype_test
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"math"
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
 )
 
 type numericTestCase struct {
@@ -194,12 +198,12 @@ func testOperations[T ztype.NumberType](t *testing.T, tc numericTestCase) {
 		assert.Equal(t, -1, result)
 
 		_, err = valid.Compare(null)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, ztype.ErrNullValue)
 	})
 
 	t.Run("Division", func(t *testing.T) {
 		_, err := valid.SafeDiv(ztype.NewNumber(tc.zeroVal.(T)))
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, ztype.ErrDivisionByZero)
 	})
 }
 
@@ -337,3 +341,252 @@ func testDatabase[T ztype.NumberType](t *testing.T, tc numericTestCase) {
 		assert.Equal(t, expected, val)
 	})
 }
+
+func TestNumericFloatTextRoundTrip(t *testing.T) {
+	float32Values := []float32{
+		0.1, 16777217, 1e-45, math.SmallestNonzeroFloat32, 1.17549435e-38, 1.1754942e-38,
+		math.MaxFloat32, -0.3, 1.0 / 3, 3.4e-10, 123456.79,
+	}
+	for _, value := range float32Values {
+		text, err := ztype.NewNumber(value).MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, strconv.FormatFloat(float64(value), 'g', -1, 32), string(text))
+
+		var decoded ztype.Numeric[float32]
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, math.Float32bits(value), math.Float32bits(decoded.Get()), string(text))
+	}
+
+	float64Values := []float64{
+		0.1, 16777217, 1e-45, 5e-324, math.SmallestNonzeroFloat64, 2.2250738585072014e-308,
+		math.MaxFloat64, 0.1 + 0.2, 1.0 / 3, 9007199254740993,
+	}
+	for _, value := range float64Values {
+		text, err := ztype.NewNumber(value).MarshalText()
+		assert.NoError(t, err)
+
+		var decoded ztype.Numeric[float64]
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, math.Float64bits(value), math.Float64bits(decoded.Get()), string(text))
+	}
+
+	assert.Equal(t, "0.1", ztype.NewNumber[float32](0.1).String())
+	assert.Equal(t, "1.6777216e+07", ztype.NewNumber[float32](16777217).String())
+
+	var overflow ztype.Numeric[float32]
+	assert.ErrorIs(t, overflow.UnmarshalText([]byte("3.5e38")), &ztype.ErrOverflow{})
+}
+
+func TestNumericDivOr(t *testing.T) {
+	a := ztype.NewNumber(20)
+	assert.Equal(t, ztype.NewNumber(4), a.DivOr(ztype.NewNumber(5), -1))
+	assert.Equal(t, ztype.NewNumber(-1), a.DivOr(ztype.NewNumber(0), -1))
+	assert.Equal(t, ztype.NewNumber(-1), a.DivOr(ztype.NewNullNumber[int](), -1))
+
+	assert.Equal(t, 4, a.DivRawOr(5, -1))
+	assert.Equal(t, -1, a.DivRawOr(0, -1))
+	assert.Equal(t, 2.5, ztype.NewNumber(5.0).DivRawOr(2, 0))
+}
+
+func TestNumericDivPanicIsTyped(t *testing.T) {
+	recoverDiv := func(div func()) (recovered any) {
+		defer func() { recovered = recover() }()
+		div()
+		return nil
+	}
+
+	for name, div := range map[string]func(){
+		"Div zero": func() { ztype.NewNumber(1).Div(ztype.NewNumber(0)) },
+		"Div null": func() { ztype.NewNumber(1).Div(ztype.NewNullNumber[int]()) },
+		"DivRaw":   func() { ztype.NewNumber(1).DivRaw(0) },
+	} {
+		recovered := recoverDiv(div)
+		p, ok := recovered.(*ztype.ErrPanic)
+		if assert.True(t, ok, name) {
+			assert.ErrorIs(t, p, ztype.ErrDivisionByZero, name)
+		}
+	}
+	assert.PanicsWithError(t, "Numeric.DivRaw: cannot divide by zero", func() { ztype.NewNumber(1).DivRaw(0) })
+}
+
+func TestNumericMinMaxIgnoreNull(t *testing.T) {
+	null := ztype.NewNullNumber[int]()
+	assert.Equal(t, 7, null.MinRaw(7))
+	assert.Equal(t, 7, null.MaxRaw(7))
+	assert.Equal(t, ztype.NewNumber(7), null.Min(ztype.NewNumber(7)))
+	assert.Equal(t, ztype.NewNumber(7), ztype.NewNumber(7).Max(null))
+	assert.True(t, null.Min(null).IsNull())
+}
+
+type namedCents int64
+
+// String makes namedCents print as money, which MarshalText must not use.
+func (c namedCents) String() string {
+	return "$" + strconv.FormatInt(int64(c)/100, 10) + "." + strconv.FormatInt(int64(c)%100, 10)
+}
+
+type namedRatio float64
+
+type namedLevel uint8
+
+func TestNumericNamedTypes(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		var n ztype.Numeric[namedCents]
+		for _, src := range []any{int64(1990), "1990", []byte("1990"), 1990.0} {
+			require.NoError(t, n.Scan(src), "%T", src)
+			assert.Equal(t, namedCents(1990), n.Get())
+		}
+		assert.Error(t, n.Scan(19.9))
+
+		value, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1990), value)
+		converted, err := driver.DefaultParameterConverter.ConvertValue(n)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1990), converted)
+
+		assert.Equal(t, "$19.90", n.String(), "String uses the type's own method")
+		text, err := n.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "1990", string(text))
+		require.NoError(t, ztype.RoundTripText(n))
+		require.NoError(t, ztype.RoundTripJSON(n))
+
+		data, err := json.Marshal(n)
+		require.NoError(t, err)
+		assert.Equal(t, "1990", string(data))
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		var n ztype.Numeric[namedRatio]
+		for _, src := range []any{0.25, "0.25", []byte("0.25")} {
+			require.NoError(t, n.Scan(src), "%T", src)
+			assert.Equal(t, namedRatio(0.25), n.Get())
+		}
+		require.NoError(t, n.Scan(int64(2)))
+		assert.Equal(t, namedRatio(2), n.Get())
+
+		n.Set(0.1)
+		value, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, 0.1, value)
+		assert.Equal(t, "0.1", n.String())
+		require.NoError(t, ztype.RoundTripText(n))
+		require.NoError(t, ztype.RoundTripJSON(n))
+	})
+
+	t.Run("uint8", func(t *testing.T) {
+		var n ztype.Numeric[namedLevel]
+		require.NoError(t, n.Scan(int64(200)))
+		assert.Equal(t, namedLevel(200), n.Get())
+		assert.ErrorIs(t, n.Scan(int64(300)), &ztype.ErrOverflow{})
+		assert.Error(t, n.Scan(int64(-1)))
+		assert.Error(t, n.Scan("abc"))
+
+		value, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(200), value)
+
+		assert.ErrorIs(t, n.UnmarshalText([]byte("256")), &ztype.ErrOverflow{})
+		require.NoError(t, n.UnmarshalText([]byte("7")))
+		assert.Equal(t, namedLevel(7), n.Get())
+		require.NoError(t, ztype.RoundTripText(n))
+		require.NoError(t, ztype.RoundTripJSON(n))
+
+		var decoded struct {
+			Level ztype.Numeric[namedLevel] `json:"level"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"level":9}`), &decoded))
+		assert.Equal(t, namedLevel(9), decoded.Level.Get())
+		assert.Error(t, json.Unmarshal([]byte(`{"level":900}`), &decoded))
+	})
+
+	t.Run("formatter sees the underlying type", func(t *testing.T) {
+		var seen any
+		ztype.SetNumberFormatter(func(v any) string { seen = v; return "x" })
+		t.Cleanup(func() { ztype.SetNumberFormatter(nil) })
+
+		n := ztype.NewNumber(namedCents(5))
+		assert.Equal(t, "x", n.String())
+		assert.Equal(t, int64(5), seen)
+		text, err := n.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "5", string(text), "MarshalText ignores the formatter")
+	})
+}
+
+func TestNumericValuerScanner(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Numeric[int64]) }, []ztypetest.Case{
+			{Name: "int64", Scan: int64(math.MaxInt64), Want: int64(math.MaxInt64)},
+			{Name: "text", Scan: "-42", Want: int64(-42)},
+			{Name: "bytes", Scan: []byte("42"), Want: int64(42)},
+			{Name: "fraction", Scan: 1.5, Err: true},
+			{Name: "time", Scan: time.Now(), Err: true},
+		})
+	})
+	t.Run("float64", func(t *testing.T) {
+		ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Numeric[float64]) }, []ztypetest.Case{
+			{Name: "float64", Scan: 1.5, Want: 1.5},
+			{Name: "int64", Scan: int64(2), Want: 2.0},
+			{Name: "text", Scan: "2.25", Want: 2.25},
+			{Name: "time", Scan: time.Now(), Err: true},
+		})
+	})
+}
+
+func TestNumericFloat32Scan(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float32
+	}{
+		{"max", math.MaxFloat32},
+		{"negative max", -math.MaxFloat32},
+		{"smallest nonzero", math.SmallestNonzeroFloat32},
+		{"negative smallest nonzero", -math.SmallestNonzeroFloat32},
+		{"fraction", 0.1},
+		{"zero", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ztype.NewNumber(tt.value).Value()
+			require.NoError(t, err)
+			assert.IsType(t, float64(0), value)
+
+			var n ztype.Numeric[float32]
+			require.NoError(t, n.Scan(value))
+			assert.Equal(t, tt.value, n.Get())
+
+			text := strconv.FormatFloat(float64(tt.value), 'g', -1, 32)
+			var fromText ztype.Numeric[float32]
+			require.NoError(t, fromText.Scan([]byte(text)))
+			assert.Equal(t, tt.value, fromText.Get())
+			require.NoError(t, fromText.Scan(text))
+			assert.Equal(t, tt.value, fromText.Get())
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		for _, value := range []any{math.MaxFloat64, -1e39, "3.5e38", []byte("-1e39")} {
+			n := ztype.NewNumber[float32](1)
+			err := n.Scan(value)
+			assert.ErrorIs(t, err, &ztype.ErrOverflow{}, value)
+			assert.Equal(t, float32(1), n.Get(), value)
+		}
+	})
+
+	t.Run("invalid text", func(t *testing.T) {
+		var n ztype.Numeric[float32]
+		assert.ErrorIs(t, n.Scan("1.5x"), &ztype.ErrInvalidFormat{})
+	})
+
+	t.Run("infinity and other sources", func(t *testing.T) {
+		var n ztype.Numeric[float32]
+		require.NoError(t, n.Scan(math.Inf(1)))
+		assert.True(t, math.IsInf(float64(n.Get()), 1))
+		require.NoError(t, n.Scan(int64(7)))
+		assert.Equal(t, float32(7), n.Get())
+		require.NoError(t, n.Scan(nil))
+		assert.True(t, n.IsNull())
+	})
+}
@@ -0,0 +1,104 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type omitNullMeta struct {
+	CreatedAt ztype.Time   `json:"created_at"`
+	Source    ztype.String `json:"source"`
+}
+
+type omitNullProfile struct {
+	Bio     ztype.String `json:"bio"`
+	Website ztype.String `json:"website"`
+}
+
+type omitNullUser struct {
+	omitNullMeta
+	ID       int                 `json:"id"`
+	Name     ztype.String        `json:"name"`
+	Email    ztype.String        `json:"email"`
+	Age      ztype.Numeric[int]  `json:"age"`
+	Active   ztype.Bool          `json:"active"`
+	Tags     ztype.Slice[string] `json:"tags"`
+	Extra    ztype.JSON          `json:"extra"`
+	Profile  omitNullProfile     `json:"profile"`
+	Manager  *omitNullProfile    `json:"manager"`
+	Note     string              `json:"note,omitempty"`
+	Seen     time.Time           `json:"seen,omitzero"`
+	Internal ztype.String        `json:"-"`
+	Plain    ztype.String
+}
+
+func TestMarshalOmitNull(t *testing.T) {
+	user := omitNullUser{
+		omitNullMeta: omitNullMeta{Source: ztype.NewString("api")},
+		ID:           1,
+		Name:         ztype.NewString("Ana"),
+		Email:        ztype.NewNullString(),
+		Age:          ztype.NewNumber(0),
+		Active:       ztype.NewBool(false),
+		Tags:         ztype.NewNullSlice[string](),
+		Profile:      omitNullProfile{Bio: ztype.NewString("")},
+		Internal:     ztype.NewString("hidden"),
+	}
+
+	data, err := ztype.MarshalOmitNull(user)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"source":"api","id":1,"name":"Ana","age":0,"active":false,"profile":{"bio":""},"manager":null}`,
+		string(data))
+
+	pointerData, err := ztype.MarshalOmitNull(&user)
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(pointerData))
+
+	standard, err := json.Marshal(user)
+	require.NoError(t, err)
+	assert.Contains(t, string(standard), `"email":null`, "json.Marshal keeps writing explicit nulls")
+}
+
+func TestMarshalOmitNullAllPopulated(t *testing.T) {
+	seen := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	user := omitNullUser{
+		Name:    ztype.NewString("Ana"),
+		Tags:    ztype.NewSlice([]string{"a"}),
+		Extra:   ztype.NewMap(map[string]any{"k": 1}),
+		Manager: &omitNullProfile{Website: ztype.NewString("https://example.com")},
+		Note:    "hi",
+		Seen:    seen,
+		Plain:   ztype.NewString("p"),
+	}
+
+	data, err := ztype.MarshalOmitNull(user)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 0,
+		"name": "Ana",
+		"tags": ["a"],
+		"extra": {"k": 1},
+		"profile": {},
+		"manager": {"website": "https://example.com"},
+		"note": "hi",
+		"seen": "2024-03-01T00:00:00Z",
+		"Plain": "p"
+	}`, string(data))
+}
+
+func TestMarshalOmitNullNonStruct(t *testing.T) {
+	data, err := ztype.MarshalOmitNull(ztype.NewNullString())
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(data))
+
+	data, err = ztype.MarshalOmitNull([]int{1})
+	require.NoError(t, err)
+	assert.Equal(t, `[1]`, string(data))
+}
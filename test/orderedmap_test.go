@@ -0,0 +1,112 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func orderedTestDocument() string {
+	values := []string{`"text"`, `12`, `true`, `null`, `[1,"a",false]`, `1.5`, `{"nested":"x"}`, `-3`}
+	entries := make([]string, 0, 24)
+	for i := range 24 {
+		key := fmt.Sprintf("k%02d", (i*7)%24)
+		entries = append(entries, fmt.Sprintf("%q:%s", key, values[i%len(values)]))
+	}
+	return "{" + strings.Join(entries, ",") + "}"
+}
+
+func TestOrderedMap(t *testing.T) {
+	t.Run("insertion order", func(t *testing.T) {
+		var m ztype.OrderedMap[string, int]
+		m.SetItem("c", 3)
+		m.SetItem("a", 1)
+		m.SetItem("b", 2)
+		m.SetItem("c", 30)
+
+		assert.Equal(t, []string{"c", "a", "b"}, slices.Collect(m.Keys()))
+		assert.Equal(t, []int{30, 1, 2}, slices.Collect(m.Values()))
+
+		value, ok := m.DeleteItem("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+		assert.Equal(t, []string{"c", "b"}, slices.Collect(m.Keys()))
+		assert.Equal(t, 2, m.Len())
+		assert.False(t, m.Has("a"))
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{"c":30,"b":2}`, string(data))
+	})
+
+	t.Run("byte-for-byte round trip", func(t *testing.T) {
+		document := orderedTestDocument()
+
+		var m ztype.OrderedMap[string, json.RawMessage]
+		require.NoError(t, json.Unmarshal([]byte(document), &m))
+		assert.True(t, m.Unmarshaled())
+		assert.Equal(t, 24, m.Len())
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, document, string(data))
+
+		var dynamic ztype.OrderedMap[string, any]
+		require.NoError(t, json.Unmarshal([]byte(document), &dynamic))
+		data, err = json.Marshal(dynamic)
+		require.NoError(t, err)
+		assert.Equal(t, document, string(data))
+	})
+
+	t.Run("Scan and Value keep order", func(t *testing.T) {
+		document := orderedTestDocument()
+
+		var m ztype.OrderedMap[string, any]
+		require.NoError(t, m.Scan([]byte(document)))
+		assert.False(t, m.Unmarshaled())
+
+		value, err := m.Value()
+		require.NoError(t, err)
+		assert.Equal(t, document, value)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var m ztype.OrderedMap[string, int]
+		require.NoError(t, json.Unmarshal([]byte(`null`), &m))
+		assert.True(t, m.IsNull())
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `null`, string(data))
+
+		require.NoError(t, m.Scan(nil))
+		value, err := m.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("int keys", func(t *testing.T) {
+		var m ztype.OrderedMap[int, string]
+		require.NoError(t, json.Unmarshal([]byte(`{"10":"j","2":"b"}`), &m))
+		assert.Equal(t, []int{10, 2}, slices.Collect(m.Keys()))
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{"10":"j","2":"b"}`, string(data))
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		var m ztype.OrderedMap[string, int]
+		assert.Error(t, json.Unmarshal([]byte(`[1,2]`), &m))
+		assert.Error(t, json.Unmarshal([]byte(`{"a":"x"}`), &m))
+		assert.Error(t, m.Scan(`{"a":1} {}`))
+		assert.Error(t, m.Scan(42))
+	})
+}
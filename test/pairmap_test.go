@@ -0,0 +1,94 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestPairMapUnmarshalJSON(t *testing.T) {
+	var m ztype.PairMap[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`[{"key":"a","value":1},{"value":2,"key":"b"},{"key":"c"}]`), &m))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 0}, m.Get())
+	assert.True(t, m.Unmarshaled())
+
+	var object ztype.PairMap[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`{"a":1}`), &object))
+	assert.Equal(t, map[string]int{"a": 1}, object.Get())
+
+	var null ztype.PairMap[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`null`), &null))
+	assert.True(t, null.IsNull())
+
+	var ints ztype.PairMap[int, string]
+	require.NoError(t, json.Unmarshal([]byte(`[{"key":1,"value":"a"},{"key":"2","value":"b"}]`), &ints))
+	assert.Equal(t, map[int]string{1: "a", 2: "b"}, ints.Get())
+}
+
+func TestPairMapMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(ztype.NewPairMap(map[string]int{"b": 2, "a": 1}))
+	require.NoError(t, err)
+	assert.Equal(t, `[{"key":"a","value":1},{"key":"b","value":2}]`, string(data))
+
+	data, err = json.Marshal(ztype.NewPairMap(map[string]int{}))
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+
+	data, err = json.Marshal(ztype.NewNullPairMap[string, int]())
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(data))
+
+	in := ztype.NewPairMap(map[int]ztype.String{10: ztype.NewString("x"), 2: ztype.NewNullString()})
+	data, err = json.Marshal(in)
+	require.NoError(t, err)
+	var out ztype.PairMap[int, ztype.String]
+	require.NoError(t, json.Unmarshal(data, &out))
+	first, second := out.Get()[10], out.Get()[2]
+	assert.Equal(t, "x", first.Get())
+	assert.True(t, second.IsNull())
+}
+
+func TestPairMapFieldNames(t *testing.T) {
+	ztype.SetPairMapFields("name", "val")
+	defer ztype.SetPairMapFields("", "")
+
+	data, err := json.Marshal(ztype.NewPairMap(map[string]bool{"on": true}))
+	require.NoError(t, err)
+	assert.Equal(t, `[{"name":"on","val":true}]`, string(data))
+
+	var m ztype.PairMap[string, bool]
+	require.NoError(t, json.Unmarshal(data, &m))
+	assert.Equal(t, map[string]bool{"on": true}, m.Get())
+
+	err = json.Unmarshal([]byte(`[{"key":"on","value":true}]`), &m)
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+	assert.ErrorContains(t, err, `no "name" field`)
+}
+
+func TestPairMapErrors(t *testing.T) {
+	m := ztype.NewPairMap(map[string]int{"kept": 1})
+	err := json.Unmarshal([]byte(`[{"key":"a","value":1},{"key":"a","value":2}]`), &m)
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+	assert.ErrorContains(t, err, "duplicate pair key a")
+	assert.Equal(t, map[string]int{"kept": 1}, m.Get())
+
+	var ints ztype.PairMap[int, int]
+	assert.ErrorIs(t, json.Unmarshal([]byte(`[{"key":1,"value":1},{"key":"01","value":2}]`), &ints), &ztype.ErrInvalidFormat{})
+	assert.ErrorIs(t, json.Unmarshal([]byte(`[{"key":"x","value":1}]`), &ints), &ztype.ErrInvalidFormat{})
+	assert.Error(t, json.Unmarshal([]byte(`[{"key":1,"value":"x"}]`), &ints))
+	assert.Error(t, json.Unmarshal([]byte(`[1,2]`), &ints))
+}
+
+func TestPairMapScan(t *testing.T) {
+	var m ztype.PairMap[string, int]
+	require.NoError(t, m.Scan([]byte(`[{"key":"a","value":1}]`)))
+	assert.Equal(t, map[string]int{"a": 1}, m.Get())
+	require.NoError(t, m.Scan(`{"b":2}`))
+	assert.Equal(t, map[string]int{"b": 2}, m.Get())
+	require.NoError(t, m.Scan(nil))
+	assert.True(t, m.IsNull())
+}
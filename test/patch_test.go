@@ -0,0 +1,111 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type patchAudit struct {
+	UpdatedBy ztype.String `json:"updated_by"`
+	UpdatedAt ztype.Time   `json:"updated_at"`
+}
+
+type patchAddress struct {
+	City    ztype.String `json:"city"`
+	Country ztype.String `json:"country"`
+}
+
+type patchUserRequest struct {
+	patchAudit
+	Name     ztype.String       `json:"name"`
+	Email    ztype.String       `json:"email,omitempty"`
+	Age      ztype.Numeric[int] `json:"age"`
+	Active   ztype.Bool         `json:"active"`
+	Timeout  ztype.Duration     `json:"timeout"`
+	Tags     ztype.Set[string]  `json:"tags"`
+	Settings ztype.JSON         `json:"settings"`
+	Address  patchAddress       `json:"address"`
+	Billing  *patchAddress      `json:"billing"`
+	Internal ztype.String       `json:"-"`
+	Note     string             `json:"note"`
+	Nickname ztype.Null[string] `json:"nickname"`
+	Legacy   ztype.String
+}
+
+func TestChangedFields(t *testing.T) {
+	input := `{
+		"name": "Ana",
+		"email": null,
+		"active": false,
+		"tags": ["a"],
+		"settings": null,
+		"updated_by": "admin",
+		"address": {"city": "Recife"},
+		"billing": {"country": null},
+		"Internal": "x",
+		"note": "plain fields are skipped",
+		"Legacy": "kept"
+	}`
+
+	var req patchUserRequest
+	require.NoError(t, json.Unmarshal([]byte(input), &req))
+
+	changed, err := ztype.ChangedFields(&req)
+	require.NoError(t, err)
+	fields := changed.Get()
+
+	name, ok := fields["name"].(ztype.String)
+	require.True(t, ok, "valued fields keep their ztype value")
+	assert.Equal(t, "Ana", name.Get())
+	assert.Contains(t, fields, "email")
+	assert.Nil(t, fields["email"], "explicit null is kept as nil")
+	assert.IsType(t, ztype.Bool{}, fields["active"])
+	assert.Nil(t, fields["settings"])
+	assert.IsType(t, map[string]any{}, fields["address"])
+	assert.Equal(t, map[string]any{"country": nil}, fields["billing"])
+	assert.Contains(t, fields, "updated_by", "embedded fields are flattened")
+	assert.Contains(t, fields, "Legacy")
+
+	for _, absent := range []string{"age", "timeout", "nickname", "updated_at", "Internal", "note", "patchAudit"} {
+		assert.NotContains(t, fields, absent)
+	}
+
+	data, err := json.Marshal(changed)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "Ana",
+		"email": null,
+		"active": false,
+		"tags": ["a"],
+		"settings": null,
+		"updated_by": "admin",
+		"address": {"city": "Recife"},
+		"billing": {"country": null},
+		"Legacy": "kept"
+	}`, string(data))
+}
+
+func TestChangedFieldsNothingPresent(t *testing.T) {
+	req := patchUserRequest{Name: ztype.NewString("set in code")}
+	req.UpdatedAt = ztype.NewTime(time.Now())
+
+	changed, err := ztype.ChangedFields(req)
+	require.NoError(t, err)
+	assert.False(t, changed.IsNull())
+	assert.Equal(t, 0, changed.Len())
+}
+
+func TestChangedFieldsInvalidInput(t *testing.T) {
+	_, err := ztype.ChangedFields(42)
+	assert.Error(t, err)
+
+	var req *patchUserRequest
+	_, err = ztype.ChangedFields(req)
+	assert.Error(t, err)
+}
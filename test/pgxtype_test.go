@@ -0,0 +1,138 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/pgxtype"
+)
+
+func newPgxTypeMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	pgxtype.Register(m)
+	return m
+}
+
+func TestPgxTypeRoundTrip(t *testing.T) {
+	m := newPgxTypeMap()
+	moment := time.Date(2024, 3, 1, 12, 30, 45, 123456000, time.UTC)
+
+	tests := []struct {
+		name   string
+		oid    uint32
+		value  any
+		target func() any
+		equal  func(t *testing.T, target any)
+	}{
+		{"bool", pgtype.BoolOID, ztype.NewBool(false), func() any { return &ztype.Bool{} }, func(t *testing.T, target any) {
+			assert.True(t, target.(*ztype.Bool).Equal(ztype.NewBool(false)))
+		}},
+		{"text", pgtype.TextOID, ztype.NewString("ação"), func() any { return &ztype.String{} }, func(t *testing.T, target any) {
+			assert.Equal(t, "ação", target.(*ztype.String).Get())
+		}},
+		{"uuid", pgtype.UUIDOID, ztype.NewString("5b1b3e7a-8f0f-4b7e-9a3e-1f2d3c4b5a69"), func() any { return &ztype.String{} }, func(t *testing.T, target any) {
+			assert.Equal(t, "5b1b3e7a-8f0f-4b7e-9a3e-1f2d3c4b5a69", target.(*ztype.String).Get())
+		}},
+		{"int8", pgtype.Int8OID, ztype.NewNumber[int64](-9007199254740993), func() any { return &ztype.Numeric[int64]{} }, func(t *testing.T, target any) {
+			assert.Equal(t, int64(-9007199254740993), target.(*ztype.Numeric[int64]).Get())
+		}},
+		{"int4", pgtype.Int4OID, ztype.NewNumber(42), func() any { return &ztype.Numeric[int]{} }, func(t *testing.T, target any) {
+			assert.Equal(t, 42, target.(*ztype.Numeric[int]).Get())
+		}},
+		{"float8", pgtype.Float8OID, ztype.NewNumber(0.1), func() any { return &ztype.Numeric[float64]{} }, func(t *testing.T, target any) {
+			assert.Equal(t, 0.1, target.(*ztype.Numeric[float64]).Get())
+		}},
+		{"numeric", pgtype.NumericOID, ztype.NewNumber(19.9), func() any { return &ztype.Numeric[float64]{} }, func(t *testing.T, target any) {
+			assert.Equal(t, 19.9, target.(*ztype.Numeric[float64]).Get())
+		}},
+		{"timestamptz", pgtype.TimestamptzOID, ztype.NewTime(moment), func() any { return &ztype.Time{} }, func(t *testing.T, target any) {
+			assert.True(t, target.(*ztype.Time).Get().Equal(moment))
+		}},
+		{"date", pgtype.DateOID, ztype.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), func() any { return &ztype.Time{} }, func(t *testing.T, target any) {
+			assert.Equal(t, "2024-03-01", target.(*ztype.Time).Get().Format(time.DateOnly))
+		}},
+		{"interval", pgtype.IntervalOID, ztype.NewDuration(90*time.Minute + 5*time.Microsecond), func() any { return &ztype.Duration{} }, func(t *testing.T, target any) {
+			assert.Equal(t, 90*time.Minute+5*time.Microsecond, target.(*ztype.Duration).Get())
+		}},
+		{"bytea", pgtype.ByteaOID, ztype.NewBytes([]byte{0, 1, 255}), func() any { return &ztype.Bytes{} }, func(t *testing.T, target any) {
+			assert.Equal(t, []byte{0, 1, 255}, target.(*ztype.Bytes).Get())
+		}},
+		{"jsonb raw", pgtype.JSONBOID, ztype.NewRawJSON(json.RawMessage(`{"a":1}`)), func() any { return &ztype.RawJSON{} }, func(t *testing.T, target any) {
+			assert.JSONEq(t, `{"a":1}`, string(target.(*ztype.RawJSON).Get()))
+		}},
+		{"jsonb map", pgtype.JSONBOID, ztype.NewMap(map[string]any{"a": "b"}), func() any { return &ztype.JSON{} }, func(t *testing.T, target any) {
+			assert.Equal(t, map[string]any{"a": "b"}, target.(*ztype.JSON).Get())
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			t.Run(tt.name+map[int16]string{0: "/text", 1: "/binary"}[format], func(t *testing.T) {
+				buf, err := m.Encode(tt.oid, format, tt.value, nil)
+				require.NoError(t, err)
+				require.NotNil(t, buf)
+
+				target := tt.target()
+				require.NoError(t, m.Scan(tt.oid, format, buf, target))
+				assert.False(t, target.(ztype.Nullable).IsNull())
+				tt.equal(t, target)
+			})
+		}
+	}
+}
+
+func TestPgxTypeNull(t *testing.T) {
+	m := newPgxTypeMap()
+
+	tests := []struct {
+		name   string
+		oid    uint32
+		value  any
+		target ztype.Nullable
+	}{
+		{"bool", pgtype.BoolOID, ztype.NewNullBool(), &ztype.Bool{}},
+		{"text", pgtype.TextOID, ztype.NewNullString(), &ztype.String{}},
+		{"int8", pgtype.Int8OID, ztype.NewNullNumber[int64](), &ztype.Numeric[int64]{}},
+		{"timestamptz", pgtype.TimestamptzOID, ztype.NewNullTime(), &ztype.Time{}},
+		{"interval", pgtype.IntervalOID, ztype.NewNullDuration(), &ztype.Duration{}},
+		{"jsonb", pgtype.JSONBOID, ztype.NewNullMap[string, any](), &ztype.JSON{}},
+	}
+
+	for _, tt := range tests {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			t.Run(tt.name, func(t *testing.T) {
+				buf, err := m.Encode(tt.oid, format, tt.value, nil)
+				require.NoError(t, err)
+				assert.Nil(t, buf, "null must be sent as SQL NULL")
+
+				tt.target.SetUnmarshaled(false)
+				require.NoError(t, m.Scan(tt.oid, format, nil, tt.target))
+				assert.True(t, tt.target.IsNull())
+			})
+		}
+	}
+}
+
+func TestPgxTypePointerAndUnknownOID(t *testing.T) {
+	m := newPgxTypeMap()
+
+	value := ztype.NewTime(time.Unix(1700000000, 0).UTC())
+	buf, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, &value, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, buf)
+
+	buf, err = m.Encode(0, pgtype.TextFormatCode, ztype.NewString("plain"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(buf))
+
+	var existing ztype.String
+	existing.Set("stale")
+	require.NoError(t, m.Scan(pgtype.TextOID, pgtype.TextFormatCode, nil, &existing))
+	assert.True(t, existing.IsNull())
+}
@@ -0,0 +1,90 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type presenceRequest struct {
+	patchAudit
+	Name     ztype.String       `json:"name"`
+	Email    ztype.String       `json:"email,omitempty"`
+	Age      ztype.Numeric[int] `json:"age"`
+	Active   ztype.Bool         `json:"active"`
+	Timeout  ztype.Duration     `json:"timeout"`
+	Tags     ztype.Set[string]  `json:"tags"`
+	Address  patchAddress       `json:"address"`
+	Billing  *patchAddress      `json:"billing"`
+	Manager  *ztype.String      `json:"manager"`
+	Internal ztype.String       `json:"-"`
+	Note     string             `json:"note"`
+	Legacy   ztype.String
+}
+
+func TestPresenceMap(t *testing.T) {
+	input := `{
+		"name": "Ana",
+		"email": null,
+		"active": false,
+		"tags": ["a"],
+		"updated_by": null,
+		"address": {"city": "Recife"},
+		"manager": "Bia",
+		"Internal": "x",
+		"note": "plain fields are skipped",
+		"Legacy": "kept"
+	}`
+
+	var req presenceRequest
+	require.NoError(t, json.Unmarshal([]byte(input), &req))
+
+	presence, err := ztype.PresenceMap(&req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ztype.Presence{
+		"updated_by":      ztype.PresenceNull,
+		"updated_at":      ztype.PresenceAbsent,
+		"name":            ztype.PresenceValue,
+		"email":           ztype.PresenceNull,
+		"age":             ztype.PresenceAbsent,
+		"active":          ztype.PresenceValue,
+		"timeout":         ztype.PresenceAbsent,
+		"tags":            ztype.PresenceValue,
+		"address.city":    ztype.PresenceValue,
+		"address.country": ztype.PresenceAbsent,
+		"billing.city":    ztype.PresenceAbsent,
+		"billing.country": ztype.PresenceAbsent,
+		"manager":         ztype.PresenceValue,
+		"Legacy":          ztype.PresenceValue,
+	}, presence)
+
+	t.Run("by value", func(t *testing.T) {
+		fromValue, err := ztype.PresenceMap(req)
+		require.NoError(t, err)
+		assert.Equal(t, presence, fromValue)
+	})
+
+	t.Run("logs as text", func(t *testing.T) {
+		data, err := json.Marshal(map[string]ztype.Presence{
+			"a": ztype.PresenceAbsent,
+			"b": ztype.PresenceNull,
+			"c": ztype.PresenceValue,
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a":"absent","b":"null","c":"value"}`, string(data))
+		assert.Equal(t, "Presence(7)", ztype.Presence(7).String())
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := ztype.PresenceMap(42)
+		assert.EqualError(t, err, "expected a struct, got int")
+
+		var nilReq *presenceRequest
+		_, err = ztype.PresenceMap(nilReq)
+		assert.EqualError(t, err, "expected a struct, got nil *ztype_test.presenceRequest")
+	})
+}
@@ -0,0 +1,120 @@
+package ztype_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type quickStatus string
+
+var quickStatusType = ztype.NewEnumType[quickStatus]("active", "blocked", "deleted")
+
+// checkQuickJSON runs RoundTripJSON on generated values of T.
+func checkQuickJSON[T json.Marshaler](t *testing.T) {
+	t.Helper()
+	err := quick.Check(func(value T) bool {
+		if err := ztype.RoundTripJSON(value); err != nil {
+			t.Log(err)
+			return false
+		}
+		return true
+	}, nil)
+	require.NoError(t, err)
+}
+
+// checkQuickText runs RoundTripText on generated values of T.
+func checkQuickText[T encoding.TextMarshaler](t *testing.T) {
+	t.Helper()
+	err := quick.Check(func(value T) bool {
+		if err := ztype.RoundTripText(value); err != nil {
+			t.Log(err)
+			return false
+		}
+		return true
+	}, nil)
+	require.NoError(t, err)
+}
+
+func TestQuickGenerateMixesNullAndValid(t *testing.T) {
+	var nulls, valid int
+	err := quick.Check(func(s ztype.String) bool {
+		if s.IsNull() {
+			nulls++
+		} else {
+			valid++
+		}
+		return true
+	}, &quick.Config{MaxCount: 400})
+	require.NoError(t, err)
+	require.Positive(t, nulls)
+	require.Positive(t, valid)
+}
+
+func TestQuickRoundTripJSON(t *testing.T) {
+	t.Run("Bool", checkQuickJSON[ztype.Bool])
+	t.Run("Byte", checkQuickJSON[ztype.Byte])
+	t.Run("Char", checkQuickJSON[ztype.Char])
+	t.Run("Bytes", checkQuickJSON[ztype.Bytes])
+	t.Run("String", checkQuickJSON[ztype.String])
+	t.Run("Time", checkQuickJSON[ztype.Time])
+	t.Run("Duration", checkQuickJSON[ztype.Duration])
+	t.Run("Rune", checkQuickJSON[ztype.Rune])
+	t.Run("IP", checkQuickJSON[ztype.IP])
+	t.Run("CIDR", checkQuickJSON[ztype.CIDR])
+	t.Run("RawJSON", checkQuickJSON[ztype.RawJSON])
+	t.Run("Money", checkQuickJSON[ztype.Money])
+	t.Run("Enum", checkQuickJSON[ztype.Enum[quickStatus]])
+	t.Run("NumericInt", checkQuickJSON[ztype.Numeric[int]])
+	t.Run("NumericUint8", checkQuickJSON[ztype.Numeric[uint8]])
+	t.Run("NumericFloat64", checkQuickJSON[ztype.Numeric[float64]])
+	t.Run("Null", checkQuickJSON[ztype.Null[string]])
+	t.Run("NullComparable", checkQuickJSON[ztype.NullComparable[int]])
+	t.Run("Slice", checkQuickJSON[ztype.Slice[int]])
+	t.Run("SliceComparable", checkQuickJSON[ztype.SliceComparable[string]])
+	t.Run("Set", checkQuickJSON[ztype.Set[string]])
+	t.Run("JSON", checkQuickJSON[ztype.JSON])
+	t.Run("MapComparable", checkQuickJSON[ztype.MapComparable[string, int]])
+	t.Run("OrderedMap", checkQuickJSON[ztype.OrderedMap[string, float64]])
+	t.Run("SyncMap", checkQuickJSON[*ztype.SyncMap[string, int]])
+}
+
+func TestQuickRoundTripText(t *testing.T) {
+	t.Run("Bool", checkQuickText[ztype.Bool])
+	t.Run("Byte", checkQuickText[ztype.Byte])
+	t.Run("Bytes", checkQuickText[ztype.Bytes])
+	t.Run("String", checkQuickText[ztype.String])
+	t.Run("Time", checkQuickText[ztype.Time])
+	t.Run("Duration", checkQuickText[ztype.Duration])
+	t.Run("Rune", checkQuickText[ztype.Rune])
+	t.Run("IP", checkQuickText[ztype.IP])
+	t.Run("CIDR", checkQuickText[ztype.CIDR])
+	t.Run("Enum", checkQuickText[ztype.Enum[quickStatus]])
+	t.Run("Numeric", checkQuickText[ztype.Numeric[int64]])
+	t.Run("JSON", checkQuickText[ztype.JSON])
+}
+
+func TestQuickRoundTripSQL(t *testing.T) {
+	check := func(property any) func(t *testing.T) {
+		return func(t *testing.T) {
+			require.NoError(t, quick.Check(property, nil))
+		}
+	}
+	t.Run("Bool", check(func(v ztype.Bool) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("Byte", check(func(v ztype.Byte) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("Bytes", check(func(v ztype.Bytes) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("String", check(func(v ztype.String) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("Time", check(func(v ztype.Time) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("Duration", check(func(v ztype.Duration) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("Rune", check(func(v ztype.Rune) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("IP", check(func(v ztype.IP) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("CIDR", check(func(v ztype.CIDR) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("Money", check(func(v ztype.Money) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("Numeric", check(func(v ztype.Numeric[int64]) bool { return ztype.RoundTripSQL(v) == nil }))
+	t.Run("JSON", check(func(v ztype.JSON) bool { return ztype.RoundTripSQL(v) == nil }))
+}
@@ -0,0 +1,232 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		literal string
+		inside  []int
+		outside []int
+	}{
+		{"[1,10]", []int{1, 5, 10}, []int{0, 11}},
+		{"[1,10)", []int{1, 9}, []int{0, 10}},
+		{"(1,10]", []int{2, 10}, []int{1, 11}},
+		{"(1,10)", []int{2, 9}, []int{1, 10}},
+		{"[5,)", []int{5, math.MaxInt}, []int{4}},
+		{"(,5]", []int{math.MinInt, 5}, []int{6}},
+		{"(,)", []int{math.MinInt, 0, math.MaxInt}, nil},
+		{"[5,5]", []int{5}, []int{4, 6}},
+		{"empty", nil, []int{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.literal, func(t *testing.T) {
+			r, err := ztype.ParseRange[int](tt.literal)
+			require.NoError(t, err)
+			for _, v := range tt.inside {
+				assert.True(t, r.Contains(ztype.NewNumber(v)), "%d in %s", v, tt.literal)
+			}
+			for _, v := range tt.outside {
+				assert.False(t, r.Contains(ztype.NewNumber(v)), "%d in %s", v, tt.literal)
+			}
+			assert.False(t, r.Contains(ztype.NewNullNumber[int]()))
+		})
+	}
+	assert.False(t, ztype.NewNullRange[int]().Contains(ztype.NewNumber(1)))
+}
+
+func TestRangeBounds(t *testing.T) {
+	r := ztype.NewRange(ztype.NewNumber(1), ztype.NewNullNumber[int]()).WithBounds(false, true)
+	lower, ok := r.Lower().Val()
+	assert.True(t, ok)
+	assert.Equal(t, 1, lower)
+	assert.True(t, r.Upper().IsNull())
+	assert.False(t, r.LowerInclusive())
+	assert.False(t, r.UpperInclusive(), "an unbounded side is never inclusive")
+	assert.Equal(t, "(1,)", r.String())
+}
+
+func TestRangeIsEmpty(t *testing.T) {
+	tests := []struct {
+		literal string
+		integer bool
+		float   bool
+	}{
+		{"[1,10)", false, false},
+		{"[5,5]", false, false},
+		{"[5,5)", true, true},
+		{"(5,5]", true, true},
+		{"(1,2)", true, false},
+		{"(1,2]", false, false},
+		{"(1,3)", false, false},
+		{"[1,)", false, false},
+		{"empty", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.literal, func(t *testing.T) {
+			assert.Equal(t, tt.integer, ztype.MustParseRange[int64](tt.literal).IsEmpty())
+			assert.Equal(t, tt.float, ztype.MustParseRange[float64](tt.literal).IsEmpty())
+		})
+	}
+
+	assert.True(t, ztype.NewNullRange[int]().IsEmpty())
+	assert.True(t, ztype.Range[int]{}.IsZero())
+	assert.True(t, ztype.NewRange(ztype.NewNumber(10), ztype.NewNumber(1)).IsEmpty())
+	assert.True(t, ztype.MustParseRange[uint8]("(255,255]").IsEmpty())
+	assert.True(t, ztype.MustParseRange[int8]("[-128,-128)").IsEmpty())
+}
+
+func TestRangeIntersectAndOverlaps(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		intersect string
+		overlaps  bool
+	}{
+		{"[1,10)", "[5,)", "[5,10)", true},
+		{"[1,5)", "[5,10)", "empty", false},
+		{"[1,5]", "[5,10)", "[5,5]", true},
+		{"(1,5]", "[1,5)", "(1,5)", true},
+		{"(,)", "[2,3]", "[2,3]", true},
+		{"(,3]", "[4,)", "empty", false},
+		{"(,3]", "(,2)", "(,2)", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"*"+tt.b, func(t *testing.T) {
+			a, b := ztype.MustParseRange[int](tt.a), ztype.MustParseRange[int](tt.b)
+			assert.Equal(t, tt.intersect, a.Intersect(b).String())
+			assert.Equal(t, tt.intersect, b.Intersect(a).String())
+			assert.Equal(t, tt.overlaps, a.Overlaps(b))
+			assert.Equal(t, tt.overlaps, b.Overlaps(a))
+		})
+	}
+
+	assert.True(t, ztype.MustParseRange[int]("[1,2]").Intersect(ztype.NewNullRange[int]()).IsNull())
+	assert.False(t, ztype.MustParseRange[int]("[1,2]").Overlaps(ztype.NewNullRange[int]()))
+	assert.True(t, ztype.MustParseRange[float64]("(1,2)").Overlaps(ztype.MustParseRange[float64]("[1.5,3]")))
+}
+
+func TestRangeValidate(t *testing.T) {
+	assert.NoError(t, ztype.MustParseRange[int]("[1,10)").Validate())
+	assert.NoError(t, ztype.MustParseRange[int]("[5,5)").Validate())
+	assert.NoError(t, ztype.NewNullRange[int]().Validate())
+	assert.NoError(t, ztype.NewRange(ztype.NewNumber(10), ztype.NewNullNumber[int]()).Validate())
+
+	err := ztype.NewRange(ztype.NewNumber(10), ztype.NewNumber(1)).Validate()
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+	assert.ErrorContains(t, err, `"[10,1]"`)
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"[1,10)", "[1,10)"},
+		{" ( 1 , 10 ] ", "(1,10]"},
+		{`["1","10"]`, "[1,10]"},
+		{"[,10)", "(,10)"},
+		{"(,)", "(,)"},
+		{"EMPTY", "empty"},
+		{"[-5,-1]", "[-5,-1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := ztype.ParseRange[int](tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.String())
+		})
+	}
+
+	floats, err := ztype.ParseRange[float64]("[0.5,infinity)")
+	require.NoError(t, err)
+	assert.True(t, floats.Contains(ztype.NewNumber(1e300)))
+	assert.Equal(t, "[0.5,infinity)", floats.String())
+
+	for _, input := range []string{"", "1,10", "[1,10", "[1;10]", "[1,2,3]", "[a,1]", `["1,2]`, "[10,1]", "{1,2}"} {
+		_, err := ztype.ParseRange[int](input)
+		assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{}, input)
+	}
+	_, err = ztype.ParseRange[int]("[1.5,2]")
+	assert.Error(t, err)
+	_, err = ztype.ParseRange[int8]("[1,300]")
+	assert.Error(t, err)
+	assert.Panics(t, func() { ztype.MustParseRange[int]("[") })
+}
+
+func TestRangeJSON(t *testing.T) {
+	tests := []struct {
+		literal string
+		json    string
+	}{
+		{"[1,10]", `{"min":1,"max":10}`},
+		{"[1,10)", `{"min":1,"max":10,"maxExclusive":true}`},
+		{"(1,10]", `{"min":1,"max":10,"minExclusive":true}`},
+		{"[18,)", `{"min":18,"max":null}`},
+		{"(,)", `{"min":null,"max":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.literal, func(t *testing.T) {
+			data, err := json.Marshal(ztype.MustParseRange[int](tt.literal))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(data))
+
+			var decoded ztype.Range[int]
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &decoded))
+			assert.Equal(t, tt.literal, decoded.String())
+			assert.True(t, decoded.Unmarshaled())
+		})
+	}
+
+	var r ztype.Range[float64]
+	require.NoError(t, json.Unmarshal([]byte(`{"min":9.9}`), &r))
+	assert.Equal(t, "[9.9,)", r.String())
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &r))
+	assert.True(t, r.IsNull())
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"min":"x"}`), &r))
+}
+
+func TestRangeScanValue(t *testing.T) {
+	var r ztype.Range[int64]
+	require.NoError(t, r.Scan("[1,10)"))
+	assert.Equal(t, "[1,10)", r.String())
+
+	require.NoError(t, r.Scan([]byte(`{"min":1,"max":10,"maxExclusive":true}`)))
+	assert.Equal(t, "[1,10)", r.String())
+
+	value, err := r.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"min":1,"max":10,"maxExclusive":true}`, value.(string))
+
+	ztype.SetRangeValueLiteral(true)
+	t.Cleanup(func() { ztype.SetRangeValueLiteral(false) })
+	value, err = r.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[1,10)", value)
+
+	empty := ztype.MustParseRange[int64]("[3,3)")
+	value, err = empty.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "empty", value)
+
+	require.NoError(t, r.Scan(nil))
+	assert.True(t, r.IsNull())
+	value, err = r.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	assert.ErrorIs(t, r.Scan(int64(1)), &ztype.ErrUnsupportedScanType{})
+	assert.ErrorIs(t, r.Scan("[2,1]"), &ztype.ErrInvalidFormat{})
+}
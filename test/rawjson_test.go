@@ -0,0 +1,162 @@
+package ztype_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
+)
+
+func TestRawJSONVerbatim(t *testing.T) {
+	var payload struct {
+		Extra   ztype.RawJSON `json:"extra"`
+		Missing ztype.RawJSON `json:"missing"`
+		Nothing ztype.RawJSON `json:"nothing"`
+	}
+	document := `{"extra":{"b": 1,  "a":[2]},"nothing":null}`
+	require.NoError(t, json.Unmarshal([]byte(document), &payload))
+
+	assert.True(t, payload.Extra.Unmarshaled())
+	assert.Equal(t, `{"b": 1,  "a":[2]}`, string(payload.Extra.Get()))
+	assert.True(t, payload.Nothing.Unmarshaled())
+	assert.True(t, payload.Nothing.IsNull())
+	assert.False(t, payload.Missing.Unmarshaled())
+
+	data, err := payload.Extra.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"b": 1,  "a":[2]}`, string(data))
+
+	// encoding/json compacts the output of Marshalers.
+	data, err = json.Marshal(&payload.Extra)
+	require.NoError(t, err)
+	assert.Equal(t, `{"b":1,"a":[2]}`, string(data))
+
+	data, err = json.Marshal(&payload.Nothing)
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(data))
+}
+
+func TestRawJSONAliasing(t *testing.T) {
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		buffer := []byte(`{"a":1}`)
+		var raw ztype.RawJSON
+		require.NoError(t, raw.UnmarshalJSON(buffer))
+
+		copy(buffer, `[9,9,9]`)
+		assert.Equal(t, `{"a":1}`, raw.String())
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		buffer := []byte(`[1,2]`)
+		var raw ztype.RawJSON
+		require.NoError(t, raw.Scan(buffer))
+
+		copy(buffer, `"xxx"`)
+		assert.Equal(t, `[1,2]`, raw.String())
+	})
+
+	t.Run("decoder reuse", func(t *testing.T) {
+		decoder := json.NewDecoder(bytes.NewBufferString(`{"a":1} {"b":2}`))
+		var first, second ztype.RawJSON
+		require.NoError(t, decoder.Decode(&first))
+		require.NoError(t, decoder.Decode(&second))
+		assert.Equal(t, `{"a":1}`, first.String())
+		assert.Equal(t, `{"b":2}`, second.String())
+	})
+}
+
+func TestRawJSONHelpers(t *testing.T) {
+	t.Run("Validate", func(t *testing.T) {
+		var raw ztype.RawJSON
+		require.NoError(t, raw.Validate())
+
+		raw.Set(json.RawMessage(`{"a":`))
+		assert.Error(t, raw.Validate())
+
+		raw.Set(json.RawMessage(`{"a":1} {}`))
+		assert.Error(t, raw.Validate())
+
+		raw.Set(json.RawMessage(` {"a":1} `))
+		assert.NoError(t, raw.Validate())
+	})
+
+	t.Run("kind", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			isObject bool
+			isArray  bool
+			isString bool
+		}{
+			{` {"a":1}`, true, false, false},
+			{`[1]`, false, true, false},
+			{`"a"`, false, false, true},
+			{`1`, false, false, false},
+		}
+
+		for _, tt := range tests {
+			raw := ztype.NewRawJSON(json.RawMessage(tt.input))
+			assert.Equal(t, tt.isObject, raw.IsObject(), tt.input)
+			assert.Equal(t, tt.isArray, raw.IsArray(), tt.input)
+			assert.Equal(t, tt.isString, raw.IsString(), tt.input)
+		}
+
+		null := ztype.NewNullRawJSON()
+		assert.False(t, null.IsObject())
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		raw := ztype.NewRawJSON(json.RawMessage(`{"a":1}`))
+		var dest map[string]int
+		require.NoError(t, raw.Decode(&dest))
+		assert.Equal(t, map[string]int{"a": 1}, dest)
+
+		null := ztype.NewNullRawJSON()
+		reset := map[string]int{"x": 1}
+		require.NoError(t, null.Decode(&reset))
+		assert.Nil(t, reset)
+	})
+
+	t.Run("Compact and Indent", func(t *testing.T) {
+		raw := ztype.NewRawJSON(json.RawMessage(`{ "a" : [1, 2] }`))
+		compact, err := raw.Compact()
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":[1,2]}`, string(compact))
+
+		indented, err := raw.Indent("", "  ")
+		require.NoError(t, err)
+		assert.Equal(t, "{\n  \"a\": [\n    1,\n    2\n  ]\n}", string(indented))
+
+		broken := ztype.NewRawJSON(json.RawMessage(`{`))
+		_, err = broken.Compact()
+		assert.Error(t, err)
+	})
+}
+
+func TestRawJSONDatabase(t *testing.T) {
+	var raw ztype.RawJSON
+	require.NoError(t, raw.Scan(`{"a":1}`))
+	value, err := raw.Value()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"a":1}`), value)
+
+	require.NoError(t, raw.Scan([]byte(`null`)))
+	assert.True(t, raw.IsNull())
+	value, err = raw.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	assert.Error(t, raw.Scan(1))
+}
+
+func TestRawJSONValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.RawJSON) }, []ztypetest.Case{
+		{Name: "bytes", Scan: []byte(`{"a":1}`), Want: []byte(`{"a":1}`)},
+		{Name: "text", Scan: `[1]`, Want: []byte(`[1]`)},
+		{Name: "int64", Scan: int64(1), Err: true},
+	})
+}
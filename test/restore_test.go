@@ -0,0 +1,123 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// restoreCase decodes invalid input into a pre-populated value and returns
+// a pointer to it.
+type restoreCase struct {
+	name   string
+	before any
+	decode func() (ztype.Nullable, error)
+}
+
+func TestFailedDecodeKeepsValue(t *testing.T) {
+	cases := []restoreCase{
+		{"Numeric JSON", ztype.NewNumber[int8](5), func() (ztype.Nullable, error) {
+			n := ztype.NewNumber[int8](5)
+			return &n, json.Unmarshal([]byte(`300`), &n)
+		}},
+		{"Numeric JSON type", ztype.NewNumber(5), func() (ztype.Nullable, error) {
+			n := ztype.NewNumber(5)
+			return &n, json.Unmarshal([]byte(`"five"`), &n)
+		}},
+		{"Numeric text", ztype.NewNumber(5), func() (ztype.Nullable, error) {
+			n := ztype.NewNumber(5)
+			return &n, n.UnmarshalText([]byte("five"))
+		}},
+		{"Numeric scan", ztype.NewNumber(5), func() (ztype.Nullable, error) {
+			n := ztype.NewNumber(5)
+			return &n, n.Scan("five")
+		}},
+		{"String JSON", ztype.NewString("kept"), func() (ztype.Nullable, error) {
+			s := ztype.NewString("kept")
+			return &s, json.Unmarshal([]byte(`123`), &s)
+		}},
+		{"Null String JSON", ztype.NewNullString(), func() (ztype.Nullable, error) {
+			s := ztype.NewNullString()
+			return &s, json.Unmarshal([]byte(`123`), &s)
+		}},
+		{"Byte JSON", ztype.NewByte(7), func() (ztype.Nullable, error) {
+			b := ztype.NewByte(7)
+			return &b, json.Unmarshal([]byte(`256`), &b)
+		}},
+		{"Byte JSON string", ztype.NewByte(7), func() (ztype.Nullable, error) {
+			b := ztype.NewByte(7)
+			return &b, json.Unmarshal([]byte(`"0xzz"`), &b)
+		}},
+		{"Byte scan", ztype.NewByte(7), func() (ztype.Nullable, error) {
+			b := ztype.NewByte(7)
+			return &b, b.Scan(int64(1000))
+		}},
+		{"Bool JSON", ztype.NewBool(true), func() (ztype.Nullable, error) {
+			b := ztype.NewBool(true)
+			return &b, json.Unmarshal([]byte(`"yes please"`), &b)
+		}},
+		{"Null Bool JSON", ztype.NewNullBool(), func() (ztype.Nullable, error) {
+			b := ztype.NewNullBool()
+			return &b, json.Unmarshal([]byte(`1`), &b)
+		}},
+		{"Map JSON", ztype.NewMap(map[string]int{"a": 1}), func() (ztype.Nullable, error) {
+			m := ztype.NewMap(map[string]int{"a": 1})
+			return &m, json.Unmarshal([]byte(`{"b":"x"}`), &m)
+		}},
+		{"Map scan", ztype.NewMap(map[string]int{"a": 1}), func() (ztype.Nullable, error) {
+			m := ztype.NewMap(map[string]int{"a": 1})
+			return &m, m.Scan(`[1]`)
+		}},
+		{"Time JSON", ztype.NewTime(time.Unix(10, 0).UTC()), func() (ztype.Nullable, error) {
+			tm := ztype.NewTime(time.Unix(10, 0).UTC())
+			return &tm, json.Unmarshal([]byte(`"tomorrow"`), &tm)
+		}},
+		{"Time scan", ztype.NewTime(time.Unix(10, 0).UTC()), func() (ztype.Nullable, error) {
+			tm := ztype.NewTime(time.Unix(10, 0).UTC())
+			return &tm, tm.Scan(int64(1))
+		}},
+		{"Duration JSON", ztype.NewDuration(time.Second), func() (ztype.Nullable, error) {
+			d := ztype.NewDuration(time.Second)
+			return &d, json.Unmarshal([]byte(`"soon"`), &d)
+		}},
+		{"Duration text", ztype.NewDuration(time.Second), func() (ztype.Nullable, error) {
+			d := ztype.NewDuration(time.Second)
+			return &d, d.UnmarshalText([]byte("soon"))
+		}},
+		{"String scan", ztype.NewString("kept"), func() (ztype.Nullable, error) {
+			s := ztype.NewString("kept")
+			return &s, s.Scan(struct{}{})
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			after, err := tc.decode()
+			require.Error(t, err)
+			if !strings.HasSuffix(tc.name, "scan") {
+				assert.True(t, after.Unmarshaled())
+			}
+			after.SetUnmarshaled(false)
+			assert.Equal(t, tc.before, reflect.ValueOf(after).Elem().Interface())
+		})
+	}
+}
+
+func TestFailedDecodeKeepsStructFields(t *testing.T) {
+	type settings struct {
+		Retries ztype.Numeric[int] `json:"retries"`
+		Timeout ztype.Duration     `json:"timeout"`
+	}
+	s := settings{Retries: ztype.NewNumber(3), Timeout: ztype.NewDuration(time.Minute)}
+	err := json.Unmarshal([]byte(`{"timeout":"2m","retries":"many"}`), &s)
+	require.Error(t, err)
+	assert.Equal(t, 3, s.Retries.Get())
+	assert.True(t, s.Retries.Unmarshaled())
+	assert.Equal(t, 2*time.Minute, s.Timeout.Get())
+}
@@ -0,0 +1,129 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestRuneJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected rune
+		isNull   bool
+		wantErr  bool
+	}{
+		{"ascii", `"a"`, 'a', false, false},
+		{"two-byte", `"é"`, 'é', false, false},
+		{"emoji", `"😀"`, '😀', false, false},
+		{"escaped emoji", `"\ud83d\ude00"`, '😀', false, false},
+		{"null", `null`, 0, true, false},
+		{"combining sequence", "\"e\u0301\"", 0, false, true},
+		{"two characters", `"ab"`, 0, false, true},
+		{"empty", `""`, 0, false, true},
+		{"number", `97`, 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r ztype.Rune
+			err := json.Unmarshal([]byte(tt.input), &r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, r.Unmarshaled())
+			assert.Equal(t, tt.isNull, r.IsNull())
+			assert.Equal(t, tt.expected, r.Get())
+		})
+	}
+
+	t.Run("marshal", func(t *testing.T) {
+		emoji := ztype.NewRune('😀')
+		data, err := json.Marshal(&emoji)
+		require.NoError(t, err)
+		assert.Equal(t, `"😀"`, string(data))
+
+		null := ztype.NewNullRune()
+		data, err = json.Marshal(&null)
+		require.NoError(t, err)
+		assert.Equal(t, `null`, string(data))
+	})
+
+	t.Run("error message", func(t *testing.T) {
+		var r ztype.Rune
+		err := json.Unmarshal([]byte("\"e\u0301\""), &r)
+		var invalid *ztype.ErrInvalidFormat
+		require.True(t, errors.As(err, &invalid))
+		assert.Equal(t, "Rune", invalid.Type)
+		assert.ErrorContains(t, err, "expected a single character, got 2 runes")
+	})
+}
+
+func TestRuneDatabase(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected rune
+		isNull   bool
+		wantErr  bool
+	}{
+		{"string", "ß", 'ß', false, false},
+		{"bytes", []byte("😀"), '😀', false, false},
+		{"code point", int64(0x1F600), '😀', false, false},
+		{"nil", nil, 0, true, false},
+		{"too long", "ab", 0, false, true},
+		{"invalid utf-8", []byte{0xff}, 0, false, true},
+		{"surrogate code point", int64(0xD800), 0, false, true},
+		{"negative", int64(-1), 0, false, true},
+		{"unsupported", 1.5, 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r ztype.Rune
+			err := r.Scan(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.isNull, r.IsNull())
+			assert.Equal(t, tt.expected, r.Get())
+		})
+	}
+
+	value, err := ztype.NewRune('😀').Value()
+	require.NoError(t, err)
+	assert.Equal(t, "😀", value)
+
+	value, err = ztype.NewNullRune().Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestRuneHelpers(t *testing.T) {
+	letter, digit, space, null := ztype.NewRune('é'), ztype.NewRune('٣'), ztype.NewRune(' '), ztype.NewNullRune()
+
+	assert.True(t, letter.IsLetter())
+	assert.False(t, letter.IsDigit())
+	assert.True(t, digit.IsDigit())
+	assert.True(t, space.IsSpace())
+	assert.False(t, null.IsLetter())
+	assert.False(t, null.IsSpace())
+
+	assert.True(t, letter.Equal(ztype.NewRune('é')))
+	assert.False(t, null.Equal(ztype.NewRune(0)))
+	assert.True(t, letter.EqualRaw('é'))
+	assert.Equal(t, "é", letter.String())
+	assert.Equal(t, "<NULL>", null.String())
+	zero := ztype.NewNullRuneIfZero(0)
+	assert.True(t, zero.IsNull())
+}
@@ -0,0 +1,222 @@
+package ztype_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// fakeResults maps a query to the result set returned by the fake driver.
+var fakeResults = map[string]fakeResult{
+	"users": {
+		columns: []string{"id", "EMAIL", "active", "created_at", "tags", "address.city", "region"},
+		rows: [][]driver.Value{
+			{int64(1), "ana@example.com", true, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), `["a"]`, "Recife", "ne"},
+			{int64(2), nil, nil, nil, nil, nil, nil},
+		},
+	},
+	"unmatched": {
+		columns: []string{"id", "nope", "missing"},
+		rows:    [][]driver.Value{{int64(1), "x", "y"}},
+	},
+}
+
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, fmt.Errorf("not supported") }
+
+type fakeStmt struct{ query string }
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return 0 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, fmt.Errorf("not supported") }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	result, ok := fakeResults[s.query]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", s.query)
+	}
+	return &fakeRows{result: result}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
+
+func init() {
+	sql.Register("ztype-fake", fakeDriver{})
+}
+
+// openFakeDB opens a database backed by the fake driver.
+func openFakeDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("ztype-fake", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type scanBase struct {
+	ID ztype.Numeric[int64] `db:"id"`
+}
+
+type scanAddress struct {
+	City ztype.String `db:"city"`
+}
+
+type scanUser struct {
+	scanBase
+	Email     ztype.String `db:"email"`
+	Active    ztype.Bool
+	CreatedAt ztype.Time `db:"created_at"`
+	Tags      ztype.Slice[string]
+	Address   scanAddress `db:"address"`
+	Region    string
+	Ignored   ztype.String `db:"-"`
+}
+
+func TestScanStruct(t *testing.T) {
+	rows, err := openFakeDB(t).Query("users")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	require.True(t, rows.Next())
+	var user scanUser
+	require.NoError(t, ztype.ScanStruct(rows, &user))
+	assert.Equal(t, int64(1), user.ID.Get())
+	assert.Equal(t, "ana@example.com", user.Email.Get())
+	assert.True(t, user.Active.Get())
+	assert.Equal(t, 2024, user.CreatedAt.Get().Year())
+	assert.Equal(t, []string{"a"}, user.Tags.Get())
+	assert.Equal(t, "Recife", user.Address.City.Get())
+	assert.Equal(t, "ne", user.Region)
+
+	require.True(t, rows.Next())
+	var empty scanUser
+	err = ztype.ScanStruct(rows, &empty)
+	require.Error(t, err, "NULL into a plain string field fails")
+
+	assert.Error(t, ztype.ScanStruct(rows, user))
+}
+
+type scanNullable struct {
+	scanBase
+	Email     ztype.String `db:"email"`
+	Active    ztype.Bool
+	CreatedAt ztype.Time `db:"created_at"`
+	Tags      ztype.Slice[string]
+	Address   scanAddress `db:"address"`
+	Region    ztype.String
+}
+
+func TestScanAll(t *testing.T) {
+	rows, err := openFakeDB(t).Query("users")
+	require.NoError(t, err)
+
+	users, err := ztype.ScanAll[scanNullable](rows)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	assert.Equal(t, "ana@example.com", users[0].Email.Get())
+	assert.Equal(t, "ne", users[0].Region.Get())
+
+	null := users[1]
+	assert.Equal(t, int64(2), null.ID.Get())
+	assert.True(t, null.Email.IsNull())
+	assert.True(t, null.Active.IsNull())
+	assert.True(t, null.CreatedAt.IsNull())
+	assert.True(t, null.Tags.IsNull())
+	assert.True(t, null.Address.City.IsNull())
+	assert.True(t, null.Region.IsNull())
+}
+
+func TestScanUnmatchedColumns(t *testing.T) {
+	rows, err := openFakeDB(t).Query("unmatched")
+	require.NoError(t, err)
+
+	_, err = ztype.ScanAll[scanNullable](rows)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nope, missing")
+
+	_, err = ztype.ScanAll[int](rows)
+	assert.Error(t, err)
+}
+
+// TestScanBoolNumberCoercion checks the coercion table in the package
+// documentation for every type and driver value kind.
+func TestScanBoolNumberCoercion(t *testing.T) {
+	type scanner interface {
+		sql.Scanner
+		driver.Valuer
+		IsNull() bool
+	}
+	tests := []struct {
+		name     string
+		target   func() scanner
+		input    any
+		expected driver.Value
+		wantErr  bool
+	}{
+		{"Numeric true", func() scanner { return new(ztype.Numeric[int]) }, true, int64(1), false},
+		{"Numeric false", func() scanner { return new(ztype.Numeric[int]) }, false, int64(0), false},
+		{"Numeric float true", func() scanner { return new(ztype.Numeric[float64]) }, true, 1.0, false},
+		{"Numeric int64", func() scanner { return new(ztype.Numeric[int]) }, int64(7), int64(7), false},
+		{"Numeric float64", func() scanner { return new(ztype.Numeric[float64]) }, 2.5, 2.5, false},
+		{"Numeric nil", func() scanner { return new(ztype.Numeric[int]) }, nil, nil, false},
+		{"Byte true", func() scanner { return new(ztype.Byte) }, true, int64(1), false},
+		{"Byte false", func() scanner { return new(ztype.Byte) }, false, int64(0), false},
+		{"Byte int64", func() scanner { return new(ztype.Byte) }, int64(200), int64(200), false},
+		{"Byte overflow", func() scanner { return new(ztype.Byte) }, int64(300), nil, true},
+		{"Byte nil", func() scanner { return new(ztype.Byte) }, nil, nil, false},
+		{"Bool true", func() scanner { return new(ztype.Bool) }, true, true, false},
+		{"Bool int64", func() scanner { return new(ztype.Bool) }, int64(1), true, false},
+		{"Bool float64", func() scanner { return new(ztype.Bool) }, 0.0, false, false},
+		{"Bool other float64", func() scanner { return new(ztype.Bool) }, 2.0, true, false},
+		{"Bool nil", func() scanner { return new(ztype.Bool) }, nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target()
+			err := target.Scan(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.input == nil, target.IsNull())
+			value, err := target.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
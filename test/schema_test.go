@@ -0,0 +1,169 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type schemaAudit struct {
+	CreatedAt ztype.Time `json:"created_at"`
+	CreatedBy string     `json:"created_by"`
+}
+
+type schemaAddress struct {
+	City ztype.String `json:"city"`
+}
+
+type schemaOrder struct {
+	schemaAudit
+	ID        ztype.Numeric[int64]              `json:"id"`
+	Total     ztype.Numeric[float64]            `json:"total"`
+	Quantity  ztype.Numeric[int16]              `json:"quantity"`
+	Paid      ztype.Bool                        `json:"paid"`
+	Status    ztype.Enum[quickStatus]           `json:"status"`
+	Timeout   ztype.Duration                    `json:"timeout"`
+	Payload   ztype.RawJSON                     `json:"payload"`
+	Price     ztype.Money                       `json:"price"`
+	Tags      ztype.Set[string]                 `json:"tags"`
+	Items     ztype.Slice[ztype.Numeric[int32]] `json:"items"`
+	Labels    ztype.Map[string, ztype.String]   `json:"labels"`
+	Address   ztype.Null[schemaAddress]         `json:"address"`
+	Nickname  ztype.NotNullColumn[ztype.String] `json:"nickname"`
+	Note      *string                           `json:"note"`
+	Deadline  time.Time                         `json:"deadline"`
+	Notes     []string                          `json:"notes,omitempty"`
+	Counters  *ztype.SyncMap[string, int]       `json:"counters"`
+	Secret    ztype.String                      `json:"-"`
+	Untagged  ztype.IP
+	internal  ztype.String
+	Shipments ztype.Array[ztype.Numeric[float32]] `json:"shipments"`
+}
+
+func TestSchemaType(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    ztype.SchemaTyper
+		jsonType string
+		format   string
+	}{
+		{"Bool", ztype.Bool{}, "boolean", ""},
+		{"Byte", ztype.Byte{}, "integer", "int32"},
+		{"Char", ztype.Char{}, "string", ""},
+		{"Bytes", ztype.Bytes{}, "string", "byte"},
+		{"String", ztype.String{}, "string", ""},
+		{"Int", ztype.Numeric[int]{}, "integer", "int64"},
+		{"Int32", ztype.Numeric[int32]{}, "integer", "int32"},
+		{"Uint64", ztype.Numeric[uint64]{}, "integer", "int64"},
+		{"Float32", ztype.Numeric[float32]{}, "number", "float"},
+		{"Float64", ztype.Numeric[float64]{}, "number", "double"},
+		{"Time", ztype.Time{}, "string", "date-time"},
+		{"Duration", ztype.Duration{}, "string", "duration"},
+		{"Rune", ztype.Rune{}, "string", ""},
+		{"Enum", ztype.Enum[quickStatus]{}, "string", ""},
+		{"IP", ztype.IP{}, "string", "ip"},
+		{"CIDR", ztype.CIDR{}, "string", "cidr"},
+		{"RawJSON", ztype.RawJSON{}, "", ""},
+		{"Money", ztype.Money{}, "object", ""},
+		{"Map", ztype.JSON{}, "object", ""},
+		{"OrderedMap", ztype.OrderedMap[string, int]{}, "object", ""},
+		{"SyncMap", (*ztype.SyncMap[string, int])(nil), "object", ""},
+		{"Slice", ztype.Slice[int]{}, "array", ""},
+		{"SliceComparable", ztype.SliceComparable[int]{}, "array", ""},
+		{"Set", ztype.Set[int]{}, "array", ""},
+		{"Array", ztype.StringArray{}, "array", ""},
+		{"NullInt", ztype.Null[int8]{}, "integer", "int32"},
+		{"NullTime", ztype.Null[time.Time]{}, "string", "date-time"},
+		{"NullStruct", ztype.Null[schemaAddress]{}, "object", ""},
+		{"NullAny", ztype.Null[any]{}, "", ""},
+		{"NotNullColumn", ztype.NotNullColumn[ztype.Time]{}, "string", "date-time"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonType, format, nullable := tc.value.SchemaType()
+			assert.Equal(t, tc.jsonType, jsonType)
+			assert.Equal(t, tc.format, format)
+			assert.True(t, nullable)
+		})
+	}
+}
+
+func TestSchemaTypeFollowsJSONModes(t *testing.T) {
+	ztype.SetByteJSONHex(true)
+	defer ztype.SetByteJSONHex(false)
+	jsonType, _, _ := ztype.Byte{}.SchemaType()
+	assert.Equal(t, "string", jsonType)
+
+	ztype.SetMoneyJSONCompact(true)
+	defer ztype.SetMoneyJSONCompact(false)
+	jsonType, _, _ = ztype.Money{}.SchemaType()
+	assert.Equal(t, "string", jsonType)
+}
+
+func TestJSONSchemaFor(t *testing.T) {
+	schema, err := ztype.JSONSchemaFor(&schemaOrder{})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"created_at": {"type": "string", "format": "date-time", "nullable": true},
+			"created_by": {"type": "string"},
+			"id": {"type": "integer", "format": "int64", "nullable": true},
+			"total": {"type": "number", "format": "double", "nullable": true},
+			"quantity": {"type": "integer", "format": "int32", "nullable": true},
+			"paid": {"type": "boolean", "nullable": true},
+			"status": {"type": "string", "nullable": true, "enum": ["active", "blocked", "deleted"]},
+			"timeout": {"type": "string", "format": "duration", "nullable": true},
+			"payload": {"nullable": true},
+			"price": {"type": "object", "nullable": true},
+			"tags": {"type": "array", "nullable": true, "items": {"type": "string"}},
+			"items": {"type": "array", "nullable": true,
+				"items": {"type": "integer", "format": "int32", "nullable": true}},
+			"labels": {"type": "object", "nullable": true,
+				"additionalProperties": {"type": "string", "nullable": true}},
+			"address": {"type": "object", "nullable": true,
+				"properties": {"city": {"type": "string", "nullable": true}}},
+			"nickname": {"type": "string", "nullable": true},
+			"note": {"type": "string", "nullable": true},
+			"deadline": {"type": "string", "format": "date-time"},
+			"notes": {"type": "array", "items": {"type": "string"}},
+			"counters": {"type": "object", "nullable": true,
+				"additionalProperties": {"type": "integer", "format": "int64"}},
+			"Untagged": {"type": "string", "format": "ip", "nullable": true},
+			"shipments": {"type": "array", "nullable": true,
+				"items": {"type": "number", "format": "float", "nullable": true}}
+		}
+	}`, string(data))
+}
+
+func TestJSONSchemaForErrors(t *testing.T) {
+	type node struct {
+		Name     ztype.String `json:"name"`
+		Children []node       `json:"children"`
+	}
+	type unsupported struct {
+		Callback func() `json:"callback"`
+	}
+
+	for name, value := range map[string]any{
+		"NotStruct":   42,
+		"Nil":         nil,
+		"ZtypeValue":  ztype.NewString("a"),
+		"Time":        time.Time{},
+		"Recursive":   node{},
+		"Unsupported": unsupported{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ztype.JSONSchemaFor(value)
+			assert.Error(t, err)
+		})
+	}
+}
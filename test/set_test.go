@@ -0,0 +1,140 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type setTestPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func TestSetBasics(t *testing.T) {
+	var s ztype.Set[string]
+	assert.True(t, s.IsNull())
+
+	s.Add("a", "b", "a")
+	assert.False(t, s.IsNull())
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Has("a"))
+
+	s.Remove("a", "missing")
+	assert.False(t, s.Has("a"))
+	assert.Equal(t, 1, s.Len())
+
+	s.SetNull()
+	assert.True(t, s.IsNull())
+	assert.True(t, s.IsZero())
+
+	assert.True(t, ztype.NewNullSetIfZero[int]().IsNull())
+	assert.False(t, ztype.NewNullSetIfZero(1).IsNull())
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := ztype.NewSet(1, 2, 3)
+	b := ztype.NewSet(3, 4)
+	null := ztype.NewNullSet[int]()
+
+	tests := []struct {
+		name     string
+		result   ztype.Set[int]
+		expected []int
+		isNull   bool
+	}{
+		{"union", a.Union(b), []int{1, 2, 3, 4}, false},
+		{"union with null", a.Union(null), []int{1, 2, 3}, false},
+		{"null union valid", null.Union(b), []int{3, 4}, false},
+		{"null union null", null.Union(null), []int{}, true},
+		{"intersect", a.Intersect(b), []int{3}, false},
+		{"intersect with null", a.Intersect(null), []int{}, false},
+		{"null intersect", null.Intersect(a), []int{}, true},
+		{"difference", a.Difference(b), []int{1, 2}, false},
+		{"difference with null", a.Difference(null), []int{1, 2, 3}, false},
+		{"null difference", null.Difference(a), []int{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.isNull, tt.result.IsNull())
+			assert.Equal(t, tt.expected, tt.result.Slice())
+		})
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, a.Slice(), "operands are not modified")
+	assert.True(t, a.Equal(ztype.NewSet(3, 2, 1)))
+	assert.False(t, a.Equal(b))
+	assert.False(t, ztype.NewSet[int]().Equal(null))
+}
+
+func TestSetJSON(t *testing.T) {
+	t.Run("dedup on decode", func(t *testing.T) {
+		var s ztype.Set[string]
+		require.NoError(t, json.Unmarshal([]byte(`["b","a","b","a"]`), &s))
+		assert.True(t, s.Unmarshaled())
+		assert.Equal(t, 2, s.Len())
+
+		data, err := json.Marshal(s)
+		require.NoError(t, err)
+		assert.Equal(t, `["a","b"]`, string(data))
+	})
+
+	t.Run("deterministic output", func(t *testing.T) {
+		numbers := ztype.NewSet(10, -1, 3, 200, 7)
+		points := ztype.NewSet(setTestPoint{2, 1}, setTestPoint{1, 9}, setTestPoint{1, 2})
+		custom := ztype.NewSet("bb", "a", "ccc")
+		custom.SetLess(func(a, b string) bool { return len(a) > len(b) })
+
+		for range 20 {
+			data, err := json.Marshal(numbers)
+			require.NoError(t, err)
+			assert.Equal(t, `[-1,3,7,10,200]`, string(data))
+
+			data, err = json.Marshal(points)
+			require.NoError(t, err)
+			assert.Equal(t, `[{"x":1,"y":2},{"x":1,"y":9},{"x":2,"y":1}]`, string(data))
+
+			data, err = json.Marshal(custom)
+			require.NoError(t, err)
+			assert.Equal(t, `["ccc","bb","a"]`, string(data))
+		}
+	})
+
+	t.Run("null and empty", func(t *testing.T) {
+		data, err := json.Marshal(ztype.NewNullSet[int]())
+		require.NoError(t, err)
+		assert.Equal(t, `null`, string(data))
+
+		data, err = json.Marshal(ztype.NewSet[int]())
+		require.NoError(t, err)
+		assert.Equal(t, `[]`, string(data))
+
+		var s ztype.Set[int]
+		require.NoError(t, json.Unmarshal([]byte(`null`), &s))
+		assert.True(t, s.IsNull())
+		assert.True(t, s.Unmarshaled())
+		assert.Error(t, json.Unmarshal([]byte(`{}`), &s))
+	})
+}
+
+func TestSetDatabase(t *testing.T) {
+	var s ztype.Set[string]
+	require.NoError(t, s.Scan([]byte(`["x","y","x"]`)))
+	assert.Equal(t, 2, s.Len())
+
+	value, err := s.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `["x","y"]`, value)
+
+	require.NoError(t, s.Scan(nil))
+	value, err = s.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	assert.Error(t, s.Scan(1))
+}
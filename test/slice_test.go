@@ -0,0 +1,218 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
+)
+
+func TestSliceConstructors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ztype.Slice[int]
+		isNull bool
+		isZero bool
+		length int
+	}{
+		{"NewSlice", ztype.NewSlice([]int{1, 2}), false, false, 2},
+		{"NewSlice empty", ztype.NewSlice([]int{}), false, true, 0},
+		{"NewNullSlice", ztype.NewNullSlice[int](), true, true, 0},
+		{"NewNullSliceIfZero empty", ztype.NewNullSliceIfZero([]int{}), true, true, 0},
+		{"NewNullSliceIfZero populated", ztype.NewNullSliceIfZero([]int{1}), false, false, 1},
+		{"zero value", ztype.Slice[int]{}, true, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.isNull, tt.input.IsNull())
+			assert.Equal(t, tt.isZero, tt.input.IsZero())
+			assert.Equal(t, tt.length, tt.input.Len())
+		})
+	}
+}
+
+func TestSliceMutation(t *testing.T) {
+	t.Run("Append on zero value", func(t *testing.T) {
+		var s ztype.Slice[int]
+		s.Append(1, 2)
+		assert.False(t, s.IsNull())
+		assert.Equal(t, []int{1, 2}, s.Get())
+	})
+
+	t.Run("Insert", func(t *testing.T) {
+		s := ztype.NewSlice([]int{1, 4})
+		require.NoError(t, s.Insert(1, 2, 3))
+		assert.Equal(t, []int{1, 2, 3, 4}, s.Get())
+		require.NoError(t, s.Insert(4, 5))
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, s.Get())
+		assert.Error(t, s.Insert(9, 0))
+		assert.Error(t, s.Insert(-1, 0))
+
+		var empty ztype.Slice[int]
+		require.NoError(t, empty.Insert(0))
+		assert.False(t, empty.IsNull())
+	})
+
+	t.Run("RemoveAt", func(t *testing.T) {
+		s := ztype.NewSlice([]int{1, 2, 3})
+		item, err := s.RemoveAt(1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, item)
+		assert.Equal(t, []int{1, 3}, s.Get())
+
+		_, err = s.RemoveAt(2)
+		assert.Error(t, err)
+	})
+
+	t.Run("SetNull", func(t *testing.T) {
+		s := ztype.NewSlice([]int{1})
+		s.SetNull()
+		assert.True(t, s.IsNull())
+		assert.Equal(t, 0, s.Len())
+	})
+
+	t.Run("GetItem", func(t *testing.T) {
+		s := ztype.NewSlice([]string{"a"})
+		item, ok := s.GetItem(0)
+		assert.True(t, ok)
+		assert.Equal(t, "a", item)
+		_, ok = s.GetItem(1)
+		assert.False(t, ok)
+	})
+}
+
+func TestSliceHelpers(t *testing.T) {
+	s := ztype.NewSlice([]int{1, 2, 3})
+	null := ztype.NewNullSlice[int]()
+
+	t.Run("Filter", func(t *testing.T) {
+		odd := s.Filter(func(_ int, v int) bool { return v%2 == 1 })
+		assert.Equal(t, []int{1, 3}, odd.Get())
+		assert.True(t, null.Filter(func(int, int) bool { return true }).IsNull())
+	})
+
+	t.Run("MapValues", func(t *testing.T) {
+		doubled := s.MapValues(func(_ int, v int) int { return v * 2 })
+		assert.Equal(t, []int{2, 4, 6}, doubled.Get())
+		assert.Equal(t, []int{1, 2, 3}, s.Get())
+	})
+
+	t.Run("TransformSlice", func(t *testing.T) {
+		labels := ztype.TransformSlice(s, func(_ int, v int) string { return strconv.Itoa(v) })
+		assert.Equal(t, []string{"1", "2", "3"}, labels.Get())
+		assert.True(t, ztype.TransformSlice(null, func(int, int) string { return "" }).IsNull())
+	})
+
+	t.Run("iterators", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3}, slices.Collect(s.Values()))
+		for index, item := range s.All() {
+			assert.Equal(t, index+1, item)
+		}
+	})
+
+	t.Run("Clone is deep", func(t *testing.T) {
+		clone := s.Clone()
+		clone.Get()[0] = 100
+		assert.Equal(t, 1, s.Get()[0])
+	})
+
+	t.Run("SliceComparable", func(t *testing.T) {
+		c := ztype.NewSliceComparable([]string{"a", "b"})
+		assert.True(t, c.Contains("b"))
+		assert.False(t, c.Contains("c"))
+		assert.Equal(t, 1, c.Index("b"))
+		assert.True(t, c.Equal(ztype.NewSliceComparable([]string{"a", "b"})))
+		assert.False(t, c.Equal(ztype.SliceComparable[string]{}))
+		assert.True(t, c.EqualRaw([]string{"a", "b"}))
+	})
+}
+
+func TestSliceJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ztype.Slice[string]
+		expected string
+	}{
+		{"populated", ztype.NewSlice([]string{"a"}), `["a"]`},
+		{"empty", ztype.NewSlice([]string{}), `[]`},
+		{"valid nil", ztype.NewSlice[string](nil), `[]`},
+		{"null", ztype.NewNullSlice[string](), `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+
+			var decoded ztype.Slice[string]
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.True(t, decoded.Unmarshaled())
+			assert.Equal(t, tt.input.IsNull(), decoded.IsNull())
+			assert.Equal(t, tt.input.Len(), decoded.Len())
+		})
+	}
+
+	t.Run("absent field", func(t *testing.T) {
+		var payload struct {
+			Tags ztype.Slice[string] `json:"tags"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{}`), &payload))
+		assert.False(t, payload.Tags.Unmarshaled())
+		assert.True(t, payload.Tags.IsNull())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var decoded ztype.Slice[int]
+		assert.Error(t, json.Unmarshal([]byte(`["a"]`), &decoded))
+	})
+}
+
+func TestSliceDatabase(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		isNull   bool
+		expected []int
+		value    any
+	}{
+		{"bytes", []byte(`[1,2]`), false, []int{1, 2}, `[1,2]`},
+		{"string", `[]`, false, []int{}, `[]`},
+		{"SQL NULL", nil, true, nil, nil},
+		{"JSON null", []byte(`null`), true, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s ztype.Slice[int]
+			require.NoError(t, s.Scan(tt.input))
+			assert.Equal(t, tt.isNull, s.IsNull())
+			assert.Equal(t, tt.expected, s.Get())
+
+			value, err := s.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		var s ztype.Slice[int]
+		assert.Error(t, s.Scan(42))
+	})
+}
+
+func TestSliceValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Slice[int]) }, []ztypetest.Case{
+		{Name: "text", Scan: "[1,2]", Want: "[1,2]"},
+		{Name: "bytes", Scan: []byte("[3]"), Want: "[3]"},
+		{Name: "object", Scan: `{"a":1}`, Err: true},
+		{Name: "int64", Scan: int64(1), Err: true},
+	})
+}
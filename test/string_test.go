@@ -2,11 +2,19 @@ package ztype_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
 )
 
 func TestNewString(t *testing.T) {
@@ -349,3 +357,105 @@ func TestString(t *testing.T) {
 		})
 	}
 }
+
+func TestStringInterner(t *testing.T) {
+	var calls atomic.Int32
+	ztype.SetStringInterner(func(s string) string {
+		calls.Add(1)
+		return ztype.InternString(s)
+	})
+	defer ztype.SetStringInterner(nil)
+
+	var fromJSON, fromText, fromScan, fromBytes ztype.String
+	require.NoError(t, json.Unmarshal([]byte(`"active"`), &fromJSON))
+	require.NoError(t, fromText.UnmarshalText([]byte("active")))
+	require.NoError(t, fromScan.Scan("active"))
+	require.NoError(t, fromBytes.Scan([]byte("active")))
+	assert.Equal(t, int32(4), calls.Load())
+
+	for _, s := range []ztype.String{fromText, fromScan, fromBytes} {
+		assert.Equal(t, "active", s.Get())
+		assert.Equal(t, unsafe.StringData(fromJSON.Get()), unsafe.StringData(s.Get()))
+	}
+
+	var null ztype.String
+	require.NoError(t, json.Unmarshal([]byte("null"), &null))
+	require.NoError(t, null.Scan(nil))
+	assert.True(t, null.IsNull())
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestStringInternerConcurrent(t *testing.T) {
+	ztype.SetStringInterner(ztype.InternString)
+	defer ztype.SetStringInterner(nil)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				expected := fmt.Sprintf("status-%d", (i+j)%5)
+				var s ztype.String
+				assert.NoError(t, s.Scan([]byte(expected)))
+				assert.Equal(t, expected, s.Get())
+			}
+		}()
+	}
+	wg.Wait()
+
+	ztype.SetStringInterner(nil)
+	var s ztype.String
+	require.NoError(t, s.UnmarshalText([]byte("plain")))
+	assert.Equal(t, "plain", s.Get())
+}
+
+// BenchmarkStringInterner decodes a low cardinality status column and
+// reports the heap the decoded rows keep alive, with and without interning.
+func BenchmarkStringInterner(b *testing.B) {
+	statuses := []string{"pending", "active", "suspended", "cancelled", "archived"}
+	rows := make([][]byte, 100_000)
+	for i := range rows {
+		rows[i] = []byte(strings.Repeat(statuses[i%len(statuses)], 4))
+	}
+
+	for _, interner := range []struct {
+		name string
+		fn   func(string) string
+	}{
+		{"Default", nil},
+		{"InternString", ztype.InternString},
+	} {
+		b.Run(interner.name, func(b *testing.B) {
+			ztype.SetStringInterner(interner.fn)
+			defer ztype.SetStringInterner(nil)
+			b.ReportAllocs()
+
+			var retained uint64
+			for b.Loop() {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				decoded := make([]ztype.String, len(rows))
+				for i, row := range rows {
+					_ = decoded[i].Scan(row)
+				}
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				retained = after.HeapAlloc - before.HeapAlloc
+				runtime.KeepAlive(decoded)
+			}
+			b.ReportMetric(float64(retained)/float64(len(rows)), "retained-B/row")
+		})
+	}
+}
+
+func TestStringValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.String) }, []ztypetest.Case{
+		{Name: "text", Scan: "hello", Want: "hello"},
+		{Name: "empty", Scan: "", Want: ""},
+		{Name: "bytes", Scan: []byte("hello"), Want: "hello"},
+		{Name: "int64", Scan: int64(1), Want: "1"},
+		{Name: "bool", Scan: true, Want: "true"},
+	})
+}
@@ -0,0 +1,117 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type stringOrEmptyProfile struct {
+	Name     ztype.String        `json:"name"`
+	Nickname ztype.StringOrEmpty `json:"nickname"`
+	Bio      ztype.StringOrEmpty `json:"bio,omitzero"`
+}
+
+func TestStringOrEmptyMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ztype.StringOrEmpty
+		expected string
+	}{
+		{"null", ztype.StringOrEmpty{}, `""`},
+		{"wrapped null", ztype.WithEmptyOnNull(ztype.NewNullString()), `""`},
+		{"empty", ztype.NewStringOrEmpty(""), `""`},
+		{"value", ztype.NewStringOrEmpty(`a"b`), `"a\"b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+
+	t.Run("String is unchanged", func(t *testing.T) {
+		data, err := json.Marshal(stringOrEmptyProfile{Bio: ztype.NewStringOrEmpty("hi")})
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":null,"nickname":"","bio":"hi"}`, string(data))
+	})
+}
+
+func TestStringOrEmptyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		isNull      bool
+		unmarshaled bool
+	}{
+		{"null", `{"nickname":null}`, true, true},
+		{"empty", `{"nickname":""}`, false, true},
+		{"value", `{"nickname":"ana"}`, false, true},
+		{"absent", `{}`, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var profile stringOrEmptyProfile
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &profile))
+			assert.Equal(t, tt.isNull, profile.Nickname.IsNull())
+			assert.Equal(t, tt.unmarshaled, profile.Nickname.Unmarshaled())
+
+			data, err := json.Marshal(profile)
+			require.NoError(t, err)
+			var again stringOrEmptyProfile
+			require.NoError(t, json.Unmarshal(data, &again))
+			assert.Equal(t, profile.Nickname.Get(), again.Nickname.Get())
+			assert.False(t, again.Nickname.IsNull())
+		})
+	}
+
+	t.Run("presence", func(t *testing.T) {
+		var profile stringOrEmptyProfile
+		require.NoError(t, json.Unmarshal([]byte(`{"nickname":null,"bio":""}`), &profile))
+		presence, err := ztype.PresenceMap(&profile)
+		require.NoError(t, err)
+		assert.Equal(t, ztype.PresenceNull, presence["nickname"])
+		assert.Equal(t, ztype.PresenceValue, presence["bio"])
+	})
+}
+
+func TestStringOrEmptyOmitZero(t *testing.T) {
+	tests := []struct {
+		name     string
+		bio      ztype.StringOrEmpty
+		expected string
+	}{
+		{"null is omitted", ztype.StringOrEmpty{}, `{"name":"ana","nickname":""}`},
+		{"empty is omitted", ztype.NewStringOrEmpty(""), `{"name":"ana","nickname":""}`},
+		{"value is kept", ztype.NewStringOrEmpty("hi"), `{"name":"ana","nickname":"","bio":"hi"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(stringOrEmptyProfile{Name: ztype.NewString("ana"), Bio: tt.bio})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestStringOrEmptySQL(t *testing.T) {
+	var s ztype.StringOrEmpty
+	value, err := s.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	zero, err := s.ValueOrZero()
+	require.NoError(t, err)
+	assert.Equal(t, "", zero)
+
+	require.NoError(t, s.Scan("ana"))
+	assert.Equal(t, "ana", s.Get())
+	assert.Equal(t, "ana", s.String())
+	require.NoError(t, s.Scan(nil))
+	assert.True(t, s.IsNull())
+}
@@ -0,0 +1,118 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestSyncMap(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var m ztype.SyncMap[string, int]
+		assert.True(t, m.IsNull())
+		m.SetItem("a", 1)
+		assert.False(t, m.IsNull())
+		assert.True(t, m.Has("a"))
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("LoadOrStore", func(t *testing.T) {
+		m := ztype.NewSyncMap(map[string]int{"a": 1})
+		actual, loaded := m.LoadOrStore("a", 2)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, actual)
+
+		actual, loaded = m.LoadOrStore("b", 2)
+		assert.False(t, loaded)
+		assert.Equal(t, 2, actual)
+	})
+
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		var m ztype.SyncMapComparable[string, int]
+		m.SetItem("a", 1)
+		assert.False(t, m.CompareAndSwap("a", 2, 3))
+		assert.True(t, m.CompareAndSwap("a", 1, 3))
+		assert.False(t, m.CompareAndDelete("a", 1))
+		assert.True(t, m.CompareAndDelete("a", 3))
+		assert.False(t, m.Has("a"))
+	})
+
+	t.Run("snapshot iteration", func(t *testing.T) {
+		m := ztype.NewSyncMap(map[string]int{"a": 1, "b": 2})
+		visited := 0
+		for key := range m.All() {
+			m.DeleteItem(key)
+			m.SetItem(key+"x", 0)
+			visited++
+		}
+		assert.Equal(t, 2, visited)
+		assert.Equal(t, 2, m.Len())
+	})
+
+	t.Run("JSON and SQL", func(t *testing.T) {
+		m := ztype.NewSyncMap(map[string]int{"a": 1})
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":1}`, string(data))
+
+		var decoded ztype.SyncMap[string, int]
+		require.NoError(t, json.Unmarshal([]byte(`null`), &decoded))
+		assert.True(t, decoded.IsNull())
+		assert.True(t, decoded.Unmarshaled())
+
+		value, err := decoded.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		require.NoError(t, decoded.Scan(`{"b":2}`))
+		value, err = decoded.Value()
+		require.NoError(t, err)
+		assert.Equal(t, `{"b":2}`, value)
+	})
+}
+
+func TestSyncMapConcurrency(t *testing.T) {
+	m := ztype.NewNullSyncMap[string, any]()
+
+	var wg sync.WaitGroup
+	for writer := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				key := strconv.Itoa(writer) + "-" + strconv.Itoa(i)
+				m.SetItem(key, i)
+				m.LoadOrStore(key, -1)
+				if i%3 == 0 {
+					m.DeleteItem(key)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				m.Has("0-1")
+				m.GetItem("1-1")
+				for range m.All() {
+				}
+				_, err := json.Marshal(m)
+				assert.NoError(t, err)
+				_, err = m.Value()
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, m.IsNull())
+	assert.Equal(t, 4*(200-67), m.Len())
+}
@@ -0,0 +1,112 @@
+package ztype_test
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type templateUser struct {
+	Name      ztype.String
+	Age       ztype.Numeric[int]
+	Active    ztype.Bool
+	CreatedAt ztype.Time
+	DeletedAt ztype.Null[time.Time]
+	Nickname  *string
+}
+
+func templateUsers() (valid, null templateUser) {
+	nickname := "ana"
+	valid = templateUser{
+		Name:      ztype.NewString("Ana <admin>"),
+		Age:       ztype.NewNumber(30),
+		Active:    ztype.NewBool(true),
+		CreatedAt: ztype.NewTime(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)),
+		DeletedAt: ztype.New(time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)),
+		Nickname:  &nickname,
+	}
+	null = templateUser{
+		Name:      ztype.NewNullString(),
+		Age:       ztype.NewNullNumber[int](),
+		Active:    ztype.NewNullBool(),
+		CreatedAt: ztype.NewNullTime(),
+		DeletedAt: ztype.NewNull[time.Time](),
+	}
+	return valid, null
+}
+
+func executeTextTemplate(t *testing.T, text string, data any) string {
+	t.Helper()
+	tmpl, err := template.New("test").Funcs(ztype.TemplateFuncs()).Parse(text)
+	require.NoError(t, err)
+	var out strings.Builder
+	require.NoError(t, tmpl.Execute(&out, data))
+	return out.String()
+}
+
+func TestTemplatePrintsValues(t *testing.T) {
+	valid, null := templateUsers()
+	const text = `{{ .Name }}|{{ .Age }}|{{ .Active }}`
+
+	assert.Equal(t, "Ana <admin>|30|true", executeTextTemplate(t, text, valid))
+	assert.Equal(t, "Ana <admin>|30|true", executeTextTemplate(t, text, &valid))
+	assert.Equal(t, "<NULL>|<NULL>|<NULL>", executeTextTemplate(t, text, null))
+}
+
+func TestTemplateConditions(t *testing.T) {
+	valid, null := templateUsers()
+	inactive := valid
+	inactive.Active = ztype.NewBool(false)
+	const text = `{{ if .Active.Truth }}active{{ else }}inactive{{ end }}` +
+		`|{{ if isNull .Name }}no name{{ else }}named{{ end }}`
+
+	assert.Equal(t, "active|named", executeTextTemplate(t, text, valid))
+	assert.Equal(t, "inactive|named", executeTextTemplate(t, text, inactive))
+	assert.Equal(t, "inactive|no name", executeTextTemplate(t, text, null))
+
+	assert.Equal(t, "yes", executeTextTemplate(t, `{{ if .Active.Get }}yes{{ end }}`, &valid))
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	valid, null := templateUsers()
+	const text = `{{ .Name | orElse "anonymous" }}` +
+		`|{{ .Age | orElse 0 }}` +
+		`|{{ .Nickname | orElse "-" }}` +
+		`|{{ deref .Age }}` +
+		`|{{ .CreatedAt | fmtTime "2006-01-02" }}` +
+		`|{{ .DeletedAt | fmtTime "02/01/2006" }}`
+
+	assert.Equal(t, "Ana <admin>|30|ana|30|2024-03-01|02/04/2024", executeTextTemplate(t, text, valid))
+	assert.Equal(t, "anonymous|0|-|<no value>||", executeTextTemplate(t, text, null))
+	assert.Equal(t, "anonymous|0|-|<no value>||", executeTextTemplate(t, text, &null))
+}
+
+func TestTemplateFormatTimeError(t *testing.T) {
+	tmpl, err := template.New("test").Funcs(ztype.TemplateFuncs()).Parse(`{{ .Name | fmtTime "2006" }}`)
+	require.NoError(t, err)
+	valid, _ := templateUsers()
+	assert.Error(t, tmpl.Execute(&strings.Builder{}, valid))
+}
+
+func TestHTMLTemplate(t *testing.T) {
+	valid, null := templateUsers()
+	tmpl, err := htmltemplate.New("test").Funcs(ztype.TemplateFuncs()).Parse(
+		`<p>{{ .Name }}</p><p>{{ .Name | orElse "anonymous" }}</p>` +
+			`{{ if .Active.Truth }}<b>active</b>{{ end }}<time>{{ .CreatedAt | fmtTime "2006-01-02" }}</time>`)
+	require.NoError(t, err)
+
+	var out strings.Builder
+	require.NoError(t, tmpl.Execute(&out, valid))
+	assert.Equal(t, "<p>Ana &lt;admin&gt;</p><p>Ana &lt;admin&gt;</p><b>active</b><time>2024-03-01</time>", out.String())
+
+	out.Reset()
+	require.NoError(t, tmpl.Execute(&out, null))
+	assert.Equal(t, "<p>&lt;NULL&gt;</p><p>anonymous</p><time></time>", out.String())
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/ztypetest"
 )
 
 // ============================== Time Tests ==============================
@@ -152,6 +154,9 @@ func TestTimeMarshalJSON(t *testing.T) {
 		expected string
 	}{
 		{"valid", ztype.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)), `"2023-01-01T12:00:00Z"`},
+		{"nanoseconds", ztype.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 123456789, time.UTC)), `"2023-01-01T12:00:00.123456789Z"`},
+		{"trailing zeros", ztype.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 120000000, time.UTC)), `"2023-01-01T12:00:00.12Z"`},
+		{"offset", ztype.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 5000, time.FixedZone("", -3*3600))), `"2023-01-01T12:00:00.000005-03:00"`},
 		{"null", ztype.NewNullTime(), "null"},
 	}
 
@@ -160,10 +165,36 @@ func TestTimeMarshalJSON(t *testing.T) {
 			data, err := tt.input.MarshalJSON()
 			assert.NoError(t, err)
 			assert.JSONEq(t, tt.expected, string(data))
+
+			if !tt.input.IsNull() {
+				text, err := tt.input.MarshalText()
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, `"`+string(text)+`"`)
+			}
 		})
 	}
 }
 
+func TestTimeRoundTripKeepsNanoseconds(t *testing.T) {
+	moment := time.Date(2023, 1, 1, 12, 0, 0, 123456789, time.UTC)
+
+	data, err := json.Marshal(ztype.NewTime(moment))
+	require.NoError(t, err)
+	var decoded ztype.Time
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, decoded.Get().Equal(moment))
+	assert.Equal(t, moment.Nanosecond(), decoded.Get().Nanosecond())
+
+	text, err := ztype.NewTime(moment).MarshalText()
+	require.NoError(t, err)
+	require.NoError(t, decoded.UnmarshalText(text))
+	assert.True(t, decoded.Get().Equal(moment))
+	assert.Equal(t, ztype.NewTime(moment).String(), string(text))
+
+	assert.NoError(t, ztype.RoundTripJSON(ztype.NewTime(moment)))
+	assert.NoError(t, ztype.RoundTripText(ztype.NewTime(moment)))
+}
+
 func TestTimeUnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -188,6 +219,50 @@ func TestTimeUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestTimeUnmarshalTextStrict(t *testing.T) {
+	t.Run("rejects trailing text", func(t *testing.T) {
+		for _, input := range []string{"15:04:99", "12:30x", "2024-01-01junk", "2024-01-01 10:00 extra"} {
+			var zt ztype.Time
+			err := zt.UnmarshalText([]byte(input))
+			assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "Time"}, input)
+			assert.True(t, zt.IsNull(), input)
+		}
+	})
+
+	t.Run("trims surrounding whitespace", func(t *testing.T) {
+		var zt ztype.Time
+		require.NoError(t, zt.UnmarshalText([]byte(" 2024-03-01T10:00:00Z\n")))
+		assert.True(t, zt.Get().Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)))
+
+		require.NoError(t, json.Unmarshal([]byte(`"  12:30  "`), &zt))
+		assert.Equal(t, 12, zt.Get().Hour())
+		assert.Equal(t, 30, zt.Get().Minute())
+
+		require.NoError(t, zt.UnmarshalText([]byte("   ")))
+		assert.True(t, zt.IsNull())
+	})
+
+	t.Run("prefers the longest layout", func(t *testing.T) {
+		var zt ztype.Time
+		require.NoError(t, zt.UnmarshalText([]byte("2024-03-01 10:20:30")))
+		assert.Equal(t, 30, zt.Get().Second())
+	})
+
+	t.Run("names the closest format", func(t *testing.T) {
+		var zt ztype.Time
+		err := zt.UnmarshalText([]byte("15:04:99"))
+		assert.ErrorContains(t, err, `closest format "15:04:05"`)
+		assert.ErrorContains(t, err, "second out of range")
+
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, time.TimeOnly, parseErr.Layout)
+
+		err = json.Unmarshal([]byte(`"2024-01-01 10:00 extra"`), &zt)
+		assert.ErrorContains(t, err, `closest format "2006-01-02 15:04"`)
+	})
+}
+
 // ============================== Duration Tests ==============================
 
 func TestNewDuration(t *testing.T) {
@@ -290,4 +365,498 @@ func TestDurationValue(t *testing.T) {
 	}
 }
 
+func TestTimeValueUTC(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	local := time.Date(2024, 3, 1, 9, 0, 0, 123456789, tokyo)
+
+	val, err := ztype.NewTime(local).Value()
+	require.NoError(t, err)
+	assert.Equal(t, tokyo, val.(time.Time).Location())
+
+	ztype.SetTimeValueUTC(true)
+	defer ztype.SetTimeValueUTC(false)
+
+	val, err = ztype.NewTime(local).Value()
+	require.NoError(t, err)
+	got := val.(time.Time)
+	assert.Equal(t, time.UTC, got.Location())
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 123456789, time.UTC), got)
+	assert.Equal(t, 123456789, got.Nanosecond())
+
+	val, err = ztype.NewTime(time.Now()).Value()
+	require.NoError(t, err)
+	assert.NotContains(t, val.(time.Time).String(), "m=", "monotonic reading is stripped")
+
+	val, err = ztype.NewNullTime().Value()
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestDurationValueMicros(t *testing.T) {
+	ztype.SetDurationValueMicros(true)
+	defer ztype.SetDurationValueMicros(false)
+
+	tests := []struct {
+		input    ztype.Duration
+		expected driver.Value
+	}{
+		{ztype.NewDuration(90*time.Minute + time.Microsecond), "01:30:00.000001"},
+		{ztype.NewDuration(time.Second + 1500*time.Nanosecond), "00:00:01.000001"},
+		{ztype.NewDuration(-(26*time.Hour + 5*time.Second)), "-26:00:05.000000"},
+		{ztype.NewDuration(0), "00:00:00.000000"},
+		{ztype.NewNullDuration(), nil},
+	}
+	for _, tt := range tests {
+		val, err := tt.input.Value()
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, val)
+	}
+
+	val, err := ztype.NewNullDuration().ValueOrZero()
+	require.NoError(t, err)
+	assert.Equal(t, "00:00:00.000000", val)
+}
+
+func TestDurationScanClock(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected time.Duration
+	}{
+		{"01:30:00.000001", 90*time.Minute + time.Microsecond},
+		{[]byte("-26:00:05.000000"), -(26*time.Hour + 5*time.Second)},
+		{"838:59:59", 838*time.Hour + 59*time.Minute + 59*time.Second},
+		{"00:00:00.5", 500 * time.Millisecond},
+		{[]byte("1h30m"), 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		var d ztype.Duration
+		require.NoError(t, d.Scan(tt.input), tt.input)
+		assert.Equal(t, tt.expected, d.Get(), tt.input)
+	}
+
+	for _, input := range []string{"01:60:00", "1:2:3", "01:00:00.", "01:00:00.0000000001"} {
+		var d ztype.Duration
+		assert.ErrorIs(t, d.Scan(input), &ztype.ErrInvalidFormat{}, input)
+	}
+}
+
+func TestDurationValueMode(t *testing.T) {
+	defer ztype.SetDurationValueMode(ztype.DurationValueNanos)
+	d := ztype.NewDuration(90*time.Minute + 1500*time.Nanosecond)
+
+	tests := []struct {
+		mode     ztype.DurationValueMode
+		expected driver.Value
+	}{
+		{ztype.DurationValueNanos, int64(90*time.Minute + 1500*time.Nanosecond)},
+		{ztype.DurationValueString, "1h30m0.0000015s"},
+		{ztype.DurationValueMicros, "01:30:00.000001"},
+	}
+	for _, tt := range tests {
+		ztype.SetDurationValueMode(tt.mode)
+		val, err := d.Value()
+		require.NoError(t, err)
+		assert.IsType(t, tt.expected, val)
+		assert.Equal(t, tt.expected, val)
+
+		null, err := ztype.NewNullDuration().Value()
+		require.NoError(t, err)
+		assert.Nil(t, null)
+	}
+
+	ztype.SetDurationValueMode(ztype.DurationValueString)
+	val, err := ztype.NewNullDuration().ValueOrZero()
+	require.NoError(t, err)
+	assert.Equal(t, "0s", val)
+
+	for _, input := range []time.Duration{0, -3 * time.Second, 26*time.Hour + time.Nanosecond} {
+		val, err := ztype.NewDuration(input).Value()
+		require.NoError(t, err)
+		var scanned ztype.Duration
+		require.NoError(t, scanned.Scan(val))
+		assert.Equal(t, input, scanned.Get(), val)
+	}
+}
+
+func TestTimeOffsetRoundTrip(t *testing.T) {
+	for _, input := range []string{
+		`"2023-06-01T10:00:00+09:00"`,
+		`"2023-06-01T10:00:00-03:30"`,
+		`"2023-06-01T10:00:00.5Z"`,
+	} {
+		var tm ztype.Time
+		require.NoError(t, json.Unmarshal([]byte(input), &tm))
+		data, err := json.Marshal(tm)
+		require.NoError(t, err)
+		assert.Equal(t, input, string(data))
+	}
+
+	scanned := ztype.Time{}
+	require.NoError(t, scanned.Scan(time.Date(2023, 6, 1, 10, 0, 0, 0, time.FixedZone("", 9*3600))))
+	assert.Equal(t, "2023-06-01T10:00:00+09:00", scanned.String())
+}
+
+func TestTimeMarshalUTC(t *testing.T) {
+	ztype.SetTimeMarshalUTC(true)
+	defer ztype.SetTimeMarshalUTC(false)
+
+	tm := ztype.NewTime(time.Date(2023, 6, 1, 10, 0, 0, 0, time.FixedZone("JST", 9*3600)))
+	data, err := json.Marshal(tm)
+	require.NoError(t, err)
+	assert.Equal(t, `"2023-06-01T01:00:00Z"`, string(data))
+	text, err := tm.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "2023-06-01T01:00:00Z", string(text))
+	assert.Equal(t, "2023-06-01T01:00:00Z", tm.String())
+	assert.Equal(t, 10, tm.Get().Hour(), "the stored time keeps its location")
+}
+
+func TestTimePreserveOffset(t *testing.T) {
+	ztype.SetTimePreserveOffset(true)
+	ztype.SetTimeMarshalUTC(true)
+	defer ztype.SetTimePreserveOffset(false)
+	defer ztype.SetTimeMarshalUTC(false)
+
+	var tm ztype.Time
+	require.NoError(t, json.Unmarshal([]byte(`"2023-06-01T10:00:00+09:00"`), &tm))
+	data, err := json.Marshal(tm)
+	require.NoError(t, err)
+	assert.Equal(t, `"2023-06-01T10:00:00+09:00"`, string(data))
+
+	later := tm.Add(ztype.NewDuration(time.Hour))
+	assert.Equal(t, "2023-06-01T11:00:00+09:00", later.String())
+
+	converted := tm.In(time.FixedZone("", -3*3600))
+	assert.Equal(t, "2023-06-01T01:00:00Z", converted.String())
+	assert.Equal(t, "2023-06-01T01:00:00Z", tm.UTC().String())
+
+	var text ztype.Time
+	require.NoError(t, text.UnmarshalText([]byte("2023-06-01T10:00:00-05:00")))
+	assert.Equal(t, "2023-06-01T10:00:00-05:00", text.String())
+	text.Set(text.Get().UTC())
+	assert.Equal(t, "2023-06-01T15:00:00Z", text.String())
+}
+
+func TestTimeDateOnly(t *testing.T) {
+	behindUTC := time.FixedZone("UTC-5", -5*3600)
+
+	t.Run("no day shift when rendered behind UTC", func(t *testing.T) {
+		var plain, date ztype.Time
+		date = date.DateOnly()
+		scanned := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, plain.Scan(scanned))
+		require.NoError(t, date.Scan(scanned))
+
+		assert.Equal(t, "2024-02-29", plain.InRaw(behindUTC).Format(time.DateOnly))
+		assert.Equal(t, "2024-03-01", date.InRaw(behindUTC).Format(time.DateOnly))
+		assert.Equal(t, "2024-03-01", date.LocalRaw().Format(time.DateOnly))
+		local := date.In(behindUTC)
+		assert.Equal(t, "2024-03-01", local.String())
+		assert.Equal(t, "2024-03-01", local.UTCRaw().Format(time.DateOnly))
+	})
+
+	t.Run("encodings", func(t *testing.T) {
+		date := ztype.NewDateOnlyTime(2024, time.March, 1)
+		assert.True(t, date.IsDateOnly())
+
+		data, err := json.Marshal(date)
+		require.NoError(t, err)
+		assert.Equal(t, `"2024-03-01"`, string(data))
+		text, err := date.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-01", string(text))
+
+		value, err := date.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-01", value)
+
+		var scanned ztype.Time
+		scanned = scanned.DateOnly()
+		require.NoError(t, scanned.Scan(value))
+		assert.True(t, scanned.Equal(date))
+		require.NoError(t, scanned.Scan([]byte("2024-03-02")))
+		assert.Equal(t, 2, scanned.Day())
+
+		null := ztype.NewNullTime().DateOnly()
+		value, err = null.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+		zero, err := null.ValueOrZero()
+		require.NoError(t, err)
+		assert.Equal(t, "1970-01-01", zero)
+
+		_, format, _ := date.SchemaType()
+		assert.Equal(t, "date", format)
+	})
+
+	t.Run("mode survives Set and decoding", func(t *testing.T) {
+		date := ztype.NewDateOnlyTime(2024, time.March, 1)
+		date.Set(time.Date(2024, 5, 10, 23, 30, 0, 0, behindUTC))
+		assert.True(t, date.IsDateOnly())
+		assert.Equal(t, time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), date.Get())
+
+		date.SetNull()
+		assert.True(t, date.IsDateOnly())
+		require.NoError(t, json.Unmarshal([]byte(`"2024-06-01T22:00:00-05:00"`), &date))
+		assert.True(t, date.IsDateOnly())
+		assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), date.Get())
+		require.NoError(t, date.Scan(nil))
+		assert.True(t, date.IsNull())
+		assert.True(t, date.IsDateOnly())
+	})
+
+	t.Run("comparisons ignore clock and location", func(t *testing.T) {
+		date := ztype.NewDateOnlyTime(2024, time.March, 1)
+		sameDay := ztype.NewTime(time.Date(2024, 3, 1, 18, 0, 0, 0, behindUTC))
+		nextDay := ztype.NewTime(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+		assert.True(t, date.Equal(sameDay))
+		assert.True(t, sameDay.Equal(date))
+		assert.False(t, date.Equal(nextDay))
+		assert.True(t, date.In(behindUTC).EqualValues(date))
+		assert.False(t, date.Equal(ztype.NewNullTime()))
+	})
+}
+
 // ... Adicione mais testes para cobrir todos os métodos restantes
+
+func TestTimeScanLocation(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	wall := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	inputs := []struct {
+		name  string
+		value time.Time
+	}{
+		{"UTC", wall},
+		{"Local", wall.In(time.Local)},
+		{"fixed", wall.In(tokyo)},
+	}
+
+	t.Run("reinterpret", func(t *testing.T) {
+		ztype.SetScanLocation(saoPaulo)
+		t.Cleanup(func() { ztype.SetScanLocation(nil) })
+
+		expected := map[string]time.Time{
+			"UTC":   time.Date(2024, 3, 1, 12, 0, 0, 0, saoPaulo),
+			"Local": wall.In(time.Local),
+			"fixed": wall.In(tokyo),
+		}
+		for _, in := range inputs {
+			var scanned ztype.Time
+			require.NoError(t, scanned.Scan(in.value), in.name)
+			got := scanned.Get()
+			assert.True(t, expected[in.name].Equal(got), in.name)
+			assert.Equal(t, expected[in.name].Location(), got.Location(), in.name)
+		}
+
+		var scanned ztype.Time
+		require.NoError(t, scanned.Scan(wall))
+		assert.Equal(t, 12, scanned.Get().Hour(), "wall clock is kept")
+		assert.Equal(t, wall.Add(3*time.Hour), scanned.Get().UTC(), "instant moves")
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		ztype.SetScanConvertTo(tokyo)
+		t.Cleanup(func() { ztype.SetScanConvertTo(nil) })
+
+		for _, in := range inputs {
+			var scanned ztype.Time
+			require.NoError(t, scanned.Scan(in.value), in.name)
+			got := scanned.Get()
+			assert.True(t, wall.Equal(got), "instant is kept for %s", in.name)
+			assert.Equal(t, tokyo, got.Location(), in.name)
+			assert.Equal(t, 21, got.Hour(), in.name)
+		}
+	})
+
+	t.Run("reinterpret then convert", func(t *testing.T) {
+		ztype.SetScanLocation(saoPaulo)
+		ztype.SetScanConvertTo(time.UTC)
+		t.Cleanup(func() {
+			ztype.SetScanLocation(nil)
+			ztype.SetScanConvertTo(nil)
+		})
+
+		expected := map[string]time.Time{
+			"UTC":   time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC),
+			"Local": wall,
+			"fixed": wall,
+		}
+		for _, in := range inputs {
+			var scanned ztype.Time
+			require.NoError(t, scanned.Scan(in.value), in.name)
+			assert.Equal(t, expected[in.name], scanned.Get(), in.name)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		for _, in := range inputs {
+			var scanned ztype.Time
+			require.NoError(t, scanned.Scan(in.value), in.name)
+			assert.Equal(t, in.value, scanned.Get(), in.name)
+		}
+	})
+
+	t.Run("date-only and null", func(t *testing.T) {
+		ztype.SetScanLocation(saoPaulo)
+		ztype.SetScanConvertTo(tokyo)
+		t.Cleanup(func() {
+			ztype.SetScanLocation(nil)
+			ztype.SetScanConvertTo(nil)
+		})
+
+		day := ztype.NewDateOnlyTime(2000, 1, 1)
+		require.NoError(t, day.Scan(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, "2024-03-01", day.String())
+
+		var scanned ztype.Time
+		require.NoError(t, scanned.Scan(nil))
+		assert.True(t, scanned.IsNull())
+	})
+}
+
+func TestDurationIntegerNanos(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected time.Duration
+	}{
+		{"bare number", `3600000000000`, time.Hour},
+		{"negative bare number", `-1500`, -1500 * time.Nanosecond},
+		{"numeric string", `"3600000000000"`, time.Hour},
+		{"zero string", `"0"`, 0},
+		{"bare zero", `0`, 0},
+		{"unit suffix", `"1h30m"`, 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d ztype.Duration
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &d))
+			assert.False(t, d.IsNull())
+			assert.Equal(t, tt.expected, d.Get())
+		})
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var d ztype.Duration
+		require.NoError(t, d.UnmarshalText([]byte("3600000000000")))
+		assert.Equal(t, time.Hour, d.Get())
+		require.NoError(t, d.UnmarshalText([]byte("0")))
+		assert.Equal(t, time.Duration(0), d.Get())
+		assert.False(t, d.IsNull())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var d ztype.Duration
+		assert.ErrorIs(t, json.Unmarshal([]byte(`1.5`), &d), &ztype.ErrInvalidFormat{})
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"1e3"`), &d), &ztype.ErrInvalidFormat{})
+		assert.ErrorIs(t, json.Unmarshal([]byte(`99999999999999999999`), &d), &ztype.ErrOverflow{})
+	})
+}
+
+func TestParseTimeFlexible(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{"RFC3339", "2024-03-01T10:00:00Z", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"RFC3339 offset", "2024-03-01T10:00:00-03:00", time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)},
+		{"RFC1123", "Fri, 01 Mar 2024 10:00:00 UTC", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"date time", "2024-03-01 10:20:30", time.Date(2024, 3, 1, 10, 20, 30, 0, time.UTC)},
+		{"date time minutes", "2024-03-01 10:20", time.Date(2024, 3, 1, 10, 20, 0, 0, time.UTC)},
+		{"date only", "2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"day first", "01/03/2024", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"day first time", "01/03/2024 10:20", time.Date(2024, 3, 1, 10, 20, 0, 0, time.UTC)},
+		{"whitespace", " 2024-03-01T10:00:00Z\n", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ztype.ParseTimeFlexible(tt.input)
+			require.NoError(t, err)
+			assert.True(t, parsed.Equal(tt.expected), parsed)
+
+			var zt ztype.Time
+			require.NoError(t, zt.UnmarshalText([]byte(tt.input)))
+			assert.True(t, zt.Get().Equal(parsed))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, input := range []string{"", "   ", "tomorrow", "2024-01-01junk", "15:04:99"} {
+			_, err := ztype.ParseTimeFlexible(input)
+			assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "Time"}, input)
+		}
+		_, err := ztype.ParseTimeFlexible("2024-01-01 10:00 extra")
+		assert.ErrorContains(t, err, `closest format "2006-01-02 15:04"`)
+	})
+
+	t.Run("in location", func(t *testing.T) {
+		zone := time.FixedZone("BRT", -3*3600)
+		parsed, err := ztype.ParseTimeFlexibleIn("2024-03-01 12:30", zone)
+		require.NoError(t, err)
+		assert.True(t, parsed.Equal(time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC)))
+		assert.Equal(t, zone, parsed.Location())
+
+		withOffset, err := ztype.ParseTimeFlexibleIn("2024-03-01T12:30:00Z", zone)
+		require.NoError(t, err)
+		assert.True(t, withOffset.Equal(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)))
+
+		_, err = ztype.ParseTimeFlexibleIn("not a time", zone)
+		assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+	})
+}
+
+func TestParseDurationFlexible(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"1h30m", 90 * time.Minute},
+		{"-2.5s", -2500 * time.Millisecond},
+		{"0", 0},
+		{"3600000000000", time.Hour},
+		{"-1500", -1500 * time.Nanosecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			parsed, err := ztype.ParseDurationFlexible(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+
+			var d ztype.Duration
+			require.NoError(t, d.UnmarshalText([]byte(tt.input)))
+			assert.Equal(t, parsed, d.Get())
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, input := range []string{"", "1e3", "1.5", "soon", "1h30"} {
+			_, err := ztype.ParseDurationFlexible(input)
+			assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{Type: "Duration"}, input)
+		}
+		_, err := ztype.ParseDurationFlexible("99999999999999999999")
+		assert.ErrorIs(t, err, &ztype.ErrOverflow{})
+	})
+}
+
+func TestTimeValuerScanner(t *testing.T) {
+	moment := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Time) }, []ztypetest.Case{
+		{Name: "time", Scan: moment, Want: moment},
+		{Name: "offset", Scan: moment.In(time.FixedZone("", -3*3600)), Want: moment},
+		{Name: "int64", Scan: int64(1), Err: true},
+		{Name: "bool", Scan: true, Err: true},
+	})
+}
+
+func TestDurationValuerScanner(t *testing.T) {
+	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer { return new(ztype.Duration) }, []ztypetest.Case{
+		{Name: "int64", Scan: int64(time.Hour), Want: int64(time.Hour)},
+		{Name: "text", Scan: "1h30m", Want: int64(90 * time.Minute)},
+		{Name: "clock", Scan: []byte("01:30:00.000000"), Want: int64(90 * time.Minute)},
+		{Name: "float", Scan: 1.5, Err: true},
+		{Name: "bool", Scan: true, Err: true},
+	})
+}
@@ -0,0 +1,110 @@
+package ztype_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type taggedAudit struct {
+	Seen ztype.Time `json:"seen" ztime:"unix=s"`
+}
+
+type taggedEvent struct {
+	taggedAudit
+	Day      ztype.Time         `json:"day" ztime:"layout=2006-01-02"`
+	Created  ztype.Time         `json:"created" ztime:"unix=ms"`
+	Updated  ztype.Time         `json:"updated"`
+	Title    ztype.String       `json:"title"`
+	Shipping *taggedShipping    `json:"shipping,omitempty"`
+	Count    ztype.Numeric[int] `json:"count"`
+}
+
+type taggedShipping struct {
+	Delivered ztype.Time `json:"delivered" ztime:"layout=02/01/2006 15:04"`
+}
+
+func TestUnmarshalJSONWithTags(t *testing.T) {
+	data := `{
+		"day": "2024-03-01",
+		"created": 1709294400123,
+		"updated": "2024-03-01T12:00:00+09:00",
+		"seen": "1709294400",
+		"title": "launch",
+		"Shipping": {"delivered": "05/03/2024 14:30"},
+		"count": 3
+	}`
+	var event taggedEvent
+	require.NoError(t, ztype.UnmarshalJSONWithTags([]byte(data), &event))
+
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), event.Day.Get())
+	assert.Equal(t, time.UnixMilli(1709294400123).UTC(), event.Created.Get())
+	assert.Equal(t, time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC), event.Updated.Get().UTC())
+	assert.Equal(t, time.Unix(1709294400, 0).UTC(), event.Seen.Get())
+	assert.Equal(t, time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC), event.Shipping.Delivered.Get())
+	assert.Equal(t, "launch", event.Title.Get())
+	assert.Equal(t, 3, event.Count.Get())
+	assert.True(t, event.Day.Unmarshaled())
+}
+
+func TestUnmarshalJSONWithTagsNulls(t *testing.T) {
+	event := taggedEvent{Day: ztype.NewTime(time.Now()), Created: ztype.NewTime(time.Now())}
+	require.NoError(t, ztype.UnmarshalJSONWithTags([]byte(`{"day":null,"created":"","updated":null}`), &event))
+	assert.True(t, event.Day.IsNull())
+	assert.True(t, event.Day.Unmarshaled())
+	assert.True(t, event.Created.IsNull())
+	assert.True(t, event.Updated.IsNull())
+	assert.False(t, event.Seen.Unmarshaled())
+}
+
+func TestUnmarshalJSONWithTagsErrors(t *testing.T) {
+	var event taggedEvent
+	err := ztype.UnmarshalJSONWithTags([]byte(`{"day":"01/03/2024"}`), &event)
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+	assert.ErrorContains(t, err, "day: ")
+
+	err = ztype.UnmarshalJSONWithTags([]byte(`{"shipping":{"delivered":"2024-03-05"}}`), &event)
+	assert.ErrorContains(t, err, "shipping.delivered: ")
+
+	err = ztype.UnmarshalJSONWithTags([]byte(`{"created":"yesterday"}`), &event)
+	assert.ErrorContains(t, err, "created: ")
+	assert.ErrorContains(t, err, "Unix timestamp in ms")
+
+	var bad struct {
+		At ztype.Time `ztime:"unix=minutes"`
+	}
+	assert.ErrorContains(t, ztype.UnmarshalJSONWithTags([]byte(`{}`), &bad), `invalid ztime tag "unix=minutes"`)
+
+	var wrongType struct {
+		At ztype.String `ztime:"unix=s"`
+	}
+	assert.Error(t, ztype.UnmarshalJSONWithTags([]byte(`{}`), &wrongType))
+	assert.Error(t, ztype.UnmarshalJSONWithTags([]byte(`{}`), event))
+}
+
+func TestMarshalJSONWithTags(t *testing.T) {
+	event := taggedEvent{
+		taggedAudit: taggedAudit{Seen: ztype.NewTime(time.Unix(1709294400, 0))},
+		Day:         ztype.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		Created:     ztype.NewNullTime(),
+		Updated:     ztype.NewTime(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)),
+		Title:       ztype.NewString("launch"),
+		Shipping:    &taggedShipping{Delivered: ztype.NewTime(time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC))},
+		Count:       ztype.NewNumber(3),
+	}
+	data, err := ztype.MarshalJSONWithTags(&event)
+	require.NoError(t, err)
+	assert.Equal(t, `{"seen":1709294400,"day":"2024-03-01","created":null,"updated":"2024-03-01T12:00:00Z",`+
+		`"title":"launch","shipping":{"delivered":"05/03/2024 14:30"},"count":3}`, string(data))
+
+	var decoded taggedEvent
+	require.NoError(t, ztype.UnmarshalJSONWithTags(data, &decoded))
+	assert.True(t, event.Day.EqualValues(decoded.Day))
+	assert.True(t, event.Seen.EqualValues(decoded.Seen))
+	assert.True(t, decoded.Created.IsNull())
+	assert.True(t, event.Shipping.Delivered.EqualValues(decoded.Shipping.Delivered))
+}
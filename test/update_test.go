@@ -0,0 +1,110 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type updateAudit struct {
+	UpdatedBy ztype.String `json:"updated_by" db:"updated_by"`
+}
+
+type updateUserRequest struct {
+	updateAudit
+	Name     ztype.String       `json:"name" db:"full_name"`
+	Email    ztype.String       `json:"email" db:"email"`
+	Age      ztype.Numeric[int] `json:"age" db:"age"`
+	Active   ztype.Bool         `json:"active" db:"is_active"`
+	Password ztype.String       `json:"password" db:"-"`
+	Nickname ztype.String       `json:"nickname"`
+}
+
+func decodeUpdateRequest(t *testing.T, input string) updateUserRequest {
+	t.Helper()
+	var req updateUserRequest
+	require.NoError(t, json.Unmarshal([]byte(input), &req))
+	return req
+}
+
+func TestBuildUpdatePlaceholders(t *testing.T) {
+	req := decodeUpdateRequest(t, `{"name":"Ana","age":30,"updated_by":"admin"}`)
+
+	t.Run("dollar", func(t *testing.T) {
+		query, args, err := ztype.BuildUpdate("users", &req, ztype.WithWhere("id", 7))
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE users SET updated_by = $1, full_name = $2, age = $3 WHERE id = $4", query)
+		require.Len(t, args, 4)
+		assert.Equal(t, "Ana", args[1].(ztype.String).String())
+		assert.Equal(t, 7, args[3])
+	})
+
+	t.Run("question", func(t *testing.T) {
+		query, args, err := ztype.BuildUpdate("app.users", req,
+			ztype.WithPlaceholder(ztype.PlaceholderQuestion),
+			ztype.WithWhere("id", 7),
+			ztype.WithWhere("tenant_id", "acme"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE app.users SET updated_by = ?, full_name = ?, age = ? WHERE id = ? AND tenant_id = ?", query)
+		assert.Len(t, args, 5)
+	})
+}
+
+func TestBuildUpdateNullsAndTags(t *testing.T) {
+	req := decodeUpdateRequest(t, `{"email":null,"active":false,"password":"secret","nickname":"ana"}`)
+
+	query, args, err := ztype.BuildUpdate("users", &req, ztype.WithWhere("id", 7))
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET email = NULL, is_active = $1, Nickname = $2 WHERE id = $3", query)
+	require.Len(t, args, 3)
+
+	value, err := args[0].(ztype.Bool).Value()
+	require.NoError(t, err)
+	assert.Equal(t, false, value)
+}
+
+func TestBuildUpdateNoChangedFields(t *testing.T) {
+	req := decodeUpdateRequest(t, `{"password":"secret","unknown":1}`)
+
+	_, _, err := ztype.BuildUpdate("users", &req, ztype.WithWhere("id", 7))
+	assert.ErrorIs(t, err, ztype.ErrNoChangedFields)
+}
+
+func TestBuildUpdateIdentifiers(t *testing.T) {
+	req := decodeUpdateRequest(t, `{"name":"Ana"}`)
+
+	tests := []struct {
+		name  string
+		table string
+		where string
+	}{
+		{"table injection", "users; DROP TABLE users", "id"},
+		{"quoted table", `"users"`, "id"},
+		{"empty table", "", "id"},
+		{"where injection", "users", "id = 1 OR 1"},
+		{"where comment", "users", "id--"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ztype.BuildUpdate(tt.table, &req, ztype.WithWhere(tt.where, 1))
+			assert.Error(t, err)
+		})
+	}
+
+	type badColumn struct {
+		Name ztype.String `json:"name" db:"name = name, admin"`
+	}
+	var bad badColumn
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"x"}`), &bad))
+	_, _, err := ztype.BuildUpdate("users", &bad)
+	assert.Error(t, err)
+
+	_, _, err = ztype.BuildUpdate("users", 42)
+	assert.Error(t, err)
+}
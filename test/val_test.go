@@ -0,0 +1,52 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhaori96/ztype"
+)
+
+// valCase runs the valid and null cases of a Val accessor.
+func valCase[T any](t *testing.T, name string, valid func() (T, bool), expected T, null func() (T, bool)) {
+	t.Run(name, func(t *testing.T) {
+		value, ok := valid()
+		assert.True(t, ok)
+		assert.Equal(t, expected, value)
+
+		value, ok = null()
+		assert.False(t, ok)
+		var zero T
+		assert.Equal(t, zero, value)
+	})
+}
+
+func TestVal(t *testing.T) {
+	moment := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	addr := netip.MustParseAddr("10.0.0.1")
+	prefix := netip.MustParsePrefix("10.0.0.0/8")
+
+	valCase(t, "String", ztype.NewString("").Val, "", ztype.NewNullString().Val)
+	valCase(t, "Bool", ztype.NewBool(false).Val, false, ztype.NewNullBool().Val)
+	valCase(t, "Byte", ztype.NewByte(0).Val, byte(0), ztype.NewNullByte().Val)
+	valCase(t, "Char", ztype.NewChar('A').Val, byte('A'), ztype.NewNullChar().Val)
+	valCase(t, "Numeric", ztype.NewNumber(0.0).Val, 0.0, ztype.NewNullNumber[float64]().Val)
+	valCase(t, "Time", ztype.NewTime(moment).Val, moment, ztype.NewNullTime().Val)
+	valCase(t, "Duration", ztype.NewDuration(time.Second).Val, time.Second, ztype.NewNullDuration().Val)
+	valCase(t, "Rune", ztype.NewRune('é').Val, 'é', ztype.NewNullRune().Val)
+	valCase(t, "Bytes", ztype.NewBytes([]byte{}).Val, []byte{}, ztype.NewNullBytes().Val)
+	valCase(t, "IP", ztype.NewIP(addr).Val, addr, ztype.NewNullIP().Val)
+	valCase(t, "CIDR", ztype.NewCIDR(prefix).Val, prefix, ztype.NewNullCIDR().Val)
+	valCase(t, "RawJSON", ztype.NewRawJSON(json.RawMessage("null")).Val, json.RawMessage("null"), ztype.NewNullRawJSON().Val)
+	valCase(t, "Enum", quickStatusType.MustNew("active").Val, quickStatus("active"), ztype.Enum[quickStatus]{}.Val)
+	valCase(t, "Map", ztype.NewMap(map[string]int{}).Val, map[string]int{}, ztype.NewNullMap[string, int]().Val)
+	valCase(t, "PairMap", ztype.NewPairMap(map[string]int{"a": 1}).Val, map[string]int{"a": 1}, ztype.NewNullPairMap[string, int]().Val)
+	valCase(t, "SyncMap", ztype.NewSyncMap(map[string]int{"a": 1}).Val, map[string]int{"a": 1}, ztype.NewNullSyncMap[string, int]().Val)
+	valCase(t, "Slice", ztype.NewSlice([]int{1}).Val, []int{1}, ztype.NewNullSlice[int]().Val)
+	valCase(t, "Array", ztype.NewArray(ztype.NewString("a")).Val, []ztype.String{ztype.NewString("a")}, ztype.NewNullArray[ztype.String]().Val)
+	valCase(t, "Null", ztype.New(0).Val, 0, ztype.NewNull[int]().Val)
+}
@@ -0,0 +1,105 @@
+package ztype_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+	"github.com/zhaori96/ztype/validatortype"
+)
+
+type validatedRequest struct {
+	Name     ztype.String            `json:"name" validate:"required,max=10"`
+	Nickname ztype.String            `json:"nickname" validate:"omitempty,max=3"`
+	Age      ztype.Numeric[int]      `json:"age" validate:"required_notnull,gte=0,lte=130"`
+	Active   ztype.Bool              `json:"active" validate:"required_notnull"`
+	Address  ztype.IP                `json:"address" validate:"omitempty,ipv4"`
+	Tags     ztype.Slice[string]     `json:"tags" validate:"omitempty,max=2,dive,min=2"`
+	Price    ztype.Money             `json:"price" validate:"omitempty,gt=0"`
+	Status   ztype.Enum[quickStatus] `json:"status" validate:"omitempty,oneof=active blocked"`
+	Score    ztype.Numeric[float64]  `json:"score" validate:"required"`
+}
+
+// newTestValidator returns a validator with the ztype integration registered.
+func newTestValidator() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validatortype.RegisterValidations(validate)
+	validatortype.RegisterTypes(validate, ztype.Enum[quickStatus]{})
+	return validate
+}
+
+// failedTags validates input decoded into a validatedRequest and returns the
+// failed tag for each field.
+func failedTags(t *testing.T, input string) map[string]string {
+	t.Helper()
+	var req validatedRequest
+	require.NoError(t, json.Unmarshal([]byte(input), &req))
+
+	failed := map[string]string{}
+	err := newTestValidator().Struct(req)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		for _, fieldErr := range validationErrors {
+			failed[fieldErr.Field()] = fieldErr.Tag()
+		}
+	} else {
+		require.NoError(t, err)
+	}
+	return failed
+}
+
+func TestValidatorTypeValidFields(t *testing.T) {
+	failed := failedTags(t, `{
+		"name": "Ana", "nickname": "an", "age": 30, "active": true,
+		"address": "10.0.0.1", "tags": ["go", "sql"],
+		"price": {"amount": "9.90", "currency": "BRL"},
+		"status": "active", "score": 1.5
+	}`)
+	assert.Empty(t, failed)
+}
+
+func TestValidatorTypeNullFields(t *testing.T) {
+	failed := failedTags(t, `{
+		"name": null, "nickname": null, "age": null, "active": null,
+		"address": null, "tags": null, "price": null, "status": null, "score": null
+	}`)
+	assert.Equal(t, map[string]string{
+		"Name":   "required",
+		"Age":    "required_notnull",
+		"Active": "required_notnull",
+		"Score":  "required",
+	}, failed)
+}
+
+func TestValidatorTypeZeroFields(t *testing.T) {
+	failed := failedTags(t, `{
+		"name": "", "nickname": "", "age": 0, "active": false,
+		"tags": [], "price": {"amount": "0", "currency": "BRL"}, "score": 0
+	}`)
+	assert.Equal(t, map[string]string{
+		"Name":  "required",
+		"Score": "required",
+	}, failed)
+}
+
+func TestValidatorTypeConstraints(t *testing.T) {
+	failed := failedTags(t, `{
+		"name": "a name that is too long", "nickname": "nick", "age": 200, "active": true,
+		"address": "::1", "tags": ["a", "b", "c"], "price": {"amount": "-1", "currency": "BRL"},
+		"status": "deleted", "score": 2
+	}`)
+	assert.Equal(t, map[string]string{
+		"Name":     "max",
+		"Nickname": "max",
+		"Age":      "lte",
+		"Address":  "ipv4",
+		"Tags":     "max",
+		"Price":    "gt",
+		"Status":   "oneof",
+	}, failed)
+}
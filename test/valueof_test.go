@@ -0,0 +1,229 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type valueOfEmail struct{ address ztype.String }
+
+func (e valueOfEmail) Value() (driver.Value, error) { return ztype.ValueOf(e.address) }
+
+func (e *valueOfEmail) Scan(src any) error { return ztype.ScanInto(&e.address, src) }
+
+// valueOfNested returns a ztype value from Value, which is not a driver value.
+type valueOfNested struct{ inner ztype.Numeric[int32] }
+
+func (n valueOfNested) Value() (driver.Value, error) { return n.inner, nil }
+
+type valueOfLoop struct{}
+
+func (l valueOfLoop) Value() (driver.Value, error) { return l, nil }
+
+type valueOfFailing struct{}
+
+func (valueOfFailing) Value() (driver.Value, error) { return nil, errors.New("boom") }
+
+type valueOfLevel int16
+
+func TestValueOfZtypeTypes(t *testing.T) {
+	for _, tt := range valuerCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ztype.ValueOf(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestValueOfGoValues(t *testing.T) {
+	moment := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var nilString *ztype.String
+	word := "a"
+	text := ztype.NewString("a")
+
+	tests := []struct {
+		name     string
+		value    any
+		expected driver.Value
+	}{
+		{"nil", nil, nil},
+		{"nil ztype pointer", nilString, nil},
+		{"ztype pointer", &text, "a"},
+		{"int64", int64(1), int64(1)},
+		{"float64", 1.5, 1.5},
+		{"bool", true, true},
+		{"string", "a", "a"},
+		{"bytes", []byte("a"), []byte("a")},
+		{"time", moment, moment},
+		{"int", 1, int64(1)},
+		{"int8", int8(-1), int64(-1)},
+		{"uint16", uint16(1), int64(1)},
+		{"uint64", uint64(math.MaxInt64), int64(math.MaxInt64)},
+		{"float32", float32(0.5), 0.5},
+		{"named int", valueOfLevel(3), int64(3)},
+		{"string pointer", &word, "a"},
+		{"date-only time", ztype.NewDateOnlyTime(2024, 3, 1), "2024-03-01"},
+		{"wrapper", valueOfEmail{address: ztype.NewString("a@b.c")}, "a@b.c"},
+		{"nested valuer", valueOfNested{inner: ztype.NewNumber[int32](7)}, int64(7)},
+		{"null nested valuer", valueOfNested{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ztype.ValueOf(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestValueOfErrors(t *testing.T) {
+	_, err := ztype.ValueOf(uint64(math.MaxUint64))
+	assert.ErrorIs(t, err, &ztype.ErrOverflow{})
+
+	_, err = ztype.ValueOf(struct{}{})
+	assert.ErrorIs(t, err, &ztype.ErrUnsupportedScanType{})
+
+	_, err = ztype.ValueOf(valueOfLoop{})
+	assert.ErrorContains(t, err, "nested more than")
+
+	_, err = ztype.ValueOf(valueOfFailing{})
+	assert.EqualError(t, err, "ztype_test.valueOfFailing: boom")
+}
+
+// scanIntoKinds lists one value of every kind a driver returns.
+var scanIntoKinds = []struct {
+	name  string
+	value any
+}{
+	{"nil", nil},
+	{"int64", int64(1)},
+	{"float64", 1.5},
+	{"bool", true},
+	{"string", "1"},
+	{"bytes", []byte("1")},
+	{"time", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+}
+
+// scanIntoFails marks a cell of the ScanInto table that must return an error.
+type scanIntoFails struct{}
+
+func TestScanIntoTable(t *testing.T) {
+	moment := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	fails := scanIntoFails{}
+
+	// Each row lists, per kind in scanIntoKinds order, the ValueOf of dest
+	// after scanning, or fails.
+	tests := []struct {
+		name     string
+		dest     func() any
+		expected []any
+	}{
+		{"Bool", func() any { return new(ztype.Bool) }, []any{nil, true, true, true, true, true, fails}},
+		{"Byte", func() any { return new(ztype.Byte) }, []any{nil, int64(1), fails, int64(1), int64(1), int64(1), fails}},
+		{"Char", func() any { return new(ztype.Char) }, []any{nil, int64(1), fails, int64(1), int64(1), int64(1), fails}},
+		{"Bytes", func() any { return new(ztype.Bytes) }, []any{nil, fails, fails, fails, []byte("1"), []byte("1"), fails}},
+		{"String", func() any { return new(ztype.String) }, []any{nil, "1", "1.5", "true", "1", "1", "2024-03-01T12:00:00Z"}},
+		{"Numeric[int64]", func() any { return new(ztype.Numeric[int64]) }, []any{nil, int64(1), fails, int64(1), int64(1), int64(1), fails}},
+		{"Numeric[float64]", func() any { return new(ztype.Numeric[float64]) }, []any{nil, 1.0, 1.5, 1.0, 1.0, 1.0, fails}},
+		{"Time", func() any { return new(ztype.Time) }, []any{nil, fails, fails, fails, fails, fails, moment}},
+		{"Duration", func() any { return new(ztype.Duration) }, []any{nil, int64(1), fails, fails, fails, fails, fails}},
+		{"Rune", func() any { return new(ztype.Rune) }, []any{nil, "\x01", fails, fails, "1", "1", fails}},
+		{"IP", func() any { return new(ztype.IP) }, []any{nil, fails, fails, fails, fails, fails, fails}},
+		{"CIDR", func() any { return new(ztype.CIDR) }, []any{nil, fails, fails, fails, fails, fails, fails}},
+		{"RawJSON", func() any { return new(ztype.RawJSON) }, []any{nil, fails, fails, fails, []byte("1"), []byte("1"), fails}},
+		{"Map", func() any { return new(ztype.Map[string, int]) }, []any{nil, fails, fails, fails, fails, fails, fails}},
+		{"Slice", func() any { return new(ztype.Slice[int]) }, []any{nil, fails, fails, fails, fails, fails, fails}},
+		{"Set", func() any { return new(ztype.Set[int]) }, []any{nil, fails, fails, fails, fails, fails, fails}},
+		{"OrderedMap", func() any { return new(ztype.OrderedMap[string, int]) }, []any{nil, fails, fails, fails, fails, fails, fails}},
+		{"Array", func() any { return new(ztype.Array[ztype.String]) }, []any{nil, fails, fails, fails, fails, fails, fails}},
+		{"Null[int64]", func() any { return new(ztype.Null[int64]) }, []any{nil, int64(1), fails, fails, int64(1), int64(1), fails}},
+		{"*any", func() any { return new(any) }, []any{nil, int64(1), 1.5, true, "1", []byte("1"), moment}},
+		{"*string", func() any { return new(string) }, []any{fails, "1", "1.5", "true", "1", "1", fails}},
+		{"*[]byte", func() any { return new([]byte) }, []any{fails, fails, fails, fails, []byte("1"), []byte("1"), fails}},
+		{"*bool", func() any { return new(bool) }, []any{fails, fails, fails, true, fails, fails, fails}},
+		{"*time.Time", func() any { return new(time.Time) }, []any{fails, fails, fails, fails, fails, fails, moment}},
+		{"*int32", func() any { return new(int32) }, []any{fails, int64(1), fails, fails, int64(1), int64(1), fails}},
+		{"*float32", func() any { return new(float32) }, []any{fails, 1.0, 1.5, fails, 1.0, 1.0, fails}},
+	}
+	for _, tt := range tests {
+		require.Len(t, tt.expected, len(scanIntoKinds), tt.name)
+		for i, kind := range scanIntoKinds {
+			t.Run(tt.name+"/"+kind.name, func(t *testing.T) {
+				dest := tt.dest()
+				err := ztype.ScanInto(dest, kind.value)
+				if tt.expected[i] == fails {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				value, err := ztype.ValueOf(dest)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected[i], value)
+			})
+		}
+	}
+}
+
+func TestScanIntoValid(t *testing.T) {
+	t.Run("parsed text", func(t *testing.T) {
+		var ip ztype.IP
+		require.NoError(t, ztype.ScanInto(&ip, "10.0.0.1"))
+		assert.Equal(t, "10.0.0.1", ip.String())
+
+		var tags ztype.Slice[string]
+		require.NoError(t, ztype.ScanInto(&tags, []byte(`["a"]`)))
+		assert.Equal(t, []string{"a"}, tags.Get())
+	})
+
+	t.Run("copies between ztype values", func(t *testing.T) {
+		var count ztype.Numeric[int32]
+		require.NoError(t, ztype.ScanInto(&count, ztype.NewString("12")))
+		assert.Equal(t, int32(12), count.Get())
+
+		count = ztype.NewNumber[int32](5)
+		require.NoError(t, ztype.ScanInto(&count, ztype.NewNullString()))
+		assert.True(t, count.IsNull())
+	})
+
+	t.Run("wrapper delegates", func(t *testing.T) {
+		var email valueOfEmail
+		require.NoError(t, ztype.ScanInto(&email, "a@b.c"))
+		assert.Equal(t, "a@b.c", email.address.Get())
+
+		require.NoError(t, ztype.ScanInto(&email, nil))
+		assert.True(t, email.address.IsNull())
+	})
+
+	t.Run("bytes are copied", func(t *testing.T) {
+		src := []byte("ab")
+		var dest []byte
+		require.NoError(t, ztype.ScanInto(&dest, src))
+		src[0] = 'x'
+		assert.Equal(t, []byte("ab"), dest)
+	})
+}
+
+func TestScanIntoErrors(t *testing.T) {
+	var s ztype.String
+	assert.Error(t, ztype.ScanInto(s, "a"), "non-pointer dest")
+	assert.Error(t, ztype.ScanInto((*ztype.String)(nil), "a"), "nil pointer dest")
+
+	var n ztype.Numeric[int8]
+	assert.ErrorIs(t, ztype.ScanInto(&n, int64(300)), &ztype.ErrOverflow{})
+
+	var b ztype.Bool
+	assert.ErrorIs(t, ztype.ScanInto(&b, time.Now()), &ztype.ErrUnsupportedScanType{})
+
+	var small uint8
+	assert.ErrorIs(t, ztype.ScanInto(&small, int64(-1)), &ztype.ErrOverflow{})
+	assert.ErrorIs(t, ztype.ScanInto(&small, "x"), &ztype.ErrInvalidFormat{})
+}
@@ -0,0 +1,78 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// valuerCases holds non-pointer values as database/sql receives them when a
+// struct field is passed to Exec directly.
+func valuerCases() []struct {
+	name     string
+	value    any
+	expected driver.Value
+} {
+	moment := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	money := ztype.NewMoney(1990, "BRL")
+
+	return []struct {
+		name     string
+		value    any
+		expected driver.Value
+	}{
+		{"Bool", ztype.NewBool(true), true},
+		{"Byte", ztype.NewByte(7), int64(7)},
+		{"Char", ztype.NewChar('A'), int64('A')},
+		{"Bytes", ztype.NewBytes([]byte("ab")), []byte("ab")},
+		{"String", ztype.NewString("a"), "a"},
+		{"Numeric", ztype.NewNumber[int64](42), int64(42)},
+		{"Float", ztype.NewNumber(1.5), 1.5},
+		{"Time", ztype.NewTime(moment), moment},
+		{"Duration", ztype.NewDuration(time.Second), int64(time.Second)},
+		{"Rune", ztype.NewRune('é'), "é"},
+		{"Enum", enumTestStatusType.MustNew("active"), "active"},
+		{"IP", ztype.NewIP(netip.MustParseAddr("10.0.0.1")), "10.0.0.1"},
+		{"CIDR", ztype.NewCIDR(netip.MustParsePrefix("10.0.0.0/8")), "10.0.0.0/8"},
+		{"RawJSON", ztype.NewRawJSON([]byte(`{"a":1}`)), []byte(`{"a":1}`)},
+		{"Map", ztype.NewMap(map[string]int{"a": 1}), `{"a":1}`},
+		{"Slice", ztype.NewSlice([]int{1, 2}), `[1,2]`},
+		{"Set", ztype.NewSet("b", "a"), `["a","b"]`},
+		{"Null", ztype.New[int64](3), int64(3)},
+		{"Money", money, `{"amount":"19.90","currency":"BRL"}`},
+		{"MoneyAmount", *money.AmountColumn(), int64(1990)},
+		{"MoneyCurrency", *money.CurrencyColumn(), "BRL"},
+		{"NullBool", ztype.NewNullBool(), nil},
+		{"NullTime", ztype.NewNullTime(), nil},
+		{"NullMap", ztype.NewNullMap[string, int](), nil},
+	}
+}
+
+func TestValuerNonPointerValues(t *testing.T) {
+	for _, tt := range valuerCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			valuer, ok := tt.value.(driver.Valuer)
+			require.True(t, ok, "%T must implement driver.Valuer on a value receiver", tt.value)
+
+			value, err := valuer.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestValuerDefaultParameterConverter(t *testing.T) {
+	for _, tt := range valuerCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := driver.DefaultParameterConverter.ConvertValue(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package ztype_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+func TestTimeStartAndEndOfWeek(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name      string
+		at        time.Time
+		weekStart time.Weekday
+		start     time.Time
+		end       time.Time
+	}{
+		{"new year Sunday start", day(2025, 1, 1).Add(15 * time.Hour), time.Sunday, day(2024, 12, 29), day(2025, 1, 5)},
+		{"new year Monday start", day(2025, 1, 1).Add(15 * time.Hour), time.Monday, day(2024, 12, 30), day(2025, 1, 6)},
+		{"Dec 31 Sunday start", day(2024, 12, 31), time.Sunday, day(2024, 12, 29), day(2025, 1, 5)},
+		{"on Sunday, Sunday start", day(2023, 12, 31).Add(23 * time.Hour), time.Sunday, day(2023, 12, 31), day(2024, 1, 7)},
+		{"on Sunday, Monday start", day(2023, 12, 31).Add(23 * time.Hour), time.Monday, day(2023, 12, 25), day(2024, 1, 1)},
+		{"Saturday start", day(2024, 3, 1), time.Saturday, day(2024, 2, 24), day(2024, 3, 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := ztype.NewTime(tt.at)
+			start := value.StartOfWeek(tt.weekStart)
+			end := value.EndOfWeek(tt.weekStart)
+			assert.Equal(t, tt.start, start.Get())
+			assert.Equal(t, tt.end.Add(-time.Nanosecond), end.Get())
+			assert.True(t, value.SameWeek(start, tt.weekStart))
+			assert.True(t, value.SameWeek(end, tt.weekStart))
+			assert.False(t, value.SameWeek(ztype.NewTime(tt.end), tt.weekStart))
+		})
+	}
+}
+
+func TestTimeWeekInLocation(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*3600)
+	// Saturday 22:00 in São Paulo is already Sunday 01:00 UTC.
+	value := ztype.NewTime(time.Date(2025, 1, 4, 22, 0, 0, 0, saoPaulo))
+
+	start := value.StartOfWeek(time.Sunday)
+	assert.Equal(t, time.Date(2024, 12, 29, 0, 0, 0, 0, saoPaulo), start.Get())
+	assert.Equal(t, saoPaulo, start.Get().Location())
+
+	saturdayUTC := ztype.NewTime(time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC))
+	assert.True(t, value.SameWeek(saturdayUTC, time.Sunday), "other is read in the receiver's location")
+	assert.False(t, saturdayUTC.SameWeek(value, time.Sunday))
+}
+
+func TestTimeEndOfWeekDateOnly(t *testing.T) {
+	value := ztype.NewDateOnlyTime(2025, 1, 1)
+	end := value.EndOfWeek(time.Monday)
+	assert.True(t, end.IsDateOnly())
+	assert.Equal(t, "2025-01-05", end.String())
+	assert.Equal(t, "2024-12-30", value.StartOfWeek(time.Monday).String())
+}
+
+func TestTimeWeekOfMonth(t *testing.T) {
+	tests := map[int]int{1: 1, 7: 1, 8: 2, 14: 2, 15: 3, 28: 4, 29: 5, 31: 5}
+	for d, want := range tests {
+		value := ztype.NewTime(time.Date(2025, 1, d, 12, 0, 0, 0, time.UTC))
+		assert.Equal(t, want, value.WeekOfMonth(), "day %d", d)
+	}
+}
+
+func TestTimeQuarter(t *testing.T) {
+	for month := time.January; month <= time.December; month++ {
+		value := ztype.NewTime(time.Date(2024, month, 15, 9, 30, 0, 0, time.UTC))
+		quarter := int(month-1)/3 + 1
+		assert.Equal(t, quarter, value.Quarter(), month.String())
+
+		start := value.StartOfQuarter()
+		assert.Equal(t, time.Date(2024, time.Month(quarter*3-2), 1, 0, 0, 0, 0, time.UTC), start.Get(), month.String())
+	}
+
+	newYearsEve := ztype.NewTime(time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC))
+	assert.Equal(t, 4, newYearsEve.Quarter())
+	assert.Equal(t, 1, newYearsEve.In(time.FixedZone("", 3600)).Quarter(), "computed in the value's location")
+}
+
+func TestTimeWeekHelpersNull(t *testing.T) {
+	null := ztype.NewNullTime()
+	valid := ztype.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	start := null.StartOfWeek(time.Monday)
+	end := null.EndOfWeek(time.Monday)
+	quarter := null.StartOfQuarter()
+	assert.True(t, start.IsNull())
+	assert.True(t, end.IsNull())
+	assert.True(t, quarter.IsNull())
+	assert.Zero(t, null.WeekOfMonth())
+	assert.Zero(t, null.Quarter())
+	assert.False(t, null.SameWeek(valid, time.Monday))
+	assert.False(t, valid.SameWeek(null, time.Monday))
+	assert.False(t, null.SameWeek(null, time.Monday))
+
+	var zero ztype.Time
+	require.NotPanics(t, func() { zero.StartOfWeek(time.Sunday) })
+}
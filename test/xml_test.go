@@ -0,0 +1,131 @@
+package ztype_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+type xmlItem struct {
+	XMLName  xml.Name               `xml:"item"`
+	Active   ztype.Bool             `xml:"active,attr"`
+	Priority ztype.Byte             `xml:"priority,attr"`
+	Price    ztype.Numeric[float64] `xml:"price,attr"`
+}
+
+type xmlFeed struct {
+	XMLName xml.Name  `xml:"feed"`
+	Items   []xmlItem `xml:"item"`
+}
+
+func TestXMLAttributes(t *testing.T) {
+	document := `<feed>
+		<item active="true" priority="3" price="9.5"/>
+		<item active="" priority="" price=""/>
+		<item/>
+	</feed>`
+
+	var feed xmlFeed
+	require.NoError(t, xml.Unmarshal([]byte(document), &feed))
+	require.Len(t, feed.Items, 3)
+
+	present, empty, absent := feed.Items[0], feed.Items[1], feed.Items[2]
+	t.Run("present", func(t *testing.T) {
+		assert.True(t, present.Active.Get())
+		assert.Equal(t, byte(3), present.Priority.Get())
+		assert.Equal(t, 9.5, present.Price.Get())
+		assert.True(t, present.Active.Unmarshaled())
+		assert.True(t, present.Priority.Unmarshaled())
+		assert.True(t, present.Price.Unmarshaled())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, empty.Active.IsNull())
+		assert.True(t, empty.Priority.IsNull())
+		assert.True(t, empty.Price.IsNull())
+		assert.True(t, empty.Active.Unmarshaled())
+		assert.True(t, empty.Priority.Unmarshaled())
+		assert.True(t, empty.Price.Unmarshaled())
+	})
+	t.Run("absent", func(t *testing.T) {
+		assert.True(t, absent.Active.IsNull())
+		assert.True(t, absent.Priority.IsNull())
+		assert.True(t, absent.Price.IsNull())
+		assert.False(t, absent.Active.Unmarshaled())
+		assert.False(t, absent.Priority.Unmarshaled())
+		assert.False(t, absent.Price.Unmarshaled())
+	})
+
+	t.Run("absent leaves values untouched", func(t *testing.T) {
+		item := xmlItem{Active: ztype.NewBool(true), Priority: ztype.NewByte(7), Price: ztype.NewNumber(1.5)}
+		require.NoError(t, xml.Unmarshal([]byte(`<item/>`), &item))
+		assert.True(t, item.Active.Get())
+		assert.Equal(t, byte(7), item.Priority.Get())
+		assert.Equal(t, 1.5, item.Price.Get())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := xml.Marshal(xmlFeed{Items: []xmlItem{present, absent}})
+		require.NoError(t, err)
+		assert.Equal(t, `<feed><item active="true" priority="3" price="9.5"></item><item></item></feed>`, string(data))
+
+		var decoded xmlFeed
+		require.NoError(t, xml.Unmarshal(data, &decoded))
+		assert.True(t, decoded.Items[0].Active.Equal(present.Active))
+		assert.True(t, decoded.Items[0].Priority.Equal(present.Priority))
+		assert.True(t, decoded.Items[0].Price.Equal(present.Price))
+		assert.True(t, decoded.Items[1].Price.IsNull())
+	})
+}
+
+func TestXMLAttributeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		attr     string
+	}{
+		{"Bool", `<item active="maybe"/>`, "active"},
+		{"Byte", `<item priority="300"/>`, "priority"},
+		{"Numeric", `<item price="cheap"/>`, "price"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item xmlItem
+			err := xml.Unmarshal([]byte(tt.document), &item)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "xml attribute "+tt.attr+": ")
+		})
+	}
+}
+
+func TestXMLElements(t *testing.T) {
+	type record struct {
+		Active ztype.Bool         `xml:"active"`
+		Level  ztype.Byte         `xml:"level"`
+		Count  ztype.Numeric[int] `xml:"count"`
+	}
+
+	var r record
+	require.NoError(t, xml.Unmarshal([]byte(`<record><active> yes </active><level>0x0F</level><count>42</count></record>`), &r))
+	assert.True(t, r.Active.Get())
+	assert.Equal(t, byte(15), r.Level.Get())
+	assert.Equal(t, 42, r.Count.Get())
+
+	r = record{}
+	document := `<record xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+		<active xsi:nil="true"/><level></level><count xsi:nil="true"></count>
+	</record>`
+	require.NoError(t, xml.Unmarshal([]byte(document), &r))
+	assert.True(t, r.Active.IsNull())
+	assert.True(t, r.Level.IsNull())
+	assert.True(t, r.Count.IsNull())
+	assert.True(t, r.Active.Unmarshaled())
+	assert.True(t, r.Count.Unmarshaled())
+
+	err := xml.Unmarshal([]byte(`<record><count>x</count></record>`), &r)
+	assert.ErrorContains(t, err, "xml element count: ")
+	assert.ErrorIs(t, err, &ztype.ErrInvalidFormat{})
+}
@@ -0,0 +1,153 @@
+package ztype_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhaori96/ztype"
+)
+
+// zeroValue is the part of every type's API the zero value must support.
+type zeroValue interface {
+	ztype.Nullable
+	json.Marshaler
+	json.Unmarshaler
+	driver.Valuer
+}
+
+// TestZeroValueIsUsableNull enforces the package guarantee that the zero
+// value of every type is a null that can be read, written and encoded.
+func TestZeroValueIsUsableNull(t *testing.T) {
+	values := map[string]zeroValue{
+		"Bool":            new(ztype.Bool),
+		"Byte":            new(ztype.Byte),
+		"Char":            new(ztype.Char),
+		"Bytes":           new(ztype.Bytes),
+		"String":          new(ztype.String),
+		"Numeric":         new(ztype.Numeric[int]),
+		"Time":            new(ztype.Time),
+		"Duration":        new(ztype.Duration),
+		"Rune":            new(ztype.Rune),
+		"Enum":            new(ztype.Enum[quickStatus]),
+		"IP":              new(ztype.IP),
+		"CIDR":            new(ztype.CIDR),
+		"RawJSON":         new(ztype.RawJSON),
+		"Money":           new(ztype.Money),
+		"Map":             new(ztype.Map[string, int]),
+		"OrderedMap":      new(ztype.OrderedMap[string, int]),
+		"SyncMap":         new(ztype.SyncMap[string, int]),
+		"Slice":           new(ztype.Slice[int]),
+		"SliceComparable": new(ztype.SliceComparable[int]),
+		"Set":             new(ztype.Set[int]),
+		"Null":            new(ztype.Null[int]),
+		"NullComparable":  new(ztype.NullComparable[int]),
+		"Array":           new(ztype.Array[ztype.String]),
+	}
+	for name, value := range values {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, value.IsNull())
+			assert.False(t, value.Unmarshaled())
+
+			data, err := value.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, "null", string(data))
+
+			driverValue, err := value.Value()
+			require.NoError(t, err)
+			assert.Nil(t, driverValue)
+
+			require.NoError(t, value.UnmarshalJSON([]byte("null")))
+			assert.True(t, value.IsNull())
+			value.SetNull()
+			assert.True(t, value.IsNull())
+		})
+	}
+}
+
+func TestZeroValueSetGetScan(t *testing.T) {
+	t.Run("Numeric", func(t *testing.T) {
+		var n ztype.Numeric[int64]
+		assert.Equal(t, int64(0), n.Get())
+		n.Set(5)
+		assert.Equal(t, int64(5), n.Get())
+		var scanned ztype.Numeric[int64]
+		require.NoError(t, scanned.Scan(int64(7)))
+		assert.Equal(t, int64(7), scanned.Get())
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		var b ztype.Bool
+		assert.False(t, b.Get())
+		b.Set(true)
+		assert.True(t, b.Get())
+		var scanned ztype.Bool
+		require.NoError(t, scanned.Scan(true))
+		assert.True(t, scanned.Get())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		var s ztype.String
+		assert.Equal(t, "", s.Get())
+		s.Set("a")
+		assert.Equal(t, "a", s.Get())
+		var scanned ztype.String
+		require.NoError(t, scanned.Scan("b"))
+		assert.Equal(t, "b", scanned.Get())
+	})
+
+	t.Run("Byte", func(t *testing.T) {
+		var b ztype.Byte
+		assert.Equal(t, byte(0), b.Get())
+		b.Set(9)
+		assert.Equal(t, byte(9), b.Get())
+		var scanned ztype.Byte
+		require.NoError(t, scanned.Scan(int64(3)))
+		assert.Equal(t, byte(3), scanned.Get())
+	})
+
+	t.Run("Time", func(t *testing.T) {
+		var tm ztype.Time
+		assert.True(t, tm.Get().IsZero())
+		now := time.Now().UTC()
+		tm.Set(now)
+		assert.Equal(t, now, tm.Get())
+		var scanned ztype.Time
+		require.NoError(t, scanned.Scan(now))
+		assert.Equal(t, now, scanned.Get())
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		var d ztype.Duration
+		assert.Equal(t, time.Duration(0), d.Get())
+		d.Set(time.Second)
+		assert.Equal(t, time.Second, d.Get())
+		var scanned ztype.Duration
+		require.NoError(t, scanned.Scan("2s"))
+		assert.Equal(t, 2*time.Second, scanned.Get())
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		var m ztype.Map[string, int]
+		assert.Equal(t, 0, m.Len())
+		_, ok := m.GetItem("a")
+		assert.False(t, ok)
+		m.SetItem("a", 1)
+		assert.Equal(t, map[string]int{"a": 1}, m.Get())
+
+		var scanned ztype.Map[string, int]
+		require.NoError(t, scanned.Scan(`{"b":2}`))
+		assert.Equal(t, map[string]int{"b": 2}, scanned.Get())
+
+		var set ztype.Map[string, int]
+		set.Set(nil)
+		set.SetItem("c", 3)
+		data, err := json.Marshal(ztype.NewMap[string, int](nil))
+		require.NoError(t, err)
+		assert.Equal(t, "{}", string(data))
+	})
+}
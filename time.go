@@ -6,12 +6,25 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // Time represents a nullable time value compatible with SQL NULL and JSON null.
 // It wraps sql.NullTime with additional JSON parsing capabilities and utility methods.
 //
+// A Time writes the offset of its time.Time, so a value parsed from
+// "2023-06-01T10:00:00+09:00" marshals back with +09:00, and a value from
+// Scan with the location the driver returned. SetTimeMarshalUTC writes every
+// time in UTC instead; with SetTimePreserveOffset, a Time also remembers the
+// offset it was parsed with and keeps writing it, until Set, In, UTC, Local
+// or Scan gives it a new location.
+//
+// A Time in date-only mode, made with NewDateOnlyTime or DateOnly, holds a
+// calendar date for DATE columns; see DateOnly.
+//
 // Example:
 //
 //	t := ztype.NewTime(time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC))
@@ -19,9 +32,100 @@ import (
 //	// Output: "2023-01-01T12:00:00Z"
 type Time struct {
 	value       sql.NullTime
+	offset      *time.Location
+	dateOnly    bool
 	unmarshaled bool
 }
 
+// SetTimeMarshalUTC makes MarshalJSON, MarshalText, AppendJSON and String
+// write valid times in UTC, as "2023-06-01T01:00:00Z" instead of
+// "2023-06-01T10:00:00+09:00". The instant is unchanged. Offsets remembered
+// with SetTimePreserveOffset still take precedence. Disabled by default.
+//
+// Example:
+//
+//	ztype.SetTimeMarshalUTC(true)
+//	data, _ := json.Marshal(ztype.NewTime(tokyoTime)) // "2023-06-01T01:00:00Z"
+func SetTimeMarshalUTC(utc bool) {
+	defaultCodec.timeMarshalUTC.Store(utc)
+}
+
+// SetTimePreserveOffset makes UnmarshalJSON and UnmarshalText remember the
+// offset of the text they parse, which MarshalJSON, MarshalText, AppendJSON
+// and String then write even when SetTimeMarshalUTC is enabled. Calling Set,
+// In, UTC, Local or Scan forgets it. Disabled by default.
+//
+// Example:
+//
+//	ztype.SetTimePreserveOffset(true)
+//	ztype.SetTimeMarshalUTC(true)
+//	var t ztype.Time
+//	json.Unmarshal([]byte(`"2023-06-01T10:00:00+09:00"`), &t)
+//	data, _ := json.Marshal(t) // "2023-06-01T10:00:00+09:00"
+func SetTimePreserveOffset(preserve bool) {
+	defaultCodec.timePreserveOffset.Store(preserve)
+}
+
+// SetTimeValueUTC makes Time.Value convert valid times to UTC and strip the
+// monotonic clock reading before handing them to the driver. Drivers such as
+// go-sql-driver/mysql write a DATETIME(6) in the location of the value, so
+// enabling it keeps stored times consistent when the connection's loc is
+// UTC. Disabled by default, returning the time unchanged.
+//
+// Example:
+//
+//	ztype.SetTimeValueUTC(true)
+//	v, _ := ztype.NewTime(time.Now()).Value() // time.Time in UTC
+func SetTimeValueUTC(utc bool) {
+	defaultCodec.timeValueUTC.Store(utc)
+}
+
+// SetScanLocation makes Time.Scan reinterpret zone-less values in loc:
+// the wall clock is kept and only the location is replaced, so the instant
+// changes. Drivers report a timestamp without time zone, or a MySQL
+// DATETIME, as a time.Time in UTC whatever the column means, so with a
+// scan location set every scanned time in UTC is taken as zone-less, and
+// times in any other location are left alone. Use it when the column holds
+// wall clock times of a known zone. nil, the default, disables it.
+//
+// Example:
+//
+//	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+//	ztype.SetScanLocation(saoPaulo)
+//	// a driver value of 2024-03-01 12:00 UTC scans as 2024-03-01 12:00 -03
+func SetScanLocation(loc *time.Location) {
+	defaultCodec.scanLocation.Store(loc)
+}
+
+// SetScanConvertTo makes Time.Scan convert scanned times to loc: the
+// instant is kept and only its presentation changes, as with time.Time.In.
+// Unlike SetScanLocation it never alters the instant, so it suits columns
+// that carry a zone, such as timestamp with time zone. When both are set,
+// zone-less values are reinterpreted first and then converted, so every
+// scanned Time ends up in loc. nil, the default, disables it.
+//
+// Example:
+//
+//	ztype.SetScanConvertTo(time.Local)
+//	// a driver value of 2024-03-01 12:00 UTC scans as the same instant in Local
+func SetScanConvertTo(loc *time.Location) {
+	defaultCodec.scanConvertTo.Store(loc)
+}
+
+// scanNormalized applies the scan location and convert-to location of the
+// Codec to a time returned by a driver.
+func (c *Codec) scanNormalized(value time.Time) time.Time {
+	if loc := c.scanLocation.Load(); loc != nil && value.Location() == time.UTC {
+		year, month, day := value.Date()
+		hour, minute, second := value.Clock()
+		value = time.Date(year, month, day, hour, minute, second, value.Nanosecond(), loc)
+	}
+	if loc := c.scanConvertTo.Load(); loc != nil {
+		value = value.In(loc)
+	}
+	return value
+}
+
 var timeFormats = []string{
 	time.ANSIC,
 	time.UnixDate,
@@ -48,6 +152,68 @@ var timeFormats = []string{
 	"15:04",
 }
 
+// timeParseLayouts holds timeFormats from the longest layout to the
+// shortest, so that when several layouts accept an input the most specific
+// one wins. The sort is stable, keeping the order of timeFormats for ties.
+var timeParseLayouts = func() []string {
+	layouts := slices.Clone(timeFormats)
+	slices.SortStableFunc(layouts, func(a, b string) int {
+		return len(b) - len(a)
+	})
+	return layouts
+}()
+
+// ParseTimeFlexible parses s with every format Time.UnmarshalText accepts,
+// reading times without an offset as UTC. It is the entry point for raw
+// strings from query parameters or headers that are not worth a Time.
+//
+// The layouts are tried from the longest to the shortest and the first one
+// that consumes the whole input, ignoring surrounding whitespace, wins; one
+// that matches only a prefix of s is rejected. On failure the
+// *ErrInvalidFormat wraps the *time.ParseError of the layout that got
+// furthest into s, naming it as the closest format.
+//
+// Example:
+//
+//	since, err := ztype.ParseTimeFlexible(r.URL.Query().Get("since"))
+//	// "2024-03-01", "2024-03-01 12:30" and "01/03/2024" all parse
+func ParseTimeFlexible(s string) (time.Time, error) {
+	return ParseTimeFlexibleIn(s, time.UTC)
+}
+
+// ParseTimeFlexibleIn is ParseTimeFlexible reading times without an offset,
+// such as "2024-03-01 12:30", in loc. Times with an offset keep it.
+//
+// Example:
+//
+//	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+//	t, err := ztype.ParseTimeFlexibleIn("2024-03-01 12:30", saoPaulo)
+//	fmt.Println(t) // Output: 2024-03-01 12:30:00 -0300 -03
+func ParseTimeFlexibleIn(s string, loc *time.Location) (time.Time, error) {
+	text := strings.TrimSpace(s)
+	var closest *time.ParseError
+	consumed := -1
+	for _, layout := range timeParseLayouts {
+		parsed, err := time.ParseInLocation(layout, text, loc)
+		if err == nil {
+			return parsed, nil
+		}
+		parseErr, ok := err.(*time.ParseError)
+		if !ok {
+			continue
+		}
+		// On ties a layout that matched up to trailing text wins.
+		n := len(text) - len(parseErr.ValueElem)
+		if n > consumed || (n == consumed && parseErr.LayoutElem == "" && closest.LayoutElem != "") {
+			closest, consumed = parseErr, n
+		}
+	}
+	if closest == nil {
+		return time.Time{}, &ErrInvalidFormat{Type: "Time", Input: s}
+	}
+	return time.Time{}, newInvalidFormat("Time", s, "closest format %q: %w", closest.Layout, closest)
+}
+
 // NewTime creates a non-null Time with an initial value.
 //
 // Example:
@@ -87,6 +253,61 @@ func NewNullTimeIfZero(value time.Time) Time {
 	return NewTime(value)
 }
 
+// NewDateOnlyTime creates a valid Time in date-only mode holding the given
+// calendar date. See DateOnly.
+//
+// Example:
+//
+//	d := ztype.NewDateOnlyTime(2024, time.March, 1)
+//	data, _ := json.Marshal(d) // "2024-03-01"
+func NewDateOnlyTime(year int, month time.Month, day int) Time {
+	t := Time{dateOnly: true}
+	t.Set(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	return t
+}
+
+// DateOnly returns a copy of the Time in date-only mode, for DATE columns
+// that drivers return as midnight UTC and that would otherwise render as the
+// previous day in a zone behind UTC. A date-only Time keeps the calendar
+// date of its value, read in the value's own location, as midnight UTC:
+//
+//   - MarshalJSON, MarshalText and String write "2006-01-02", and Value
+//     returns that string instead of a time.Time. Scan also accepts it.
+//   - Equal compares calendar dates, ignoring clock and location.
+//   - In, Local and UTC keep the date, at midnight in the new location.
+//
+// The mode survives Set, SetNull, Scan and decoding, which store only the
+// date of what they are given.
+//
+// Example:
+//
+//	var d ztype.Time
+//	d = d.DateOnly()
+//	_ = d.Scan(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+//	fmt.Println(d.In(time.FixedZone("", -5*3600)).Format(time.DateOnly)) // Output: 2024-03-01
+func (t Time) DateOnly() Time {
+	t.dateOnly = true
+	if t.value.Valid {
+		t.Set(t.value.Time)
+	}
+	return t
+}
+
+// IsDateOnly reports whether the Time is in date-only mode. See DateOnly.
+//
+// Example:
+//
+//	ztype.NewDateOnlyTime(2024, time.March, 1).IsDateOnly() // true
+func (t Time) IsDateOnly() bool {
+	return t.dateOnly
+}
+
+// civilDate returns the calendar date of value as midnight in loc.
+func civilDate(value time.Time, loc *time.Location) time.Time {
+	year, month, day := value.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
 // Get returns the underlying time.Time value.
 // Returns zero time if NULL.
 //
@@ -98,14 +319,49 @@ func (t *Time) Get() time.Time {
 	return t.value.Time
 }
 
+// Val returns the time and whether it is valid.
+//
+// Example:
+//
+//	if seen, ok := t.Val(); ok { fmt.Println(time.Since(seen)) }
+func (t Time) Val() (time.Time, bool) {
+	return t.value.Time, t.value.Valid
+}
+
 // Set updates the value and marks it as valid.
 //
 // Example:
 //
 //	t.Set(time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC))
 func (t *Time) Set(value time.Time) {
+	if t.dateOnly {
+		value = civilDate(value, time.UTC)
+	}
 	t.value.Time = value
 	t.value.Valid = true
+	t.offset = nil
+}
+
+// setParsed stores a time parsed from text, remembering its offset when
+// c preserves offsets.
+func (t *Time) setParsed(c *Codec, parsed time.Time) {
+	t.Set(parsed)
+	if c.timePreserveOffset.Load() {
+		_, offset := parsed.Zone()
+		t.offset = time.FixedZone("", offset)
+	}
+}
+
+// marshaled returns the time the text and JSON forms write: in the
+// remembered offset, else in UTC when c marshals in UTC.
+func (t Time) marshaled(c *Codec) time.Time {
+	switch {
+	case t.offset != nil:
+		return t.value.Time.In(t.offset)
+	case c.timeMarshalUTC.Load():
+		return t.value.Time.UTC()
+	}
+	return t.value.Time
 }
 
 // SetNull marks the time as NULL.
@@ -117,6 +373,7 @@ func (t *Time) Set(value time.Time) {
 func (t *Time) SetNull() {
 	t.value.Time = time.Time{}
 	t.value.Valid = false
+	t.offset = nil
 }
 
 // IsNull returns true if the time is NULL.
@@ -134,7 +391,7 @@ func (t *Time) IsNull() bool {
 //
 //	t := ztype.Time{}
 //	fmt.Println(t.IsEmpty()) // Output: true
-func (t *Time) IsEmpty() bool {
+func (t Time) IsEmpty() bool {
 	return !t.value.Valid || t.value.Time.IsZero()
 }
 
@@ -144,7 +401,7 @@ func (t *Time) IsEmpty() bool {
 //
 //	t := ztype.NewNullTime()
 //	fmt.Println(t.IsZero()) // Output: true
-func (t *Time) IsZero() bool {
+func (t Time) IsZero() bool {
 	return t.IsEmpty()
 }
 
@@ -269,7 +526,8 @@ func (t *Time) BeforeRaw(value time.Time) bool {
 //	nyTime := t.In(loc)
 //	fmt.Println(nyTime.Get().Format(time.RFC822))
 func (t Time) In(loc *time.Location) Time {
-	t.value.Time = t.value.Time.In(loc)
+	t.value.Time = t.InRaw(loc)
+	t.offset = nil
 	return t
 }
 
@@ -280,6 +538,9 @@ func (t Time) In(loc *time.Location) Time {
 //	raw := t.InRaw(time.FixedZone("BST", 3600))
 //	fmt.Println(raw.Format(time.RFC822Z))
 func (t *Time) InRaw(loc *time.Location) time.Time {
+	if t.dateOnly {
+		return civilDate(t.value.Time, loc)
+	}
 	return t.value.Time.In(loc)
 }
 
@@ -290,7 +551,8 @@ func (t *Time) InRaw(loc *time.Location) time.Time {
 //	localTime := t.Local()
 //	fmt.Println(localTime.Get().Format(time.RFC3339))
 func (t Time) Local() Time {
-	t.value.Time = t.value.Time.Local()
+	t.value.Time = t.LocalRaw()
+	t.offset = nil
 	return t
 }
 
@@ -301,7 +563,7 @@ func (t Time) Local() Time {
 //	rawLocal := t.LocalRaw()
 //	fmt.Println(rawLocal.Format(time.Kitchen))
 func (t *Time) LocalRaw() time.Time {
-	return t.value.Time.Local()
+	return t.InRaw(time.Local)
 }
 
 // Location returns the timezone information.
@@ -499,7 +761,8 @@ func (t *Time) Format(layout string) string {
 //	utcTime := t.UTC()
 //	fmt.Println(utcTime.Get().Location())
 func (t Time) UTC() Time {
-	t.value.Time = t.value.Time.UTC()
+	t.value.Time = t.UTCRaw()
+	t.offset = nil
 	return t
 }
 
@@ -510,6 +773,9 @@ func (t Time) UTC() Time {
 //	utc := t.UTCRaw()
 //	fmt.Println(utc.Location())
 func (t *Time) UTCRaw() time.Time {
+	if t.dateOnly {
+		return civilDate(t.value.Time, time.UTC)
+	}
 	return t.value.Time.UTC()
 }
 
@@ -606,47 +872,72 @@ func (t *Time) SetUnmarshaled(value bool) {
 //
 //	if t.Equal(otherTime) { fmt.Println("Equal values and null status") }
 func (t *Time) Equal(other Time) bool {
+	if t.dateOnly || other.dateOnly {
+		return t.value.Valid == other.value.Valid &&
+			civilDate(t.value.Time, time.UTC).Equal(civilDate(other.value.Time, time.UTC))
+	}
 	return t.value.Valid == other.value.Valid &&
 		t.value.Time.Equal(other.value.Time)
 }
 
-// EqualRaw compares the value with a raw time.Time, ignoring null status.
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
 //
 // Example:
 //
-//	if t.EqualRaw(time.Now()) { fmt.Println("Matches current time") }
-func (t *Time) EqualRaw(other time.Time) bool {
-	return t.value.Valid && t.value.Time.Equal(other)
+//	t := ztype.NewTime(now)
+//	fmt.Println(t.EqualValues(ztype.NewTime(now)))  // Output: true
+func (t Time) EqualValues(other Time) bool {
+	return t.Equal(other)
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-// Example typically used internally by encoding packages.
-func (t *Time) MarshalBinary() ([]byte, error) {
-	return t.value.Time.MarshalBinary()
+// Clone returns a copy of the Time, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	t := ztype.NewTime(time.Now())
+//	c := t.Clone()
+func (t Time) Clone() Time {
+	return t
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-// Example typically used internally by encoding packages.
-func (t *Time) UnmarshalBinary(data []byte) error {
-	return t.value.Time.UnmarshalBinary(data)
+// EqualRaw compares the value with a raw time.Time, ignoring null status.
+//
+// Example:
+//
+//	if t.EqualRaw(time.Now()) { fmt.Println("Matches current time") }
+func (t *Time) EqualRaw(other time.Time) bool {
+	return t.value.Valid && t.value.Time.Equal(other)
 }
 
 // MarshalText implements encoding.TextMarshaler.
-// Outputs RFC3339 format for valid times, empty string for NULL.
+// Outputs the same RFC3339Nano text as MarshalJSON for valid times, empty
+// string for NULL.
 //
 // Example:
 //
 //	data, _ := t.MarshalText()
 //	fmt.Println(string(data))
-func (t *Time) MarshalText() ([]byte, error) {
+func (t Time) MarshalText() ([]byte, error) {
 	if t.value.Valid {
-		return []byte(t.value.Time.Format(time.RFC3339)), nil
+		return t.appendText(&defaultCodec, nil), nil
 	}
 	return nil, nil
 }
 
+// appendText appends the text form of a valid Time: RFC3339Nano or the
+// layout of c, or the date alone in date-only mode.
+func (t Time) appendText(c *Codec, dst []byte) []byte {
+	if t.dateOnly {
+		return t.value.Time.AppendFormat(dst, time.DateOnly)
+	}
+	return t.marshaled(c).AppendFormat(dst, c.timeLayoutOr(time.RFC3339Nano))
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.
-// Supports multiple time formats.
+// Supports multiple time formats, see ParseTimeFlexible. Empty or blank
+// input is null.
 //
 // Example:
 //
@@ -655,33 +946,52 @@ func (t *Time) MarshalText() ([]byte, error) {
 func (t *Time) UnmarshalText(data []byte) error {
 	t.unmarshaled = true
 	s := string(data)
-	if s == "" {
+	if strings.TrimSpace(s) == "" {
 		t.SetNull()
 		return nil
 	}
-	for _, layout := range timeFormats {
-		parsed, err := time.Parse(layout, s)
-		if err == nil {
-			t.value.Time = parsed
-			t.value.Valid = true
-			return nil
-		}
+	parsed, err := ParseTimeFlexible(s)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("invalid time format: %s", s)
+	t.setParsed(&defaultCodec, parsed)
+	return nil
 }
 
 // MarshalJSON implements json.Marshaler.
-// Outputs RFC3339 format for valid times, null for NULL.
+// Outputs RFC3339Nano format for valid times, null for NULL, so a round
+// trip keeps nanoseconds; trailing zeros of the fraction are dropped, and
+// whole seconds are written exactly as RFC3339.
 //
 // Example:
 //
 //	data, _ := json.Marshal(t)
 //	fmt.Println(string(data))
-func (t *Time) MarshalJSON() ([]byte, error) {
-	if t.value.Valid {
-		return json.Marshal(t.value.Time.Format(time.RFC3339))
+func (t Time) MarshalJSON() ([]byte, error) {
+	return t.AppendJSON(make([]byte, 0, 40))
+}
+
+// AppendJSON appends the JSON encoding of the Time to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewTime(moment).AppendJSON(buf) // "2024-03-01T12:00:00Z"
+func (t Time) AppendJSON(dst []byte) ([]byte, error) {
+	return t.appendJSONCodec(&defaultCodec, dst)
+}
+
+// appendJSONCodec implements AppendJSON with the options of c.
+func (t Time) appendJSONCodec(c *Codec, dst []byte) ([]byte, error) {
+	if !t.value.Valid {
+		return append(dst, "null"...), nil
 	}
-	return []byte("null"), nil
+	if c.timeLayout.Load() != nil {
+		return appendJSONString(dst, t.appendText(c, nil)), nil
+	}
+	dst = append(dst, '"')
+	dst = t.appendText(c, dst)
+	return append(dst, '"'), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -692,6 +1002,12 @@ func (t *Time) MarshalJSON() ([]byte, error) {
 //	err := json.Unmarshal([]byte("\"2023-01-01T00:00:00Z\""), &t)
 //	fmt.Println(t.Get().Year())
 func (t *Time) UnmarshalJSON(data []byte) error {
+	return t.unmarshalJSONCodec(&defaultCodec, data)
+}
+
+// unmarshalJSONCodec implements UnmarshalJSON with the options of c.
+// Date-only Times read their date form whatever the layout of c.
+func (t *Time) unmarshalJSONCodec(c *Codec, data []byte) error {
 	t.unmarshaled = true
 	if bytes.Equal(data, []byte("null")) {
 		t.SetNull()
@@ -699,47 +1015,153 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 	}
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
-		return err
+		return wrapJSONError("Time", data, err)
 	}
-	for _, layout := range timeFormats {
-		parsed, err := time.Parse(layout, s)
-		if err == nil {
-			t.value.Time = parsed
-			t.value.Valid = true
-			return nil
-		}
+	parse := c.parseTime
+	if t.dateOnly {
+		parse = ParseTimeFlexible
+	}
+	parsed, err := parse(s)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("invalid time format: %s", s)
+	t.setParsed(c, parsed)
+	return nil
 }
 
-// Scan implements sql.Scanner for database integration.
+// Scan implements sql.Scanner for database integration. In date-only mode
+// it also reads "2006-01-02" text, as Value writes it. Other scanned times
+// are normalized as SetScanLocation and SetScanConvertTo select.
 //
 // Example:
 //
 //	err := db.QueryRow("SELECT created_at FROM users").Scan(&t)
 func (t *Time) Scan(value any) error {
-	return t.value.Scan(value)
+	return t.scanCodec(&defaultCodec, value)
+}
+
+// scanCodec implements Scan with the scan locations of c.
+func (t *Time) scanCodec(c *Codec, value any) error {
+	if t.dateOnly {
+		var text string
+		switch v := value.(type) {
+		case string:
+			text = v
+		case []byte:
+			text = string(v)
+		}
+		if text != "" {
+			parsed, err := ParseTimeFlexible(text)
+			if err != nil {
+				return err
+			}
+			t.Set(parsed)
+			return nil
+		}
+	}
+	scanned := t.value
+	if err := scanned.Scan(value); err != nil {
+		return wrapScanError("Time", value, err)
+	}
+	if !scanned.Valid {
+		t.SetNull()
+		return nil
+	}
+	if t.dateOnly {
+		t.Set(scanned.Time)
+		return nil
+	}
+	t.Set(c.scanNormalized(scanned.Time))
+	return nil
 }
 
-// Value implements driver.Valuer for database integration.
+// Value implements driver.Valuer for database integration. With
+// SetTimeValueUTC enabled, valid times are returned in UTC. In date-only
+// mode the value is a "2006-01-02" string.
 //
 // Example:
 //
 //	_, err := db.Exec("INSERT INTO users (created_at) VALUES (?)", t.Value())
 func (t Time) Value() (driver.Value, error) {
+	return t.valueCodec(&defaultCodec)
+}
+
+// valueCodec implements Value with the options of c.
+func (t Time) valueCodec(c *Codec) (driver.Value, error) {
+	if t.value.Valid && t.dateOnly {
+		return t.value.Time.Format(time.DateOnly), nil
+	}
+	if t.value.Valid && c.timeValueUTC.Load() {
+		return t.value.Time.Round(0).UTC(), nil
+	}
 	return t.value.Value()
 }
 
+// ValueOrZero is like Value, but returns the Unix epoch in UTC instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullTime().ValueOrZero() // 1970-01-01 00:00:00 +0000 UTC
+func (t Time) ValueOrZero() (driver.Value, error) {
+	if !t.value.Valid {
+		if t.dateOnly {
+			return time.Unix(0, 0).UTC().Format(time.DateOnly), nil
+		}
+		return time.Unix(0, 0).UTC(), nil
+	}
+	return t.Value()
+}
+
 // String returns RFC3339Nano format for valid times, "<NULL>" for NULL.
 //
 // Example:
 //
 //	fmt.Println(t.String())
-func (t *Time) String() string {
+func (t Time) String() string {
+	if !t.value.Valid {
+		return nullToken()
+	}
+	return string(t.appendText(&defaultCodec, nil))
+}
+
+// AppendString appends the String output to dst without allocating when
+// dst has enough capacity, for log lines built in a reused buffer.
+//
+// Example:
+//
+//	buf = event.At.AppendString(buf[:0]) // "2024-03-01T12:00:00Z"
+func (t Time) AppendString(dst []byte) []byte {
+	if !t.value.Valid {
+		return append(dst, nullToken()...)
+	}
+	return t.appendText(&defaultCodec, dst)
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullTime().StringOr("never"))  // Output: never
+func (t Time) StringOr(fallback string) string {
 	if !t.value.Valid {
-		return "<NULL>"
+		return fallback
 	}
-	return t.value.Time.Format(time.RFC3339Nano)
+	return t.String()
+}
+
+// SchemaType reports Time as a nullable RFC 3339 string, with the "date"
+// format in date-only mode.
+//
+// Example:
+//
+//	ztype.Time{}.SchemaType() // "string", "date-time", true
+func (t Time) SchemaType() (jsonType string, format string, nullable bool) {
+	if t.dateOnly {
+		return "string", "date", true
+	}
+	return "string", "date-time", true
 }
 
 // Duration represents a nullable time.Duration compatible with SQL NULL and JSON null.
@@ -755,6 +1177,142 @@ type Duration struct {
 	unmarshaled bool
 }
 
+// DurationValueMode selects what Duration.Value returns for valid values.
+// See SetDurationValueMode.
+type DurationValueMode int32
+
+const (
+	// DurationValueNanos returns int64 nanoseconds, the default.
+	DurationValueNanos DurationValueMode = iota
+	// DurationValueString returns the time.Duration string, such as "1h30m0s",
+	// for VARCHAR columns.
+	DurationValueString
+	// DurationValueMicros returns a "[-]HH:MM:SS.ffffff" TIME(6) literal.
+	// See SetDurationValueMicros.
+	DurationValueMicros
+)
+
+// SetDurationValueMode selects what Duration.Value returns for valid
+// durations. Null is always returned as NULL, and Scan reads every form
+// back, so a column written in one mode can be read in any other.
+//
+// Example:
+//
+//	ztype.SetDurationValueMode(ztype.DurationValueString)
+//	v, _ := ztype.NewDuration(90 * time.Minute).Value()
+//	fmt.Println(v) // Output: 1h30m0s
+func SetDurationValueMode(mode DurationValueMode) {
+	defaultCodec.durationValueMode.Store(int32(mode))
+}
+
+// SetDurationValueMicros makes Duration.Value return valid durations as a
+// "[-]HH:MM:SS.ffffff" string with microsecond precision, the literal a
+// MySQL TIME(6) column stores, instead of int64 nanoseconds. Precision below
+// a microsecond is truncated, and hours may exceed 24. Scan reads the same
+// form back. Disabling it restores DurationValueNanos.
+//
+// Example:
+//
+//	ztype.SetDurationValueMicros(true)
+//	v, _ := ztype.NewDuration(90*time.Minute + time.Microsecond).Value()
+//	fmt.Println(v) // Output: 01:30:00.000001
+func SetDurationValueMicros(micros bool) {
+	if micros {
+		SetDurationValueMode(DurationValueMicros)
+		return
+	}
+	SetDurationValueMode(DurationValueNanos)
+}
+
+// formatClockDuration formats d as "[-]HH:MM:SS.ffffff", truncated to
+// microseconds.
+func formatClockDuration(d time.Duration) string {
+	sign, abs := "", uint64(d)
+	if d < 0 {
+		sign, abs = "-", -abs
+	}
+	micros := abs / uint64(time.Microsecond)
+	return fmt.Sprintf("%s%02d:%02d:%02d.%06d", sign,
+		micros/3600e6, micros/60e6%60, micros/1e6%60, micros%1e6)
+}
+
+// parseClockDuration parses the "[-]HH:MM:SS[.fraction]" form written by
+// formatClockDuration and returned by TIME columns.
+func parseClockDuration(s string) (time.Duration, bool) {
+	negative := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimPrefix(s, "-"), ":")
+	if len(parts) != 3 || len(parts[1]) != 2 || len(parts[2]) < 2 {
+		return 0, false
+	}
+	seconds, fraction, hasFraction := strings.Cut(parts[2], ".")
+	if len(seconds) != 2 || (hasFraction && (fraction == "" || len(fraction) > 9)) {
+		return 0, false
+	}
+	var fields [3]int64
+	for i, text := range []string{parts[0], parts[1], seconds} {
+		n, err := strconv.ParseUint(text, 10, 32)
+		if err != nil || (i > 0 && n > 59) {
+			return 0, false
+		}
+		fields[i] = int64(n)
+	}
+	var nanos int64
+	if hasFraction {
+		n, err := strconv.ParseUint(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		nanos = int64(n)
+	}
+	d := time.Duration(fields[0])*time.Hour + time.Duration(fields[1])*time.Minute +
+		time.Duration(fields[2])*time.Second + time.Duration(nanos)
+	if negative {
+		d = -d
+	}
+	return d, true
+}
+
+// ParseDurationFlexible parses s as Duration.UnmarshalText does: an
+// optionally signed integer is nanoseconds, as Scan reads int64 columns, and
+// anything else is a Go duration string such as "1h30m". It is the entry
+// point for raw strings outside of JSON.
+//
+// Example:
+//
+//	timeout, err := ztype.ParseDurationFlexible(r.Header.Get("X-Timeout"))
+//	// "30s" and "30000000000" are both 30 seconds
+func ParseDurationFlexible(s string) (time.Duration, error) {
+	if isIntegerText(s) {
+		nanos, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, &ErrOverflow{Type: "Duration", Value: s}
+		}
+		return time.Duration(nanos), nil
+	}
+	dur, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, wrapInvalidFormat("Duration", s, err)
+	}
+	return dur, nil
+}
+
+// isIntegerText reports whether text is decimal digits with an optional
+// leading sign.
+func isIntegerText(text string) bool {
+	if text != "" && (text[0] == '-' || text[0] == '+') {
+		text = text[1:]
+	}
+	if text == "" {
+		return false
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // NewDuration creates a non-null Duration with initial value.
 //
 // Example:
@@ -803,6 +1361,15 @@ func (d *Duration) Get() time.Duration {
 	return d.value
 }
 
+// Val returns the duration and whether it is valid.
+//
+// Example:
+//
+//	if timeout, ok := d.Val(); ok { ctx, cancel = context.WithTimeout(ctx, timeout) }
+func (d Duration) Val() (time.Duration, bool) {
+	return d.value, d.valid
+}
+
 // Set updates the value and marks it as valid.
 //
 // Example:
@@ -833,14 +1400,24 @@ func (d *Duration) IsNull() bool {
 	return !d.valid
 }
 
-// IsZero returns true if NULL or zero duration.
+// IsEmpty returns true if NULL or zero duration.
+//
+// Example:
+//
+//	d := ztype.NewDuration(0)
+//	fmt.Println(d.IsEmpty()) // Output: true
+func (d Duration) IsEmpty() bool {
+	return !d.valid || d.value == 0
+}
+
+// IsZero is an alias for IsEmpty.
 //
 // Example:
 //
 //	d := ztype.Duration{}
 //	fmt.Println(d.IsZero()) // Output: true
-func (d *Duration) IsZero() bool {
-	return !d.valid || d.value == 0
+func (d Duration) IsZero() bool {
+	return d.IsEmpty()
 }
 
 // Unmarshaled indicates if the value was set through JSON/Text unmarshaling.
@@ -867,6 +1444,28 @@ func (d *Duration) Equal(other Duration) bool {
 	return d.valid == other.valid && d.value == other.value
 }
 
+// EqualValues reports whether both hold the same value and null state,
+// ignoring the unmarshaled flag. Unlike Equal, it can be called on
+// non-pointer values such as struct fields read through an interface.
+//
+// Example:
+//
+//	d := ztype.NewDuration(time.Second)
+//	fmt.Println(d.EqualValues(ztype.NewDuration(time.Second)))  // Output: true
+func (d Duration) EqualValues(other Duration) bool {
+	return d.Equal(other)
+}
+
+// Clone returns a copy of the Duration, keeping its null and unmarshaled state.
+//
+// Example:
+//
+//	d := ztype.NewDuration(time.Second)
+//	c := d.Clone()
+func (d Duration) Clone() Duration {
+	return d
+}
+
 // EqualRaw compares the value with a raw time.Duration, ignoring null status.
 //
 // Example:
@@ -883,7 +1482,7 @@ func (d *Duration) EqualRaw(other time.Duration) bool {
 //
 //	data, _ := d.MarshalText()
 //	fmt.Println(string(data))
-func (d *Duration) MarshalText() ([]byte, error) {
+func (d Duration) MarshalText() ([]byte, error) {
 	if d.valid {
 		return []byte(d.value.String()), nil
 	}
@@ -891,6 +1490,8 @@ func (d *Duration) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
+// An integer such as "3600000000000" is nanoseconds, matching Scan; any
+// other text is a Go duration string such as "1h30m". "0" is zero.
 //
 // Example:
 //
@@ -902,7 +1503,7 @@ func (d *Duration) UnmarshalText(data []byte) error {
 		d.SetNull()
 		return nil
 	}
-	dur, err := time.ParseDuration(string(data))
+	dur, err := ParseDurationFlexible(string(data))
 	if err != nil {
 		return err
 	}
@@ -918,19 +1519,36 @@ func (d *Duration) UnmarshalText(data []byte) error {
 //
 //	data, _ := json.Marshal(d)
 //	fmt.Println(string(data)) // Output: "1h30m0s"
-func (d *Duration) MarshalJSON() ([]byte, error) {
-	if d.valid {
-		return json.Marshal(d.value.String())
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return d.AppendJSON(make([]byte, 0, 24))
+}
+
+// AppendJSON appends the JSON encoding of the Duration to dst, the same bytes
+// MarshalJSON returns. See JSONAppender.
+//
+// Example:
+//
+//	buf, _ = ztype.NewDuration(time.Minute).AppendJSON(buf) // "1m0s"
+func (d Duration) AppendJSON(dst []byte) ([]byte, error) {
+	if !d.valid {
+		return append(dst, "null"...), nil
 	}
-	return []byte("null"), nil
+	dst = append(dst, '"')
+	dst = append(dst, d.value.String()...)
+	return append(dst, '"'), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
+// Accepts a bare integer of nanoseconds, matching Scan, or a string read as
+// UnmarshalText does: an integer string is nanoseconds too, and anything
+// else is a Go duration string such as "1h30m".
 //
 // Example:
 //
 //	err := json.Unmarshal([]byte("\"1h30m\""), &d)
 //	fmt.Println(d.Get().Minutes()) // Output: 90
+//	err = json.Unmarshal([]byte("3600000000000"), &d)
+//	fmt.Println(d.Get()) // Output: 1h0m0s
 func (d *Duration) UnmarshalJSON(data []byte) error {
 	d.unmarshaled = true
 	if bytes.Equal(data, []byte("null")) {
@@ -938,10 +1556,15 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 	var s string
-	if err := json.Unmarshal(data, &s); err != nil {
-		return err
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '"' {
+		s = string(trimmed)
+		if !isIntegerText(s) {
+			return newInvalidFormat("Duration", s, "expected integer nanoseconds or a duration string")
+		}
+	} else if err := json.Unmarshal(data, &s); err != nil {
+		return wrapJSONError("Duration", data, err)
 	}
-	dur, err := time.ParseDuration(s)
+	dur, err := ParseDurationFlexible(s)
 	if err != nil {
 		return err
 	}
@@ -951,7 +1574,8 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 }
 
 // Scan implements sql.Scanner for database integration.
-// Supports int64 (nanoseconds) and string formats.
+// Supports int64 (nanoseconds), Go duration strings such as "1h30m" and
+// TIME literals such as "01:30:00.000000", from string or []byte.
 //
 // Example:
 //
@@ -966,39 +1590,212 @@ func (d *Duration) Scan(value any) error {
 		d.value = time.Duration(v)
 		d.valid = true
 	case string:
-		dur, err := time.ParseDuration(v)
-		if err != nil {
-			return err
-		}
-		d.value = dur
-		d.valid = true
+		return d.scanText(v)
+	case []byte:
+		return d.scanText(string(v))
 	default:
-		return fmt.Errorf("unsupported type: %T", value)
+		return newUnsupportedScanType(value)
+	}
+	return nil
+}
+
+// scanText parses a Go duration string or a TIME literal.
+func (d *Duration) scanText(text string) error {
+	dur, ok := parseClockDuration(text)
+	if !ok {
+		var err error
+		if dur, err = time.ParseDuration(text); err != nil {
+			return wrapInvalidFormat("Duration", text, err)
+		}
 	}
+	d.value = dur
+	d.valid = true
 	return nil
 }
 
 // Value implements driver.Valuer for database integration.
-// Returns duration as int64 nanoseconds.
+// Returns duration as int64 nanoseconds by default; SetDurationValueMode
+// selects the duration string or a TIME(6) literal instead.
 //
 // Example:
 //
 //	_, err := db.Exec("INSERT INTO sessions (duration) VALUES (?)", d.Value())
 func (d Duration) Value() (driver.Value, error) {
+	return d.valueCodec(&defaultCodec)
+}
+
+// valueCodec implements Value with the options of c.
+func (d Duration) valueCodec(c *Codec) (driver.Value, error) {
 	if !d.valid {
 		return nil, nil
 	}
+	switch DurationValueMode(c.durationValueMode.Load()) {
+	case DurationValueString:
+		return d.value.String(), nil
+	case DurationValueMicros:
+		return formatClockDuration(d.value), nil
+	}
 	return int64(d.value), nil
 }
 
+// ValueOrZero is like Value, but returns a zero duration instead of NULL
+// when null, for NOT NULL columns. See NotNullColumn.
+//
+// Example:
+//
+//	v, _ := ztype.NewNullDuration().ValueOrZero() // int64(0)
+func (d Duration) ValueOrZero() (driver.Value, error) {
+	if !d.valid {
+		return NewDuration(0).Value()
+	}
+	return d.Value()
+}
+
 // String returns the duration string for valid values, "<NULL>" for NULL.
 //
 // Example:
 //
 //	fmt.Println(d.String()) // Output: "1h30m0s" or "<NULL>"
-func (d *Duration) String() string {
+func (d Duration) String() string {
 	if !d.valid {
-		return "<NULL>"
+		return nullToken()
 	}
 	return d.value.String()
 }
+
+// AppendString appends the String output, such as "1h30m0s", to dst
+// without allocating when dst has enough capacity.
+//
+// Example:
+//
+//	buf = ztype.NewDuration(90 * time.Minute).AppendString(buf[:0]) // "1h30m0s"
+func (d Duration) AppendString(dst []byte) []byte {
+	if !d.valid {
+		return append(dst, nullToken()...)
+	}
+	return appendDuration(dst, d.value)
+}
+
+// appendDuration appends d in the format of time.Duration.String, which
+// builds the text backwards in a fixed buffer.
+func appendDuration(dst []byte, d time.Duration) []byte {
+	var buf [32]byte
+	w := len(buf)
+	u := uint64(d)
+	if d < 0 {
+		u = -u
+	}
+	if u < uint64(time.Second) {
+		var prec int
+		w--
+		buf[w] = 's'
+		w--
+		switch {
+		case u == 0:
+			buf[w] = '0'
+			return append(dst, buf[w:]...)
+		case u < uint64(time.Microsecond):
+			buf[w] = 'n'
+		case u < uint64(time.Millisecond):
+			prec = 3
+			w--
+			copy(buf[w:], "µ")
+		default:
+			prec = 6
+			buf[w] = 'm'
+		}
+		w, u = appendDurationFraction(buf[:w], u, prec)
+		w = appendDurationInt(buf[:w], u)
+	} else {
+		w--
+		buf[w] = 's'
+		w, u = appendDurationFraction(buf[:w], u, 9)
+		w = appendDurationInt(buf[:w], u%60)
+		if u /= 60; u > 0 {
+			w--
+			buf[w] = 'm'
+			w = appendDurationInt(buf[:w], u%60)
+			if u /= 60; u > 0 {
+				w--
+				buf[w] = 'h'
+				w = appendDurationInt(buf[:w], u)
+			}
+		}
+	}
+	if d < 0 {
+		w--
+		buf[w] = '-'
+	}
+	return append(dst, buf[w:]...)
+}
+
+// appendDurationFraction writes the prec least significant digits of v at
+// the end of buf, without trailing zeros and with a decimal point when any
+// remain, returning the new start and v without those digits.
+func appendDurationFraction(buf []byte, v uint64, prec int) (int, uint64) {
+	w := len(buf)
+	printed := false
+	for range prec {
+		digit := v % 10
+		printed = printed || digit != 0
+		if printed {
+			w--
+			buf[w] = byte(digit) + '0'
+		}
+		v /= 10
+	}
+	if printed {
+		w--
+		buf[w] = '.'
+	}
+	return w, v
+}
+
+// appendDurationInt writes v in decimal at the end of buf, returning the new
+// start.
+func appendDurationInt(buf []byte, v uint64) int {
+	w := len(buf)
+	if v == 0 {
+		w--
+		buf[w] = '0'
+		return w
+	}
+	for ; v > 0; v /= 10 {
+		w--
+		buf[w] = byte(v%10) + '0'
+	}
+	return w
+}
+
+// StringOr returns String for valid values and fallback for null, taking
+// precedence over the token set with SetNullToken.
+//
+// Example:
+//
+//	fmt.Println(ztype.NewNullDuration().StringOr("-"))  // Output: -
+func (d Duration) StringOr(fallback string) string {
+	if !d.valid {
+		return fallback
+	}
+	return d.String()
+}
+
+// SchemaType reports Duration as a nullable string such as "1h30m0s".
+//
+// Example:
+//
+//	ztype.Duration{}.SchemaType() // "string", "duration", true
+func (d Duration) SchemaType() (jsonType string, format string, nullable bool) {
+	return "string", "duration", true
+}
+
+// Format implements fmt.Formatter. Verbs such as %d (nanoseconds) or %q and
+// their flags apply to the underlying time.Duration; %v and %s print String
+// and null prints "<NULL>" for every verb.
+//
+// Example:
+//
+//	fmt.Printf("%d", ztype.NewDuration(time.Microsecond)) // Output: 1000
+func (d Duration) Format(f fmt.State, verb rune) {
+	formatNullable(f, verb, d.valid, d.String(), d.value)
+}
@@ -0,0 +1,353 @@
+package ztype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeTag is a parsed `ztime` struct tag: either a layout for time.Parse
+// and time.Format, or the unit of a Unix timestamp.
+type timeTag struct {
+	layout string
+	unit   string
+}
+
+// parseTimeTag parses `ztime:"layout=2006-01-02"` or `ztime:"unix=ms"`; the
+// unit is one of s, ms, us or ns. Everything after "layout=" is the layout,
+// so it may contain commas.
+func parseTimeTag(tag string) (timeTag, error) {
+	if layout, ok := strings.CutPrefix(tag, "layout="); ok && layout != "" {
+		return timeTag{layout: layout}, nil
+	}
+	if unit, ok := strings.CutPrefix(tag, "unix="); ok {
+		switch unit {
+		case "s", "ms", "us", "ns":
+			return timeTag{unit: unit}, nil
+		}
+	}
+	return timeTag{}, fmt.Errorf("invalid ztime tag %q", tag)
+}
+
+// parse decodes the JSON value raw with the tag. Null and the empty string
+// report ok=false.
+func (tag timeTag) parse(raw json.RawMessage) (parsed time.Time, ok bool, err error) {
+	if bytes.Equal(raw, []byte("null")) {
+		return time.Time{}, false, nil
+	}
+	text := string(raw)
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return time.Time{}, false, wrapJSONError("Time", raw, err)
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return time.Time{}, false, nil
+		}
+	} else if tag.layout != "" {
+		return time.Time{}, false, newInvalidFormat("Time", text, "expected a string with layout %q", tag.layout)
+	}
+
+	if tag.layout != "" {
+		parsed, err := time.Parse(tag.layout, text)
+		if err != nil {
+			return time.Time{}, false, newInvalidFormat("Time", text, "layout %q: %w", tag.layout, err)
+		}
+		return parsed, true, nil
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return time.Time{}, false, newInvalidFormat("Time", text, "expected a Unix timestamp in %s", tag.unit)
+	}
+	switch tag.unit {
+	case "s":
+		parsed = time.Unix(n, 0)
+	case "ms":
+		parsed = time.UnixMilli(n)
+	case "us":
+		parsed = time.UnixMicro(n)
+	default:
+		parsed = time.Unix(0, n)
+	}
+	return parsed.UTC(), true, nil
+}
+
+// appendFormat appends the JSON encoding of t in the tag's format.
+func (tag timeTag) appendFormat(dst []byte, t Time) []byte {
+	if !t.value.Valid {
+		return append(dst, "null"...)
+	}
+	moment := t.marshaled(&defaultCodec)
+	if tag.layout != "" {
+		return appendJSONString(dst, moment.Format(tag.layout))
+	}
+	switch tag.unit {
+	case "s":
+		return strconv.AppendInt(dst, moment.Unix(), 10)
+	case "ms":
+		return strconv.AppendInt(dst, moment.UnixMilli(), 10)
+	case "us":
+		return strconv.AppendInt(dst, moment.UnixMicro(), 10)
+	}
+	return strconv.AppendInt(dst, moment.UnixNano(), 10)
+}
+
+// timeTagField is a JSON field that holds a tagged Time, or a struct with
+// tagged Time fields of its own.
+type timeTagField struct {
+	name   string
+	index  []int
+	tag    *timeTag
+	nested reflect.Type
+}
+
+// timeTagFields lists the fields of struct type t that UnmarshalJSONWithTags
+// and MarshalJSONWithTags handle, flattening embedded structs the way
+// encoding/json does.
+func timeTagFields(t reflect.Type) ([]timeTagField, error) {
+	var fields []timeTagField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && !hasJSONName(field) && fieldType.Kind() == reflect.Struct &&
+			fieldType.PkgPath() != packagePath {
+			embedded, err := timeTagFields(fieldType)
+			if err != nil {
+				return nil, err
+			}
+			for _, inner := range embedded {
+				inner.index = append([]int{i}, inner.index...)
+				fields = append(fields, inner)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		if tagText, ok := field.Tag.Lookup("ztime"); ok {
+			if field.Type != reflect.TypeFor[Time]() {
+				return nil, fmt.Errorf("field %s: ztime tag on %v, expected ztype.Time", field.Name, field.Type)
+			}
+			tag, err := parseTimeTag(tagText)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			fields = append(fields, timeTagField{name: name, index: []int{i}, tag: &tag})
+			continue
+		}
+		if fieldType.Kind() != reflect.Struct || fieldType.PkgPath() == packagePath {
+			continue
+		}
+		nested, err := timeTagFields(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		if len(nested) > 0 {
+			fields = append(fields, timeTagField{name: name, index: []int{i}, nested: fieldType})
+		}
+	}
+	return fields, nil
+}
+
+// jsonMember is a member of a JSON object, kept in document order.
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// readJSONObject splits a JSON object into its members. ok is false when
+// data is not a well-formed object, which is left for encoding/json to
+// report.
+func readJSONObject(data []byte) (members []jsonMember, ok bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, false
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false
+		}
+		members = append(members, jsonMember{key: token.(string), value: value})
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, false
+	}
+	return members, true
+}
+
+// writeJSONObject joins members into a JSON object.
+func writeJSONObject(members []jsonMember) []byte {
+	dst := []byte{'{'}
+	for i, member := range members {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, member.key)
+		dst = append(dst, ':')
+		dst = append(dst, member.value...)
+	}
+	return append(dst, '}')
+}
+
+// findTimeTagField returns the field a JSON key decodes into, matching
+// names exactly first and then case-insensitively, like encoding/json.
+func findTimeTagField(fields []timeTagField, key string) *timeTagField {
+	for i := range fields {
+		if fields[i].name == key {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, key) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSONWithTags decodes data into the struct pointed to by dest like
+// json.Unmarshal, except that Time fields tagged with `ztime` are read in
+// the tagged format: `ztime:"layout=2006-01-02"` parses strings with that
+// layout, and `ztime:"unix=ms"` reads Unix timestamps in s, ms, us or ns,
+// as numbers or numeric strings, in UTC. Untagged fields keep the standard
+// behavior. Tags are honored in nested and embedded structs too, but not
+// inside slices or maps.
+//
+// Null and the empty string make a tagged field null. A value that does not
+// match its tag fails with an error naming the field by its JSON path.
+//
+// Example:
+//
+//	type Event struct {
+//		Day     ztype.Time `json:"day" ztime:"layout=2006-01-02"`
+//		Created ztype.Time `json:"created" ztime:"unix=ms"`
+//		Updated ztype.Time `json:"updated"`
+//	}
+//	var event Event
+//	err := ztype.UnmarshalJSONWithTags(data, &event)
+func UnmarshalJSONWithTags(data []byte, dest any) error {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", dest)
+	}
+	rewritten, err := rewriteTaggedTimes("", data, target.Elem().Type())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(rewritten, dest)
+}
+
+// rewriteTaggedTimes replaces the values of tagged Time fields in the JSON
+// object data with the RFC 3339 text Time.UnmarshalJSON reads.
+func rewriteTaggedTimes(path string, data []byte, t reflect.Type) ([]byte, error) {
+	fields, err := timeTagFields(t)
+	if err != nil || len(fields) == 0 {
+		return data, err
+	}
+	members, ok := readJSONObject(data)
+	if !ok {
+		return data, nil
+	}
+	for i, member := range members {
+		field := findTimeTagField(fields, member.key)
+		if field == nil {
+			continue
+		}
+		fieldPath := field.name
+		if path != "" {
+			fieldPath = path + "." + field.name
+		}
+		if field.nested != nil {
+			if members[i].value, err = rewriteTaggedTimes(fieldPath, member.value, field.nested); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		parsed, ok, err := field.tag.parse(member.value)
+		if err != nil {
+			return nil, decodeError(fieldPath, err)
+		}
+		members[i].value = []byte("null")
+		if ok {
+			members[i].value = appendJSONString(nil, parsed.Format(time.RFC3339Nano))
+		}
+	}
+	return writeJSONObject(members), nil
+}
+
+// MarshalJSONWithTags encodes v like json.Marshal, except that Time fields
+// tagged with `ztime` are written in the tagged format, so the output reads
+// back with UnmarshalJSONWithTags. Null Times are written as null.
+//
+// Example:
+//
+//	data, err := ztype.MarshalJSONWithTags(event)
+//	// {"day":"2024-03-01","created":1709294400000,"updated":"2024-03-01T12:00:00Z"}
+func MarshalJSONWithTags(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return data, nil
+	}
+	return formatTaggedTimes(data, value)
+}
+
+// formatTaggedTimes replaces the values of tagged Time fields in the JSON
+// object data, the encoding of the struct value.
+func formatTaggedTimes(data []byte, value reflect.Value) ([]byte, error) {
+	fields, err := timeTagFields(value.Type())
+	if err != nil || len(fields) == 0 {
+		return data, err
+	}
+	members, ok := readJSONObject(data)
+	if !ok {
+		return data, nil
+	}
+	for i, member := range members {
+		field := findTimeTagField(fields, member.key)
+		if field == nil || field.name != member.key {
+			continue
+		}
+		fieldValue, err := value.FieldByIndexErr(field.index)
+		if err != nil {
+			continue
+		}
+		if field.tag != nil {
+			members[i].value = field.tag.appendFormat(nil, fieldValue.Interface().(Time))
+			continue
+		}
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if members[i].value, err = formatTaggedTimes(member.value, fieldValue); err != nil {
+			return nil, err
+		}
+	}
+	return writeJSONObject(members), nil
+}
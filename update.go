@@ -0,0 +1,195 @@
+package ztype
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoChangedFields is returned by BuildUpdate when no field of the struct
+// was present in the decoded input, so there is nothing to SET.
+var ErrNoChangedFields = errors.New("no changed fields to update")
+
+// PlaceholderStyle selects how BuildUpdate writes bind parameters.
+type PlaceholderStyle int
+
+const (
+	// PlaceholderDollar writes numbered parameters ($1, $2, ...), as used by PostgreSQL.
+	PlaceholderDollar PlaceholderStyle = iota
+	// PlaceholderQuestion writes positional parameters (?), as used by MySQL and SQLite.
+	PlaceholderQuestion
+)
+
+// identifierPattern matches the plain SQL identifiers BuildUpdate accepts,
+// optionally qualified as in schema.table.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// updateOptions holds the configuration of BuildUpdate.
+type updateOptions struct {
+	placeholder PlaceholderStyle
+	where       []updateCondition
+}
+
+// updateCondition is a single "column = value" condition of the WHERE clause.
+type updateCondition struct {
+	column string
+	value  any
+}
+
+// UpdateOption configures BuildUpdate.
+type UpdateOption func(*updateOptions)
+
+// WithPlaceholder sets the bind parameter style. Defaults to PlaceholderDollar.
+//
+// Example:
+//
+//	ztype.BuildUpdate("users", &req, ztype.WithPlaceholder(ztype.PlaceholderQuestion))
+func WithPlaceholder(style PlaceholderStyle) UpdateOption {
+	return func(o *updateOptions) {
+		o.placeholder = style
+	}
+}
+
+// WithWhere adds a "column = value" condition to the WHERE clause. Multiple
+// conditions are joined with AND.
+//
+// Example:
+//
+//	ztype.BuildUpdate("users", &req, ztype.WithWhere("id", 42))
+func WithWhere(column string, value any) UpdateOption {
+	return func(o *updateOptions) {
+		o.where = append(o.where, updateCondition{column: column, value: value})
+	}
+}
+
+// BuildUpdate builds an UPDATE statement that sets only the ztype fields of
+// v that were present in the decoded input. Columns come from the `db` tag,
+// falling back to the field name; `db:"-"` skips a field. Explicitly null
+// fields are written as NULL, except NotNullColumn fields, which bind their
+// zero value; valued fields are bind parameters holding the ztype value.
+// Embedded structs are flattened. Table and column names must be plain
+// identifiers, which keeps them safe to inline in the query. Returns
+// ErrNoChangedFields when nothing was present.
+//
+// Example:
+//
+//	json.Unmarshal([]byte(`{"name":"Ana","email":null}`), &req)
+//	query, args, err := ztype.BuildUpdate("users", &req, ztype.WithWhere("id", 42))
+//	// query: UPDATE users SET name = $1, email = NULL WHERE id = $2
+//	// args:  [ztype.String("Ana"), 42]
+func BuildUpdate(table string, v any, opts ...UpdateOption) (string, []any, error) {
+	options := updateOptions{placeholder: PlaceholderDollar}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if !identifierPattern.MatchString(table) {
+		return "", nil, fmt.Errorf("invalid table name %q", table)
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", nil, fmt.Errorf("expected a struct, got nil %T", v)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("expected a struct, got %T", v)
+	}
+	if !value.CanAddr() {
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		value = addressable
+	}
+
+	var query strings.Builder
+	var args []any
+	placeholder := func(arg any) string {
+		args = append(args, arg)
+		if options.placeholder == PlaceholderQuestion {
+			return "?"
+		}
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	set, err := collectUpdateColumns(value, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(set) == 0 {
+		return "", nil, ErrNoChangedFields
+	}
+
+	query.WriteString("UPDATE " + table + " SET ")
+	for i, column := range set {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(column.column + " = ")
+		if column.value == nil {
+			query.WriteString("NULL")
+		} else {
+			query.WriteString(placeholder(column.value))
+		}
+	}
+
+	for i, condition := range options.where {
+		if !identifierPattern.MatchString(condition.column) {
+			return "", nil, fmt.Errorf("invalid column name %q", condition.column)
+		}
+		if i == 0 {
+			query.WriteString(" WHERE ")
+		} else {
+			query.WriteString(" AND ")
+		}
+		query.WriteString(condition.column + " = " + placeholder(condition.value))
+	}
+
+	return query.String(), args, nil
+}
+
+// collectUpdateColumns appends the present ztype fields of the addressable
+// struct value to columns, in field order. A nil value marks an explicit null.
+func collectUpdateColumns(value reflect.Value, columns []updateCondition) ([]updateCondition, error) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		column, _, _ := strings.Cut(field.Tag.Get("db"), ",")
+		if column == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if field.Type.Kind() != reflect.Pointer && reflect.PointerTo(field.Type).Implements(nullableType) {
+			nullable := fieldValue.Addr().Interface().(Nullable)
+			if !nullable.Unmarshaled() {
+				continue
+			}
+			if column == "" {
+				column = field.Name
+			}
+			if !identifierPattern.MatchString(column) || strings.Contains(column, ".") {
+				return nil, fmt.Errorf("invalid column name %q", column)
+			}
+			var arg any
+			if _, zeroOnNull := nullable.(interface{ writesZeroOnNull() }); !nullable.IsNull() || zeroOnNull {
+				arg = fieldValue.Interface()
+			}
+			columns = append(columns, updateCondition{column: column, value: arg})
+			continue
+		}
+
+		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			var err error
+			if columns, err = collectUpdateColumns(fieldValue, columns); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,94 @@
+// Package validatortype integrates ztype with go-playground/validator, which
+// otherwise sees ztype fields as opaque structs and skips tags such as
+// required or max.
+//
+// Register the types once, next to the validator:
+//
+//	validate := validator.New()
+//	validatortype.RegisterValidations(validate)
+//
+// Valid values are validated through their underlying Go value; null values
+// behave like nil pointers, so they fail every tag unless omitempty is set.
+package validatortype
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/zhaori96/ztype"
+)
+
+// RequiredNotNullTag is the tag registered by RegisterValidations that fails
+// only for null values. Unlike required, it accepts valid zero values such
+// as false, 0 or "".
+const RequiredNotNullTag = "required_notnull"
+
+// RegisterValidations registers the non-generic ztype types, JSON and the
+// common instantiations of Numeric, Slice and Set, plus the
+// required_notnull tag. Other generic instantiations, such as Enum types,
+// are added with RegisterTypes.
+//
+// Example:
+//
+//	type Request struct {
+//		Name   ztype.String       `validate:"required,max=10"`
+//		Age    ztype.Numeric[int] `validate:"omitempty,gte=18"`
+//		Active ztype.Bool         `validate:"required_notnull"`
+//	}
+//	validate := validator.New()
+//	validatortype.RegisterValidations(validate)
+//	err := validate.Struct(req)
+func RegisterValidations(v *validator.Validate) {
+	RegisterTypes(v,
+		ztype.Bool{}, ztype.Byte{}, ztype.Char{}, ztype.Bytes{}, ztype.String{},
+		ztype.Time{}, ztype.Duration{}, ztype.Rune{}, ztype.IP{}, ztype.CIDR{},
+		ztype.RawJSON{}, ztype.Money{}, ztype.JSON{},
+		ztype.Numeric[int]{}, ztype.Numeric[int8]{}, ztype.Numeric[int16]{},
+		ztype.Numeric[int32]{}, ztype.Numeric[int64]{}, ztype.Numeric[uint]{},
+		ztype.Numeric[uint8]{}, ztype.Numeric[uint16]{}, ztype.Numeric[uint32]{},
+		ztype.Numeric[uint64]{}, ztype.Numeric[float32]{}, ztype.Numeric[float64]{},
+		ztype.Slice[string]{}, ztype.Slice[int]{}, ztype.Slice[any]{},
+		ztype.Set[string]{}, ztype.Set[int]{},
+	)
+	// The tag is a constant and the func is not nil, so this cannot fail.
+	_ = v.RegisterValidation(RequiredNotNullTag, func(validator.FieldLevel) bool {
+		// Null values never reach this func: the validator reports them
+		// like nil pointers before running the tag.
+		return true
+	})
+}
+
+// RegisterTypes registers additional ztype types, typically other generic
+// instantiations. Pass a zero value of each type.
+//
+// Example:
+//
+//	validatortype.RegisterTypes(validate, ztype.Enum[Status]{}, ztype.Slice[Item]{})
+func RegisterTypes(v *validator.Validate, values ...any) {
+	v.RegisterCustomTypeFunc(underlyingValue, values...)
+}
+
+// underlyingValue returns the value the validator checks for a ztype field:
+// nil for null, the amount in minor units for Money, the text form for IP and
+// CIDR (so the ip and cidr tags work) and the result of Get otherwise.
+func underlyingValue(field reflect.Value) any {
+	pointer := reflect.New(field.Type())
+	pointer.Elem().Set(field)
+	if nullable, ok := pointer.Interface().(ztype.Nullable); ok && nullable.IsNull() {
+		return nil
+	}
+	switch value := pointer.Interface().(type) {
+	case *ztype.Money:
+		return value.Amount()
+	case *ztype.IP:
+		return value.String()
+	case *ztype.CIDR:
+		return value.String()
+	}
+	get := pointer.MethodByName("Get")
+	if get.IsValid() && get.Type().NumIn() == 0 && get.Type().NumOut() == 1 {
+		return get.Call(nil)[0].Interface()
+	}
+	return field.Interface()
+}
@@ -0,0 +1,252 @@
+package ztype
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// maxValuerDepth bounds how many nested Value calls ValueOf follows, so a
+// Value method returning its own receiver fails instead of recursing forever.
+const maxValuerDepth = 16
+
+// ValueOf converts v to a driver.Value the way database/sql would for a query
+// argument, without its reflection based parameter converter. It lets a
+// custom type holding ztype fields implement driver.Valuer in one line, and
+// tests check conversions without a database.
+//
+// The conversion table:
+//
+//	nil, nil pointer, null ztype value      nil
+//	Bool                                    bool
+//	Byte, Char, Numeric[integer]            int64
+//	Duration, MoneyAmount                   int64
+//	Numeric[float32], Numeric[float64]      float64
+//	String, Rune, Enum, IP, CIDR            string
+//	MoneyCurrency                           string
+//	Map, Slice, Set, Money, OrderedMap      string (JSON)
+//	Array                                   string (PostgreSQL array literal)
+//	Bytes, RawJSON                          []byte
+//	Time                                    time.Time, or string when date-only
+//	Null[T]                                 the value of T, converted again
+//	any other driver.Valuer                 its Value, converted again
+//	int64, float64, bool, string, []byte,   unchanged
+//	time.Time
+//	other integers, float32                 int64, float64
+//	types based on a basic kind             the converted basic value
+//
+// A uint64 above math.MaxInt64 is an *ErrOverflow and any other type an
+// *ErrUnsupportedScanType. Errors from a Value method are wrapped with the
+// Go type that returned them.
+//
+// Example:
+//
+//	type Email struct{ address ztype.String }
+//
+//	func (e Email) Value() (driver.Value, error) { return ztype.ValueOf(e.address) }
+func ValueOf(v any) (driver.Value, error) {
+	return valueOf(&defaultCodec, v, 0)
+}
+
+// valueOf converts v with the options of c, following nested Valuers up to
+// maxValuerDepth.
+func valueOf(c *Codec, v any, depth int) (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if value := reflect.ValueOf(v); value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil, nil
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		if depth >= maxValuerDepth {
+			return nil, fmt.Errorf("%T: Value nested more than %d levels", v, maxValuerDepth)
+		}
+		var result driver.Value
+		var err error
+		if codec, ok := v.(codecValuer); ok {
+			result, err = codec.valueCodec(c)
+		} else {
+			result, err = valuer.Value()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%T: %w", v, err)
+		}
+		if driver.IsValue(result) {
+			return result, nil
+		}
+		return valueOf(c, result, depth+1)
+	}
+
+	switch value := v.(type) {
+	case int64, float64, bool, string, []byte, time.Time:
+		return v, nil
+	case int:
+		return int64(value), nil
+	case int8:
+		return int64(value), nil
+	case int16:
+		return int64(value), nil
+	case int32:
+		return int64(value), nil
+	case uint:
+		return uintValue(uint64(value))
+	case uint8:
+		return int64(value), nil
+	case uint16:
+		return int64(value), nil
+	case uint32:
+		return int64(value), nil
+	case uint64:
+		return uintValue(value)
+	case float32:
+		return float64(value), nil
+	}
+
+	value := reflect.ValueOf(v)
+	switch {
+	case value.Kind() == reflect.Pointer:
+		return valueOf(c, value.Elem().Interface(), depth)
+	case value.CanInt():
+		return value.Int(), nil
+	case value.CanUint():
+		return uintValue(value.Uint())
+	case value.CanFloat():
+		return value.Float(), nil
+	case value.Kind() == reflect.Bool:
+		return value.Bool(), nil
+	case value.Kind() == reflect.String:
+		return value.String(), nil
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8:
+		return value.Bytes(), nil
+	}
+	return nil, newUnsupportedScanType(v)
+}
+
+// uintValue converts n to int64, the only integer a driver.Value holds.
+func uintValue(n uint64) (driver.Value, error) {
+	if n > math.MaxInt64 {
+		return nil, &ErrOverflow{Type: "int64", Value: strconv.FormatUint(n, 10)}
+	}
+	return int64(n), nil
+}
+
+// ScanInto stores src into dest, which must be a non-nil pointer, the way
+// database/sql would for a result column. It lets a custom type holding
+// ztype fields implement sql.Scanner in one line, and tests check
+// conversions without a database. A src that is a driver.Valuer, such as
+// another ztype value, is first converted with ValueOf, so values can be
+// copied between ztype types.
+//
+// The conversion table, by dest:
+//
+//	sql.Scanner (every ztype type)   its Scan method
+//	other Nullable                   SetNull for nil, an error otherwise
+//	*any                             src unchanged
+//	*string                          string, []byte, int64, float64, bool
+//	*[]byte                          []byte (copied), string
+//	*bool                            bool
+//	*time.Time                       time.Time
+//	*int..., *uint..., *float...     int64, float64, and number text
+//
+// The ztype Scan methods accept, besides nil, which sets null:
+//
+//	Bool                            bool, string, []byte, int64 and float64 0 or 1
+//	Byte, Char, Numeric[integer]    int64, whole float64, bool, string, []byte
+//	Numeric[float32], [float64]     int64, float64, bool, string, []byte
+//	String                          string, []byte, int64, float64, bool, time.Time
+//	Bytes, RawJSON                  []byte, string
+//	Time                            time.Time; string, []byte when date-only
+//	Duration                        int64, string, []byte
+//	Rune                            string, []byte, int64
+//	Enum, IP, CIDR                  string, []byte
+//	Map, Slice, Set, OrderedMap     string, []byte (JSON)
+//	Array                           string, []byte (PostgreSQL array literal)
+//
+// nil into a dest that is not a Scanner, Nullable or *any is an error, as
+// with database/sql. Scan errors are returned unchanged, so errors.Is still
+// matches *ErrInvalidFormat and the other package errors; a src of the
+// wrong type is an *ErrUnsupportedScanType.
+//
+// Example:
+//
+//	func (e *Email) Scan(src any) error { return ztype.ScanInto(&e.address, src) }
+func ScanInto(dest any, src any) error {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer, got %T", dest)
+	}
+	if _, ok := src.(driver.Valuer); ok {
+		value, err := ValueOf(src)
+		if err != nil {
+			return err
+		}
+		src = value
+	}
+
+	switch d := dest.(type) {
+	case sql.Scanner:
+		return d.Scan(src)
+	case Nullable:
+		if src != nil {
+			return fmt.Errorf("%T does not implement sql.Scanner", dest)
+		}
+		d.SetNull()
+		return nil
+	case *any:
+		*d = src
+		return nil
+	}
+
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dest)
+	}
+	switch d := dest.(type) {
+	case *string:
+		switch s := src.(type) {
+		case string:
+			*d = s
+			return nil
+		case []byte:
+			*d = string(s)
+			return nil
+		case int64, float64, bool:
+			*d = fmt.Sprint(s)
+			return nil
+		}
+	case *[]byte:
+		switch s := src.(type) {
+		case []byte:
+			*d = append([]byte(nil), s...)
+			return nil
+		case string:
+			*d = []byte(s)
+			return nil
+		}
+	case *bool:
+		if s, ok := src.(bool); ok {
+			*d = s
+			return nil
+		}
+	case *time.Time:
+		if s, ok := src.(time.Time); ok {
+			*d = s
+			return nil
+		}
+	}
+
+	if elem := target.Elem(); elem.CanInt() || elem.CanUint() || elem.CanFloat() {
+		switch s := src.(type) {
+		case int64, float64:
+			return convertNumber(s, elem)
+		case string:
+			return convertNumberText(s, elem)
+		case []byte:
+			return convertNumberText(string(s), elem)
+		}
+	}
+	return newUnsupportedScanType(src)
+}
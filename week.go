@@ -0,0 +1,111 @@
+package ztype
+
+import "time"
+
+// StartOfWeek returns midnight of the first day of the week containing the
+// Time, with weeks starting on weekStart, in the location of the Time. Use
+// time.Monday for ISO weeks and time.Sunday for the US convention. A null
+// Time stays null.
+//
+// Example:
+//
+//	wed := ztype.NewTime(time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC))
+//	wed.StartOfWeek(time.Sunday) // 2024-12-29 00:00
+//	wed.StartOfWeek(time.Monday) // 2024-12-30 00:00
+func (t Time) StartOfWeek(weekStart time.Weekday) Time {
+	if !t.value.Valid {
+		return t
+	}
+	t.value.Time = startOfWeek(t.value.Time, weekStart)
+	return t
+}
+
+// EndOfWeek returns the last nanosecond of the week containing the Time,
+// with weeks starting on weekStart, in the location of the Time. For a
+// date-only Time it returns the last day of the week. A null Time stays
+// null.
+//
+// Example:
+//
+//	wed := ztype.NewTime(time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC))
+//	wed.EndOfWeek(time.Sunday) // 2025-01-04 23:59:59.999999999
+func (t Time) EndOfWeek(weekStart time.Weekday) Time {
+	if !t.value.Valid {
+		return t
+	}
+	start := startOfWeek(t.value.Time, weekStart)
+	if t.dateOnly {
+		t.value.Time = start.AddDate(0, 0, 6)
+		return t
+	}
+	t.value.Time = start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+	return t
+}
+
+// SameWeek reports whether both Times fall in the same week, with weeks
+// starting on weekStart, reading other in the location of the Time. Returns
+// false when either Time is null.
+//
+// Example:
+//
+//	sat := ztype.NewTime(time.Date(2025, 1, 4, 9, 0, 0, 0, time.UTC))
+//	sun := ztype.NewTime(time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC))
+//	sat.SameWeek(sun, time.Monday) // true
+//	sat.SameWeek(sun, time.Sunday) // false
+func (t Time) SameWeek(other Time, weekStart time.Weekday) bool {
+	if !t.value.Valid || !other.value.Valid {
+		return false
+	}
+	otherTime := other.value.Time.In(t.value.Time.Location())
+	return startOfWeek(t.value.Time, weekStart).Equal(startOfWeek(otherTime, weekStart))
+}
+
+// startOfWeek returns midnight of the last weekStart on or before value, in
+// the location of value.
+func startOfWeek(value time.Time, weekStart time.Weekday) time.Time {
+	back := (int(value.Weekday()) - int(weekStart) + 7) % 7
+	year, month, day := value.Date()
+	return time.Date(year, month, day-back, 0, 0, 0, 0, value.Location())
+}
+
+// WeekOfMonth returns which seven day block of its month the Time falls in,
+// 1 for days 1 to 7 through 5 for days 29 to 31, so that it counts the
+// occurrences of a weekday, as in "the second Tuesday". Returns 0 for null.
+//
+// Example:
+//
+//	ztype.NewTime(time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)).WeekOfMonth() // 2
+func (t Time) WeekOfMonth() int {
+	if !t.value.Valid {
+		return 0
+	}
+	return (t.value.Time.Day()-1)/7 + 1
+}
+
+// Quarter returns the quarter of the year, 1 to 4, in the location of the
+// Time. Returns 0 for null.
+//
+// Example:
+//
+//	ztype.NewTime(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)).Quarter() // 2
+func (t Time) Quarter() int {
+	if !t.value.Valid {
+		return 0
+	}
+	return (int(t.value.Time.Month())-1)/3 + 1
+}
+
+// StartOfQuarter returns midnight of the first day of the quarter containing
+// the Time, in the location of the Time. A null Time stays null.
+//
+// Example:
+//
+//	ztype.NewTime(time.Date(2025, 5, 20, 9, 0, 0, 0, time.UTC)).StartOfQuarter() // 2025-04-01 00:00
+func (t Time) StartOfQuarter() Time {
+	if !t.value.Valid {
+		return t
+	}
+	month := time.Month((t.Quarter()-1)*3 + 1)
+	t.value.Time = time.Date(t.value.Time.Year(), month, 1, 0, 0, 0, 0, t.value.Time.Location())
+	return t
+}
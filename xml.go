@@ -0,0 +1,195 @@
+package ztype
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// xmlSchemaInstance is the namespace of the xsi:nil attribute.
+const xmlSchemaInstance = "http://www.w3.org/2001/XMLSchema-instance"
+
+var (
+	_ xml.MarshalerAttr   = Bool{}
+	_ xml.MarshalerAttr   = Byte{}
+	_ xml.MarshalerAttr   = Numeric[int]{}
+	_ xml.UnmarshalerAttr = (*Bool)(nil)
+	_ xml.UnmarshalerAttr = (*Byte)(nil)
+	_ xml.UnmarshalerAttr = (*Numeric[int])(nil)
+	_ xml.Unmarshaler     = (*Bool)(nil)
+	_ xml.Unmarshaler     = (*Byte)(nil)
+	_ xml.Unmarshaler     = (*Numeric[int])(nil)
+)
+
+// xmlTextValue is a ztype value read from XML through its text form.
+type xmlTextValue interface {
+	encoding.TextUnmarshaler
+	SetNull()
+	SetUnmarshaled(value bool)
+}
+
+// marshalXMLAttr writes the text form of value as the attribute name,
+// omitting the attribute when value is null.
+func marshalXMLAttr(name xml.Name, value encoding.TextMarshaler) (xml.Attr, error) {
+	text, err := value.MarshalText()
+	if err != nil || text == nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// unmarshalXMLAttr reads the attribute into value, where an empty
+// attribute is null. Errors name the attribute.
+func unmarshalXMLAttr(attr xml.Attr, value xmlTextValue) error {
+	return unmarshalXMLText("attribute", attr.Name, attr.Value, value)
+}
+
+// unmarshalXMLElement reads the character data of the element into value,
+// where an empty element or one with xsi:nil="true" is null. Errors name
+// the element.
+func unmarshalXMLElement(d *xml.Decoder, start xml.StartElement, value xmlTextValue) error {
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+	if isXMLNil(start) {
+		text = ""
+	}
+	return unmarshalXMLText("element", start.Name, text, value)
+}
+
+// unmarshalXMLText stores the trimmed text in value, or null when it is
+// empty, and marks value as unmarshaled either way.
+func unmarshalXMLText(kind string, name xml.Name, text string, value xmlTextValue) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		value.SetNull()
+		value.SetUnmarshaled(true)
+		return nil
+	}
+	if err := value.UnmarshalText([]byte(text)); err != nil {
+		return fmt.Errorf("xml %s %s: %w", kind, name.Local, err)
+	}
+	return nil
+}
+
+// isXMLNil reports whether the element carries xsi:nil="true", with the
+// prefix declared or not.
+func isXMLNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xmlSchemaInstance || attr.Name.Space == "xsi") {
+			return strings.TrimSpace(attr.Value) == "true" || strings.TrimSpace(attr.Value) == "1"
+		}
+	}
+	return false
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr, writing "true" or "false"
+// and omitting the attribute when the Bool is null.
+//
+// Example:
+//
+//	type Item struct {
+//		Active ztype.Bool `xml:"active,attr"`
+//	}
+//	data, _ := xml.Marshal(Item{Active: ztype.NewBool(true)}) // <Item active="true"></Item>
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, b)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, accepting the tokens of
+// UnmarshalText. An empty attribute is null; an absent one leaves the Bool
+// untouched and not unmarshaled.
+//
+// Example:
+//
+//	var item Item
+//	err := xml.Unmarshal([]byte(`<Item active="yes"/>`), &item)
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(attr, b)
+}
+
+// UnmarshalXML implements xml.Unmarshaler for elements, accepting the
+// tokens of UnmarshalText. An empty element or xsi:nil="true" is null.
+//
+// Example:
+//
+//	var b ztype.Bool
+//	err := xml.Unmarshal([]byte(`<active>true</active>`), &b)
+func (b *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLElement(d, start, b)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr, writing the decimal value and
+// omitting the attribute when the Byte is null.
+//
+// Example:
+//
+//	type Item struct {
+//		Priority ztype.Byte `xml:"priority,attr"`
+//	}
+//	data, _ := xml.Marshal(Item{Priority: ztype.NewByte(3)}) // <Item priority="3"></Item>
+func (b Byte) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, b)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, accepting the forms of
+// UnmarshalText, such as "3" or "0x0F". An empty attribute is null; an
+// absent one leaves the Byte untouched and not unmarshaled.
+//
+// Example:
+//
+//	var item Item
+//	err := xml.Unmarshal([]byte(`<Item priority="3"/>`), &item)
+func (b *Byte) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(attr, b)
+}
+
+// UnmarshalXML implements xml.Unmarshaler for elements, accepting the forms
+// of UnmarshalText. An empty element or xsi:nil="true" is null.
+//
+// Example:
+//
+//	var b ztype.Byte
+//	err := xml.Unmarshal([]byte(`<priority>3</priority>`), &b)
+func (b *Byte) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLElement(d, start, b)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr, writing the number as
+// MarshalText does and omitting the attribute when the Numeric is null.
+//
+// Example:
+//
+//	type Item struct {
+//		Price ztype.Numeric[float64] `xml:"price,attr"`
+//	}
+//	data, _ := xml.Marshal(Item{Price: ztype.NewNumber(9.5)}) // <Item price="9.5"></Item>
+func (n Numeric[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, n)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, parsing the attribute as
+// UnmarshalText does. An empty attribute is null; an absent one leaves the
+// Numeric untouched and not unmarshaled.
+//
+// Example:
+//
+//	var item Item
+//	err := xml.Unmarshal([]byte(`<Item price="9.5"/>`), &item)
+func (n *Numeric[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(attr, n)
+}
+
+// UnmarshalXML implements xml.Unmarshaler for elements, parsing the
+// character data as UnmarshalText does. An empty element or xsi:nil="true"
+// is null.
+//
+// Example:
+//
+//	var n ztype.Numeric[int]
+//	err := xml.Unmarshal([]byte(`<count>42</count>`), &n)
+func (n *Numeric[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLElement(d, start, n)
+}
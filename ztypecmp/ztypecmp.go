@@ -0,0 +1,75 @@
+// Package ztypecmp provides go-cmp options for ztype values. It lives apart
+// from ztype so that only the tests that use go-cmp link it.
+package ztypecmp
+
+import (
+	"net/netip"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/zhaori96/ztype"
+)
+
+// packagePath is the import path of ztype, used to recognize its types.
+var packagePath = reflect.TypeFor[ztype.Bool]().PkgPath()
+
+// Options returns go-cmp options that compare ztype values by value and
+// null state, ignoring the unmarshaled flag, so a value decoded from JSON
+// or a database equals one built with a constructor. Like EqualValues, they
+// treat the nil and empty storage of a ztype collection as equal. This is
+// the recommended way to compare structs of ztype values in tests:
+//
+//	if diff := cmp.Diff(want, got, ztypecmp.Options()...); diff != "" {
+//		t.Errorf("mismatch (-want +got):\n%s", diff)
+//	}
+//
+// For single values without go-cmp, use the EqualValues methods.
+func Options() []cmp.Option {
+	return []cmp.Option{
+		cmp.Exporter(func(t reflect.Type) bool {
+			return t.PkgPath() == packagePath
+		}),
+		cmp.FilterPath(isIgnoredField, cmp.Ignore()),
+		cmp.FilterPath(isCollectionField, cmp.FilterValues(bothEmpty, cmp.Ignore())),
+		cmp.Comparer(func(a, b netip.Addr) bool { return a == b }),
+		cmp.Comparer(func(a, b netip.Prefix) bool { return a == b }),
+	}
+}
+
+// isIgnoredField reports whether the path ends at a ztype field that does
+// not take part in value equality: the unmarshaled flag and Set's ordering func.
+func isIgnoredField(path cmp.Path) bool {
+	field, ok := path.Last().(cmp.StructField)
+	if !ok || len(path) < 2 {
+		return false
+	}
+	parent := path.Index(-2).Type()
+	if parent.PkgPath() != packagePath {
+		return false
+	}
+	switch field.Name() {
+	case "unmarshaled", "less":
+		return true
+	}
+	return false
+}
+
+// isCollectionField reports whether the path ends at the map or slice that
+// holds the content of a ztype collection.
+func isCollectionField(path cmp.Path) bool {
+	field, ok := path.Last().(cmp.StructField)
+	if !ok || len(path) < 2 || path.Index(-2).Type().PkgPath() != packagePath {
+		return false
+	}
+	switch field.Type().Kind() {
+	case reflect.Map, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// bothEmpty reports whether the maps or slices a and b are both nil or empty.
+func bothEmpty(a, b any) bool {
+	return reflect.ValueOf(a).Len() == 0 && reflect.ValueOf(b).Len() == 0
+}
@@ -0,0 +1,154 @@
+// Package ztypetest checks that SQL-facing nullable types follow the
+// database/sql conventions of ztype, so that types written outside this
+// module behave like Bool, Time or Duration:
+//
+//   - a fresh value is null, and the Value of a null value is nil;
+//   - scanning nil makes a value null, whatever it held before;
+//   - a scanned value reports the expected driver value, and scanning that
+//     driver value back gives it again;
+//   - scanning a type the value does not support is an error.
+//
+// Call RunValuerScannerTests from a test of the type:
+//
+//	func TestPercentSQL(t *testing.T) {
+//		ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer {
+//			return new(Percent)
+//		}, []ztypetest.Case{
+//			{Name: "int64", Scan: int64(42), Want: int64(42)},
+//			{Name: "text", Scan: "42%", Want: int64(42)},
+//			{Name: "float", Scan: 1.5, Err: true},
+//		})
+//	}
+package ztypetest
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ScannerValuer is the pointer to a nullable SQL-facing type, such as
+// *ztype.Time.
+type ScannerValuer interface {
+	sql.Scanner
+	driver.Valuer
+	IsNull() bool
+}
+
+// Case is a value scanned into a fresh ScannerValuer.
+type Case struct {
+	// Name names the subtest.
+	Name string
+	// Scan is the value given to Scan, as a driver would.
+	Scan any
+	// Want is the driver value Value must return after the scan. Times are
+	// compared with time.Time.Equal and byte slices by content.
+	Want driver.Value
+	// Err tells that Scan must fail, for types the value does not support.
+	Err bool
+}
+
+// unsupported is a scan source no driver produces, which every
+// ScannerValuer must reject.
+type unsupported struct{}
+
+// RunValuerScannerTests runs the conformance checks of the package against
+// the values built by factory, which must return a fresh null value on
+// every call, and then runs cases.
+//
+// Example:
+//
+//	ztypetest.RunValuerScannerTests(t, func() ztypetest.ScannerValuer {
+//		return new(ztype.Duration)
+//	}, []ztypetest.Case{{Name: "nanos", Scan: int64(time.Second), Want: int64(time.Second)}})
+func RunValuerScannerTests(t *testing.T, factory func() ScannerValuer, cases []Case) {
+	t.Helper()
+
+	t.Run("null value is nil", func(t *testing.T) {
+		v := factory()
+		if !v.IsNull() {
+			t.Fatalf("factory returned a non-null %T", v)
+		}
+		checkValue(t, v, nil)
+	})
+
+	t.Run("nil scans to null", func(t *testing.T) {
+		v := factory()
+		if err := v.Scan(nil); err != nil {
+			t.Fatalf("Scan(nil): %v", err)
+		}
+		if !v.IsNull() {
+			t.Fatalf("Scan(nil) left %T non-null", v)
+		}
+		checkValue(t, v, nil)
+	})
+
+	t.Run("wrong type errors", func(t *testing.T) {
+		if err := factory().Scan(unsupported{}); err == nil {
+			t.Fatalf("Scan(%T) returned no error", unsupported{})
+		}
+	})
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			v := factory()
+			err := v.Scan(tc.Scan)
+			if tc.Err {
+				if err == nil {
+					t.Fatalf("Scan(%#v) returned no error", tc.Scan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%#v): %v", tc.Scan, err)
+			}
+			if v.IsNull() {
+				t.Fatalf("Scan(%#v) left %T null", tc.Scan, v)
+			}
+			checkValue(t, v, tc.Want)
+
+			value, _ := v.Value()
+			again := factory()
+			if err := again.Scan(value); err != nil {
+				t.Fatalf("Scan of Value %#v: %v", value, err)
+			}
+			checkValue(t, again, tc.Want)
+
+			if err := v.Scan(nil); err != nil {
+				t.Fatalf("Scan(nil) after a value: %v", err)
+			}
+			if !v.IsNull() {
+				t.Fatalf("Scan(nil) after a value left %T non-null", v)
+			}
+		})
+	}
+}
+
+// checkValue fails t unless the Value of v is want.
+func checkValue(t *testing.T, v driver.Valuer, want driver.Value) {
+	t.Helper()
+	got, err := v.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	if !equalValues(got, want) {
+		t.Fatalf("Value = %#v (%T), want %#v (%T)", got, got, want, want)
+	}
+}
+
+// equalValues compares driver values, times by instant and byte slices by
+// content.
+func equalValues(got, want driver.Value) bool {
+	switch want := want.(type) {
+	case time.Time:
+		got, ok := got.(time.Time)
+		return ok && got.Equal(want)
+	case []byte:
+		got, ok := got.([]byte)
+		return ok && bytes.Equal(got, want)
+	}
+	return reflect.DeepEqual(got, want)
+}